@@ -0,0 +1,79 @@
+// Command anonymize produces a shareable copy of a transactions JSON file
+//
+// Usage:
+//
+//	go run ./cmd/anonymize [-seed 42] [-fields description,amount,date] data/transactions.json > fixture.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/tools"
+)
+
+func main() {
+	seed := flag.Int64("seed", 1, "random seed; the same seed produces the same output")
+	fields := flag.String("fields", "description,amount,date", "comma-separated fields to anonymize")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [flags] <transactions.json>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	opts, err := parseFields(*seed, *fields)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(2)
+	}
+
+	data, err := os.ReadFile(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to read input: %v\n", err)
+		os.Exit(1)
+	}
+
+	var transactions []domain.Transaction
+	if err := json.Unmarshal(data, &transactions); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to parse transactions: %v\n", err)
+		os.Exit(1)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(tools.Anonymize(transactions, opts)); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to write output: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// parseFields builds the options for the comma-separated -fields flag
+func parseFields(seed int64, fields string) (tools.AnonymizeOptions, error) {
+	opts := tools.AnonymizeOptions{Seed: seed}
+
+	for _, field := range strings.Split(fields, ",") {
+		switch strings.TrimSpace(field) {
+		case "description":
+			opts.Description = true
+		case "amount":
+			opts.Amount = true
+		case "date":
+			opts.Date = true
+		case "":
+		default:
+			return opts, fmt.Errorf("unknown field %q (supported: description, amount, date)", field)
+		}
+	}
+
+	return opts, nil
+}
+
@@ -0,0 +1,598 @@
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/middleware"
+	"github.com/danntastico/stori-backend/internal/repository"
+	"github.com/danntastico/stori-backend/internal/service"
+)
+
+// Config holds application configuration, grouped by concern
+type Config struct {
+	Server        ServerConfig
+	Security      SecurityConfig
+	AI            AIConfig
+	Observability ObservabilityConfig
+	Database      DatabaseConfig
+	Analytics     AnalyticsConfig
+	Alerts        AlertsConfig
+}
+
+// ServerConfig holds HTTP server settings
+type ServerConfig struct {
+	Port  string // PORT
+	Env   string // ENV: development, staging or production
+	Debug bool   // DEBUG: panic details and stack traces in 500 responses
+
+	ResponseEnvelopeEnabled bool // RESPONSE_ENVELOPE_ENABLED: wrap JSON responses in a status/data envelope
+
+	ReadTimeout  time.Duration // READ_TIMEOUT_SECONDS: limit for reading a whole request
+	WriteTimeout time.Duration // WRITE_TIMEOUT_SECONDS: limit for writing a response
+
+	WSReadLimit    int64         // WS_READ_LIMIT: largest message, in bytes, WebSocket clients may send
+	WSPingInterval time.Duration // WS_PING_INTERVAL: seconds between pings to WebSocket clients
+
+	TLSCertFile         string // TLS_CERT_FILE: PEM certificate; HTTPS is served when set with TLS_KEY_FILE
+	TLSKeyFile          string // TLS_KEY_FILE: PEM private key
+	TLSAutoRedirectHTTP bool   // TLS_AUTO_REDIRECT_HTTP: with HTTPS, redirect plain HTTP on port 80
+}
+
+// TLSEnabled reports whether the server should serve HTTPS
+func (c ServerConfig) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// SecurityConfig holds access control and encryption settings
+type SecurityConfig struct {
+	AllowedOrigins    []string // CORS_ALLOWED_ORIGINS
+	ExposeHeaders     []string // CORS_EXPOSE_HEADERS, response headers readable by browser scripts
+	WebhookSecret     string   // WEBHOOK_SECRET; empty disables the webhook endpoint
+	SigningSecret     string   // SIGNING_SECRET for X-Stori-Signature on imports; empty accepts unsigned imports
+	AdminAllowedCIDRs []string // ADMIN_ALLOWED_CIDRS
+	TrustedProxyCIDRs []string // TRUSTED_PROXY_CIDRS, peers whose X-Forwarded-For/X-Real-IP are honored
+	DebugAllowedIPs   []string // DEBUG_ALLOWED_IPS
+	DebugToken        string   // DEBUG_TOKEN; empty disables /debug/goroutines
+	EncryptionKey     []byte   // ENCRYPTION_KEY; nil stores transactions unencrypted
+	EncryptionKeyOld  []byte   // ENCRYPTION_KEY_OLD, only during key rotation
+}
+
+// AIConfig holds settings for the advice generator
+type AIConfig struct {
+	OpenAIAPIKey            string        // OPENAI_API_KEY; empty uses mock responses
+	OpenAIBaseURL           string        // OPENAI_BASE_URL, full chat completions URL; empty uses api.openai.com
+	OpenAIAPIVersion        string        // OPENAI_API_VERSION, Azure's api-version query parameter
+	CircuitBreakerThreshold int           // CIRCUIT_BREAKER_THRESHOLD, consecutive failures before failing fast
+	CircuitBreakerTimeout   time.Duration // CIRCUIT_BREAKER_TIMEOUT_SECONDS before a trial call is allowed
+}
+
+// ObservabilityConfig holds logging and profiling settings
+type ObservabilityConfig struct {
+	LogLevel         string // LOG_LEVEL
+	LogFormat        string // LOG_FORMAT: text or json
+	ProfilingEnabled bool   // DEBUG_PROFILING_ENABLED
+	AuditLogEnabled  bool   // AUDIT_LOG_ENABLED: record state-changing requests
+	AuditLogFile     string // AUDIT_LOG_FILE: JSON lines file for audit entries
+}
+
+// DatabaseConfig holds persistence settings
+type DatabaseConfig struct {
+	AdviceHistoryFile string // ADVICE_HISTORY_FILE; empty keeps advice in memory
+	BudgetFile        string // BUDGET_FILE: JSON file with category budgets, created if missing
+
+	CategoryMetadataFile string // CATEGORY_METADATA_FILE: JSON file with category metadata overrides, created if missing
+
+	DataFilePaths []string // DATA_FILE_PATHS: transaction JSON files loaded alongside the embedded data
+}
+
+// AnalyticsConfig holds settings for the financial calculations
+type AnalyticsConfig struct {
+	BaseCurrency             string   // BASE_CURRENCY
+	DefaultLocale            string   // DEFAULT_LOCALE
+	RecurrenceMinOccurrences int      // RECURRENCE_MIN_OCCURRENCES
+	DeductibleCategories     []string // DEDUCTIBLE_CATEGORIES
+	TaxYearDataFile          string   // TAX_YEAR_DATA; empty uses the built-in brackets
+	WarmupOnStartup          bool     // WARMUP_ON_STARTUP: fill the analytics cache once the server is listening
+	Workers                  int      // ANALYTICS_WORKERS: goroutines aggregating large datasets; 0 is one per CPU
+	AutoCategorize           bool     // AUTOCATEGORIZE_ENABLED: suggest a category for transactions created without one
+	DecimalPrecision         int      // DECIMAL_PRECISION: decimals money amounts are rounded to, e.g., 0 for JPY
+
+	// FINANCIAL_BENCHMARKS: spending guidelines as a share of income; empty uses the built-in ones
+	FinancialBenchmarks []service.FinancialBenchmark
+}
+
+// AlertsConfig holds settings for outbound notifications
+type AlertsConfig struct {
+	BudgetWebhookURL    string        // BUDGET_ALERT_WEBHOOK_URL; empty disables budget alerts
+	BudgetWebhookSecret string        // BUDGET_ALERT_WEBHOOK_SECRET: key for the X-Signature HMAC-SHA256
+	BudgetThreshold     float64       // BUDGET_ALERT_THRESHOLD: share of a monthly limit that triggers an alert
+	BudgetCheckInterval time.Duration // BUDGET_CHECK_INTERVAL_MINUTES between budget checks
+	SMTPHost            string        // SMTP_HOST; empty disables summary emails
+	SMTPPort            int           // SMTP_PORT: mail submission port
+	SMTPUser            string        // SMTP_USER: login, also the sender address
+	SMTPPass            string        // SMTP_PASS
+	SummaryEmailTo      string        // SUMMARY_EMAIL_TO: recipient of the monthly summary email
+}
+
+// ConfigError describes one invalid setting
+type ConfigError struct {
+	Field   string // Environment variable name, e.g., "PORT"
+	Message string // What is wrong, e.g., "must be a number between 1 and 65535, got \"0\""
+}
+
+// Error implements the error interface
+func (e *ConfigError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// configError creates a ConfigError with a formatted message
+func configError(field, format string, args ...interface{}) *ConfigError {
+	return &ConfigError{Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+// validateConfig checks cfg and returns every problem found, in settings order
+func validateConfig(cfg Config) []ConfigError {
+	return configProblems(cfg.Validate())
+}
+
+// configProblems flattens err, as returned by buildConfig or Validate, into its ConfigErrors
+// Errors that are not ConfigErrors, such as a CONFIG_FILE read failure, are kept with no Field.
+func configProblems(err error) []ConfigError {
+	var problems []ConfigError
+	var collect func(err error)
+	collect = func(err error) {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range joined.Unwrap() {
+				collect(e)
+			}
+			return
+		}
+
+		var configErr *ConfigError
+		if errors.As(err, &configErr) {
+			problems = append(problems, *configErr)
+		} else if err != nil {
+			problems = append(problems, ConfigError{Message: err.Error()})
+		}
+	}
+
+	collect(err)
+	return problems
+}
+
+// Validate checks every sub-struct and reports all problems together
+// Every problem is a *ConfigError; use validateConfig to list them.
+func (c Config) Validate() error {
+	return errors.Join(
+		c.Server.Validate(),
+		c.Security.Validate(),
+		c.AI.Validate(),
+		c.Observability.Validate(),
+		c.Database.Validate(),
+		c.Analytics.Validate(),
+		c.Alerts.Validate(),
+	)
+}
+
+// Validate checks the port is a usable TCP port, the environment is named, the timeouts
+// and WebSocket limits are positive and, when TLS is configured, that the certificate and key are readable and
+// belong together
+func (c ServerConfig) Validate() error {
+	var errs []error
+	port, err := strconv.Atoi(c.Port)
+	if err != nil || port < 1 || port > 65535 {
+		errs = append(errs, configError("PORT", "must be a number between 1 and 65535, got %q", c.Port))
+	}
+	if c.Env == "" {
+		errs = append(errs, configError("ENV", "must not be empty"))
+	}
+	if c.ReadTimeout <= 0 {
+		errs = append(errs, configError("READ_TIMEOUT_SECONDS", "must be positive, got %v", c.ReadTimeout))
+	}
+	if c.WriteTimeout <= 0 {
+		errs = append(errs, configError("WRITE_TIMEOUT_SECONDS", "must be positive, got %v", c.WriteTimeout))
+	}
+	if c.WSReadLimit <= 0 {
+		errs = append(errs, configError("WS_READ_LIMIT", "must be positive, got %d", c.WSReadLimit))
+	}
+	if c.WSPingInterval < time.Second {
+		errs = append(errs, configError("WS_PING_INTERVAL", "must be at least 1, got %v", c.WSPingInterval))
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		errs = append(errs, configError("TLS_CERT_FILE", "must be set together with TLS_KEY_FILE"))
+	} else if c.TLSEnabled() {
+		if _, err := tls.LoadX509KeyPair(c.TLSCertFile, c.TLSKeyFile); err != nil {
+			errs = append(errs, configError("TLS_CERT_FILE", "cannot load certificate and key: %v", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks the CORS origins, admin and proxy networks, debug token and encryption keys
+func (c SecurityConfig) Validate() error {
+	var errs []error
+	for _, origin := range c.AllowedOrigins {
+		if !isValidOrigin(origin) {
+			errs = append(errs, configError("CORS_ALLOWED_ORIGINS", "entries must be * or http(s)://host[:port] URLs, got %q", origin))
+		}
+	}
+	if _, err := middleware.ParseCIDRs(c.AdminAllowedCIDRs); err != nil {
+		errs = append(errs, configError("ADMIN_ALLOWED_CIDRS", "%v", err))
+	}
+	if _, err := middleware.ParseCIDRs(c.TrustedProxyCIDRs); err != nil {
+		errs = append(errs, configError("TRUSTED_PROXY_CIDRS", "%v", err))
+	}
+	if _, err := middleware.ParseCIDRs(c.DebugAllowedIPs); err != nil {
+		errs = append(errs, configError("DEBUG_ALLOWED_IPS", "%v", err))
+	}
+	if c.SigningSecret != "" && len(c.SigningSecret) < 16 {
+		errs = append(errs, configError("SIGNING_SECRET", "must be at least 16 characters, got %d", len(c.SigningSecret)))
+	}
+	if c.DebugToken != "" && len(c.DebugToken) < 16 {
+		errs = append(errs, configError("DEBUG_TOKEN", "must be at least 16 characters, got %d", len(c.DebugToken)))
+	}
+	if c.EncryptionKey != nil && len(c.EncryptionKey) != 32 {
+		errs = append(errs, configError("ENCRYPTION_KEY", "must be 32 bytes, got %d", len(c.EncryptionKey)))
+	}
+	if c.EncryptionKeyOld != nil {
+		if c.EncryptionKey == nil {
+			errs = append(errs, configError("ENCRYPTION_KEY_OLD", "is set but ENCRYPTION_KEY is missing"))
+		}
+		if len(c.EncryptionKeyOld) != 32 {
+			errs = append(errs, configError("ENCRYPTION_KEY_OLD", "must be 32 bytes, got %d", len(c.EncryptionKeyOld)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks the OpenAI key looks like one, when set, and the circuit breaker settings
+func (c AIConfig) Validate() error {
+	var errs []error
+	// Azure OpenAI keys have no "sk-" prefix
+	if c.OpenAIAPIKey != "" && !service.IsAzureOpenAIURL(c.OpenAIBaseURL) && !strings.HasPrefix(c.OpenAIAPIKey, "sk-") {
+		errs = append(errs, configError("OPENAI_API_KEY", `must start with "sk-"`))
+	}
+	if c.OpenAIBaseURL != "" {
+		if parsed, err := url.Parse(c.OpenAIBaseURL); err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			errs = append(errs, configError("OPENAI_BASE_URL", "must be an absolute https URL, got %q", c.OpenAIBaseURL))
+		}
+	}
+	if c.CircuitBreakerThreshold < 1 {
+		errs = append(errs, configError("CIRCUIT_BREAKER_THRESHOLD", "must be at least 1, got %d", c.CircuitBreakerThreshold))
+	}
+	if c.CircuitBreakerTimeout < time.Second {
+		errs = append(errs, configError("CIRCUIT_BREAKER_TIMEOUT_SECONDS", "must be at least 1, got %v", c.CircuitBreakerTimeout))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks the log level and format are supported and the audit log has a file
+func (c ObservabilityConfig) Validate() error {
+	var errs []error
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, configError("LOG_LEVEL", "must be debug, info, warn or error, got %q", c.LogLevel))
+	}
+	if c.LogFormat != middleware.LogFormatText && c.LogFormat != middleware.LogFormatJSON {
+		errs = append(errs, configError("LOG_FORMAT", "must be %s or %s, got %q", middleware.LogFormatText, middleware.LogFormatJSON, c.LogFormat))
+	}
+	if c.AuditLogEnabled && c.AuditLogFile == "" {
+		errs = append(errs, configError("AUDIT_LOG_FILE", "is required when AUDIT_LOG_ENABLED is true"))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks the advice history, budget and category metadata files can be created
+// and every extra transaction file exists
+func (c DatabaseConfig) Validate() error {
+	var errs []error
+	for _, file := range c.DataFilePaths {
+		if info, err := os.Stat(file); err != nil || info.IsDir() {
+			errs = append(errs, configError("DATA_FILE_PATHS", "%q is not a readable file", file))
+		}
+	}
+	if c.AdviceHistoryFile != "" && !dirExists(filepath.Dir(c.AdviceHistoryFile)) {
+		errs = append(errs, configError("ADVICE_HISTORY_FILE", "directory %q does not exist", filepath.Dir(c.AdviceHistoryFile)))
+	}
+	if c.BudgetFile == "" {
+		errs = append(errs, configError("BUDGET_FILE", "must not be empty"))
+	} else if !dirExists(filepath.Dir(c.BudgetFile)) {
+		errs = append(errs, configError("BUDGET_FILE", "directory %q does not exist", filepath.Dir(c.BudgetFile)))
+	}
+	if c.CategoryMetadataFile == "" {
+		errs = append(errs, configError("CATEGORY_METADATA_FILE", "must not be empty"))
+	} else if !dirExists(filepath.Dir(c.CategoryMetadataFile)) {
+		errs = append(errs, configError("CATEGORY_METADATA_FILE", "directory %q does not exist", filepath.Dir(c.CategoryMetadataFile)))
+	}
+	return errors.Join(errs...)
+}
+
+// isValidOrigin reports whether origin is "*" or an http(s) URL with a host and nothing after it
+// Wildcard subdomains such as "https://*.example.com" are accepted.
+func isValidOrigin(origin string) bool {
+	if origin == "*" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" &&
+		(u.Path == "" || u.Path == "/") && u.RawQuery == "" && u.Fragment == "" && u.User == nil
+}
+
+// dirExists reports whether path is an existing directory
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// Validate checks the base currency, recurrence threshold, worker count and decimal precision
+func (c AnalyticsConfig) Validate() error {
+	var errs []error
+	if !domain.IsValidCurrency(c.BaseCurrency) {
+		errs = append(errs, configError("BASE_CURRENCY", "must be an ISO 4217 code, got %q", c.BaseCurrency))
+	}
+	if c.RecurrenceMinOccurrences < 2 {
+		errs = append(errs, configError("RECURRENCE_MIN_OCCURRENCES", "must be at least 2, got %d", c.RecurrenceMinOccurrences))
+	}
+	if c.Workers < 0 {
+		errs = append(errs, configError("ANALYTICS_WORKERS", "must be 0 (one per CPU) or more, got %d", c.Workers))
+	}
+	if c.DecimalPrecision < 0 || c.DecimalPrecision > 4 {
+		errs = append(errs, configError("DECIMAL_PRECISION", "must be between 0 and 4, got %d", c.DecimalPrecision))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks the budget alert threshold and interval and, when alerts are enabled,
+// that the webhook is an https URL with a signing secret; when summary emails are enabled,
+// it checks the SMTP port and the sender and recipient addresses
+func (c AlertsConfig) Validate() error {
+	var errs []error
+	if c.BudgetWebhookURL != "" {
+		if parsed, err := url.Parse(c.BudgetWebhookURL); err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			errs = append(errs, configError("BUDGET_ALERT_WEBHOOK_URL", "must be an absolute https URL, got %q", c.BudgetWebhookURL))
+		}
+		if c.BudgetWebhookSecret == "" {
+			errs = append(errs, configError("BUDGET_ALERT_WEBHOOK_SECRET", "is required when BUDGET_ALERT_WEBHOOK_URL is set"))
+		}
+	}
+	if c.BudgetThreshold <= 0 {
+		errs = append(errs, configError("BUDGET_ALERT_THRESHOLD", "must be positive, got %v", c.BudgetThreshold))
+	}
+	if c.BudgetCheckInterval < time.Minute {
+		errs = append(errs, configError("BUDGET_CHECK_INTERVAL_MINUTES", "must be at least 1, got %v", c.BudgetCheckInterval))
+	}
+	if c.SMTPHost != "" {
+		if c.SMTPPort < 1 || c.SMTPPort > 65535 {
+			errs = append(errs, configError("SMTP_PORT", "must be a number between 1 and 65535, got %d", c.SMTPPort))
+		}
+		if _, err := mail.ParseAddress(c.SMTPUser); err != nil {
+			errs = append(errs, configError("SMTP_USER", "must be an email address when SMTP_HOST is set, got %q", c.SMTPUser))
+		}
+		if _, err := mail.ParseAddress(c.SummaryEmailTo); err != nil {
+			errs = append(errs, configError("SUMMARY_EMAIL_TO", "must be an email address when SMTP_HOST is set, got %q", c.SummaryEmailTo))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// loadConfig loads and validates configuration from environment variables with defaults
+// When CONFIG_FILE names a YAML or TOML file, its values replace the defaults and
+// environment variables still take precedence (see loadConfigFromFile).
+// Exits the process, listing every problem, when a setting does not parse or validate.
+func loadConfig() Config {
+	var config Config
+	var err error
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		config, err = loadConfigFromFile(path)
+		if err == nil {
+			log.Printf("📄 Configuration file loaded from %s", path)
+		}
+	} else {
+		config, err = buildConfig(getEnv)
+	}
+	if err == nil {
+		err = config.Validate()
+	}
+	if problems := configProblems(err); len(problems) > 0 {
+		lines := make([]string, len(problems))
+		for i, problem := range problems {
+			lines[i] = "   - " + problem.Error()
+		}
+		log.Fatalf("❌ Invalid configuration:\n%s", strings.Join(lines, "\n"))
+	}
+
+	log.Println("⚙️  Configuration loaded:")
+	log.Printf("   Port: %s", config.Server.Port)
+	log.Printf("   Allowed Origins: %v", config.Security.AllowedOrigins)
+	log.Printf("   Log Level: %s", config.Observability.LogLevel)
+	log.Printf("   Log Format: %s", config.Observability.LogFormat)
+	log.Printf("   Base Currency: %s", config.Analytics.BaseCurrency)
+	log.Printf("   Default Locale: %s", config.Analytics.DefaultLocale)
+	log.Printf("   Environment: %s", config.Server.Env)
+	log.Printf("   TLS Enabled: %t", config.Server.TLSEnabled())
+	log.Printf("   Response Envelope Enabled: %t", config.Server.ResponseEnvelopeEnabled)
+	log.Printf("   Profiling Enabled: %t", config.Observability.ProfilingEnabled)
+	log.Printf("   Audit Log Enabled: %t", config.Observability.AuditLogEnabled)
+	log.Printf("   Warmup On Startup: %t", config.Analytics.WarmupOnStartup)
+	log.Printf("   Admin Allowed CIDRs: %v", config.Security.AdminAllowedCIDRs)
+	log.Printf("   Trusted Proxy CIDRs: %v", config.Security.TrustedProxyCIDRs)
+	log.Printf("   Budget Alerts Enabled: %t", config.Alerts.BudgetWebhookURL != "")
+
+	return config
+}
+
+// buildConfig assembles the configuration from get, which returns the value of a
+// setting (named like its environment variable) or defaultValue when it is unset
+func buildConfig(get func(key, defaultValue string) string) (Config, error) {
+	// Values that do not parse are collected and reported together as ConfigErrors
+	var errs []error
+	atoi := func(key, defaultValue string) int {
+		value := get(key, defaultValue)
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			errs = append(errs, configError(key, "must be a whole number, got %q", value))
+		}
+		return n
+	}
+	parseFloat := func(key, defaultValue string) float64 {
+		value := get(key, defaultValue)
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			errs = append(errs, configError(key, "must be a number, got %q", value))
+		}
+		return f
+	}
+
+	recurrenceMinOccurrences := atoi("RECURRENCE_MIN_OCCURRENCES", "3")
+	analyticsWorkers := atoi("ANALYTICS_WORKERS", "0")
+	budgetAlertThreshold := parseFloat("BUDGET_ALERT_THRESHOLD", "0.9")
+	budgetCheckIntervalMinutes := atoi("BUDGET_CHECK_INTERVAL_MINUTES", "60")
+	smtpPort := atoi("SMTP_PORT", "587")
+	decimalPrecision := atoi("DECIMAL_PRECISION", "2")
+	circuitBreakerThreshold := atoi("CIRCUIT_BREAKER_THRESHOLD", "5")
+	circuitBreakerTimeoutSeconds := atoi("CIRCUIT_BREAKER_TIMEOUT_SECONDS", "30")
+	readTimeoutSeconds := atoi("READ_TIMEOUT_SECONDS", "15")
+	writeTimeoutSeconds := atoi("WRITE_TIMEOUT_SECONDS", "15")
+	wsReadLimit := int64(atoi("WS_READ_LIMIT", "512"))
+	wsPingIntervalSeconds := atoi("WS_PING_INTERVAL", "30")
+
+	var err error
+	financialBenchmarks := service.FinancialBenchmarks
+	if value := get("FINANCIAL_BENCHMARKS", ""); value != "" {
+		if financialBenchmarks, err = service.ParseFinancialBenchmarks(value); err != nil {
+			errs = append(errs, configError("FINANCIAL_BENCHMARKS", "%v", err))
+		}
+	}
+
+	var encryptionKey, encryptionKeyOld []byte
+	if value := get("ENCRYPTION_KEY", ""); value != "" {
+		if encryptionKey, err = repository.ParseEncryptionKey(value); err != nil {
+			errs = append(errs, configError("ENCRYPTION_KEY", "%v", err))
+		}
+	}
+	if value := get("ENCRYPTION_KEY_OLD", ""); value != "" {
+		if encryptionKeyOld, err = repository.ParseEncryptionKey(value); err != nil {
+			errs = append(errs, configError("ENCRYPTION_KEY_OLD", "%v", err))
+		}
+	}
+
+	config := Config{
+		Server: ServerConfig{
+			Port:  get("PORT", "8080"),
+			Env:   get("ENV", "development"),
+			Debug: get("DEBUG", "false") == "true",
+
+			ResponseEnvelopeEnabled: get("RESPONSE_ENVELOPE_ENABLED", "false") == "true",
+
+			ReadTimeout:  time.Duration(readTimeoutSeconds) * time.Second,
+			WriteTimeout: time.Duration(writeTimeoutSeconds) * time.Second,
+
+			WSReadLimit:    wsReadLimit,
+			WSPingInterval: time.Duration(wsPingIntervalSeconds) * time.Second,
+
+			TLSCertFile:         get("TLS_CERT_FILE", ""),
+			TLSKeyFile:          get("TLS_KEY_FILE", ""),
+			TLSAutoRedirectHTTP: get("TLS_AUTO_REDIRECT_HTTP", "true") == "true",
+		},
+		Security: SecurityConfig{
+			AllowedOrigins:    parseList(get("CORS_ALLOWED_ORIGINS", "http://localhost:5173,http://localhost:3000")),
+			ExposeHeaders:     parseList(get("CORS_EXPOSE_HEADERS", "Retry-After,X-Request-Id,X-Idempotency-Replayed")),
+			WebhookSecret:     get("WEBHOOK_SECRET", ""),
+			SigningSecret:     get("SIGNING_SECRET", ""),
+			AdminAllowedCIDRs: parseList(get("ADMIN_ALLOWED_CIDRS", "127.0.0.0/8,::1/128")),
+			TrustedProxyCIDRs: parseList(get("TRUSTED_PROXY_CIDRS", "")),
+			DebugAllowedIPs:   parseList(get("DEBUG_ALLOWED_IPS", "127.0.0.1,::1")),
+			DebugToken:        get("DEBUG_TOKEN", ""),
+			EncryptionKey:     encryptionKey,
+			EncryptionKeyOld:  encryptionKeyOld,
+		},
+		AI: AIConfig{
+			OpenAIAPIKey:            get("OPENAI_API_KEY", ""),
+			OpenAIBaseURL:           get("OPENAI_BASE_URL", ""),
+			OpenAIAPIVersion:        get("OPENAI_API_VERSION", ""),
+			CircuitBreakerThreshold: circuitBreakerThreshold,
+			CircuitBreakerTimeout:   time.Duration(circuitBreakerTimeoutSeconds) * time.Second,
+		},
+		Observability: ObservabilityConfig{
+			LogLevel:         get("LOG_LEVEL", "info"),
+			LogFormat:        get("LOG_FORMAT", middleware.LogFormatText),
+			ProfilingEnabled: get("DEBUG_PROFILING_ENABLED", "false") == "true",
+			AuditLogEnabled:  get("AUDIT_LOG_ENABLED", "false") == "true",
+			AuditLogFile:     get("AUDIT_LOG_FILE", ""),
+		},
+		Database: DatabaseConfig{
+			AdviceHistoryFile: get("ADVICE_HISTORY_FILE", ""),
+			BudgetFile:        get("BUDGET_FILE", "budgets.json"),
+
+			// Kept out of data/, whose *.json files are embedded as transaction sources
+			CategoryMetadataFile: get("CATEGORY_METADATA_FILE", "category_metadata.json"),
+
+			DataFilePaths: parseList(get("DATA_FILE_PATHS", "")),
+		},
+		Analytics: AnalyticsConfig{
+			BaseCurrency:             strings.ToUpper(get("BASE_CURRENCY", "USD")),
+			DefaultLocale:            get("DEFAULT_LOCALE", "en-US"),
+			RecurrenceMinOccurrences: recurrenceMinOccurrences,
+			DeductibleCategories:     parseList(get("DEDUCTIBLE_CATEGORIES", "healthcare")),
+			TaxYearDataFile:          get("TAX_YEAR_DATA", ""),
+			WarmupOnStartup:          get("WARMUP_ON_STARTUP", "true") == "true",
+			Workers:                  analyticsWorkers,
+			AutoCategorize:           get("AUTOCATEGORIZE_ENABLED", "false") == "true",
+			DecimalPrecision:         decimalPrecision,
+			FinancialBenchmarks:      financialBenchmarks,
+		},
+		Alerts: AlertsConfig{
+			BudgetWebhookURL:    get("BUDGET_ALERT_WEBHOOK_URL", ""),
+			BudgetWebhookSecret: get("BUDGET_ALERT_WEBHOOK_SECRET", ""),
+			BudgetThreshold:     budgetAlertThreshold,
+			BudgetCheckInterval: time.Duration(budgetCheckIntervalMinutes) * time.Minute,
+			SMTPHost:            get("SMTP_HOST", ""),
+			SMTPPort:            smtpPort,
+			SMTPUser:            get("SMTP_USER", ""),
+			SMTPPass:            get("SMTP_PASS", ""),
+			SummaryEmailTo:      get("SUMMARY_EMAIL_TO", ""),
+		},
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// getEnv gets an environment variable or returns a default value
+func getEnv(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// parseList splits a comma-separated string into trimmed, non-empty values
+func parseList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		trimmed := strings.TrimSpace(item)
+		if trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// loadConfigFromFile builds the configuration from a YAML (.yaml, .yml) or TOML (.toml) file
+// Keys are the environment variable names, case-insensitive, at the top level:
+//
+//	PORT: 8080
+//	CORS_ALLOWED_ORIGINS: [https://app.example.com, https://admin.example.com]
+//
+// Lists may be written as arrays or comma-separated strings. Environment variables
+// override file values, and settings missing from both keep their defaults.
+// The result is not validated; call Validate.
+func loadConfigFromFile(path string) (Config, error) {
+	values, err := readConfigFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("CONFIG_FILE %s: %w", path, err)
+	}
+
+	return buildConfig(func(key, defaultValue string) string {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+		if value, ok := values[key]; ok {
+			return value
+		}
+		return defaultValue
+	})
+}
+
+// readConfigFile decodes a flat YAML or TOML file into setting values keyed by upper-case name
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := map[string]interface{}{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported format %q, expected .yaml, .yml or .toml", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(raw))
+	var unknown []string
+	for key, value := range raw {
+		text, err := configValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		key = strings.ToUpper(key)
+		if !knownConfigKeys[key] {
+			unknown = append(unknown, key)
+		}
+		values[key] = text
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown settings: %s", strings.Join(unknown, ", "))
+	}
+
+	return values, nil
+}
+
+// configValue renders a decoded file value the way it would be written in an environment variable
+func configValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			text, err := configValue(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = text
+		}
+		return strings.Join(items, ","), nil
+	case map[string]interface{}:
+		return "", fmt.Errorf("nested tables are not supported, use top-level keys")
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// knownConfigKeys lists every setting buildConfig reads, so typos in a file are reported
+var knownConfigKeys = func() map[string]bool {
+	keys := map[string]bool{}
+	buildConfig(func(key, defaultValue string) string {
+		keys[key] = true
+		return defaultValue
+	})
+	return keys
+}()
+
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// validConfig returns a configuration that passes every sub-struct validation
+func validConfig(t *testing.T) Config {
+	t.Helper()
+
+	return Config{
+		Server: ServerConfig{
+			Port: "8080", Env: "development", ReadTimeout: 15 * time.Second, WriteTimeout: 15 * time.Second,
+			WSReadLimit: 512, WSPingInterval: 30 * time.Second,
+		},
+		Security: SecurityConfig{
+			AllowedOrigins:    []string{"http://localhost:5173"},
+			AdminAllowedCIDRs: []string{"127.0.0.0/8"},
+		},
+		AI: AIConfig{
+			OpenAIAPIKey:            "sk-test",
+			CircuitBreakerThreshold: 5,
+			CircuitBreakerTimeout:   30 * time.Second,
+		},
+		Observability: ObservabilityConfig{LogLevel: "info", LogFormat: "text"},
+		Database: DatabaseConfig{
+			AdviceHistoryFile: filepath.Join(t.TempDir(), "advice.json"),
+			BudgetFile:        filepath.Join(t.TempDir(), "budgets.json"),
+
+			CategoryMetadataFile: filepath.Join(t.TempDir(), "category_metadata.json"),
+		},
+		Analytics: AnalyticsConfig{BaseCurrency: "USD", RecurrenceMinOccurrences: 3, DecimalPrecision: 2},
+		Alerts:    AlertsConfig{BudgetThreshold: 0.9, BudgetCheckInterval: time.Hour},
+	}
+}
+
+func TestConfig_SubStructValidation(t *testing.T) {
+	if err := validConfig(t).Validate(); err != nil {
+		t.Fatalf("Validate() on a valid config = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr string
+	}{
+		{"server port not a number", func(c *Config) { c.Server.Port = "http" }, "PORT"},
+		{"server port out of range", func(c *Config) { c.Server.Port = "70000" }, "PORT"},
+		{"server missing environment", func(c *Config) { c.Server.Env = "" }, "ENV: must not be empty"},
+		{"security origin without scheme", func(c *Config) { c.Security.AllowedOrigins = []string{"localhost:5173"} }, "CORS_ALLOWED_ORIGINS"},
+		{"security origin with path", func(c *Config) { c.Security.AllowedOrigins = []string{"https://app.example.com/login"} }, "CORS_ALLOWED_ORIGINS"},
+		{"tls cert without key", func(c *Config) { c.Server.TLSCertFile = "cert.pem" }, "must be set together with TLS_KEY_FILE"},
+		{"tls unreadable files", func(c *Config) {
+			c.Server.TLSCertFile, c.Server.TLSKeyFile = "/does/not/exist/cert.pem", "/does/not/exist/key.pem"
+		}, "TLS_CERT_FILE: cannot load"},
+		{"server zero read timeout", func(c *Config) { c.Server.ReadTimeout = 0 }, "READ_TIMEOUT_SECONDS"},
+		{"server negative write timeout", func(c *Config) { c.Server.WriteTimeout = -time.Second }, "WRITE_TIMEOUT_SECONDS"},
+		{"server zero websocket read limit", func(c *Config) { c.Server.WSReadLimit = 0 }, "WS_READ_LIMIT"},
+		{"server sub-second websocket ping", func(c *Config) { c.Server.WSPingInterval = time.Millisecond }, "WS_PING_INTERVAL"},
+		{"security invalid CIDR", func(c *Config) { c.Security.AdminAllowedCIDRs = []string{"10.0.0.0/99"} }, "ADMIN_ALLOWED_CIDRS"},
+		{"security invalid proxy CIDR", func(c *Config) { c.Security.TrustedProxyCIDRs = []string{"10.0.0.1/"} }, "TRUSTED_PROXY_CIDRS"},
+		{"security invalid debug IP", func(c *Config) { c.Security.DebugAllowedIPs = []string{"localhost"} }, "DEBUG_ALLOWED_IPS"},
+		{"security short key", func(c *Config) { c.Security.EncryptionKey = make([]byte, 16) }, "ENCRYPTION_KEY: must be 32 bytes"},
+		{"security old key without key", func(c *Config) { c.Security.EncryptionKeyOld = make([]byte, 32) }, "ENCRYPTION_KEY is missing"},
+		{"security short debug token", func(c *Config) { c.Security.DebugToken = "secret" }, "DEBUG_TOKEN: must be at least 16 characters"},
+		{"security short signing secret", func(c *Config) { c.Security.SigningSecret = "secret" }, "SIGNING_SECRET: must be at least 16 characters"},
+		{"ai malformed key", func(c *Config) { c.AI.OpenAIAPIKey = "not-a-key" }, "OPENAI_API_KEY"},
+		{"ai zero breaker threshold", func(c *Config) { c.AI.CircuitBreakerThreshold = 0 }, "CIRCUIT_BREAKER_THRESHOLD"},
+		{"ai sub-second breaker timeout", func(c *Config) { c.AI.CircuitBreakerTimeout = 0 }, "CIRCUIT_BREAKER_TIMEOUT_SECONDS"},
+		{"observability unknown level", func(c *Config) { c.Observability.LogLevel = "verbose" }, "LOG_LEVEL"},
+		{"observability unknown format", func(c *Config) { c.Observability.LogFormat = "xml" }, "LOG_FORMAT"},
+		{"observability audit log without file", func(c *Config) { c.Observability.AuditLogEnabled = true }, "AUDIT_LOG_FILE"},
+		{"database missing directory", func(c *Config) { c.Database.AdviceHistoryFile = "/does/not/exist/advice.json" }, "ADVICE_HISTORY_FILE"},
+		{"database missing budget file", func(c *Config) { c.Database.BudgetFile = "" }, "BUDGET_FILE"},
+		{"database missing category metadata file", func(c *Config) { c.Database.CategoryMetadataFile = "" }, "CATEGORY_METADATA_FILE"},
+		{"database missing data file", func(c *Config) { c.Database.DataFilePaths = []string{"/does/not/exist/2023.json"} }, "DATA_FILE_PATHS"},
+		{"analytics invalid currency", func(c *Config) { c.Analytics.BaseCurrency = "DOLLARS" }, "BASE_CURRENCY"},
+		{"analytics recurrence too low", func(c *Config) { c.Analytics.RecurrenceMinOccurrences = 1 }, "RECURRENCE_MIN_OCCURRENCES"},
+		{"analytics negative workers", func(c *Config) { c.Analytics.Workers = -1 }, "ANALYTICS_WORKERS"},
+		{"analytics precision too high", func(c *Config) { c.Analytics.DecimalPrecision = 5 }, "DECIMAL_PRECISION"},
+		{"alerts webhook over http", func(c *Config) {
+			c.Alerts.BudgetWebhookURL, c.Alerts.BudgetWebhookSecret = "http://hooks.example.com/budget", "secret"
+		}, "BUDGET_ALERT_WEBHOOK_URL"},
+		{"alerts webhook without secret", func(c *Config) { c.Alerts.BudgetWebhookURL = "https://hooks.example.com/budget" }, "BUDGET_ALERT_WEBHOOK_SECRET"},
+		{"alerts zero threshold", func(c *Config) { c.Alerts.BudgetThreshold = 0 }, "BUDGET_ALERT_THRESHOLD"},
+		{"alerts sub-minute interval", func(c *Config) { c.Alerts.BudgetCheckInterval = time.Second }, "BUDGET_CHECK_INTERVAL_MINUTES"},
+		{"summary email without recipient", func(c *Config) {
+			c.Alerts.SMTPHost, c.Alerts.SMTPPort, c.Alerts.SMTPUser = "smtp.example.com", 587, "reports@example.com"
+		}, "SUMMARY_EMAIL_TO"},
+		{"summary email invalid port", func(c *Config) {
+			c.Alerts.SMTPHost, c.Alerts.SMTPUser, c.Alerts.SummaryEmailTo = "smtp.example.com", "reports@example.com", "ana@example.com"
+		}, "SMTP_PORT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := validConfig(t)
+			tt.mutate(&config)
+
+			err := config.Validate()
+			if err == nil {
+				t.Fatal("Expected validation error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %q, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Each sub-struct validates independently of the others
+	if err := (SecurityConfig{AllowedOrigins: []string{"*", "https://*.example.com", "http://localhost:3000"}}).Validate(); err != nil {
+		t.Errorf("SecurityConfig.Validate() with wildcard origins = %v, want nil", err)
+	}
+	if err := (ServerConfig{Port: "0", Env: "test", ReadTimeout: time.Second, WriteTimeout: time.Second, WSReadLimit: 1, WSPingInterval: time.Second}).Validate(); err == nil {
+		t.Error("ServerConfig.Validate() accepted port 0")
+	}
+	if err := (AIConfig{CircuitBreakerThreshold: 1, CircuitBreakerTimeout: time.Second}).Validate(); err != nil {
+		t.Errorf("AIConfig.Validate() without a key = %v, want nil", err)
+	}
+	dir := t.TempDir()
+	database := DatabaseConfig{BudgetFile: filepath.Join(dir, "budgets.json"), CategoryMetadataFile: filepath.Join(dir, "category_metadata.json")}
+	if err := database.Validate(); err != nil {
+		t.Errorf("DatabaseConfig.Validate() without a history file = %v, want nil", err)
+	}
+}
+
+func TestConfig_ValidateReportsAllErrors(t *testing.T) {
+	config := validConfig(t)
+	config.Server.Port = ""
+	config.Analytics.BaseCurrency = ""
+
+	err := config.Validate()
+	if err == nil {
+		t.Fatal("Expected validation error, got nil")
+	}
+	for _, want := range []string{"PORT", "BASE_CURRENCY"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %q, want it to mention %q", err, want)
+		}
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	if problems := validateConfig(validConfig(t)); len(problems) != 0 {
+		t.Fatalf("validateConfig() on a valid config = %v, want none", problems)
+	}
+
+	tests := []struct {
+		name      string
+		mutate    func(c *Config)
+		wantField string // Empty when the config is valid
+	}{
+		{"port 0", func(c *Config) { c.Server.Port = "0" }, "PORT"},
+		{"port 1", func(c *Config) { c.Server.Port = "1" }, ""},
+		{"port 65535", func(c *Config) { c.Server.Port = "65535" }, ""},
+		{"port 65536", func(c *Config) { c.Server.Port = "65536" }, "PORT"},
+		{"port negative", func(c *Config) { c.Server.Port = "-1" }, "PORT"},
+		{"origin wildcard", func(c *Config) { c.Security.AllowedOrigins = []string{"*"} }, ""},
+		{"origin with port", func(c *Config) { c.Security.AllowedOrigins = []string{"https://app.example.com:8443"} }, ""},
+		{"origin unparsable", func(c *Config) { c.Security.AllowedOrigins = []string{"http://[::1"} }, "CORS_ALLOWED_ORIGINS"},
+		{"origin without host", func(c *Config) { c.Security.AllowedOrigins = []string{"https://"} }, "CORS_ALLOWED_ORIGINS"},
+		{"openai key empty", func(c *Config) { c.AI.OpenAIAPIKey = "" }, ""},
+		{"openai key prefix only", func(c *Config) { c.AI.OpenAIAPIKey = "sk-" }, ""},
+		{"openai key uppercase prefix", func(c *Config) { c.AI.OpenAIAPIKey = "SK-test" }, "OPENAI_API_KEY"},
+		{"openai key without dash", func(c *Config) { c.AI.OpenAIAPIKey = "sktest" }, "OPENAI_API_KEY"},
+		{"openai azure key", func(c *Config) {
+			c.AI.OpenAIAPIKey = "0123abcd"
+			c.AI.OpenAIBaseURL = "https://acme.openai.azure.com/openai/deployments/gpt/chat/completions"
+		}, ""},
+		{"openai base url http", func(c *Config) { c.AI.OpenAIBaseURL = "http://proxy.example.com/v1/chat/completions" }, "OPENAI_BASE_URL"},
+		{"openai base url relative", func(c *Config) { c.AI.OpenAIBaseURL = "/v1/chat/completions" }, "OPENAI_BASE_URL"},
+		{"read timeout 1ns", func(c *Config) { c.Server.ReadTimeout = time.Nanosecond }, ""},
+		{"read timeout zero", func(c *Config) { c.Server.ReadTimeout = 0 }, "READ_TIMEOUT_SECONDS"},
+		{"read timeout negative", func(c *Config) { c.Server.ReadTimeout = -time.Second }, "READ_TIMEOUT_SECONDS"},
+		{"write timeout 1ns", func(c *Config) { c.Server.WriteTimeout = time.Nanosecond }, ""},
+		{"write timeout zero", func(c *Config) { c.Server.WriteTimeout = 0 }, "WRITE_TIMEOUT_SECONDS"},
+		{"write timeout negative", func(c *Config) { c.Server.WriteTimeout = -time.Second }, "WRITE_TIMEOUT_SECONDS"},
+		{"log level debug", func(c *Config) { c.Observability.LogLevel = "debug" }, ""},
+		{"log level warn", func(c *Config) { c.Observability.LogLevel = "warn" }, ""},
+		{"log level error", func(c *Config) { c.Observability.LogLevel = "error" }, ""},
+		{"log level uppercase", func(c *Config) { c.Observability.LogLevel = "INFO" }, "LOG_LEVEL"},
+		{"log level warning", func(c *Config) { c.Observability.LogLevel = "warning" }, "LOG_LEVEL"},
+		{"log level empty", func(c *Config) { c.Observability.LogLevel = "" }, "LOG_LEVEL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := validConfig(t)
+			tt.mutate(&config)
+
+			problems := validateConfig(config)
+			if tt.wantField == "" {
+				if len(problems) != 0 {
+					t.Errorf("validateConfig() = %v, want none", problems)
+				}
+				return
+			}
+			if len(problems) != 1 {
+				t.Fatalf("validateConfig() = %v, want exactly one problem", problems)
+			}
+			if problems[0].Field != tt.wantField || problems[0].Message == "" {
+				t.Errorf("validateConfig() = %+v, want a message for %s", problems[0], tt.wantField)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_ListsEveryProblem(t *testing.T) {
+	config := validConfig(t)
+	config.Server.Port = "0"
+	config.Server.ReadTimeout = 0
+	config.AI.OpenAIAPIKey = "key"
+	config.Observability.LogLevel = "verbose"
+
+	var fields []string
+	for _, problem := range validateConfig(config) {
+		fields = append(fields, problem.Field)
+	}
+
+	want := []string{"PORT", "READ_TIMEOUT_SECONDS", "OPENAI_API_KEY", "LOG_LEVEL"}
+	if strings.Join(fields, ",") != strings.Join(want, ",") {
+		t.Errorf("validateConfig() fields = %v, want %v", fields, want)
+	}
+}
+
+func TestBuildConfig_UnparsableNumbers(t *testing.T) {
+	values := map[string]string{
+		"READ_TIMEOUT_SECONDS":   "fifteen",
+		"BUDGET_ALERT_THRESHOLD": "90%",
+		"WS_READ_LIMIT":          "512kb",
+	}
+	_, err := buildConfig(func(key, defaultValue string) string {
+		if value, ok := values[key]; ok {
+			return value
+		}
+		return defaultValue
+	})
+
+	// Every bad value is reported instead of silently falling back to its default
+	var fields []string
+	for _, problem := range configProblems(err) {
+		fields = append(fields, problem.Field)
+	}
+	want := []string{"BUDGET_ALERT_THRESHOLD", "READ_TIMEOUT_SECONDS", "WS_READ_LIMIT"}
+	if strings.Join(fields, ",") != strings.Join(want, ",") {
+		t.Errorf("buildConfig() problems = %v, want %v", fields, want)
+	}
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) || !strings.Contains(configErr.Message, "got") {
+		t.Errorf("buildConfig() error = %v, want a ConfigError naming the value", err)
+	}
+}
+
+func TestLoadConfigFromFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"yaml", "config.yaml", `
+PORT: 9090
+env: staging
+CORS_ALLOWED_ORIGINS:
+  - https://app.example.com
+  - https://admin.example.com
+DEBUG_PROFILING_ENABLED: true
+CIRCUIT_BREAKER_THRESHOLD: 3
+`},
+		{"toml", "config.toml", `
+PORT = 9090
+env = "staging"
+CORS_ALLOWED_ORIGINS = ["https://app.example.com", "https://admin.example.com"]
+DEBUG_PROFILING_ENABLED = true
+CIRCUIT_BREAKER_THRESHOLD = 3
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+
+			config, err := loadConfigFromFile(path)
+			if err != nil {
+				t.Fatalf("loadConfigFromFile() error = %v", err)
+			}
+
+			if config.Server.Port != "9090" || config.Server.Env != "staging" {
+				t.Errorf("Server = %+v, want port 9090 in staging", config.Server)
+			}
+			if want := []string{"https://app.example.com", "https://admin.example.com"}; strings.Join(config.Security.AllowedOrigins, ",") != strings.Join(want, ",") {
+				t.Errorf("AllowedOrigins = %v, want %v", config.Security.AllowedOrigins, want)
+			}
+			if !config.Observability.ProfilingEnabled || config.AI.CircuitBreakerThreshold != 3 {
+				t.Errorf("Expected profiling on and a threshold of 3, got %+v / %+v", config.Observability, config.AI)
+			}
+			// Settings missing from the file keep their defaults
+			if config.Analytics.BaseCurrency != "USD" || config.Observability.LogLevel != "info" {
+				t.Errorf("Expected defaults for unset settings, got %+v", config.Analytics)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFromFile_EnvOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte("PORT: 9090\nLOG_LEVEL: debug\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	t.Setenv("PORT", "7070")
+
+	config, err := loadConfigFromFile(path)
+	if err != nil {
+		t.Fatalf("loadConfigFromFile() error = %v", err)
+	}
+	if config.Server.Port != "7070" {
+		t.Errorf("Port = %q, want the environment value 7070", config.Server.Port)
+	}
+	if config.Observability.LogLevel != "debug" {
+		t.Errorf("LogLevel = %q, want the file value debug", config.Observability.LogLevel)
+	}
+}
+
+func TestLoadConfigFromFile_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{"unknown setting", "config.yaml", "PROT: 8080\n", "unknown settings: PROT"},
+		{"nested table", "config.toml", "[server]\nport = 8080\n", "nested tables"},
+		{"malformed yaml", "config.yaml", "PORT: [8080\n", "config.yaml"},
+		{"unsupported format", "config.json", `{"PORT": 8080}`, "unsupported format"},
+		{"invalid encryption key", "config.yaml", "ENCRYPTION_KEY: short\n", "ENCRYPTION_KEY"},
+		{"invalid financial benchmarks", "config.yaml", "FINANCIAL_BENCHMARKS: housing:rent:300\n", "FINANCIAL_BENCHMARKS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+
+			_, err := loadConfigFromFile(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("loadConfigFromFile() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := loadConfigFromFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("loadConfigFromFile() of a missing file returned no error")
+	}
+
+	// A file that loads can still fail validation
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte("PORT: 70000\nCORS_ALLOWED_ORIGINS: not-a-url\n"), 0o600)
+	config, err := loadConfigFromFile(path)
+	if err != nil {
+		t.Fatalf("loadConfigFromFile() error = %v", err)
+	}
+	if err := config.Validate(); err == nil || !strings.Contains(err.Error(), "PORT") || !strings.Contains(err.Error(), "CORS_ALLOWED_ORIGINS") {
+		t.Errorf("Validate() = %v, want PORT and CORS_ALLOWED_ORIGINS errors", err)
+	}
+}
+
@@ -0,0 +1,165 @@
+//go:build integration
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/repository"
+	"github.com/danntastico/stori-backend/internal/service"
+	"github.com/danntastico/stori-backend/internal/testutil/sampledata"
+)
+
+// TestFullServerIntegration exercises the fully wired router over real HTTP
+// Run with: go test -tags integration ./...
+func TestFullServerIntegration(t *testing.T) {
+	data := sampledata.Transactions(t)
+
+	config := loadConfig()
+	config.AI.OpenAIAPIKey = "" // Always use mock AI responses
+	config.Security.EncryptionKey = nil
+	config.Security.EncryptionKeyOld = nil
+	config.Database.BudgetFile = filepath.Join(t.TempDir(), "budgets.json")
+	config.Database.CategoryMetadataFile = filepath.Join(t.TempDir(), "category_metadata.json")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	router, _ := newRouter(ctx, config, []repository.DataSource{{Name: "transactions", Data: data}})
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	get := func(t *testing.T, path string, out interface{}) {
+		t.Helper()
+
+		resp, err := http.DefaultClient.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", path, err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("GET %s: expected status 200, got %d", path, resp.StatusCode)
+		}
+		if out != nil {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				t.Fatalf("GET %s: failed to decode response: %v", path, err)
+			}
+		}
+	}
+
+	t.Run("health", func(t *testing.T) {
+		get(t, "/api/health", nil)
+	})
+
+	t.Run("transactions", func(t *testing.T) {
+		var response domain.TransactionsResponse
+		get(t, "/api/transactions?limit=500", &response)
+
+		if response.Count != 112 || len(response.Transactions) != 112 {
+			t.Errorf("Expected 112 transactions, got count %d with %d items", response.Count, len(response.Transactions))
+		}
+	})
+
+	t.Run("category summary", func(t *testing.T) {
+		var summary domain.CategorySummary
+		get(t, "/api/summary/categories", &summary)
+
+		want := domain.FinancialSummary{TotalIncome: 56000, TotalExpenses: 19557, NetSavings: 36443, SavingsRate: 65.08}
+		if summary.Summary != want {
+			t.Errorf("Summary = %+v, want %+v", summary.Summary, want)
+		}
+	})
+
+	t.Run("advice", func(t *testing.T) {
+		resp, err := http.DefaultClient.Post(server.URL+"/api/advice", "application/json", strings.NewReader(`{"context": "savings"}`))
+		if err != nil {
+			t.Fatalf("POST /api/advice failed: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+		}
+
+		var advice service.AdviceResponse
+		if err := json.NewDecoder(resp.Body).Decode(&advice); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if advice.Advice == "" {
+			t.Error("Expected non-empty advice")
+		}
+	})
+	// Upgrades must pass through every middleware's response writer
+	t.Run("websocket feed", func(t *testing.T) {
+		conn, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/api/ws/transactions", nil)
+		if err != nil {
+			t.Fatalf("Dial() error = %v (response %v)", err, resp)
+		}
+		conn.Close()
+	})
+
+	t.Run("server-sent events", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/events", nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("GET /api/events failed: %v", err)
+		}
+		defer resp.Body.Close()
+
+		// The first line only arrives if every middleware passes the flush through
+		line, err := bufio.NewReader(resp.Body).ReadString('\n')
+		if err != nil || line != "retry: 1000\n" {
+			t.Errorf("Expected the stream to start with a retry line, got %q (%v)", line, err)
+		}
+	})
+
+	// Runs last because it adds transactions
+	t.Run("mint import", func(t *testing.T) {
+		mint, err := os.Open(filepath.Join("internal", "importer", "testdata", "mint_sample.csv"))
+		if err != nil {
+			t.Fatalf("Failed to open Mint fixture: %v", err)
+		}
+		defer mint.Close()
+
+		resp, err := http.DefaultClient.Post(server.URL+"/api/transactions/import?format=mint", "text/csv", mint)
+		if err != nil {
+			t.Fatalf("POST /api/transactions/import failed: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusMultiStatus {
+			t.Fatalf("Expected status 207, got %d", resp.StatusCode)
+		}
+
+		var response domain.TransactionsResponse
+		get(t, "/api/transactions?limit=500", &response)
+
+		imported := map[string]domain.Transaction{}
+		for _, tx := range response.Transactions {
+			if tx.Notes != "" {
+				imported[tx.Description] = tx
+			}
+		}
+		if credit := imported["Acme Corp"]; credit.Type != "income" || credit.Amount != 4250 {
+			t.Errorf("Mint credit imported as %+v, want income of 4250", credit)
+		}
+		if debit := imported["Safeway"]; debit.Type != "expense" || debit.Amount != -87.43 {
+			t.Errorf("Mint debit imported as %+v, want expense of -87.43", debit)
+		}
+	})
+}
+
@@ -0,0 +1,125 @@
+// Package categorizer infers a transaction's category from its description
+package categorizer
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+//go:embed category_rules.json
+var categoryRulesData []byte
+
+// Uncategorized is assigned when no category can be inferred
+const Uncategorized = "uncategorized"
+
+// Rules maps regular expressions, matched case-insensitively against a
+// transaction's description, to the category they suggest
+type Rules map[string]string
+
+// categoryRule is a compiled entry of Rules
+type categoryRule struct {
+	pattern  *regexp.Regexp
+	category string
+}
+
+// Categorizer suggests a category for a transaction from its description
+// It reports how confident the suggestion is, so callers can leave ambiguous transactions
+// alone; importers that need a category for every row use Categorize instead.
+type Categorizer struct {
+	rules []categoryRule
+}
+
+// New creates a categorizer from the embedded category_rules.json
+func New() (*Categorizer, error) {
+	return NewFromJSON(categoryRulesData)
+}
+
+// NewFromJSON creates a categorizer from raw Rules JSON
+func NewFromJSON(data []byte) (*Categorizer, error) {
+	var rules Rules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse category rules: %w", err)
+	}
+
+	return NewFromRules(rules)
+}
+
+// NewFromRules creates a categorizer from rules
+// Returns an error naming the first pattern that does not compile or has no category.
+func NewFromRules(rules Rules) (*Categorizer, error) {
+	// Compile in a stable order so errors are reproducible
+	patterns := make([]string, 0, len(rules))
+	for pattern := range rules {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+
+	compiled := make([]categoryRule, 0, len(patterns))
+	for _, pattern := range patterns {
+		category := strings.TrimSpace(rules[pattern])
+		if category == "" {
+			return nil, fmt.Errorf("category rule %q has no category", pattern)
+		}
+
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid category rule %q: %w", pattern, err)
+		}
+		compiled = append(compiled, categoryRule{pattern: re, category: category})
+	}
+
+	return &Categorizer{rules: compiled}, nil
+}
+
+// Classify suggests a category for tx and how confident the suggestion is, from 0 to 1
+// Confidence is the share of matching rules that agree on the category, so a description
+// matched only by rules for one category scores 1, e.g., "WHOLE FOODS MARKET" ->
+// ("groceries", 1), while "UBER EATS" matches dining and transportation and scores 0.5.
+// No matching rule returns ("", 0, nil).
+// Returns ErrInsufficientData when tx has no description to match.
+func (c *Categorizer) Classify(tx domain.Transaction) (string, float64, error) {
+	description := strings.TrimSpace(tx.Description)
+	if description == "" {
+		return "", 0, fmt.Errorf("%w: transaction has no description", domain.ErrInsufficientData)
+	}
+
+	votes := make(map[string]int)
+	matches := 0
+	for _, rule := range c.rules {
+		if rule.pattern.MatchString(description) {
+			votes[rule.category]++
+			matches++
+		}
+	}
+	if matches == 0 {
+		return "", 0, nil
+	}
+
+	// Most votes wins; ties go to the alphabetically first category
+	best := ""
+	for category, count := range votes {
+		if best == "" || count > votes[best] || (count == votes[best] && category < best) {
+			best = category
+		}
+	}
+
+	return best, float64(votes[best]) / float64(matches), nil
+}
+
+// Categorize returns the best suggestion for description whatever its confidence, or
+// Uncategorized when no rule matches
+// e.g., "Shell gas station" -> "transportation"
+func (c *Categorizer) Categorize(description string) string {
+	category, _, err := c.Classify(domain.Transaction{Description: description})
+	if err != nil || category == "" {
+		return Uncategorized
+	}
+	return category
+}
+
@@ -0,0 +1,91 @@
+package categorizer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func TestCategorizer_Classify(t *testing.T) {
+	categorizer, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		description    string
+		wantCategory   string
+		wantConfidence float64
+	}{
+		{"WHOLE FOODS MARKET", "groceries", 1},
+		{"NETFLIX.COM", "subscriptions", 1},
+		{"Trader Joe's #552", "groceries", 1},
+		{"ACME Corp Payroll", "salary", 1},
+		{"UBER EATS", "dining", 0.5}, // Also matches the rideshare rule
+		{"Parent teacher fundraiser", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			category, confidence, err := categorizer.Classify(domain.Transaction{Description: tt.description})
+			if err != nil {
+				t.Fatalf("Classify() error = %v", err)
+			}
+			if category != tt.wantCategory || confidence != tt.wantConfidence {
+				t.Errorf("Classify(%q) = (%q, %v), want (%q, %v)",
+					tt.description, category, confidence, tt.wantCategory, tt.wantConfidence)
+			}
+		})
+	}
+
+	if _, _, err := categorizer.Classify(domain.Transaction{Description: "  "}); !errors.Is(err, domain.ErrInsufficientData) {
+		t.Errorf("Classify() without a description error = %v, want ErrInsufficientData", err)
+	}
+}
+
+func TestCategorizer_Categorize(t *testing.T) {
+	categorizer, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		description string
+		want        string
+	}{
+		{"ACME Corp Payroll", "salary"},
+		{"Monthly rent", "rent"},
+		{"SAFEWAY #1234", "groceries"},
+		{"Sushi dinner", "dining"},
+		{"Shell gas station", "transportation"},
+		{"Chevron fuel", "transportation"},
+		{"Electric bill", "utilities"},
+		{"CVS Pharmacy", "healthcare"},
+		{"Netflix.com", "subscriptions"},
+		{"AMAZON MKTPLACE", "shopping"},
+		{"Parent teacher fundraiser", Uncategorized}, // "rent" only matches whole words
+		{"", Uncategorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if got := categorizer.Categorize(tt.description); got != tt.want {
+				t.Errorf("Categorize(%q) = %q, want %q", tt.description, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewFromRules_Invalid(t *testing.T) {
+	if _, err := NewFromRules(Rules{"(unclosed": "groceries"}); err == nil {
+		t.Error("Expected an error for a pattern that does not compile")
+	}
+	if _, err := NewFromRules(Rules{"costco": " "}); err == nil {
+		t.Error("Expected an error for a rule without a category")
+	}
+	if _, err := NewFromJSON([]byte(`["costco"]`)); err == nil {
+		t.Error("Expected an error for rules that are not an object")
+	}
+}
+
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// MaxFeedbackCommentLength is the maximum length of a feedback comment, in characters
+const MaxFeedbackCommentLength = 200
+
+// AdviceFeedback is a user's rating of a piece of generated advice
+type AdviceFeedback struct {
+	AdviceID    string    `json:"advice_id"`
+	Rating      int       `json:"rating"`            // 1 (poor) to 5 (excellent)
+	Helpful     bool      `json:"helpful"`           // Whether the advice was useful
+	Comment     string    `json:"comment,omitempty"` // Optional, at most MaxFeedbackCommentLength characters
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// Validate checks the rating range and comment length
+// Returns *ValidationErrors listing every invalid field
+func (f *AdviceFeedback) Validate() error {
+	var errs ValidationErrors
+
+	if f.Rating < 1 || f.Rating > 5 {
+		errs.add("rating", ErrInvalidRating)
+	}
+	if utf8.RuneCountInString(f.Comment) > MaxFeedbackCommentLength {
+		errs.add("comment", ErrInvalidComment)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return &errs
+}
+
+// FeedbackStats aggregates all advice feedback
+type FeedbackStats struct {
+	AverageRating  float64 `json:"average_rating"`  // Mean rating, rounded to 2 decimals
+	PercentHelpful float64 `json:"percent_helpful"` // Share of responses marked helpful (0-100)
+	TotalResponses int     `json:"total_responses"`
+}
+
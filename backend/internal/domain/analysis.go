@@ -0,0 +1,198 @@
+package domain
+
+// RecurringPattern describes a charge that repeats at a regular interval
+type RecurringPattern struct {
+	Category      string  `json:"category"`       // Transaction category
+	Description   string  `json:"description"`    // Shared description of the charges
+	AverageAmount float64 `json:"average_amount"` // Average absolute amount per charge
+	FrequencyDays int     `json:"frequency_days"` // Average days between charges
+	Occurrences   int     `json:"occurrences"`    // Number of matching transactions
+	LastCharged   string  `json:"last_charged"`   // Date of the most recent charge (YYYY-MM-DD)
+}
+
+// UpcomingBill is a recurring charge expected in the near future
+type UpcomingBill struct {
+	Category        string  `json:"category"`         // Transaction category
+	EstimatedAmount float64 `json:"estimated_amount"` // Expected charge amount (positive value)
+	DueDate         string  `json:"due_date"`         // Expected date (YYYY-MM-DD)
+	DaysUntilDue    int     `json:"days_until_due"`   // Days from today until due
+}
+
+// IncomeStability describes how much monthly income varies
+// CoefficientOfVariation (StdDev / Mean, as a percentage) reads as:
+//   - below 10%: very stable (e.g., fixed salary)
+//   - 10% to 30%: moderately variable
+//   - above 30%: highly variable (e.g., freelance or gig income)
+type IncomeStability struct {
+	Mean                   float64   `json:"mean"`                     // Average monthly income
+	StdDev                 float64   `json:"std_dev"`                  // Population standard deviation of monthly income
+	CoefficientOfVariation float64   `json:"coefficient_of_variation"` // StdDev / Mean * 100; 0 when Mean is 0
+	MinMonthlyIncome       float64   `json:"min_monthly_income"`       // Lowest monthly income
+	MaxMonthlyIncome       float64   `json:"max_monthly_income"`       // Highest monthly income
+	MonthlyIncomes         []float64 `json:"monthly_incomes"`          // Income per month, chronological
+}
+
+// Benchmark statuses
+const (
+	BenchmarkOnTrack = "on_track"
+	BenchmarkOver    = "over"
+	BenchmarkUnder   = "under"
+)
+
+// DefaultBenchmarkTolerance is how many percentage points from a target still count as on track
+const DefaultBenchmarkTolerance = 5.0
+
+// BenchmarkClassifier rates a share of income against a benchmark target
+// Both are percentages; a share within Tolerance percentage points of the target, boundaries
+// included, is on track.
+type BenchmarkClassifier struct {
+	Tolerance float64
+}
+
+// Classify returns BenchmarkOver above target+Tolerance, BenchmarkUnder below
+// target-Tolerance and BenchmarkOnTrack otherwise
+func (c BenchmarkClassifier) Classify(actual, target float64) string {
+	switch {
+	case actual > target+c.Tolerance:
+		return BenchmarkOver
+	case actual < target-c.Tolerance:
+		return BenchmarkUnder
+	default:
+		return BenchmarkOnTrack
+	}
+}
+
+// BenchmarkEntry compares spending in one benchmark group with its target share of income
+type BenchmarkEntry struct {
+	Category      string  `json:"category"`       // Benchmark group, e.g., "housing"
+	UserPercent   float64 `json:"user_percent"`   // Average monthly spend in the group as a percentage of monthly income
+	TargetPercent float64 `json:"target_percent"` // Benchmark maximum as a percentage of monthly income
+	Status        string  `json:"status"`         // "on_track", "over" or "under"
+	Delta         float64 `json:"delta"`          // UserPercent - TargetPercent, in percentage points
+}
+
+// BenchmarkReport compares spending with every configured benchmark
+// Targets are maximums, so a group under its target also meets it; OverallComplianceRate is
+// 0 when no benchmarks are configured.
+type BenchmarkReport struct {
+	Entries               []BenchmarkEntry `json:"entries"`                 // One per benchmark, in configuration order
+	OverallComplianceRate float64          `json:"overall_compliance_rate"` // Percentage of entries not over their target
+}
+
+// RationalizationSuggestion is a concrete proposal to bring spending in line with a benchmark
+type RationalizationSuggestion struct {
+	Category                 string  `json:"category"`                   // Benchmark group, e.g., "housing"
+	CurrentMonthlyAverage    float64 `json:"current_monthly_average"`    // Average monthly spend in the group
+	RecommendedMonthlyTarget float64 `json:"recommended_monthly_target"` // Benchmark share of monthly income
+	PotentialMonthlySaving   float64 `json:"potential_monthly_saving"`   // Current average minus target
+	Rationale                string  `json:"rationale"`                  // Human-readable explanation
+}
+
+// BurnRate describes how fast money is spent
+// Runway fields are nil (JSON null) when there are no expenses, i.e., the runway is infinite.
+type BurnRate struct {
+	DailyAverageSpend       float64  `json:"daily_average_spend"`         // Total expenses / days in the period
+	WeeklyAverageSpend      float64  `json:"weekly_average_spend"`        // DailyAverageSpend * 7
+	MonthlyRunRate          float64  `json:"monthly_run_rate"`            // DailyAverageSpend * 30
+	DaysOfRunwayFromSavings *float64 `json:"days_of_runway_from_savings"` // NetSavings / DailyAverageSpend (0 if savings are negative)
+	DaysOfRunwayFromIncome  *float64 `json:"days_of_runway_from_income"`  // Average monthly income / DailyAverageSpend
+}
+
+// PeriodStats aggregates the transactions of a single period
+// The expense extremes are single transactions (not sums) and are omitted when the
+// period has no expenses.
+type PeriodStats struct {
+	Period                string       `json:"period"`                            // "YYYY-MM" (monthly) or "YYYY-Www" (weekly)
+	TransactionCount      int          `json:"transaction_count"`                 // Income and expense transactions
+	TotalIncome           float64      `json:"total_income"`                      // Sum of income
+	TotalExpenses         float64      `json:"total_expenses"`                    // Sum of expenses (positive value)
+	NetSavings            float64      `json:"net_savings"`                       // TotalIncome - TotalExpenses
+	MaxExpenseTransaction *Transaction `json:"max_expense_transaction,omitempty"` // Largest single expense
+	MinExpenseTransaction *Transaction `json:"min_expense_transaction,omitempty"` // Smallest single expense
+}
+
+// SeasonalPattern describes an expense category whose spending concentrates in certain months
+// Months are calendar months (1 = January); each month's share of the category's spending is
+// pooled across years. High months have at least 1.5x the average monthly share, low months at
+// most half of it.
+type SeasonalPattern struct {
+	Category         string  `json:"category"`
+	HighMonths       []int   `json:"high_months"`
+	LowMonths        []int   `json:"low_months"`
+	SeasonalityScore float64 `json:"seasonality_score"` // Coefficient of variation of the monthly shares (ratio, not %)
+}
+
+// Spending momentum trends
+const (
+	MomentumAccelerating = "accelerating"
+	MomentumDecelerating = "decelerating"
+	MomentumStable       = "stable"
+)
+
+// SpendingMomentum describes whether monthly spending is speeding up or slowing down
+// MoMChange is the first derivative of monthly expenses and Acceleration the second, both
+// for the latest month. The trend is stable while |Acceleration| stays below 5.
+type SpendingMomentum struct {
+	MoMChange     float64   `json:"mom_change"`     // Latest month's expenses minus the previous month's
+	Acceleration  float64   `json:"acceleration"`   // Latest MoMChange minus the previous one
+	Trend         string    `json:"trend"`          // "accelerating", "decelerating" or "stable"
+	MonthlyDeltas []float64 `json:"monthly_deltas"` // MoMChange for every month after the first, chronological
+	Accelerations []float64 `json:"accelerations"`  // Acceleration for every month after the second, chronological
+}
+
+// IncomeGrowth compares average monthly income in the earlier and later half of the history
+// AnnualizedRate compounds PercentageChange over the months between the midpoints of the
+// two halves. Both percentages are 0 when the early average is 0.
+type IncomeGrowth struct {
+	EarlyPeriodAvg   float64 `json:"early_period_avg"`  // Average monthly income in the first half
+	LatePeriodAvg    float64 `json:"late_period_avg"`   // Average monthly income in the second half
+	AbsoluteChange   float64 `json:"absolute_change"`   // LatePeriodAvg - EarlyPeriodAvg
+	PercentageChange float64 `json:"percentage_change"` // AbsoluteChange / EarlyPeriodAvg * 100
+	AnnualizedRate   float64 `json:"annualized_rate"`   // PercentageChange compounded to a 12-month rate, as a percentage
+}
+
+// MonthSummary totals the transactions of one calendar month
+type MonthSummary struct {
+	Period     string             `json:"period"`     // "YYYY-MM"
+	Income     float64            `json:"income"`     // Sum of income
+	Expenses   float64            `json:"expenses"`   // Sum of expenses (positive value)
+	Net        float64            `json:"net"`        // Income - Expenses
+	Categories map[string]float64 `json:"categories"` // Spending per expense category (positive values)
+}
+
+// YoYComparison compares a calendar month with the same month a year earlier
+// Deltas has every expense category spent on in either year; a category missing from one
+// year counts as zero there.
+type YoYComparison struct {
+	CurrentYear MonthSummary       `json:"current_year"`
+	PriorYear   MonthSummary       `json:"prior_year"`
+	Deltas      map[string]float64 `json:"deltas"` // Current minus prior spending per category
+}
+
+// Income trends
+const (
+	TrendGrowing   = "growing"
+	TrendStable    = "stable"
+	TrendDeclining = "declining"
+)
+
+// TrendAnalysis fits a least-squares line to monthly income and expenses
+// x is the month index from 0 for the first month, so an intercept is the fitted value for
+// that month and a slope the change per month. IncomeTrend is stable while the income slope
+// stays within 1% of average monthly income.
+type TrendAnalysis struct {
+	IncomeSlopePerMonth   float64 `json:"income_slope_per_month"`   // Fitted change in income per month
+	ExpensesSlopePerMonth float64 `json:"expenses_slope_per_month"` // Fitted change in expenses per month
+	IncomeIntercept       float64 `json:"income_intercept"`         // Fitted income for the first month
+	ExpensesIntercept     float64 `json:"expenses_intercept"`       // Fitted expenses for the first month
+	RSquaredIncome        float64 `json:"r_squared_income"`         // Share of income variance the line explains, 0 to 1
+	RSquaredExpenses      float64 `json:"r_squared_expenses"`       // Share of expense variance the line explains, 0 to 1
+	IncomeTrend           string  `json:"income_trend"`             // "growing", "stable" or "declining"
+}
+
+// CorrelationMatrix holds the Pearson correlation of monthly spending between expense categories
+// Indexed by category twice, e.g., matrix["groceries"]["dining"]; symmetric, with 1 on the
+// diagonal. Values near 1 mean the categories rise and fall together, near -1 that one
+// replaces the other.
+type CorrelationMatrix map[string]map[string]float64
+
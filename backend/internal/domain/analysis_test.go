@@ -0,0 +1,33 @@
+package domain
+
+import "testing"
+
+func TestBenchmarkClassifier_Classify(t *testing.T) {
+	classifier := BenchmarkClassifier{Tolerance: DefaultBenchmarkTolerance}
+
+	tests := []struct {
+		name     string
+		actual   float64
+		target   float64
+		expected string
+	}{
+		{"at target", 30, 30, BenchmarkOnTrack},
+		{"within tolerance above", 33, 30, BenchmarkOnTrack},
+		{"within tolerance below", 27, 30, BenchmarkOnTrack},
+		{"exactly 5 points over", 35, 30, BenchmarkOnTrack},
+		{"exactly 5 points under", 25, 30, BenchmarkOnTrack},
+		{"just over tolerance", 35.01, 30, BenchmarkOver},
+		{"just under tolerance", 24.99, 30, BenchmarkUnder},
+		{"far over", 60, 30, BenchmarkOver},
+		{"no spending", 0, 15, BenchmarkUnder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifier.Classify(tt.actual, tt.target); got != tt.expected {
+				t.Errorf("Classify(%v, %v) = %q, want %q", tt.actual, tt.target, got, tt.expected)
+			}
+		})
+	}
+}
+
@@ -0,0 +1,55 @@
+package domain
+
+import "strings"
+
+// Budget is a monthly spending limit for an expense category
+type Budget struct {
+	Category     string  `json:"category"`      // Expense category the limit applies to
+	MonthlyLimit float64 `json:"monthly_limit"` // Maximum spend per calendar month (positive value)
+}
+
+// Budget warnings attached to expense categories in a CategorySummary
+const (
+	BudgetWarningApproachingLimit = "approaching_limit" // Spent at least BudgetWarningThreshold of the limit
+	BudgetWarningOverLimit        = "over_limit"        // Spent the whole limit or more
+
+	// BudgetWarningThreshold is the share of a monthly limit that triggers approaching_limit
+	BudgetWarningThreshold = 0.8
+)
+
+// Validate checks that the budget names a category and has a positive limit
+// Surrounding whitespace is trimmed from the category
+func (b *Budget) Validate() error {
+	b.Category = strings.TrimSpace(b.Category)
+	if b.Category == "" {
+		return ErrInvalidCategory
+	}
+	if b.MonthlyLimit <= 0 {
+		return ErrInvalidBudget
+	}
+	return nil
+}
+
+// BudgetAlertPayload is the JSON body posted to the budget alert webhook
+// OverageAmount and OveragePercent are negative while spending is still under the limit.
+type BudgetAlertPayload struct {
+	Category       string  `json:"category"`        // Expense category the alert is about
+	Month          string  `json:"month"`           // Month the spending was measured in, "YYYY-MM"
+	BudgetLimit    float64 `json:"budget_limit"`    // Monthly limit of the category's budget
+	ActualSpend    float64 `json:"actual_spend"`    // Spent in the category during Month
+	OverageAmount  float64 `json:"overage_amount"`  // ActualSpend - BudgetLimit
+	OveragePercent float64 `json:"overage_percent"` // OverageAmount as a percentage of BudgetLimit
+}
+
+// SavingsStreak tracks consecutive months that met a goal (e.g., positive net savings)
+// Months are formatted "YYYY-MM"; start/end fields are empty when the streak is 0.
+type SavingsStreak struct {
+	CurrentStreak       int    `json:"current_streak"`        // Consecutive months ending with the latest month
+	CurrentStreakStart  string `json:"current_streak_start"`  // First month of the current streak
+	LongestStreak       int    `json:"longest_streak"`        // Longest run of consecutive months
+	LongestStreakStart  string `json:"longest_streak_start"`  // First month of the longest streak
+	LongestStreakEnd    string `json:"longest_streak_end"`    // Last month of the longest streak
+	TotalPositiveMonths int    `json:"total_positive_months"` // Months that met the goal
+	TotalMonths         int    `json:"total_months"`          // Calendar months in the data
+}
+
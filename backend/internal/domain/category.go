@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CategoryMetadata describes what a category means and how clients should display it
+type CategoryMetadata struct {
+	Name        string `json:"name"`        // Category as it appears on transactions, e.g., "groceries"
+	Description string `json:"description"` // What belongs in the category
+	IconName    string `json:"icon_name"`   // Icon identifier for clients, e.g., "shopping-cart"
+	Color       string `json:"color"`       // Display color as #RRGGBB
+	IsDefault   bool   `json:"is_default"`  // True for built-in entries that have not been overridden
+}
+
+// hexColor matches colors written as #RRGGBB
+var hexColor = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// Validate checks that the metadata names a category and has a #RRGGBB color, if any
+// Surrounding whitespace is trimmed from every field
+func (m *CategoryMetadata) Validate() error {
+	m.Name = strings.TrimSpace(m.Name)
+	m.Description = strings.TrimSpace(m.Description)
+	m.IconName = strings.TrimSpace(m.IconName)
+	m.Color = strings.TrimSpace(m.Color)
+
+	if m.Name == "" {
+		return ErrInvalidCategory
+	}
+	if m.Color != "" && !hexColor.MatchString(m.Color) {
+		return fmt.Errorf("%w: color must be #RRGGBB, got %q", ErrInvalidCategoryMetadata, m.Color)
+	}
+	return nil
+}
+
+// DefaultCategories returns the built-in metadata for the standard categories, sorted by name
+func DefaultCategories() []CategoryMetadata {
+	return []CategoryMetadata{
+		{Name: "dining", Description: "Restaurants, cafes, takeout and food delivery", IconName: "utensils", Color: "#F97316", IsDefault: true},
+		{Name: "entertainment", Description: "Movies, concerts, games and hobbies", IconName: "film", Color: "#A855F7", IsDefault: true},
+		{Name: "groceries", Description: "Supermarkets and food for the home", IconName: "shopping-cart", Color: "#22C55E", IsDefault: true},
+		{Name: "healthcare", Description: "Doctors, pharmacy, dental and insurance copays", IconName: "heart-pulse", Color: "#EF4444", IsDefault: true},
+		{Name: "rent", Description: "Rent or mortgage payments for your home", IconName: "home", Color: "#3B82F6", IsDefault: true},
+		{Name: "salary", Description: "Wages and paychecks from employment", IconName: "briefcase", Color: "#10B981", IsDefault: true},
+		{Name: "savings", Description: "Transfers into savings and investment accounts", IconName: "piggy-bank", Color: "#14B8A6", IsDefault: true},
+		{Name: "shopping", Description: "Clothing, electronics and other retail purchases", IconName: "shopping-bag", Color: "#EC4899", IsDefault: true},
+		{Name: "subscriptions", Description: "Streaming, software and other recurring memberships", IconName: "repeat", Color: "#6366F1", IsDefault: true},
+		{Name: "transportation", Description: "Fuel, transit, rideshare, parking and car costs", IconName: "car", Color: "#F59E0B", IsDefault: true},
+		{Name: "utilities", Description: "Electricity, water, gas, internet and phone bills", IconName: "bolt", Color: "#EAB308", IsDefault: true},
+	}
+}
+
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// SetUserID returns a copy of ctx carrying the authenticated user's ID
+func SetUserID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, userIDKey, id)
+}
+
+// GetUserID returns the user ID stored by SetUserID
+// ok is false when no user ID was set.
+func GetUserID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(userIDKey).(string)
+	return id, ok
+}
+
+// SetTenantID returns a copy of ctx carrying the tenant the request belongs to
+func SetTenantID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantIDKey, id)
+}
+
+// GetTenantID returns the tenant ID stored by SetTenantID
+// ok is false when no tenant ID was set.
+func GetTenantID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(tenantIDKey).(string)
+	return id, ok
+}
+
+// SetPreferredCurrency returns a copy of ctx carrying the ISO 4217 currency to report amounts in
+func SetPreferredCurrency(ctx context.Context, currency string) context.Context {
+	return context.WithValue(ctx, preferredCurrencyKey, currency)
+}
+
+// GetPreferredCurrency returns the currency stored by SetPreferredCurrency, or "" if unset
+func GetPreferredCurrency(ctx context.Context) string {
+	currency, _ := ctx.Value(preferredCurrencyKey).(string)
+	return currency
+}
+
+// SetLocale returns a copy of ctx carrying the client's locale, e.g., "en-US"
+func SetLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey, locale)
+}
+
+// GetLocale returns the locale stored by SetLocale, or "" if unset
+func GetLocale(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey).(string)
+	return locale
+}
+
+// SetFiscalYearStart returns a copy of ctx carrying the month the client's fiscal year starts in
+func SetFiscalYearStart(ctx context.Context, month time.Month) context.Context {
+	return context.WithValue(ctx, fiscalYearStartKey, month)
+}
+
+// GetFiscalYearStart returns the month stored by SetFiscalYearStart, or January if unset
+func GetFiscalYearStart(ctx context.Context) time.Month {
+	if month, ok := ctx.Value(fiscalYearStartKey).(time.Month); ok {
+		return month
+	}
+	return time.January
+}
+
@@ -0,0 +1,14 @@
+package domain
+
+// contextKey is unexported so no other package can collide with these keys
+type contextKey int
+
+// Keys for request-scoped values
+const (
+	userIDKey contextKey = iota
+	tenantIDKey
+	preferredCurrencyKey
+	localeKey
+	fiscalYearStartKey
+)
+
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestContextIdentity_PropagatesThroughMiddleware(t *testing.T) {
+	// A middleware sets the identity; the downstream handler reads it back
+	setIdentity := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := SetUserID(r.Context(), "user-42")
+			ctx = SetTenantID(ctx, "tenant-7")
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	var userID, tenantID string
+	var userOK, tenantOK bool
+	handler := setIdentity(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, userOK = GetUserID(r.Context())
+		tenantID, tenantOK = GetTenantID(r.Context())
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if !userOK || userID != "user-42" {
+		t.Errorf("GetUserID() = %q, %v; want %q, true", userID, userOK, "user-42")
+	}
+	if !tenantOK || tenantID != "tenant-7" {
+		t.Errorf("GetTenantID() = %q, %v; want %q, true", tenantID, tenantOK, "tenant-7")
+	}
+}
+
+func TestContextIdentity_Missing(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"empty context", context.Background()},
+		// Same underlying value as the typed key, but a different type
+		{"untyped int key", context.WithValue(context.Background(), 0, "user-42")},
+		{"string key", context.WithValue(context.Background(), "userID", "user-42")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if id, ok := GetUserID(tt.ctx); ok || id != "" {
+				t.Errorf("GetUserID() = %q, %v; want zero value", id, ok)
+			}
+			if id, ok := GetTenantID(tt.ctx); ok || id != "" {
+				t.Errorf("GetTenantID() = %q, %v; want zero value", id, ok)
+			}
+		})
+	}
+
+	// User and tenant keys do not alias each other
+	ctx := SetUserID(context.Background(), "user-42")
+	if id, ok := GetTenantID(ctx); ok {
+		t.Errorf("GetTenantID() on a user-only context = %q, want unset", id)
+	}
+}
+
+func TestContextIdentity_Concurrent(t *testing.T) {
+	parent := SetTenantID(context.Background(), "shared-tenant")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			want := fmt.Sprintf("user-%d", i)
+			ctx := SetUserID(parent, want)
+
+			if got, _ := GetUserID(ctx); got != want {
+				errs <- fmt.Errorf("goroutine %d: GetUserID() = %q, want %q", i, got, want)
+			}
+			if tenant, _ := GetTenantID(ctx); tenant != "shared-tenant" {
+				errs <- fmt.Errorf("goroutine %d: GetTenantID() = %q", i, tenant)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if _, ok := GetUserID(parent); ok {
+		t.Error("Expected the parent context to stay free of user IDs")
+	}
+}
+
+func TestContextFinancialPreferences(t *testing.T) {
+	// Unset values fall back to the zero value or January
+	ctx := context.Background()
+	if got := GetPreferredCurrency(ctx); got != "" {
+		t.Errorf("GetPreferredCurrency() = %q, want empty", got)
+	}
+	if got := GetLocale(ctx); got != "" {
+		t.Errorf("GetLocale() = %q, want empty", got)
+	}
+	if got := GetFiscalYearStart(ctx); got != time.January {
+		t.Errorf("GetFiscalYearStart() = %v, want January", got)
+	}
+
+	ctx = SetPreferredCurrency(ctx, "MXN")
+	ctx = SetLocale(ctx, "es-MX")
+	ctx = SetFiscalYearStart(ctx, time.April)
+
+	if got := GetPreferredCurrency(ctx); got != "MXN" {
+		t.Errorf("GetPreferredCurrency() = %q, want MXN", got)
+	}
+	if got := GetLocale(ctx); got != "es-MX" {
+		t.Errorf("GetLocale() = %q, want es-MX", got)
+	}
+	if got := GetFiscalYearStart(ctx); got != time.April {
+		t.Errorf("GetFiscalYearStart() = %v, want April", got)
+	}
+}
+
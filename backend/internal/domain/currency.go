@@ -0,0 +1,30 @@
+package domain
+
+import (
+	_ "embed"
+	"strings"
+)
+
+// iso4217Codes is a whitespace-separated list of active ISO 4217 currency codes
+//
+//go:embed iso4217.txt
+var iso4217Codes string
+
+// validCurrencies is the lookup set built from iso4217Codes
+var validCurrencies = buildCurrencySet(iso4217Codes)
+
+// IsValidCurrency reports whether code is a known ISO 4217 currency code
+func IsValidCurrency(code string) bool {
+	_, ok := validCurrencies[code]
+	return ok
+}
+
+// buildCurrencySet converts the embedded code list into a set
+func buildCurrencySet(codes string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, code := range strings.Fields(codes) {
+		set[code] = struct{}{}
+	}
+	return set
+}
+
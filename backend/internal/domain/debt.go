@@ -0,0 +1,22 @@
+package domain
+
+// AmortizationSnapshot captures the state of a loan at the end of a year of repayment
+// The last snapshot may cover a partial year, ending at payoff.
+type AmortizationSnapshot struct {
+	Year          int     `json:"year"`           // 1-based year index
+	Balance       float64 `json:"balance"`        // Outstanding balance at end of year
+	PrincipalPaid float64 `json:"principal_paid"` // Principal repaid so far
+	InterestPaid  float64 `json:"interest_paid"`  // Interest paid so far
+}
+
+// DebtPayoffPlan describes how a loan is repaid with the minimum payment plus an optional extra
+type DebtPayoffPlan struct {
+	Principal           float64                `json:"principal"`             // Starting balance
+	AnnualRate          float64                `json:"annual_rate"`           // e.g., 0.15 for 15%
+	MinimumPayment      float64                `json:"minimum_payment"`       // Amortized monthly payment over the standard term
+	ExtraMonthlyPayment float64                `json:"extra_monthly_payment"` // Paid on top of the minimum every month
+	TotalPayoffMonths   int                    `json:"total_payoff_months"`   // Months until the balance reaches zero
+	TotalInterestPaid   float64                `json:"total_interest_paid"`   // Sum of all interest
+	YearlySnapshots     []AmortizationSnapshot `json:"yearly_snapshots"`      // One entry per (possibly partial) year
+}
+
@@ -1,25 +1,211 @@
 package domain
 
-import "errors"
+import (
+	"fmt"
+	"strings"
+)
+
+// Machine-readable error codes, stable across message wording changes
+const (
+	CodeInvalidDate          = "INVALID_DATE"
+	CodeInvalidCategory      = "INVALID_CATEGORY"
+	CodeInvalidType          = "INVALID_TYPE"
+	CodeInvalidAmount        = "INVALID_AMOUNT"
+	CodeInvalidTag           = "INVALID_TAG"
+	CodeInvalidPaymentMethod = "INVALID_PAYMENT_METHOD"
+	CodeInvalidCurrency      = "INVALID_CURRENCY"
+	CodeUnsupportedCurrency  = "UNSUPPORTED_CURRENCY"
+	CodeNoTransactions       = "NO_TRANSACTIONS"
+	CodeInvalidDateRange     = "INVALID_DATE_RANGE"
+	CodeInvalidProjection    = "INVALID_PROJECTION"
+	CodeInvalidCursor        = "INVALID_CURSOR"
+	CodeInvalidBudget        = "INVALID_BUDGET"
+	CodeBudgetNotFound       = "BUDGET_NOT_FOUND"
+	CodeInvalidAggregation   = "INVALID_AGGREGATION"
+	CodeInvalidFilingStatus  = "INVALID_FILING_STATUS"
+	CodeInvalidRating        = "INVALID_RATING"
+	CodeInvalidComment       = "INVALID_COMMENT"
+	CodeAdviceNotFound       = "ADVICE_NOT_FOUND"
+	CodeServiceUnavailable   = "SERVICE_UNAVAILABLE"
+	CodeSSRFAttempt          = "SSRF_ATTEMPT"
+	CodeInsufficientData     = "INSUFFICIENT_DATA"
+	CodeInvalidCategoryMerge = "INVALID_CATEGORY_MERGE"
+	CodeInvalidAmountRange   = "INVALID_AMOUNT_RANGE"
+	CodeInvalidExportField   = "INVALID_EXPORT_FIELD"
+	CodeTransactionNotFound  = "TRANSACTION_NOT_FOUND"
+	CodeInvalidDebt          = "INVALID_DEBT"
+	CodeInvalidImportFile    = "INVALID_IMPORT_FILE"
+	CodeInvalidCategoryMeta  = "INVALID_CATEGORY_METADATA"
+	CodeCategoryNotFound     = "CATEGORY_NOT_FOUND"
+	CodeInvalidPage          = "INVALID_PAGE"
+	CodeDuplicateID          = "DUPLICATE_TRANSACTION_ID"
+)
+
+// DomainError is a domain failure carrying a machine-readable code
+// Clients should branch on Code; Message is for humans and may change
+type DomainError struct {
+	Code    string // e.g., "INVALID_DATE"
+	Message string // Human-readable description
+}
+
+// Error implements the error interface
+func (e *DomainError) Error() string {
+	return e.Message
+}
 
 // Domain-level errors
 var (
 	// ErrInvalidDate is returned when a transaction has an invalid date format
-	ErrInvalidDate = errors.New("invalid date format, expected YYYY-MM-DD")
+	ErrInvalidDate = &DomainError{Code: CodeInvalidDate, Message: "invalid date format, expected YYYY-MM-DD"}
 
 	// ErrInvalidCategory is returned when a transaction has an empty category
-	ErrInvalidCategory = errors.New("category cannot be empty")
+	ErrInvalidCategory = &DomainError{Code: CodeInvalidCategory, Message: "category cannot be empty"}
 
 	// ErrInvalidType is returned when a transaction type is not "income" or "expense"
-	ErrInvalidType = errors.New("type must be either 'income' or 'expense'")
+	ErrInvalidType = &DomainError{Code: CodeInvalidType, Message: "type must be either 'income' or 'expense'"}
 
 	// ErrInvalidAmount is returned when amount sign doesn't match transaction type
-	ErrInvalidAmount = errors.New("amount sign must match transaction type")
+	ErrInvalidAmount = &DomainError{Code: CodeInvalidAmount, Message: "amount sign must match transaction type"}
+
+	// ErrInvalidTag is returned when a transaction has a blank tag
+	ErrInvalidTag = &DomainError{Code: CodeInvalidTag, Message: "tags cannot be blank"}
+
+	// ErrInvalidPaymentMethod is returned when a payment method is not one of domain.PaymentMethods
+	ErrInvalidPaymentMethod = &DomainError{Code: CodeInvalidPaymentMethod, Message: "payment method must be one of: credit_card, debit_card, cash, bank_transfer"}
+
+	// ErrInvalidCurrency is returned when a currency is not a known ISO 4217 code
+	ErrInvalidCurrency = &DomainError{Code: CodeInvalidCurrency, Message: "currency must be a valid ISO 4217 code"}
+
+	// ErrUnsupportedCurrency is returned when no exchange rate is available for a currency
+	ErrUnsupportedCurrency = &DomainError{Code: CodeUnsupportedCurrency, Message: "no exchange rate available for currency"}
 
 	// ErrNoTransactions is returned when no transactions are found
-	ErrNoTransactions = errors.New("no transactions found")
+	ErrNoTransactions = &DomainError{Code: CodeNoTransactions, Message: "no transactions found"}
 
 	// ErrInvalidDateRange is returned when date range is invalid
-	ErrInvalidDateRange = errors.New("invalid date range: start date must be before end date")
+	ErrInvalidDateRange = &DomainError{Code: CodeInvalidDateRange, Message: "invalid date range: start date must be before end date"}
+
+	// ErrInvalidAmountRange is returned when an amount range is negative or inverted
+	ErrInvalidAmountRange = &DomainError{Code: CodeInvalidAmountRange, Message: "invalid amount range: minimum must not be negative or greater than maximum"}
+
+	// ErrInvalidExportField is returned when an export asks for a column that is not a transaction field
+	ErrInvalidExportField = &DomainError{Code: CodeInvalidExportField, Message: "unknown export field"}
+
+	// ErrInvalidProjection is returned when forecast parameters are out of range
+	ErrInvalidProjection = &DomainError{Code: CodeInvalidProjection, Message: "invalid projection: years must be between 1 and 50 and rate must not be negative"}
+
+	// ErrInvalidDebt is returned when debt payoff parameters are out of range
+	ErrInvalidDebt = &DomainError{Code: CodeInvalidDebt, Message: "invalid debt: principal must be positive, rate between 0 and 1 and extra payment not negative"}
+
+	// ErrInvalidCursor is returned when a pagination cursor is malformed or stale
+	ErrInvalidCursor = &DomainError{Code: CodeInvalidCursor, Message: "invalid pagination cursor"}
+
+	// ErrInvalidBudget is returned when a budget limit is not positive
+	ErrInvalidBudget = &DomainError{Code: CodeInvalidBudget, Message: "budget monthly limit must be positive"}
+
+	// ErrBudgetNotFound is returned when no budget exists for a category
+	ErrBudgetNotFound = &DomainError{Code: CodeBudgetNotFound, Message: "no budget set for category"}
+
+	// ErrInvalidAggregation is returned when an aggregation is not "monthly" or "weekly"
+	ErrInvalidAggregation = &DomainError{Code: CodeInvalidAggregation, Message: "aggregation must be either 'monthly' or 'weekly'"}
+
+	// ErrInvalidFilingStatus is returned when no tax brackets exist for a filing status
+	ErrInvalidFilingStatus = &DomainError{Code: CodeInvalidFilingStatus, Message: "filing status must be one of: single, married_joint, married_separate, head_of_household"}
+
+	// ErrInvalidRating is returned when advice feedback has a rating outside 1-5
+	ErrInvalidRating = &DomainError{Code: CodeInvalidRating, Message: "rating must be between 1 and 5"}
+
+	// ErrInvalidComment is returned when an advice feedback comment is too long
+	ErrInvalidComment = &DomainError{Code: CodeInvalidComment, Message: "comment must be at most 200 characters"}
+
+	// ErrAdviceNotFound is returned when no advice record exists for an ID
+	ErrAdviceNotFound = &DomainError{Code: CodeAdviceNotFound, Message: "advice not found"}
+
+	// ErrServiceUnavailable is returned when an upstream dependency is failing and calls are short-circuited
+	ErrServiceUnavailable = &DomainError{Code: CodeServiceUnavailable, Message: "service temporarily unavailable"}
+
+	// ErrSSRFAttempt is returned when an outbound request targets a non-HTTPS URL or a private, loopback or link-local address
+	ErrSSRFAttempt = &DomainError{Code: CodeSSRFAttempt, Message: "outbound request to a disallowed address"}
+
+	// ErrInsufficientData is returned when there is too little history for an analysis
+	ErrInsufficientData = &DomainError{Code: CodeInsufficientData, Message: "not enough data for this analysis"}
+
+	// ErrInvalidCategoryMerge is returned when a category merge has the same source and target
+	ErrInvalidCategoryMerge = &DomainError{Code: CodeInvalidCategoryMerge, Message: "source and target categories must be different"}
+
+	// ErrTransactionNotFound is returned when no transaction exists for an ID
+	ErrTransactionNotFound = &DomainError{Code: CodeTransactionNotFound, Message: "transaction not found"}
+
+	// ErrInvalidImportFile is returned when an imported file is not in the expected format
+	ErrInvalidImportFile = &DomainError{Code: CodeInvalidImportFile, Message: "invalid import file"}
+
+	// ErrInvalidCategoryMetadata is returned when category metadata has a malformed field, e.g., its color
+	ErrInvalidCategoryMetadata = &DomainError{Code: CodeInvalidCategoryMeta, Message: "invalid category metadata"}
+
+	// ErrCategoryNotFound is returned when no metadata exists for a category
+	ErrCategoryNotFound = &DomainError{Code: CodeCategoryNotFound, Message: "category not found"}
+
+	// ErrInvalidPage is returned when a page number or page size is less than 1
+	ErrInvalidPage = &DomainError{Code: CodeInvalidPage, Message: "invalid page: page and page size must be at least 1"}
+
+	// ErrDuplicateID is returned when a new transaction reuses the ID of a stored one
+	ErrDuplicateID = &DomainError{Code: CodeDuplicateID, Message: "a transaction with this ID already exists"}
 )
 
+// HTTPError is returned when an upstream HTTP API (e.g., OpenAI) answers with a non-200 status
+type HTTPError struct {
+	StatusCode int    // Upstream HTTP status code
+	Message    string // Upstream response body or error message
+	RetryAfter string // Upstream Retry-After header, if any (e.g., on 429)
+}
+
+// Error implements the error interface
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("upstream API error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// ValidationError describes a single invalid field
+type ValidationError struct {
+	Field   string       `json:"field"`   // JSON name of the invalid field
+	Code    string       `json:"code"`    // Machine-readable code, e.g., "INVALID_TYPE"
+	Message string       `json:"message"` // Human-readable description
+	err     *DomainError // Underlying sentinel error, for errors.Is and errors.As
+}
+
+// Error implements the error interface
+func (e ValidationError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors aggregates every violation found while validating a value
+type ValidationErrors []ValidationError
+
+// Error implements the error interface by joining all violations
+func (v *ValidationErrors) Error() string {
+	messages := make([]string, len(*v))
+	for i, e := range *v {
+		messages[i] = e.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap exposes the underlying sentinel errors so errors.Is keeps working
+// e.g., errors.Is(err, ErrInvalidType)
+func (v *ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(*v))
+	for i, e := range *v {
+		errs[i] = e.err
+	}
+	return errs
+}
+
+// add appends a violation for field backed by the given sentinel error
+func (v *ValidationErrors) add(field string, err *DomainError) {
+	*v = append(*v, ValidationError{
+		Field:   field,
+		Code:    err.Code,
+		Message: err.Error(),
+		err:     err,
+	})
+}
+
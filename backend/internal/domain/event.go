@@ -0,0 +1,32 @@
+package domain
+
+// Event types published when transactions change
+const (
+	EventTransactionCreated = "transaction_created" // Data is the stored Transaction
+	EventAnalyticsUpdated   = "analytics_updated"   // Data is an AnalyticsUpdate
+)
+
+// Event is a change notification delivered to subscribers of an EventBus
+type Event struct {
+	Type string
+	Data any // Serialized as the event's JSON payload
+}
+
+// AnalyticsUpdate tells subscribers that analytics computed from transactions are stale
+type AnalyticsUpdate struct {
+	Change string `json:"change"` // "created", "imported", "updated" or "category_renamed"
+	Count  int    `json:"count"`  // Transactions affected
+}
+
+// EventBus fans events out to every current subscriber
+type EventBus interface {
+	// Subscribe returns a channel receiving every event published from now on
+	Subscribe() <-chan Event
+
+	// Unsubscribe stops delivery to a channel returned by Subscribe and closes it
+	Unsubscribe(events <-chan Event)
+
+	// Publish delivers event to every subscriber without blocking
+	Publish(event Event)
+}
+
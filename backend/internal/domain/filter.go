@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// TransactionFilter describes optional criteria for selecting transactions
+// Zero-value fields are ignored; all set fields must match (AND semantics).
+// Multi-valued fields match when the transaction has any of the values (Types,
+// Categories) or all of them (Tags).
+type TransactionFilter struct {
+	StartDate  *time.Time // Inclusive lower bound on the transaction date
+	EndDate    *time.Time // Inclusive upper bound on the transaction date
+	Types      []string   // "income" and/or "expense"
+	Categories []string   // Exact category names
+	Merchant   string     // Exact merchant name (case-sensitive)
+	MinAmount  *float64   // Inclusive lower bound on the absolute amount
+	MaxAmount  *float64   // Inclusive upper bound on the absolute amount
+	Query      string     // Case-insensitive substring of the description
+	Tags       []string   // Transaction must carry every listed tag
+
+	PaymentMethod string // Exact payment method, e.g., "credit_card"
+}
+
+// Matches reports whether the transaction satisfies every criterion of the filter
+// When checking many transactions, build the predicate once with Predicate instead.
+func (f TransactionFilter) Matches(tx Transaction) bool {
+	return f.Predicate()(tx)
+}
+
+// Predicate composes one check per set criterion into a single function
+// Criteria that are not set add no work; an empty filter matches everything.
+func (f TransactionFilter) Predicate() func(Transaction) bool {
+	var checks []func(tx *Transaction) bool
+
+	if f.StartDate != nil || f.EndDate != nil {
+		checks = append(checks, func(tx *Transaction) bool {
+			date, err := tx.ParseDate()
+			if err != nil {
+				return false
+			}
+			if f.StartDate != nil && date.Before(*f.StartDate) {
+				return false
+			}
+			return f.EndDate == nil || !date.After(*f.EndDate)
+		})
+	}
+	if len(f.Types) > 0 {
+		checks = append(checks, func(tx *Transaction) bool { return containsString(f.Types, tx.Type) })
+	}
+	if len(f.Categories) > 0 {
+		checks = append(checks, func(tx *Transaction) bool { return containsString(f.Categories, tx.Category) })
+	}
+	if f.Merchant != "" {
+		checks = append(checks, func(tx *Transaction) bool { return tx.Merchant == f.Merchant })
+	}
+	if f.PaymentMethod != "" {
+		checks = append(checks, func(tx *Transaction) bool { return tx.PaymentMethod == f.PaymentMethod })
+	}
+	if f.MinAmount != nil {
+		checks = append(checks, func(tx *Transaction) bool { return tx.AbsoluteAmount() >= *f.MinAmount })
+	}
+	if f.MaxAmount != nil {
+		checks = append(checks, func(tx *Transaction) bool { return tx.AbsoluteAmount() <= *f.MaxAmount })
+	}
+	if f.Query != "" {
+		query := strings.ToLower(f.Query)
+		checks = append(checks, func(tx *Transaction) bool {
+			return strings.Contains(strings.ToLower(tx.Description), query)
+		})
+	}
+	if len(f.Tags) > 0 {
+		checks = append(checks, func(tx *Transaction) bool {
+			for _, tag := range f.Tags {
+				if !tx.HasTag(tag) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	return func(tx Transaction) bool {
+		for _, check := range checks {
+			if !check(&tx) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// IsEmpty reports whether the filter has no criteria set
+func (f TransactionFilter) IsEmpty() bool {
+	return f.StartDate == nil && f.EndDate == nil && len(f.Types) == 0 && len(f.Categories) == 0 &&
+		f.Merchant == "" && f.MinAmount == nil && f.MaxAmount == nil && f.Query == "" && len(f.Tags) == 0 &&
+		f.PaymentMethod == ""
+}
+
+// containsString reports whether values includes value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
@@ -0,0 +1,21 @@
+package domain
+
+// YearlyBalance captures the projected savings balance at the end of a year
+type YearlyBalance struct {
+	Year                 int     `json:"year"`                    // 1-based year index
+	Balance              float64 `json:"balance"`                 // Balance at end of year
+	ContributionsToDate  float64 `json:"contributions_to_date"`   // Sum of contributions so far
+	InterestEarnedToDate float64 `json:"interest_earned_to_date"` // Sum of interest so far
+}
+
+// SavingsProjection describes compound growth of recurring monthly savings
+type SavingsProjection struct {
+	AnnualRate          float64         `json:"annual_rate"`           // e.g., 0.07 for 7%
+	Years               int             `json:"years"`                 // Projection horizon
+	MonthlyContribution float64         `json:"monthly_contribution"`  // Average monthly net savings
+	YearlySnapshots     []YearlyBalance `json:"yearly_snapshots"`      // One entry per year
+	FinalBalance        float64         `json:"final_balance"`         // Balance at end of horizon
+	TotalContributions  float64         `json:"total_contributions"`   // Sum of all contributions
+	TotalInterestEarned float64         `json:"total_interest_earned"` // Sum of all interest
+}
+
@@ -0,0 +1,57 @@
+package domain
+
+// Statuses of a single transaction in a bulk import
+const (
+	ImportStatusCreated     = "created"     // Stored
+	ImportStatusValid       = "valid"       // Would be stored; dry runs only
+	ImportStatusInvalid     = "invalid"     // Rejected; see Errors
+	ImportStatusSkipped     = "skipped"     // A duplicate left out by ConflictSkip
+	ImportStatusOverwritten = "overwritten" // A duplicate stored over the existing transaction by ConflictOverwrite
+)
+
+// ConflictResolution decides what a bulk import does with a transaction whose date, amount
+// and description match one already stored, or one earlier in the same import
+type ConflictResolution string
+
+// Conflict resolution strategies
+const (
+	ConflictSkip      ConflictResolution = "skip"      // Keep the existing transaction and drop the new one
+	ConflictOverwrite ConflictResolution = "overwrite" // Replace the existing transaction with the new one
+	ConflictAppend    ConflictResolution = "append"    // Store both, suffixing the new description, e.g., "Market (2)"
+)
+
+// IsValid returns true if c is one of the ConflictResolution constants
+func (c ConflictResolution) IsValid() bool {
+	switch c {
+	case ConflictSkip, ConflictOverwrite, ConflictAppend:
+		return true
+	}
+	return false
+}
+
+// ImportOptions controls how a bulk import stores transactions
+type ImportOptions struct {
+	ConflictResolution ConflictResolution // Empty stores conflicting transactions unchanged
+	DryRun             bool               // Validate and report without storing anything
+}
+
+// ImportItemResult reports the outcome for one transaction of a bulk import
+type ImportItemResult struct {
+	Index    int              `json:"index"`              // Position in the submitted list
+	Status   string           `json:"status"`             // One of the ImportStatus constants
+	Errors   ValidationErrors `json:"errors,omitempty"`   // Every invalid field, when Status is "invalid"
+	Conflict bool             `json:"conflict,omitempty"` // Duplicates an existing or earlier transaction
+}
+
+// ImportReport summarizes a bulk import
+// Valid transactions are stored even when others are invalid, unless DryRun is set.
+type ImportReport struct {
+	DryRun                 bool               `json:"dry_run"`                  // Nothing was stored
+	Results                []ImportItemResult `json:"results"`                  // One entry per submitted transaction, in order
+	ValidCount             int                `json:"valid_count"`              // Transactions that passed validation
+	InvalidCount           int                `json:"invalid_count"`            // Transactions that failed validation
+	ConflictCount          int                `json:"conflict_count"`           // Valid transactions that duplicate another
+	EstimatedTotalIncome   float64            `json:"estimated_total_income"`   // Sum of valid income
+	EstimatedTotalExpenses float64            `json:"estimated_total_expenses"` // Sum of valid expenses (positive value)
+}
+
@@ -0,0 +1,50 @@
+package domain
+
+// PaginationMeta describes which page of a list a response holds
+// Responses that return every item report a single page holding all of them.
+type PaginationMeta struct {
+	Page       int `json:"page"`        // 1-based page number
+	PageSize   int `json:"page_size"`   // Maximum items per page
+	Total      int `json:"total"`       // Items across all pages
+	TotalPages int `json:"total_pages"` // Pages needed for Total items; an empty list is one empty page
+}
+
+// NewPaginationMeta returns the metadata of the given page of total items
+func NewPaginationMeta(page, pageSize, total int) PaginationMeta {
+	totalPages := 1
+	if pageSize > 0 && total > pageSize {
+		totalPages = (total + pageSize - 1) / pageSize
+	}
+
+	return PaginationMeta{
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
+
+// SinglePage returns the metadata of an unpaginated list of total items
+func SinglePage(total int) PaginationMeta {
+	return NewPaginationMeta(1, total, total)
+}
+
+// Paginate returns the items on the given 1-based page, with its metadata
+// A page past the last one is empty rather than an error, e.g., page 3 of 10 items at 5
+// per page returns no items and TotalPages 2.
+// Returns ErrInvalidPage if page or pageSize is less than 1.
+func Paginate[T any](items []T, page, pageSize int) ([]T, PaginationMeta, error) {
+	if page < 1 || pageSize < 1 {
+		return nil, PaginationMeta{}, ErrInvalidPage
+	}
+
+	// Compare page numbers rather than offsets so a huge page cannot overflow
+	start := len(items)
+	if page-1 <= len(items)/pageSize {
+		start = min((page-1)*pageSize, len(items))
+	}
+	end := start + min(pageSize, len(items)-start)
+
+	return items[start:end], NewPaginationMeta(page, pageSize, len(items)), nil
+}
+
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	tests := []struct {
+		name      string
+		page      int
+		pageSize  int
+		wantItems []int
+		wantMeta  PaginationMeta
+	}{
+		{"first page", 1, 4, []int{1, 2, 3, 4}, PaginationMeta{Page: 1, PageSize: 4, Total: 10, TotalPages: 3}},
+		{"partial last page", 3, 4, []int{9, 10}, PaginationMeta{Page: 3, PageSize: 4, Total: 10, TotalPages: 3}},
+		{"exact division", 2, 5, []int{6, 7, 8, 9, 10}, PaginationMeta{Page: 2, PageSize: 5, Total: 10, TotalPages: 2}},
+		{"page size above total", 1, 25, items, PaginationMeta{Page: 1, PageSize: 25, Total: 10, TotalPages: 1}},
+		{"out of range page", 4, 4, []int{}, PaginationMeta{Page: 4, PageSize: 4, Total: 10, TotalPages: 3}},
+		{"huge page", 1 << 62, 4, []int{}, PaginationMeta{Page: 1 << 62, PageSize: 4, Total: 10, TotalPages: 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, meta, err := Paginate(items, tt.page, tt.pageSize)
+			if err != nil {
+				t.Fatalf("Paginate() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.wantItems) {
+				t.Errorf("Paginate() items = %v, want %v", got, tt.wantItems)
+			}
+			if meta != tt.wantMeta {
+				t.Errorf("Paginate() meta = %+v, want %+v", meta, tt.wantMeta)
+			}
+		})
+	}
+
+	for _, bad := range [][2]int{{0, 4}, {-1, 4}, {1, 0}} {
+		if _, _, err := Paginate(items, bad[0], bad[1]); !errors.Is(err, ErrInvalidPage) {
+			t.Errorf("Paginate(page %d, size %d) error = %v, want ErrInvalidPage", bad[0], bad[1], err)
+		}
+	}
+}
+
+func TestSinglePage(t *testing.T) {
+	if got, want := SinglePage(7), (PaginationMeta{Page: 1, PageSize: 7, Total: 7, TotalPages: 1}); got != want {
+		t.Errorf("SinglePage(7) = %+v, want %+v", got, want)
+	}
+	if got := SinglePage(0); got.TotalPages != 1 || got.Total != 0 {
+		t.Errorf("SinglePage(0) = %+v, want one empty page", got)
+	}
+}
+
@@ -0,0 +1,10 @@
+package domain
+
+import "time"
+
+// ReportDelivery confirms that a report was sent
+type ReportDelivery struct {
+	Recipient string    `json:"recipient"` // Email address the report was sent to
+	SentAt    time.Time `json:"sent_at"`   // When the SMTP server accepted it
+}
+
@@ -0,0 +1,26 @@
+package domain
+
+// TaxDisclaimer accompanies every tax estimate
+const TaxDisclaimer = "This is a rough estimate for planning purposes only, based on federal income tax brackets and your recorded transactions. It is not tax advice; consult a tax professional."
+
+// Supported filing statuses
+const (
+	FilingStatusSingle          = "single"
+	FilingStatusMarriedJoint    = "married_joint"
+	FilingStatusMarriedSeparate = "married_separate"
+	FilingStatusHeadOfHousehold = "head_of_household"
+)
+
+// TaxEstimate is an approximate annual income tax liability
+type TaxEstimate struct {
+	TaxYear            int     `json:"tax_year"`
+	FilingStatus       string  `json:"filing_status"`
+	GrossIncome        float64 `json:"gross_income"`        // Sum of income transactions in the year
+	DeductibleExpenses float64 `json:"deductible_expenses"` // Expenses in deductible categories
+	StandardDeduction  float64 `json:"standard_deduction"`  // Used instead when larger than DeductibleExpenses
+	TaxableIncome      float64 `json:"taxable_income"`      // GrossIncome minus the larger deduction, never negative
+	EstimatedTax       float64 `json:"estimated_tax"`       // Tax from the progressive brackets
+	EffectiveRate      float64 `json:"effective_rate"`      // (EstimatedTax / GrossIncome) * 100
+	Disclaimer         string  `json:"disclaimer"`
+}
+
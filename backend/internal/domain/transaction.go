@@ -1,17 +1,33 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"math"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // Transaction represents a single financial transaction
 type Transaction struct {
-	Date        string  `json:"date"`        // ISO 8601 format (YYYY-MM-DD)
-	Amount      float64 `json:"amount"`      // Positive for income, negative for expenses
-	Category    string  `json:"category"`    // e.g., "salary", "rent", "groceries"
-	Description string  `json:"description"` // Human-readable description
-	Type        string  `json:"type"`        // "income" or "expense"
+	ID          string   `json:"id,omitempty"`       // Stable identifier; assigned by the repository when missing
+	Date        string   `json:"date"`               // ISO 8601 format (YYYY-MM-DD)
+	Amount      float64  `json:"amount"`             // Positive for income, negative for expenses
+	Category    string   `json:"category"`           // e.g., "salary", "rent", "groceries"
+	Description string   `json:"description"`        // Human-readable description
+	Type        string   `json:"type"`               // "income" or "expense"
+	Merchant    string   `json:"merchant,omitempty"` // Optional; derived from description on load when empty
+	Currency    string   `json:"currency,omitempty"` // ISO 4217 code; empty means the base currency
+	Tags        []string `json:"tags,omitempty"`     // Free-form labels, e.g., "vacation", "business"
+	Notes       string   `json:"notes,omitempty"`    // Free-form remarks, e.g., carried over from an import
+
+	PaymentMethod string `json:"payment_method,omitempty"` // One of PaymentMethods; empty when unknown
+	AccountID     string `json:"account_id,omitempty"`     // Data source the transaction was loaded from, e.g., "checking"
+
+	// Computed by recurrence detection
+	IsRecurring          bool `json:"is_recurring"`                     // Part of a regular charge pattern
+	RecurrencePeriodDays *int `json:"recurrence_period_days,omitempty"` // Days between charges, when recurring
 }
 
 // Period represents a time range
@@ -21,11 +37,26 @@ type Period struct {
 	Months int    `json:"months"` // Number of months in period
 }
 
+// TransactionsPeriod is the period of a transaction listing with the totals of the listed transactions
+// Totals are always present, so a listing without income reports "income": 0.
+type TransactionsPeriod struct {
+	Period
+	Currency         string  `json:"currency,omitempty"` // Currency of the totals; the base currency
+	Income           float64 `json:"income"`             // Sum of income amounts
+	Expenses         float64 `json:"expenses"`           // Sum of absolute expense amounts
+	NetSavings       float64 `json:"net_savings"`        // Income - Expenses
+	TransactionCount int     `json:"transaction_count"`  // Number of transactions summed
+}
+
 // CategoryDetail holds aggregated data for a single category
 type CategoryDetail struct {
-	Total      float64 `json:"total"`      // Total amount for this category
-	Count      int     `json:"count"`      // Number of transactions
-	Percentage float64 `json:"percentage"` // Percentage of total expenses/income
+	Total           float64 `json:"total"`             // Total amount for this category
+	Count           int     `json:"count"`             // Number of transactions
+	Percentage      float64 `json:"percentage"`        // Percentage of total expenses/income
+	PercentOfIncome float64 `json:"percent_of_income"` // Percentage of total income
+	Average         float64 `json:"average"`           // Total / Count
+	MonthlyAverage  float64 `json:"monthly_average"`   // Total / months in the period
+	Warning         string  `json:"warning,omitempty"` // Budget warning for the latest month, e.g., "approaching_limit"
 }
 
 // FinancialSummary provides high-level financial metrics
@@ -38,10 +69,25 @@ type FinancialSummary struct {
 
 // CategorySummary contains category-wise breakdown and overall summary
 type CategorySummary struct {
-	Income   map[string]CategoryDetail `json:"income"`   // Income categories
-	Expenses map[string]CategoryDetail `json:"expenses"` // Expense categories
-	Summary  FinancialSummary          `json:"summary"`  // Overall financial summary
-	Period   Period                    `json:"period"`   // Time period covered
+	Income   map[string]CategoryDetail `json:"income"`             // Income categories
+	Expenses map[string]CategoryDetail `json:"expenses"`           // Expense categories
+	Summary  FinancialSummary          `json:"summary"`            // Overall financial summary
+	Period   Period                    `json:"period"`             // Time period covered
+	Currency string                    `json:"currency,omitempty"` // Currency of all amounts, when converted
+
+	// MoMChange is the percentage change in spending per expense category between
+	// the most recent month and the month before it
+	MoMChange map[string]float64 `json:"mom_change"`
+}
+
+// EmptyCategorySummary returns the summary of a period without transactions
+// Maps are empty rather than nil so they serialize as {} instead of null.
+func EmptyCategorySummary() *CategorySummary {
+	return &CategorySummary{
+		Income:    map[string]CategoryDetail{},
+		Expenses:  map[string]CategoryDetail{},
+		MoMChange: map[string]float64{},
+	}
 }
 
 // TimelinePoint represents aggregated data for a specific time period
@@ -56,13 +102,36 @@ type TimelinePoint struct {
 type TimelineResponse struct {
 	Timeline    []TimelinePoint `json:"timeline"`    // Ordered time series data
 	Aggregation string          `json:"aggregation"` // "monthly" or "weekly"
+	PaginationMeta
+}
+
+// EmptyTimelineResponse returns a monthly timeline without any points
+// Timeline is empty rather than nil so it serializes as [] instead of null.
+func EmptyTimelineResponse() *TimelineResponse {
+	return &TimelineResponse{
+		Timeline:       []TimelinePoint{},
+		Aggregation:    "monthly",
+		PaginationMeta: SinglePage(0),
+	}
+}
+
+// SpendingHeatmap holds total expenses by day of week and hour of day
+// Matrix is indexed [dayOfWeek][hourOfDay] with Sunday = 0 and hours 0-23.
+// Transactions currently carry only a date, so all spending lands in hour 0;
+// the shape stays the same once timestamps are recorded.
+type SpendingHeatmap struct {
+	Matrix [][]float64 `json:"matrix"` // 7 rows (days) x 24 columns (hours), expense totals
+	Period Period      `json:"period"` // Time period covered
 }
 
 // TransactionsResponse contains transactions with metadata
 type TransactionsResponse struct {
-	Transactions []Transaction `json:"transactions"` // List of transactions
-	Count        int           `json:"count"`        // Total count
-	Period       Period        `json:"period"`       // Time period covered
+	Transactions []Transaction      `json:"transactions"`          // List of transactions
+	Count        int                `json:"count"`                 // Total count
+	Period       TransactionsPeriod `json:"period"`                // Time period covered, with totals
+	NextCursor   string             `json:"next_cursor,omitempty"` // Opaque cursor for the following page, when paginated
+	PrevCursor   string             `json:"prev_cursor,omitempty"` // Opaque cursor for the preceding page, when paginated
+	PaginationMeta
 }
 
 // AIAdviceRequest represents a request for financial advice
@@ -79,12 +148,74 @@ type AIAdviceResponse struct {
 	Timestamp       time.Time `json:"timestamp"`       // When advice was generated
 }
 
+// Accepted values for Transaction.PaymentMethod
+const (
+	PaymentMethodCreditCard   = "credit_card"
+	PaymentMethodDebitCard    = "debit_card"
+	PaymentMethodCash         = "cash"
+	PaymentMethodBankTransfer = "bank_transfer"
+)
+
+// PaymentMethods lists every accepted payment method
+var PaymentMethods = []string{PaymentMethodCreditCard, PaymentMethodDebitCard, PaymentMethodCash, PaymentMethodBankTransfer}
+
+// IsValidPaymentMethod reports whether method is one of PaymentMethods
+func IsValidPaymentMethod(method string) bool {
+	for _, valid := range PaymentMethods {
+		if method == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// PaymentMethodSummary breaks expenses down by payment method
+// Transactions without a payment method are grouped under "unspecified".
+type PaymentMethodSummary struct {
+	Methods       map[string]CategoryDetail `json:"methods"`
+	TotalExpenses float64                   `json:"total_expenses"`
+}
+
+// MerchantDetail holds aggregated data for a single merchant
+type MerchantDetail struct {
+	CategoryDetail
+	TopCategory string `json:"top_category"` // Category with the highest spend at this merchant
+}
+
 // HealthResponse represents API health status
 type HealthResponse struct {
 	Status    string    `json:"status"`    // "healthy" or "unhealthy"
 	Timestamp time.Time `json:"timestamp"` // Current server time
 }
 
+// BuildInfo describes the running build of the API
+type BuildInfo struct {
+	Version    string `json:"version"`     // Semantic version or "dev"
+	Commit     string `json:"commit"`      // Git commit SHA or "dev"
+	BuildDate  string `json:"build_date"`  // Build timestamp or "dev"
+	GoVersion  string `json:"go_version"`  // Go toolchain version
+	ModulePath string `json:"module_path"` // Main module path
+}
+
+// DuplicateGroup is a set of transactions that look like one transaction recorded more than once
+type DuplicateGroup struct {
+	Transactions []Transaction `json:"transactions"` // Suspected duplicates, in stored order
+	Reason       string        `json:"reason"`       // Fields they share, e.g., "same date, amount and category"
+}
+
+// DuplicatesResponse lists the suspected duplicate transactions
+type DuplicatesResponse struct {
+	Groups []DuplicateGroup `json:"groups"` // Ordered by their first transaction
+	Count  int              `json:"count"`  // Number of groups
+}
+
+// GoroutineDump reports the live goroutines and their stacks
+type GoroutineDump struct {
+	GoroutineCount int    `json:"goroutine_count"`
+	Stack          string `json:"stack"`                     // All goroutine stacks, cut at the size limit
+	StackTruncated bool   `json:"stack_truncated,omitempty"` // Stack was cut at the size limit
+}
+
 // Helper methods
 
 // IsIncome returns true if the transaction is income
@@ -97,11 +228,29 @@ func (t *Transaction) IsExpense() bool {
 	return t.Type == "expense"
 }
 
+// HasTag returns true if the transaction is labeled with the given tag
+func (t *Transaction) HasTag(tag string) bool {
+	for _, existing := range t.Tags {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
+
 // AbsoluteAmount returns the absolute value of the amount
 func (t *Transaction) AbsoluteAmount() float64 {
 	return math.Abs(t.Amount)
 }
 
+// Fingerprint returns a hex SHA-256 of the date, amount and description
+// Two transactions with the same fingerprint are treated as the same real-world transaction,
+// e.g., when the same statement is imported twice.
+func (t *Transaction) Fingerprint() string {
+	sum := sha256.Sum256([]byte(t.Date + "|" + strconv.FormatFloat(t.Amount, 'f', -1, 64) + "|" + t.Description))
+	return hex.EncodeToString(sum[:])
+}
+
 // ParseDate parses the transaction date into a time.Time
 func (t *Transaction) ParseDate() (time.Time, error) {
 	return time.Parse("2006-01-02", t.Date)
@@ -116,28 +265,82 @@ func (t *Transaction) GetYearMonth() (string, error) {
 	return date.Format("2006-01"), nil
 }
 
+// TransactionPatch lists the fields of a partial transaction update
+// Nil fields are left unchanged; a non-nil Tags replaces every tag (an empty list clears them).
+type TransactionPatch struct {
+	Date        *string   `json:"date"`
+	Amount      *float64  `json:"amount"`
+	Category    *string   `json:"category"`
+	Description *string   `json:"description"`
+	Type        *string   `json:"type"`
+	Tags        *[]string `json:"tags"`
+	Notes       *string   `json:"notes"`
+}
+
+// Apply copies the set fields of the patch onto tx
+// The result is not validated; call Validate on the merged transaction.
+func (p TransactionPatch) Apply(tx *Transaction) {
+	if p.Date != nil {
+		tx.Date = *p.Date
+	}
+	if p.Amount != nil {
+		tx.Amount = *p.Amount
+	}
+	if p.Category != nil {
+		tx.Category = *p.Category
+	}
+	if p.Description != nil {
+		tx.Description = *p.Description
+	}
+	if p.Type != nil {
+		tx.Type = *p.Type
+	}
+	if p.Tags != nil {
+		tx.Tags = append([]string(nil), (*p.Tags)...)
+	}
+	if p.Notes != nil {
+		tx.Notes = *p.Notes
+	}
+}
+
 // Validate checks if the transaction has valid data
+// All violations are collected and returned together as *ValidationErrors
 func (t *Transaction) Validate() error {
-	if t.Date == "" {
-		return ErrInvalidDate
-	}
+	var errs ValidationErrors
+
 	if _, err := t.ParseDate(); err != nil {
-		return ErrInvalidDate
+		errs.add("date", ErrInvalidDate)
 	}
 	if t.Category == "" {
-		return ErrInvalidCategory
+		errs.add("category", ErrInvalidCategory)
 	}
 	if t.Type != "income" && t.Type != "expense" {
-		return ErrInvalidType
+		errs.add("type", ErrInvalidType)
 	}
 	// Validate amount sign matches type
-	if t.Type == "income" && t.Amount < 0 {
-		return ErrInvalidAmount
+	if (t.Type == "income" && t.Amount < 0) || (t.Type == "expense" && t.Amount > 0) {
+		errs.add("amount", ErrInvalidAmount)
+	}
+	// Merchant is optional; normalize surrounding whitespace
+	t.Merchant = strings.TrimSpace(t.Merchant)
+	if t.Currency != "" && !IsValidCurrency(t.Currency) {
+		errs.add("currency", ErrInvalidCurrency)
 	}
-	if t.Type == "expense" && t.Amount > 0 {
-		return ErrInvalidAmount
+	if t.PaymentMethod != "" && !IsValidPaymentMethod(t.PaymentMethod) {
+		errs.add("payment_method", ErrInvalidPaymentMethod)
 	}
-	return nil
+	// Tags are optional, but each tag must have content
+	for _, tag := range t.Tags {
+		if strings.TrimSpace(tag) == "" {
+			errs.add("tags", ErrInvalidTag)
+			break
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return &errs
 }
 
 // CalculateSavingsRate computes the savings rate percentage
@@ -149,6 +352,24 @@ func (fs *FinancialSummary) CalculateSavingsRate() {
 	}
 }
 
+// AddTotals sets the period's income, expenses, net savings and count from transactions
+// Amounts are summed as given, so transactions must already share one currency.
+func (p *TransactionsPeriod) AddTotals(transactions []Transaction) {
+	var income, expenses float64
+	for _, tx := range transactions {
+		if tx.IsIncome() {
+			income += tx.Amount
+		} else if tx.IsExpense() {
+			expenses += tx.AbsoluteAmount()
+		}
+	}
+
+	p.Income = roundToTwoDecimals(income)
+	p.Expenses = roundToTwoDecimals(expenses)
+	p.NetSavings = roundToTwoDecimals(income - expenses)
+	p.TransactionCount = len(transactions)
+}
+
 // Helper function to round to 2 decimal places
 func roundToTwoDecimals(val float64) float64 {
 	return math.Round(val*100) / 100
@@ -1,6 +1,10 @@
 package domain
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -28,6 +32,140 @@ func TestTransaction_IsExpense(t *testing.T) {
 	}
 }
 
+func TestTransaction_Validate_MultipleErrors(t *testing.T) {
+	tx := Transaction{
+		Date:   "2024-01-01",
+		Amount: 100,
+		Type:   "transfer",
+	}
+
+	err := tx.Validate()
+
+	var validationErrs *ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected *ValidationErrors, got %T", err)
+	}
+
+	if len(*validationErrs) != 2 {
+		t.Fatalf("Expected 2 validation errors, got %d: %v", len(*validationErrs), err)
+	}
+
+	expected := []struct{ field, code string }{
+		{"category", "INVALID_CATEGORY"},
+		{"type", "INVALID_TYPE"},
+	}
+	for i, want := range expected {
+		got := (*validationErrs)[i]
+		if got.Field != want.field || got.Code != want.code || got.Message == "" {
+			t.Errorf("Error %d = %+v, want field %q code %q", i, got, want.field, want.code)
+		}
+	}
+
+	// Sentinel errors remain detectable
+	if !errors.Is(err, ErrInvalidCategory) || !errors.Is(err, ErrInvalidType) {
+		t.Error("Expected errors.Is to match both sentinel errors")
+	}
+	if errors.Is(err, ErrInvalidDate) {
+		t.Error("Expected errors.Is not to match ErrInvalidDate")
+	}
+}
+
+func TestDomainError_Code(t *testing.T) {
+	tests := []struct {
+		err  error
+		code string
+	}{
+		{ErrInvalidDate, "INVALID_DATE"},
+		{ErrInvalidCategory, "INVALID_CATEGORY"},
+		{ErrInvalidType, "INVALID_TYPE"},
+		{ErrInvalidAmount, "INVALID_AMOUNT"},
+		{ErrNoTransactions, "NO_TRANSACTIONS"},
+		{ErrInvalidDateRange, "INVALID_DATE_RANGE"},
+		{fmt.Errorf("%w: JPY", ErrUnsupportedCurrency), "UNSUPPORTED_CURRENCY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			var target *DomainError
+			if !errors.As(tt.err, &target) {
+				t.Fatalf("Expected *DomainError, got %T", tt.err)
+			}
+			if target.Code != tt.code {
+				t.Errorf("Code = %q, want %q", target.Code, tt.code)
+			}
+		})
+	}
+
+	// Messages are unchanged from the original sentinel strings
+	if ErrInvalidDate.Error() != "invalid date format, expected YYYY-MM-DD" {
+		t.Errorf("Unexpected message: %q", ErrInvalidDate.Error())
+	}
+}
+
+func TestTransaction_Validate_TrimsMerchant(t *testing.T) {
+	tx := Transaction{
+		Date:     "2024-01-01",
+		Amount:   -30,
+		Category: "shopping",
+		Type:     "expense",
+		Merchant: "  Amazon  ",
+	}
+
+	if err := tx.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if tx.Merchant != "Amazon" {
+		t.Errorf("Expected merchant 'Amazon', got %q", tx.Merchant)
+	}
+}
+
+func TestTransaction_Validate_PaymentMethod(t *testing.T) {
+	tests := []struct {
+		method  string
+		wantErr bool
+	}{
+		{"", false}, // Optional for backward compatibility
+		{PaymentMethodCreditCard, false},
+		{PaymentMethodDebitCard, false},
+		{PaymentMethodCash, false},
+		{PaymentMethodBankTransfer, false},
+		{"crypto", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tx := Transaction{
+				Date:          "2024-01-01",
+				Amount:        -30,
+				Category:      "shopping",
+				Type:          "expense",
+				PaymentMethod: tt.method,
+			}
+
+			err := tx.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrInvalidPaymentMethod) {
+				t.Errorf("Expected ErrInvalidPaymentMethod, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTransaction_HasTag(t *testing.T) {
+	tx := Transaction{Tags: []string{"vacation", "business"}}
+
+	if !tx.HasTag("vacation") || !tx.HasTag("business") {
+		t.Error("Expected HasTag() to return true for each assigned tag")
+	}
+
+	if tx.HasTag("groceries") {
+		t.Error("Expected HasTag() to return false for an unassigned tag")
+	}
+}
+
 func TestTransaction_AbsoluteAmount(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -183,18 +321,108 @@ func TestTransaction_Validate(t *testing.T) {
 			},
 			wantErr: ErrInvalidAmount,
 		},
+		{
+			name: "valid tags",
+			transaction: Transaction{
+				Date:     "2024-01-01",
+				Amount:   -1200,
+				Category: "rent",
+				Type:     "expense",
+				Tags:     []string{"housing", "fixed"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "blank tag",
+			transaction: Transaction{
+				Date:     "2024-01-01",
+				Amount:   -1200,
+				Category: "rent",
+				Type:     "expense",
+				Tags:     []string{"housing", "  "},
+			},
+			wantErr: ErrInvalidTag,
+		},
+		{
+			name: "valid currency",
+			transaction: Transaction{
+				Date:     "2024-01-01",
+				Amount:   -1200,
+				Category: "rent",
+				Type:     "expense",
+				Currency: "MXN",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "invalid currency",
+			transaction: Transaction{
+				Date:     "2024-01-01",
+				Amount:   -1200,
+				Category: "rent",
+				Type:     "expense",
+				Currency: "XYZ",
+			},
+			wantErr: ErrInvalidCurrency,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			err := tt.transaction.Validate()
-			if err != tt.wantErr {
+			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
+func TestTransactionsPeriod_AddTotals(t *testing.T) {
+	period := TransactionsPeriod{Period: Period{Start: "2024-01-01", End: "2024-01-31"}}
+	period.AddTotals([]Transaction{
+		{Amount: 2800.10, Type: "income"},
+		{Amount: -1200, Type: "expense"},
+		{Amount: -85.35, Type: "expense"},
+	})
+
+	if period.Income != 2800.10 {
+		t.Errorf("Income = %v, want 2800.10", period.Income)
+	}
+	if period.Expenses != 1285.35 {
+		t.Errorf("Expenses = %v, want 1285.35", period.Expenses)
+	}
+	if period.NetSavings != 1514.75 {
+		t.Errorf("NetSavings = %v, want 1514.75", period.NetSavings)
+	}
+	if period.TransactionCount != 3 {
+		t.Errorf("TransactionCount = %d, want 3", period.TransactionCount)
+	}
+	if period.Start != "2024-01-01" || period.End != "2024-01-31" {
+		t.Errorf("AddTotals changed the range to %s..%s", period.Start, period.End)
+	}
+}
+
+func TestTransactionsPeriod_JSONKeepsZeroTotals(t *testing.T) {
+	period := TransactionsPeriod{Period: Period{Start: "2024-01-01", End: "2024-01-31"}}
+	period.AddTotals([]Transaction{{Amount: -40, Type: "expense"}})
+
+	data, err := json.Marshal(period)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	for _, field := range []string{`"income":0`, `"expenses":40`, `"net_savings":-40`, `"transaction_count":1`} {
+		if !strings.Contains(string(data), field) {
+			t.Errorf("Expected %s in %s", field, data)
+		}
+	}
+
+	// Periods of other responses carry no totals at all
+	data, _ = json.Marshal(period.Period)
+	if strings.Contains(string(data), "income") {
+		t.Errorf("Expected a bare period without totals, got %s", data)
+	}
+}
+
 func TestFinancialSummary_CalculateSavingsRate(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -249,3 +477,58 @@ func TestFinancialSummary_CalculateSavingsRate(t *testing.T) {
 	}
 }
 
+func TestIsValidCurrency(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected bool
+	}{
+		{"USD", true},
+		{"MXN", true},
+		{"JPY", true},
+		{"usd", false},
+		{"XYZ", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if result := IsValidCurrency(tt.code); result != tt.expected {
+				t.Errorf("IsValidCurrency(%q) = %v, want %v", tt.code, result, tt.expected)
+			}
+		})
+	}
+}
+
+// FuzzTransactionValidate checks that Validate never panics, whatever the field values
+// Errors are expected for most inputs. Run extended fuzzing with, e.g.:
+//
+//	go test -run='^$' -fuzz=FuzzTransactionValidate -fuzztime=10m ./internal/domain
+//
+// Failing inputs are saved under testdata/fuzz/ and replayed by plain `go test`.
+func FuzzTransactionValidate(f *testing.F) {
+	// date, amount, category, description, type, merchant, currency, tag
+	f.Add("2024-01-01", 2800.0, "salary", "Bi-weekly salary", "income", "", "", "")
+	f.Add("2024-01-02", -1200.0, "rent", "Monthly rent", "expense", "Landlord", "USD", "housing")
+	f.Add("2024-01-01", 100.0, "", "", "transfer", "", "", "")
+	f.Add("01/02/2024", -50.0, "groceries", "Whole Foods", "expense", "  Whole Foods  ", "usd", "")
+	f.Add("2024-02-30", 0.0, "dining", "Zero amount", "expense", "", "XXX", " ")
+	f.Add("", -100.0, "shopping", "Income with negative amount", "income", "", "EURO", "gift")
+
+	f.Fuzz(func(t *testing.T, date string, amount float64, category, description, txType, merchant, currency, tag string) {
+		tx := Transaction{
+			Date:        date,
+			Amount:      amount,
+			Category:    category,
+			Description: description,
+			Type:        txType,
+			Merchant:    merchant,
+			Currency:    currency,
+		}
+		if tag != "" {
+			tx.Tags = []string{tag}
+		}
+
+		_ = tx.Validate()
+	})
+}
+
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/service"
+)
+
+// AdviceFeedbackHandler handles ratings of generated advice
+type AdviceFeedbackHandler struct {
+	feedbackService *service.AdviceFeedbackService
+}
+
+// NewAdviceFeedbackHandler creates a new advice feedback handler
+func NewAdviceFeedbackHandler(feedbackService *service.AdviceFeedbackService) *AdviceFeedbackHandler {
+	return &AdviceFeedbackHandler{
+		feedbackService: feedbackService,
+	}
+}
+
+// adviceFeedbackRequest is the body of POST /api/advice/{id}/feedback
+type adviceFeedbackRequest struct {
+	Rating  int    `json:"rating"`
+	Helpful bool   `json:"helpful"`
+	Comment string `json:"comment"`
+}
+
+// HandleSubmitFeedback handles POST /api/advice/{id}/feedback
+// Body: {"rating": 1-5, "helpful": true, "comment": "..."} (comment at most 200 characters)
+// Responds 201 with the stored feedback, 404 for an unknown advice ID and 422 for invalid fields
+func (h *AdviceFeedbackHandler) HandleSubmitFeedback(w http.ResponseWriter, r *http.Request) {
+	// Only allow POST method
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req adviceFeedbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	feedback, err := h.feedbackService.SubmitFeedback(chi.URLParam(r, "id"), domain.AdviceFeedback{
+		Rating:  req.Rating,
+		Helpful: req.Helpful,
+		Comment: req.Comment,
+	})
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, feedback)
+}
+
+// HandleFeedbackStats handles GET /api/advice/feedback/stats
+// Returns the average rating, share of helpful responses and response count
+func (h *AdviceFeedbackHandler) HandleFeedbackStats(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	stats, err := h.feedbackService.GetAdviceFeedbackStats()
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, stats)
+}
+
@@ -1,10 +1,14 @@
 package handlers
 
 import (
-	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/middleware"
 	"github.com/danntastico/stori-backend/internal/service"
 )
 
@@ -12,13 +16,21 @@ import (
 type AdviceHandler struct {
 	analyticsService *service.AnalyticsService
 	aiService        *service.AIService
+	history          service.AdviceRepository
 }
 
-// NewAdviceHandler creates a new advice handler
-func NewAdviceHandler(analyticsService *service.AnalyticsService, aiService *service.AIService) *AdviceHandler {
+// Pagination defaults for GET /api/advice/history
+const (
+	defaultHistoryPageSize = 10
+	maxHistoryPageSize     = 100
+)
+
+// NewAdviceHandler creates a new advice handler that records generated advice in history
+func NewAdviceHandler(analyticsService *service.AnalyticsService, aiService *service.AIService, history service.AdviceRepository) *AdviceHandler {
 	return &AdviceHandler{
 		analyticsService: analyticsService,
 		aiService:        aiService,
+		history:          history,
 	}
 }
 
@@ -26,8 +38,7 @@ func NewAdviceHandler(analyticsService *service.AnalyticsService, aiService *ser
 func (h *AdviceHandler) GetAdvice(w http.ResponseWriter, r *http.Request) {
 	// Parse request body
 	var req service.AdviceRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeJSONBody(w, r, &req) {
 		return
 	}
 
@@ -40,6 +51,7 @@ func (h *AdviceHandler) GetAdvice(w http.ResponseWriter, r *http.Request) {
 	summary, err := h.analyticsService.GetCategorySummary()
 	if err != nil {
 		log.Printf("Error getting category summary for AI: %v", err)
+		middleware.SetErrorSource(r.Context(), middleware.ErrorSourceAnalytics)
 		respondWithError(w, http.StatusInternalServerError, "Failed to analyze financial data")
 		return
 	}
@@ -48,10 +60,75 @@ func (h *AdviceHandler) GetAdvice(w http.ResponseWriter, r *http.Request) {
 	advice, err := h.aiService.GetFinancialAdvice(r.Context(), *summary, req)
 	if err != nil {
 		log.Printf("Error generating AI advice: %v", err)
+		middleware.SetErrorSource(r.Context(), middleware.ErrorSourceAI)
+
+		// Upstream errors (e.g., rate limiting, open circuit) carry their own status
+		var httpErr *domain.HTTPError
+		if errors.As(err, &httpErr) || errors.Is(err, domain.ErrServiceUnavailable) {
+			handleServiceError(w, err)
+			return
+		}
+
 		respondWithError(w, http.StatusInternalServerError, "Failed to generate advice")
 		return
 	}
 
+	// Record the advice; a storage failure shouldn't cost the client its answer
+	record := service.AdviceRecord{
+		RequestedAt:       time.Now().UTC(),
+		Request:           req,
+		Response:          *advice,
+		FinancialSnapshot: *summary,
+	}
+	if err := h.history.Save(record); err != nil {
+		log.Printf("Error saving advice history: %v", err)
+	}
+
 	respondWithJSON(w, http.StatusOK, advice)
 }
 
+// GetAdviceHistory handles GET /api/advice/history?page=1&pageSize=10
+// Returns previously generated advice, newest first
+func (h *AdviceHandler) GetAdviceHistory(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	page, err := parsePositiveIntParam(r, "page", 1)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid page: must be a positive integer")
+		return
+	}
+
+	pageSize, err := parsePositiveIntParam(r, "pageSize", defaultHistoryPageSize)
+	if err != nil || pageSize > maxHistoryPageSize {
+		respondWithError(w, http.StatusBadRequest, "Invalid pageSize: must be between 1 and 100")
+		return
+	}
+
+	history, err := h.history.List(page, pageSize)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, history)
+}
+
+// parsePositiveIntParam reads an optional positive integer query parameter
+func parsePositiveIntParam(r *http.Request, name string, defaultValue int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, errors.New("must be a positive integer")
+	}
+	return n, nil
+}
+
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/danntastico/stori-backend/internal/service"
+)
+
+// AnalysisHandler handles spending pattern analysis requests
+type AnalysisHandler struct {
+	analyticsService *service.AnalyticsService
+}
+
+// NewAnalysisHandler creates a new analysis handler
+func NewAnalysisHandler(analyticsService *service.AnalyticsService) *AnalysisHandler {
+	return &AnalysisHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// HandleUpcomingBills handles GET /api/analysis/upcoming-bills
+// Query parameters:
+//   - days: look-ahead window in days (default 30)
+func (h *AnalysisHandler) HandleUpcomingBills(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	days := 30
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 1 {
+			respondWithError(w, http.StatusBadRequest, "Invalid days, expected a positive integer")
+			return
+		}
+		days = parsed
+	}
+
+	bills, err := h.analyticsService.GetUpcomingBills(days)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, bills)
+}
+
+// HandlePeriodStats handles GET /api/analysis/stats
+// Query parameters:
+//   - aggregation: "monthly" (default) or "weekly"
+func (h *AnalysisHandler) HandlePeriodStats(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	aggregation := r.URL.Query().Get("aggregation")
+	if aggregation == "" {
+		aggregation = "monthly"
+	}
+
+	stats, err := h.analyticsService.GetPeriodStats(aggregation)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, stats)
+}
+
+// HandleSeasonalPatterns handles GET /api/analysis/seasonal
+// Returns expense categories whose spending concentrates in certain months of the year;
+// categories need at least 12 months of data
+func (h *AnalysisHandler) HandleSeasonalPatterns(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	patterns, err := h.analyticsService.DetectSeasonalPatterns()
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, patterns)
+}
+
+// HandleSpendingMomentum handles GET /api/analysis/spending-momentum
+// Returns whether monthly spending is accelerating, decelerating or stable;
+// needs at least 3 months of data
+func (h *AnalysisHandler) HandleSpendingMomentum(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	momentum, err := h.analyticsService.GetSpendingMomentum()
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, momentum)
+}
+
+// HandleIncomeGrowth handles GET /api/analysis/income-growth
+// Compares average monthly income in the first and second half of the history;
+// needs at least 4 months of data
+func (h *AnalysisHandler) HandleIncomeGrowth(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	growth, err := h.analyticsService.GetIncomeGrowthRate()
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, growth)
+}
+
+// HandleCategoryCorrelations handles GET /api/analysis/correlations
+// Returns the correlation of monthly spending between every pair of expense categories;
+// categories need spending in at least 3 months
+func (h *AnalysisHandler) HandleCategoryCorrelations(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	matrix, err := h.analyticsService.GetCategoryCorrelations()
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, matrix)
+}
+
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/service"
+)
+
+// CategoryHandler handles category maintenance and metadata requests
+type CategoryHandler struct {
+	analyticsService *service.AnalyticsService
+	metadataService  *service.CategoryMetadataService
+}
+
+// NewCategoryHandler creates a new category handler
+func NewCategoryHandler(analyticsService *service.AnalyticsService, metadataService *service.CategoryMetadataService) *CategoryHandler {
+	return &CategoryHandler{
+		analyticsService: analyticsService,
+		metadataService:  metadataService,
+	}
+}
+
+// mergeCategoriesRequest is the body of POST /api/categories/merge
+type mergeCategoriesRequest struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// mergeCategoriesResponse reports how many transactions a merge moved
+type mergeCategoriesResponse struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Merged int    `json:"merged"` // Transactions moved from source to target
+}
+
+// HandleMerge handles POST /api/categories/merge
+// Body: {"source": "grocery", "target": "groceries"}
+// Moves every source transaction to target; 400 when source and target are blank or equal
+func (h *CategoryHandler) HandleMerge(w http.ResponseWriter, r *http.Request) {
+	// Only allow POST method
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req mergeCategoriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	merged, err := h.analyticsService.MergeCategories(req.Source, req.Target)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, mergeCategoriesResponse{
+		Source: req.Source,
+		Target: req.Target,
+		Merged: merged,
+	})
+}
+
+// categoryMetadataResponse lists the metadata of every category
+type categoryMetadataResponse struct {
+	Categories []domain.CategoryMetadata `json:"categories"`
+}
+
+// HandleListMetadata handles GET /api/categories/metadata
+// Returns the description, icon and color of every category, built-in or overridden
+func (h *CategoryHandler) HandleListMetadata(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	categories, err := h.metadataService.ListMetadata()
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, categoryMetadataResponse{Categories: categories})
+}
+
+// HandleOverrideMetadata handles POST /api/categories/metadata/{name}
+// Body: {"description": "...", "icon_name": "car", "color": "#F59E0B"}; omitted fields keep
+// their current value. Returns the stored entry; 400 when the color is not #RRGGBB.
+func (h *CategoryHandler) HandleOverrideMetadata(w http.ResponseWriter, r *http.Request) {
+	// Only allow POST method
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var override domain.CategoryMetadata
+	if err := json.NewDecoder(r.Body).Decode(&override); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	metadata, err := h.metadataService.OverrideMetadata(chi.URLParam(r, "name"), override)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, metadata)
+}
+
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/danntastico/stori-backend/internal/service"
+)
+
+// DebtHandler handles debt repayment requests
+type DebtHandler struct {
+	debtService *service.DebtService
+}
+
+// NewDebtHandler creates a new debt handler
+func NewDebtHandler(debtService *service.DebtService) *DebtHandler {
+	return &DebtHandler{
+		debtService: debtService,
+	}
+}
+
+// HandleDebtPayoff handles GET /api/analysis/debt-payoff
+// Query parameters:
+//   - principal: outstanding loan balance (required)
+//   - rate: annual interest rate as a decimal, e.g., 0.15 (default 0)
+//   - extra: extra payment on top of the minimum each month (default 0)
+func (h *DebtHandler) HandleDebtPayoff(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Parse query parameters
+	query := r.URL.Query()
+
+	principal, err := parseFiniteFloat(query.Get("principal"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid principal, expected a number")
+		return
+	}
+
+	rate := 0.0
+	if rateStr := query.Get("rate"); rateStr != "" {
+		if rate, err = parseFiniteFloat(rateStr); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid rate, expected a decimal number")
+			return
+		}
+	}
+
+	extra := 0.0
+	if extraStr := query.Get("extra"); extraStr != "" {
+		if extra, err = parseFiniteFloat(extraStr); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid extra, expected a number")
+			return
+		}
+	}
+
+	plan, err := h.debtService.CalculateDebtPayoff(principal, rate, extra)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, plan)
+}
+
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// sseKeepAliveInterval is how often an idle stream sends a comment so proxies keep it open
+const sseKeepAliveInterval = 15 * time.Second
+
+// sseDeadlineMargin ends a stream this long before the request deadline, so the stream
+// closes cleanly instead of the router's timeout answering 504 mid-stream
+const sseDeadlineMargin = time.Second
+
+// EventsHandler streams change events to browsers as Server-Sent Events
+type EventsHandler struct {
+	bus domain.EventBus
+}
+
+// NewEventsHandler creates an SSE handler for the bus's events
+func NewEventsHandler(bus domain.EventBus) *EventsHandler {
+	return &EventsHandler{bus: bus}
+}
+
+// ServeHTTP handles GET /api/events
+// Responds with a text/event-stream carrying "transaction_created" events (the stored
+// transaction) and "analytics_updated" events ({"change", "count"}) as they happen.
+// Streams end before the request timeout; EventSource clients reconnect automatically.
+func (h *EventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		respondWithError(w, http.StatusInternalServerError, "Streaming not supported")
+		return
+	}
+
+	// The server's write timeout is meant for ordinary responses, not long-lived streams
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	ctx := r.Context()
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline.Add(-sseDeadlineMargin))
+		defer cancel()
+	}
+
+	events := h.bus.Subscribe()
+	defer h.bus.Unsubscribe(events)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 1000\n\n")
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Client disconnected or the stream reached its deadline
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event.Data)
+			if err != nil {
+				log.Printf("Failed to encode %s event: %v", event.Type, err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/danntastico/stori-backend/internal/service"
+)
+
+// ForecastHandler handles financial forecast requests
+type ForecastHandler struct {
+	forecastingService *service.ForecastingService
+}
+
+// NewForecastHandler creates a new forecast handler
+func NewForecastHandler(forecastingService *service.ForecastingService) *ForecastHandler {
+	return &ForecastHandler{
+		forecastingService: forecastingService,
+	}
+}
+
+// HandleSavingsGrowth handles GET /api/forecast/savings-growth
+// Query parameters:
+//   - annualRate: expected annual return as a decimal (default 0.07)
+//   - years: projection horizon in years (default 10)
+func (h *ForecastHandler) HandleSavingsGrowth(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Parse query parameters
+	query := r.URL.Query()
+
+	annualRate := 0.07
+	if rateStr := query.Get("annualRate"); rateStr != "" {
+		parsed, err := strconv.ParseFloat(rateStr, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid annualRate, expected a decimal number")
+			return
+		}
+		annualRate = parsed
+	}
+
+	years := 10
+	if yearsStr := query.Get("years"); yearsStr != "" {
+		parsed, err := strconv.Atoi(yearsStr)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid years, expected an integer")
+			return
+		}
+		years = parsed
+	}
+
+	projection, err := h.forecastingService.ProjectSavingsGrowth(annualRate, years)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, projection)
+}
+
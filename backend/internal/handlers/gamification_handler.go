@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/danntastico/stori-backend/internal/service"
+)
+
+// GamificationHandler handles progress and streak requests
+type GamificationHandler struct {
+	analyticsService *service.AnalyticsService
+}
+
+// NewGamificationHandler creates a new gamification handler
+func NewGamificationHandler(analyticsService *service.AnalyticsService) *GamificationHandler {
+	return &GamificationHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// HandleSavingsStreak handles GET /api/gamification/savings-streak
+func (h *GamificationHandler) HandleSavingsStreak(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	streak, err := h.analyticsService.GetSavingsStreak()
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, streak)
+}
+
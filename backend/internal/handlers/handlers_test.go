@@ -1,36 +1,41 @@
 package handlers
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
 
 	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/middleware"
 	"github.com/danntastico/stori-backend/internal/repository"
 	"github.com/danntastico/stori-backend/internal/service"
+	"github.com/danntastico/stori-backend/internal/testutil"
+	"github.com/danntastico/stori-backend/internal/testutil/sampledata"
 )
 
-// Test data
-var testJSON = []byte(`[
-	{"date": "2024-01-01", "amount": 2800, "category": "salary", "description": "Bi-weekly salary", "type": "income"},
-	{"date": "2024-01-02", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"},
-	{"date": "2024-01-03", "amount": -85, "category": "groceries", "description": "Whole Foods", "type": "expense"},
-	{"date": "2024-02-01", "amount": 2800, "category": "salary", "description": "Bi-weekly salary", "type": "income"}
-]`)
-
 func setupTestHandlers(t *testing.T) (*TransactionHandler, *SummaryHandler) {
 	t.Helper()
 
-	repo, err := repository.NewJSONRepository(testJSON)
-	if err != nil {
-		t.Fatalf("Failed to create repository: %v", err)
-	}
-
-	analyticsService := service.NewAnalyticsService(repo)
+	analyticsService := testutil.NewTestService(t, testutil.MinimalJSON)
 	transactionHandler := NewTransactionHandler(analyticsService)
-	summaryHandler := NewSummaryHandler(analyticsService)
+	summaryHandler := NewSummaryHandler(analyticsService, nil)
 
 	return transactionHandler, summaryHandler
 }
@@ -74,6 +79,81 @@ func TestHealthHandler_MethodNotAllowed(t *testing.T) {
 	}
 }
 
+func TestVersionHandler(t *testing.T) {
+	handler := NewVersionHandler(domain.BuildInfo{
+		Version:   "dev",
+		Commit:    "dev",
+		BuildDate: "dev",
+		GoVersion: "go1.22.0",
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/version", nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Response is not valid JSON: %v", err)
+	}
+
+	for _, field := range []string{"version", "commit", "build_date", "go_version", "module_path"} {
+		if _, exists := response[field]; !exists {
+			t.Errorf("Expected field '%s' in response", field)
+		}
+	}
+}
+
+func TestGoroutineHandler(t *testing.T) {
+	handler := NewGoroutineHandler()
+
+	dump := func() domain.GoroutineDump {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/debug/goroutines", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+		var response domain.GoroutineDump
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("Response is not valid JSON: %v", err)
+		}
+		return response
+	}
+
+	baseline := dump()
+
+	// Park 5 goroutines until the test ends
+	release := make(chan struct{})
+	var started sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		started.Add(1)
+		go func() {
+			started.Done()
+			<-release
+		}()
+	}
+	started.Wait()
+	defer close(release)
+
+	response := dump()
+	if response.GoroutineCount < baseline.GoroutineCount+5 {
+		t.Errorf("Expected at least %d goroutines, got %d", baseline.GoroutineCount+5, response.GoroutineCount)
+	}
+	if !strings.Contains(response.Stack, "TestGoroutineHandler") {
+		t.Error("Expected the stack dump to include the parked goroutines")
+	}
+	if len(response.Stack) > maxGoroutineStackBytes {
+		t.Errorf("Expected the stack dump to be capped at %d bytes, got %d", maxGoroutineStackBytes, len(response.Stack))
+	}
+}
+
 func TestTransactionHandler_GetAll(t *testing.T) {
 	handler, _ := setupTestHandlers(t)
 
@@ -96,7 +176,7 @@ func TestTransactionHandler_GetAll(t *testing.T) {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
 
-	expectedCount := 4
+	expectedCount := 3
 	if response.Count != expectedCount {
 		t.Errorf("Expected count %d, got %d", expectedCount, response.Count)
 	}
@@ -128,7 +208,7 @@ func TestTransactionHandler_GetByDateRange(t *testing.T) {
 			startDate:      "2024-01-01",
 			endDate:        "2024-12-31",
 			expectedStatus: http.StatusOK,
-			expectedCount:  4,
+			expectedCount:  3,
 		},
 		{
 			name:           "invalid start date format",
@@ -144,6 +224,13 @@ func TestTransactionHandler_GetByDateRange(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedCount:  0,
 		},
+		{
+			name:           "start date after end date",
+			startDate:      "2024-12-31",
+			endDate:        "2024-01-01",
+			expectedStatus: http.StatusBadRequest,
+			expectedCount:  0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -158,6 +245,14 @@ func TestTransactionHandler_GetByDateRange(t *testing.T) {
 				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
 			}
 
+			// Errors must be reported in the error format, never as a null transactions response
+			if tt.expectedStatus != http.StatusOK {
+				var response ErrorResponse
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil || response.Message == "" {
+					t.Errorf("Expected an error response, got %q", w.Body.String())
+				}
+			}
+
 			if tt.expectedStatus == http.StatusOK {
 				var response domain.TransactionsResponse
 				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
@@ -172,197 +267,1933 @@ func TestTransactionHandler_GetByDateRange(t *testing.T) {
 	}
 }
 
-func TestTransactionHandler_MethodNotAllowed(t *testing.T) {
-	handler, _ := setupTestHandlers(t)
-
-	req := httptest.NewRequest(http.MethodPost, "/api/transactions", nil)
-	w := httptest.NewRecorder()
-
-	handler.ServeHTTP(w, req)
+func TestTransactionHandler_FilterByTagAndMerchant(t *testing.T) {
+	analyticsService := testutil.NewTestService(t, []byte(`[
+		{"date": "2024-01-05", "amount": -300, "category": "shopping", "description": "Laptop bag", "type": "expense", "tags": ["business", "travel"]},
+		{"date": "2024-02-06", "amount": -80, "category": "dining", "description": "Client lunch", "type": "expense", "tags": ["business"]},
+		{"date": "2024-02-07", "amount": -45, "category": "groceries", "description": "Safeway", "type": "expense", "merchant": "Safeway"}
+	]`))
+	handler := NewTransactionHandler(analyticsService)
 
-	if w.Code != http.StatusMethodNotAllowed {
-		t.Errorf("Expected status 405, got %d", w.Code)
+	tests := []struct {
+		name          string
+		query         string
+		expectedCount int
+	}{
+		{"tag only", "?tag=business", 2},
+		{"tag with date range", "?tag=business&startDate=2024-02-01&endDate=2024-02-28", 1},
+		{"other tag", "?tag=travel", 1},
+		{"merchant", "?merchant=Safeway", 1},
+		{"merchant with tag", "?merchant=Safeway&tag=business", 0},
+		{"type with several categories", "?type=expense&category=dining&category=groceries", 2},
+		{"date and amount", "?startDate=2024-02-01&minAmount=50", 1},
 	}
-}
 
-func TestSummaryHandler_GetCategorySummary(t *testing.T) {
-	_, handler := setupTestHandlers(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/transactions"+tt.query, nil)
+			w := httptest.NewRecorder()
 
-	req := httptest.NewRequest(http.MethodGet, "/api/summary/categories", nil)
-	w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
 
-	handler.HandleCategorySummary(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d", w.Code)
+			}
 
-	if w.Code != http.StatusOK {
-		t.Errorf("Expected status 200, got %d", w.Code)
-	}
+			var response domain.TransactionsResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
 
-	contentType := w.Header().Get("Content-Type")
-	if contentType != "application/json" {
-		t.Errorf("Expected Content-Type 'application/json', got '%s'", contentType)
+			if response.Count != tt.expectedCount {
+				t.Errorf("Expected count %d, got %d", tt.expectedCount, response.Count)
+			}
+		})
 	}
+}
 
-	var response domain.CategorySummary
-	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
-		t.Fatalf("Failed to decode response: %v", err)
+func TestParseTransactionFilter(t *testing.T) {
+	date := func(value string) *time.Time {
+		d, _ := time.Parse("2006-01-02", value)
+		return &d
 	}
+	amount := func(value float64) *float64 { return &value }
 
-	// Verify structure
-	if response.Income == nil {
-		t.Error("Expected income map to be non-nil")
+	tests := []struct {
+		name     string
+		query    string
+		expected domain.TransactionFilter
+		wantErr  bool
+	}{
+		{
+			name:     "no parameters",
+			query:    "",
+			expected: domain.TransactionFilter{},
+		},
+		{
+			name:     "date range",
+			query:    "?startDate=2024-01-01&endDate=2024-01-31",
+			expected: domain.TransactionFilter{StartDate: date("2024-01-01"), EndDate: date("2024-01-31")},
+		},
+		{
+			name:     "start date only",
+			query:    "?startDate=2024-02-01",
+			expected: domain.TransactionFilter{StartDate: date("2024-02-01")},
+		},
+		{
+			name:     "type and category",
+			query:    "?type=expense&category=rent",
+			expected: domain.TransactionFilter{Types: []string{"expense"}, Categories: []string{"rent"}},
+		},
+		{
+			name:     "repeated type and category",
+			query:    "?type=expense&type=income&category=rent&category=salary",
+			expected: domain.TransactionFilter{Types: []string{"expense", "income"}, Categories: []string{"rent", "salary"}},
+		},
+		{
+			name:     "amount bounds",
+			query:    "?minAmount=10&maxAmount=99.5",
+			expected: domain.TransactionFilter{MinAmount: amount(10), MaxAmount: amount(99.5)},
+		},
+		{
+			name:     "search and tags",
+			query:    "?q=+Whole+&tag=business&tag=travel",
+			expected: domain.TransactionFilter{Query: "Whole", Tags: []string{"business", "travel"}},
+		},
+		{
+			name:  "all fields combined",
+			query: "?startDate=2024-01-01&endDate=2024-12-31&type=income&category=salary&merchant=Acme&minAmount=0&maxAmount=5000&q=salary&tag=payroll",
+			expected: domain.TransactionFilter{
+				StartDate:  date("2024-01-01"),
+				EndDate:    date("2024-12-31"),
+				Types:      []string{"income"},
+				Categories: []string{"salary"},
+				Merchant:   "Acme",
+				MinAmount:  amount(0),
+				MaxAmount:  amount(5000),
+				Query:      "salary",
+				Tags:       []string{"payroll"},
+			},
+		},
+		{name: "invalid start date", query: "?startDate=01-01-2024", wantErr: true},
+		{name: "invalid end date", query: "?endDate=2024-13-01", wantErr: true},
+		{name: "start after end", query: "?startDate=2024-02-01&endDate=2024-01-01", wantErr: true},
+		{name: "invalid type", query: "?type=transfer", wantErr: true},
+		{name: "invalid repeated type", query: "?type=income&type=transfer", wantErr: true},
+		{name: "non-numeric amount", query: "?minAmount=ten", wantErr: true},
+		{name: "negative amount", query: "?maxAmount=-5", wantErr: true},
+		{name: "min above max", query: "?minAmount=100&maxAmount=10", wantErr: true},
 	}
 
-	if response.Expenses == nil {
-		t.Error("Expected expenses map to be non-nil")
-	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/transactions"+tt.query, nil)
 
-	// Verify we have income categories
-	if len(response.Income) == 0 {
-		t.Error("Expected at least one income category")
-	}
+			filter, err := ParseTransactionFilter(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTransactionFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
 
-	// Verify we have expense categories
-	if len(response.Expenses) == 0 {
-		t.Error("Expected at least one expense category")
+			if !tt.wantErr && !reflect.DeepEqual(filter, tt.expected) {
+				t.Errorf("ParseTransactionFilter() = %+v, want %+v", filter, tt.expected)
+			}
+		})
 	}
+}
 
-	// Verify financial summary
-	if response.Summary.TotalIncome <= 0 {
-		t.Error("Expected positive total income")
-	}
+func TestTransactionHandler_FilterByPaymentMethod(t *testing.T) {
+	handler := NewTransactionHandler(testutil.NewTestService(t, []byte(`[
+		{"date": "2024-01-05", "amount": -300, "category": "shopping", "description": "Laptop bag", "type": "expense", "payment_method": "credit_card"},
+		{"date": "2024-01-06", "amount": -80, "category": "dining", "description": "Client lunch", "type": "expense", "payment_method": "cash"},
+		{"date": "2024-01-07", "amount": -45, "category": "groceries", "description": "Safeway", "type": "expense"}
+	]`)))
 
-	if response.Summary.TotalExpenses <= 0 {
-		t.Error("Expected positive total expenses")
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedCount  int
+	}{
+		{"credit card", "?paymentMethod=credit_card", http.StatusOK, 1},
+		{"cash", "?paymentMethod=cash", http.StatusOK, 1},
+		{"no matches", "?paymentMethod=debit_card", http.StatusOK, 0},
+		{"invalid method", "?paymentMethod=crypto", http.StatusBadRequest, 0},
 	}
 
-	// Verify period information
-	if response.Period.Start == "" {
-		t.Error("Expected period start date")
-	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/transactions"+tt.query, nil)
+			w := httptest.NewRecorder()
 
-	if response.Period.End == "" {
-		t.Error("Expected period end date")
-	}
+			handler.ServeHTTP(w, req)
 
-	if response.Period.Months <= 0 {
-		t.Error("Expected positive number of months")
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response domain.TransactionsResponse
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if response.Count != tt.expectedCount {
+					t.Errorf("Expected count %d, got %d", tt.expectedCount, response.Count)
+				}
+			}
+		})
 	}
 }
 
-func TestSummaryHandler_GetTimeline(t *testing.T) {
+func TestSummaryHandler_GetPaymentMethodSummary(t *testing.T) {
 	_, handler := setupTestHandlers(t)
 
-	req := httptest.NewRequest(http.MethodGet, "/api/summary/timeline", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/summary/payment-methods", nil)
 	w := httptest.NewRecorder()
 
-	handler.HandleTimeline(w, req)
+	handler.HandlePaymentMethodSummary(w, req)
 
 	if w.Code != http.StatusOK {
-		t.Errorf("Expected status 200, got %d", w.Code)
-	}
-
-	contentType := w.Header().Get("Content-Type")
-	if contentType != "application/json" {
-		t.Errorf("Expected Content-Type 'application/json', got '%s'", contentType)
+		t.Fatalf("Expected status 200, got %d", w.Code)
 	}
 
-	var response domain.TimelineResponse
+	var response domain.PaymentMethodSummary
 	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
 
-	// Verify aggregation type
-	if response.Aggregation != "monthly" {
-		t.Errorf("Expected aggregation 'monthly', got '%s'", response.Aggregation)
-	}
-
-	// Verify we have timeline data
-	if len(response.Timeline) == 0 {
-		t.Error("Expected at least one timeline point")
-	}
-
-	// Verify timeline points have required fields
-	for i, point := range response.Timeline {
-		if point.Period == "" {
-			t.Errorf("Timeline point %d has empty period", i)
-		}
-
-		// Income should be non-negative
-		if point.Income < 0 {
-			t.Errorf("Timeline point %d has negative income: %v", i, point.Income)
-		}
-
-		// Expenses should be non-negative (we convert to positive)
-		if point.Expenses < 0 {
-			t.Errorf("Timeline point %d has negative expenses: %v", i, point.Expenses)
-		}
-	}
-
-	// Verify timeline is sorted
-	for i := 1; i < len(response.Timeline); i++ {
-		if response.Timeline[i-1].Period > response.Timeline[i].Period {
-			t.Error("Timeline is not sorted chronologically")
-			break
-		}
+	// The fixture has no payment methods, so every expense is unspecified
+	if response.TotalExpenses != 1285 || response.Methods["unspecified"].Count != 2 {
+		t.Errorf("Unexpected payment method summary: %+v", response)
 	}
 }
 
-func TestSummaryHandler_MethodNotAllowed(t *testing.T) {
-	_, handler := setupTestHandlers(t)
+func TestTransactionHandler_CombinedFilters(t *testing.T) {
+	handler, _ := setupTestHandlers(t)
 
 	tests := []struct {
-		name    string
-		path    string
-		handler http.HandlerFunc
+		name           string
+		query          string
+		expectedStatus int
+		expectedCount  int
 	}{
-		{"categories POST", "/api/summary/categories", handler.HandleCategorySummary},
-		{"timeline POST", "/api/summary/timeline", handler.HandleTimeline},
+		{"type", "?type=income", http.StatusOK, 1},
+		{"type and amount", "?type=expense&minAmount=100", http.StatusOK, 1},
+		{"search", "?q=whole", http.StatusOK, 1},
+		{"start date only", "?startDate=2024-01-03", http.StatusOK, 1},
+		{"invalid amount", "?minAmount=abc", http.StatusBadRequest, 0},
+		{"NaN amount", "?minAmount=NaN", http.StatusBadRequest, 0},
+		{"infinite amount", "?maxAmount=Inf", http.StatusBadRequest, 0},
+		{"paginated", "?type=expense&limit=1", http.StatusOK, 1},
+		{"invalid limit", "?limit=0", http.StatusBadRequest, 0},
+		{"invalid cursor", "?cursor=abc", http.StatusBadRequest, 0},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			req := httptest.NewRequest(http.MethodGet, "/api/transactions"+tt.query, nil)
 			w := httptest.NewRecorder()
 
-			tt.handler(w, req)
+			handler.ServeHTTP(w, req)
 
-			if w.Code != http.StatusMethodNotAllowed {
-				t.Errorf("Expected status 405, got %d", w.Code)
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response domain.TransactionsResponse
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+
+				if response.Count != tt.expectedCount {
+					t.Errorf("Expected count %d, got %d", tt.expectedCount, response.Count)
+				}
 			}
 		})
 	}
 }
 
-func TestRespondWithError(t *testing.T) {
+func TestTransactionHandler_AmountRange(t *testing.T) {
+	handler := NewTransactionHandler(testutil.NewTestService(t, []byte(`[
+		{"date": "2024-01-01", "amount": 2800, "category": "salary", "description": "Salary", "type": "income"},
+		{"date": "2024-01-03", "amount": -150, "category": "groceries", "description": "Whole Foods", "type": "expense"},
+		{"date": "2024-01-05", "amount": -45, "category": "utilities", "description": "Electric bill", "type": "expense"}
+	]`)))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/transactions?minAmount=100&maxAmount=200", nil)
 	w := httptest.NewRecorder()
 
-	respondWithError(w, http.StatusBadRequest, "Test error message")
+	handler.ServeHTTP(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("Expected status 400, got %d", w.Code)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
 	}
 
-	var response ErrorResponse
+	// Bounds apply to the absolute amount, so the -150 expense matches
+	var response domain.TransactionsResponse
 	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
-		t.Fatalf("Failed to decode error response: %v", err)
+		t.Fatalf("Failed to decode response: %v", err)
 	}
+	if response.Count != 1 || response.Transactions[0].Amount != -150 {
+		t.Errorf("Expected only the -150 expense, got %+v", response.Transactions)
+	}
+}
 
-	if response.Error != "Bad Request" {
-		t.Errorf("Expected error 'Bad Request', got '%s'", response.Error)
+func TestNegotiate(t *testing.T) {
+	supported := []string{"application/json", "text/csv"}
+
+	tests := []struct {
+		name     string
+		accept   string
+		expected string
+	}{
+		{"no accept header", "", "application/json"},
+		{"json", "application/json", "application/json"},
+		{"csv", "text/csv", "text/csv"},
+		{"wildcard", "*/*", "application/json"},
+		{"type wildcard", "text/*", "text/csv"},
+		{"quality factors", "application/json;q=0.5, text/csv;q=0.9", "text/csv"},
+		{"specific range overrides wildcard", "*/*;q=0.8, application/json;q=0.1", "text/csv"},
+		{"browser default", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", "application/json"},
+		{"refused type", "text/csv;q=0", ""},
+		{"unsupported type", "application/xml", ""},
 	}
 
-	if response.Message != "Test error message" {
-		t.Errorf("Expected message 'Test error message', got '%s'", response.Message)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/transactions", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			if result := negotiate(req, supported); result != tt.expected {
+				t.Errorf("negotiate(%q) = %q, want %q", tt.accept, result, tt.expected)
+			}
+		})
 	}
 }
 
-func TestHandleServiceError(t *testing.T) {
+func TestTransactionHandler_ContentNegotiation(t *testing.T) {
+	handler, _ := setupTestHandlers(t)
+
 	tests := []struct {
-		name           string
-		err            error
-		expectedStatus int
+		name                string
+		accept              string
+		expectedStatus      int
+		expectedContentType string
 	}{
-		{
+		{"json", "application/json", http.StatusOK, "application/json"},
+		{"wildcard", "*/*", http.StatusOK, "application/json"},
+		{"csv", "text/csv", http.StatusOK, "text/csv; charset=utf-8"},
+		{"unsupported", "application/xml", http.StatusNotAcceptable, "application/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/transactions", nil)
+			req.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			if contentType := w.Header().Get("Content-Type"); contentType != tt.expectedContentType {
+				t.Errorf("Expected Content-Type %q, got %q", tt.expectedContentType, contentType)
+			}
+		})
+	}
+
+	// CSV body has a header row plus one row per transaction
+	req := httptest.NewRequest(http.MethodGet, "/api/transactions?type=income", nil)
+	req.Header.Set("Accept", "text/csv")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 CSV lines, got %d: %q", len(lines), w.Body.String())
+	}
+	if lines[1] != "2024-01-01,2800.00,salary,Bi-weekly salary,income,,," {
+		t.Errorf("Unexpected CSV row: %q", lines[1])
+	}
+}
+
+func TestTransactionHandler_Duplicates(t *testing.T) {
+	handler := NewTransactionHandler(testutil.NewTestService(t, []byte(`[
+		{"date": "2024-01-02", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"},
+		{"date": "2024-01-03", "amount": -85, "category": "groceries", "description": "Whole Foods", "type": "expense"},
+		{"date": "2024-01-03", "amount": -85, "category": "groceries", "description": "Whole Foods", "type": "expense"}
+	]`)))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/transactions/duplicates", nil)
+	w := httptest.NewRecorder()
+
+	handler.HandleDuplicates(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var response domain.DuplicatesResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Count != 1 || len(response.Groups) != 1 || len(response.Groups[0].Transactions) != 2 {
+		t.Fatalf("Expected the groceries pair as the only group, got %+v", response)
+	}
+	if response.Groups[0].Reason != "same date, amount, category and description" {
+		t.Errorf("Unexpected reason %q", response.Groups[0].Reason)
+	}
+}
+
+func TestTransactionHandler_Export(t *testing.T) {
+	handler, _ := setupTestHandlers(t)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedHeader string
+	}{
+		{"default columns", "", http.StatusOK, "date,amount,category,description,type,merchant,currency,tags"},
+		{"subset", "?fields=date,amount,category", http.StatusOK, "date,amount,category"},
+		{"order preserved", "?fields=category,%20date", http.StatusOK, "category,date"},
+		{"with filters", "?type=income&fields=amount", http.StatusOK, "amount"},
+		{"unknown field", "?fields=date,secret", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/transactions/export"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.HandleExport(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			if tt.expectedStatus != http.StatusOK {
+				var response ErrorResponse
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode error response: %v", err)
+				}
+				if !strings.Contains(response.Message, "secret") {
+					t.Errorf("Expected the error to name the invalid field, got %q", response.Message)
+				}
+				return
+			}
+
+			if contentType := w.Header().Get("Content-Type"); contentType != "text/csv; charset=utf-8" {
+				t.Errorf("Expected CSV content type, got %q", contentType)
+			}
+			lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+			if lines[0] != tt.expectedHeader {
+				t.Errorf("Expected header %q, got %q", tt.expectedHeader, lines[0])
+			}
+		})
+	}
+}
+
+func TestTransactionHandler_Patch(t *testing.T) {
+	handler, _ := setupTestHandlers(t)
+
+	r := chi.NewRouter()
+	r.Patch("/api/transactions/{id}", handler.HandlePatch)
+
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		expectedStatus int
+		expectedField  string
+	}{
+		{"description only", "tx-2", `{"description": "Rent (January)"}`, http.StatusOK, ""},
+		{"category", "tx-2", `{"category": "housing"}`, http.StatusOK, ""},
+		{"invalid type", "tx-2", `{"type": "transfer"}`, http.StatusUnprocessableEntity, "type"},
+		{"sign no longer matches type", "tx-2", `{"type": "income"}`, http.StatusUnprocessableEntity, "amount"},
+		{"notes", "tx-2", `{"notes": "paid late"}`, http.StatusOK, ""},
+		{"unknown field", "tx-2", `{"merchant": "Landlord"}`, http.StatusBadRequest, ""},
+		{"malformed body", "tx-2", `{`, http.StatusBadRequest, ""},
+		{"oversized body", "tx-2", `{"notes": "` + strings.Repeat("a", maxCreateBodySize) + `"}`, http.StatusRequestEntityTooLarge, ""},
+		{"unknown transaction", "missing", `{"category": "housing"}`, http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/api/transactions/"+tt.id, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if tt.expectedField != "" {
+				var response ValidationErrorResponse
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if len(response.ValidationErrors) != 1 || response.ValidationErrors[0].Field != tt.expectedField {
+					t.Errorf("Expected a single error on %q, got %+v", tt.expectedField, response.ValidationErrors)
+				}
+			}
+		})
+	}
+
+	// Both successful patches were applied to the same transaction
+	req := httptest.NewRequest(http.MethodPatch, "/api/transactions/tx-2", strings.NewReader(`{}`))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var tx domain.Transaction
+	if err := json.NewDecoder(w.Body).Decode(&tx); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if tx.ID != "tx-2" || tx.Description != "Rent (January)" || tx.Category != "housing" || tx.Type != "expense" {
+		t.Errorf("Expected the patched rent transaction, got %+v", tx)
+	}
+}
+
+func TestTransactionHandler_BulkImport(t *testing.T) {
+	body := `[
+		{"date": "2024-02-01", "amount": 2800, "category": "salary", "description": "Salary", "type": "income"},
+		{"date": "2024-02-02", "amount": 1200, "category": "rent", "description": "Rent", "type": "expense"},
+		{"date": "2024-02-03", "amount": -40, "category": "groceries", "description": "Market", "type": "transfer"}
+	]`
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedCount int // Repository size after the call; MinimalJSON holds 3
+	}{
+		{"dry run", "?dryRun=true", 3},
+		{"import", "", 4},
+		{"import skipping conflicts", "?onConflict=skip", 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := testutil.NewTestRepo(t, testutil.MinimalJSON)
+			handler := NewTransactionHandler(service.NewAnalyticsService(repo))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/transactions/bulk"+tt.query, strings.NewReader(body))
+			w := httptest.NewRecorder()
+
+			handler.HandleBulkImport(w, req)
+
+			if w.Code != http.StatusMultiStatus {
+				t.Fatalf("Expected status 207, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var report domain.ImportReport
+			if err := json.NewDecoder(w.Body).Decode(&report); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if report.DryRun != (tt.query == "?dryRun=true") || report.ValidCount != 1 || report.InvalidCount != 2 {
+				t.Errorf("Unexpected report %+v", report)
+			}
+			if report.EstimatedTotalIncome != 2800 || report.EstimatedTotalExpenses != 0 {
+				t.Errorf("Unexpected estimated totals %+v", report)
+			}
+			if errs := report.Results[1].Errors; len(errs) != 1 || errs[0].Field != "amount" {
+				t.Errorf("Expected an amount error on the second item, got %+v", errs)
+			}
+
+			if count := repo.Count(); count != tt.expectedCount {
+				t.Errorf("Expected %d stored transactions, got %d", tt.expectedCount, count)
+			}
+		})
+	}
+
+	handler, _ := setupTestHandlers(t)
+	for _, tc := range []struct{ query, body string }{
+		{"?dryRun=maybe", body},
+		{"?onConflict=merge", body},
+		{"", `{"date": "2024-02-01"}`},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/transactions/bulk"+tc.query, strings.NewReader(tc.body))
+		w := httptest.NewRecorder()
+		handler.HandleBulkImport(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %q, got %d", tc.query+tc.body, w.Code)
+		}
+	}
+
+	oversized := "[" + strings.Repeat(" ", maxBulkImportBodySize) + "]"
+	req := httptest.NewRequest(http.MethodPost, "/api/transactions/bulk", strings.NewReader(oversized))
+	w := httptest.NewRecorder()
+	handler.HandleBulkImport(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413 for an oversized body, got %d", w.Code)
+	}
+}
+
+func TestTransactionHandler_ImportMint(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "importer", "testdata", "mint_sample.csv"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	repo := testutil.NewTestRepo(t, testutil.MinimalJSON)
+	handler := NewTransactionHandler(service.NewAnalyticsService(repo))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/transactions/import?format=mint", bytes.NewReader(data))
+	w := httptest.NewRecorder()
+
+	handler.HandleImport(w, req)
+
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("Expected status 207, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var report domain.ImportReport
+	if err := json.NewDecoder(w.Body).Decode(&report); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if report.ValidCount != 5 || report.InvalidCount != 0 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+	if count := repo.Count(); count != 8 {
+		t.Errorf("Expected 8 stored transactions, got %d", count)
+	}
+
+	for _, tc := range []struct {
+		query string
+		body  string
+		code  string
+	}{
+		{"", string(data), ""},
+		{"?format=ynab", string(data), ""},
+		{"?format=mint&dryRun=maybe", string(data), ""},
+		{"?format=mint", "date,amount\n2024-01-01,10\n", domain.CodeInvalidImportFile},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/transactions/import"+tc.query, strings.NewReader(tc.body))
+		w := httptest.NewRecorder()
+		handler.HandleImport(w, req)
+
+		var response ErrorResponse
+		json.NewDecoder(w.Body).Decode(&response)
+		if w.Code != http.StatusBadRequest || response.Code != tc.code {
+			t.Errorf("Expected status 400 with code %q for %q, got %d %+v", tc.code, tc.query, w.Code, response)
+		}
+	}
+
+	oversized := "date\n" + strings.Repeat("a", maxBulkImportBodySize)
+	req = httptest.NewRequest(http.MethodPost, "/api/transactions/import?format=mint", strings.NewReader(oversized))
+	w = httptest.NewRecorder()
+	handler.HandleImport(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413 for an oversized body, got %d", w.Code)
+	}
+}
+
+func TestTransactionHandler_Create(t *testing.T) {
+	handler, _ := setupTestHandlers(t)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{"valid", `{"date": "2024-02-10", "amount": -30, "category": "dining", "description": "Lunch", "type": "expense"}`, http.StatusCreated},
+		{"invalid", `{"date": "2024-02-10", "amount": 30, "category": "dining", "type": "expense"}`, http.StatusUnprocessableEntity},
+		{"malformed", `{"date": `, http.StatusBadRequest},
+		{"taken ID", `{"id": "tx-1", "date": "2024-02-10", "amount": -30, "category": "dining", "description": "Lunch", "type": "expense"}`, http.StatusConflict},
+		{"oversized", `{"description": "` + strings.Repeat("a", maxCreateBodySize) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/transactions", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.HandleCreate(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus == http.StatusCreated {
+				var created domain.Transaction
+				if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if created.ID == "" || created.Description != "Lunch" {
+					t.Errorf("Unexpected created transaction %+v", created)
+				}
+			}
+		})
+	}
+}
+
+// newTransactionFeedServer serves transaction creation and the WebSocket feed over real HTTP
+func newTransactionFeedServer(t *testing.T, allowedOrigins []string) *httptest.Server {
+	t.Helper()
+
+	analyticsService := testutil.NewTestService(t, testutil.MinimalJSON)
+	hub := NewTransactionHub(time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	go hub.Run(ctx)
+	analyticsService.SetTransactionBroadcaster(hub)
+
+	r := chi.NewRouter()
+	r.Post("/api/transactions", NewTransactionHandler(analyticsService).HandleCreate)
+	r.Get("/api/ws/transactions", NewTransactionFeedHandler(hub, 512, allowedOrigins).ServeHTTP)
+
+	server := httptest.NewServer(r)
+	t.Cleanup(func() {
+		cancel()
+		server.Close()
+	})
+	return server
+}
+
+func TestTransactionFeedHandler_BroadcastsCreatedTransactions(t *testing.T) {
+	server := newTransactionFeedServer(t, nil)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/api/ws/transactions", nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+
+	// The server answers pings once the connection is registered with the hub
+	registered := make(chan struct{}, 1)
+	conn.SetPongHandler(func(string) error {
+		registered <- struct{}{}
+		return nil
+	})
+	messages := make(chan []byte, 1)
+	go func() {
+		defer close(messages)
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			messages <- data
+		}
+	}()
+
+	if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+	select {
+	case <-registered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the connection to be registered")
+	}
+
+	body := `{"date": "2024-02-10", "amount": -30, "category": "dining", "description": "Team lunch", "type": "expense"}`
+	resp, err := http.Post(server.URL+"/api/transactions", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST /api/transactions failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d", resp.StatusCode)
+	}
+
+	select {
+	case data, ok := <-messages:
+		if !ok {
+			t.Fatal("Connection closed before a transaction arrived")
+		}
+		var tx domain.Transaction
+		if err := json.Unmarshal(data, &tx); err != nil {
+			t.Fatalf("Failed to decode message %q: %v", data, err)
+		}
+		if tx.ID == "" || tx.Description != "Team lunch" || tx.Amount != -30 {
+			t.Errorf("Unexpected transaction %+v", tx)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the created transaction")
+	}
+}
+
+func TestTransactionFeedHandler_RejectsDisallowedOrigin(t *testing.T) {
+	server := newTransactionFeedServer(t, []string{"http://localhost:5173"})
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/ws/transactions"
+
+	_, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {"http://evil.example.com"}})
+	if err == nil {
+		t.Fatal("Expected the upgrade to be refused")
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected status 403, got %v", resp)
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {"http://localhost:5173"}})
+	if err != nil {
+		t.Fatalf("Expected an allowed origin to connect, got %v", err)
+	}
+	conn.Close()
+}
+
+func TestEventsHandler_StreamsCreatedTransaction(t *testing.T) {
+	bus := service.NewInMemoryEventBus()
+	repo := repository.NewPublishingRepository(testutil.NewTestRepo(t, testutil.MinimalJSON), bus)
+	analyticsService := service.NewAnalyticsService(repo)
+
+	r := chi.NewRouter()
+	r.Post("/api/transactions", NewTransactionHandler(analyticsService).HandleCreate)
+	r.Get("/api/events", NewEventsHandler(bus).ServeHTTP)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/events", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /api/events failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Expected Content-Type text/event-stream, got %q", ct)
+	}
+
+	// The handler subscribes before sending headers, so the transaction cannot be missed
+	type sseEvent struct{ name, data string }
+	events := make(chan sseEvent, 4)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		var event sseEvent
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				event.name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				event.data = strings.TrimPrefix(line, "data: ")
+			case line == "" && event.name != "":
+				events <- event
+				event = sseEvent{}
+			}
+		}
+	}()
+
+	go func() {
+		body := `{"date": "2024-02-10", "amount": -30, "category": "dining", "description": "Team lunch", "type": "expense"}`
+		resp, err := http.Post(server.URL+"/api/transactions", "application/json", strings.NewReader(body))
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+
+	timeout := time.After(time.Second)
+	for _, want := range []string{domain.EventTransactionCreated, domain.EventAnalyticsUpdated} {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatalf("Stream ended before %s", want)
+			}
+			if event.name != want {
+				t.Fatalf("Expected event %s, got %s", want, event.name)
+			}
+			if want == domain.EventTransactionCreated {
+				var tx domain.Transaction
+				if err := json.Unmarshal([]byte(event.data), &tx); err != nil {
+					t.Fatalf("Failed to decode %q: %v", event.data, err)
+				}
+				if tx.ID == "" || tx.Description != "Team lunch" {
+					t.Errorf("Unexpected transaction %+v", tx)
+				}
+			}
+		case <-timeout:
+			t.Fatalf("Timed out waiting for %s", want)
+		}
+	}
+}
+
+func TestTransactionHandler_MethodNotAllowed(t *testing.T) {
+	handler, _ := setupTestHandlers(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/transactions", nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", w.Code)
+	}
+}
+
+func TestSummaryHandler_GetCategorySummary(t *testing.T) {
+	_, handler := setupTestHandlers(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/summary/categories", nil)
+	w := httptest.NewRecorder()
+
+	handler.HandleCategorySummary(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+
+	contentType := w.Header().Get("Content-Type")
+	if contentType != "application/json" {
+		t.Errorf("Expected Content-Type 'application/json', got '%s'", contentType)
+	}
+
+	var response domain.CategorySummary
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// Verify structure
+	if response.Income == nil {
+		t.Error("Expected income map to be non-nil")
+	}
+
+	if response.Expenses == nil {
+		t.Error("Expected expenses map to be non-nil")
+	}
+
+	// Verify we have income categories
+	if len(response.Income) == 0 {
+		t.Error("Expected at least one income category")
+	}
+
+	// Verify we have expense categories
+	if len(response.Expenses) == 0 {
+		t.Error("Expected at least one expense category")
+	}
+
+	// Verify financial summary
+	if response.Summary.TotalIncome <= 0 {
+		t.Error("Expected positive total income")
+	}
+
+	if response.Summary.TotalExpenses <= 0 {
+		t.Error("Expected positive total expenses")
+	}
+
+	// Verify period information
+	if response.Period.Start == "" {
+		t.Error("Expected period start date")
+	}
+
+	if response.Period.End == "" {
+		t.Error("Expected period end date")
+	}
+
+	if response.Period.Months <= 0 {
+		t.Error("Expected positive number of months")
+	}
+}
+
+func TestSummaryHandler_GetCategorySummaryInCurrency(t *testing.T) {
+	_, handler := setupTestHandlers(t)
+	converter, err := service.NewStaticRateConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	handler.analyticsService.SetCurrencyConverter(converter, "USD")
+
+	tests := []struct {
+		name           string
+		currency       string
+		expectedStatus int
+	}{
+		{"supported currency", "MXN", http.StatusOK},
+		{"lowercase currency", "mxn", http.StatusOK},
+		{"unknown code", "XYZ", http.StatusBadRequest},
+		{"valid code without rate", "ZAR", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/summary/categories?currency="+tt.currency, nil)
+			w := httptest.NewRecorder()
+
+			handler.HandleCategorySummary(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response domain.CategorySummary
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if response.Currency != "MXN" {
+					t.Errorf("Expected currency MXN, got %s", response.Currency)
+				}
+			}
+		})
+	}
+}
+
+func TestSummaryHandler_GetCategorySummaryPreferredCurrency(t *testing.T) {
+	_, handler := setupTestHandlers(t)
+	converter, err := service.NewStaticRateConverter()
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+	handler.analyticsService.SetCurrencyConverter(converter, "USD")
+	chain := middleware.FinancialContext("en-US")(http.HandlerFunc(handler.HandleCategorySummary))
+
+	tests := []struct {
+		name             string
+		query            string
+		header           string
+		expectedCurrency string
+	}{
+		{"header only", "", "MXN", "MXN"},
+		{"query overrides header", "?currency=EUR", "MXN", "EUR"},
+		{"no header is not converted", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/summary/categories"+tt.query, nil)
+			if tt.header != "" {
+				req.Header.Set(middleware.PreferredCurrencyHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			chain.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d", w.Code)
+			}
+			var response domain.CategorySummary
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response.Currency != tt.expectedCurrency {
+				t.Errorf("Expected currency %s, got %s", tt.expectedCurrency, response.Currency)
+			}
+		})
+	}
+}
+
+func TestSummaryHandler_GetTimeline(t *testing.T) {
+	_, handler := setupTestHandlers(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/summary/timeline", nil)
+	w := httptest.NewRecorder()
+
+	handler.HandleTimeline(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+
+	contentType := w.Header().Get("Content-Type")
+	if contentType != "application/json" {
+		t.Errorf("Expected Content-Type 'application/json', got '%s'", contentType)
+	}
+
+	var response domain.TimelineResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// Verify aggregation type
+	if response.Aggregation != "monthly" {
+		t.Errorf("Expected aggregation 'monthly', got '%s'", response.Aggregation)
+	}
+
+	// Verify we have timeline data
+	if len(response.Timeline) == 0 {
+		t.Error("Expected at least one timeline point")
+	}
+
+	// Verify timeline points have required fields
+	for i, point := range response.Timeline {
+		if point.Period == "" {
+			t.Errorf("Timeline point %d has empty period", i)
+		}
+
+		// Income should be non-negative
+		if point.Income < 0 {
+			t.Errorf("Timeline point %d has negative income: %v", i, point.Income)
+		}
+
+		// Expenses should be non-negative (we convert to positive)
+		if point.Expenses < 0 {
+			t.Errorf("Timeline point %d has negative expenses: %v", i, point.Expenses)
+		}
+	}
+
+	// Verify timeline is sorted
+	for i := 1; i < len(response.Timeline); i++ {
+		if response.Timeline[i-1].Period > response.Timeline[i].Period {
+			t.Error("Timeline is not sorted chronologically")
+			break
+		}
+	}
+}
+
+func TestSummaryHandler_GetMerchantSummary(t *testing.T) {
+	_, handler := setupTestHandlers(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/summary/merchants", nil)
+	w := httptest.NewRecorder()
+
+	handler.HandleMerchantSummary(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+
+	var response map[string]domain.MerchantDetail
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	wholeFoods, exists := response["Whole Foods"]
+	if !exists {
+		t.Fatal("Expected 'Whole Foods' merchant in response")
+	}
+
+	if wholeFoods.Total != 85 || wholeFoods.TopCategory != "groceries" {
+		t.Errorf("Unexpected merchant detail: %+v", wholeFoods)
+	}
+}
+
+func TestSummaryHandler_GetSpendingHeatmap(t *testing.T) {
+	_, handler := setupTestHandlers(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/summary/heatmap", nil)
+	w := httptest.NewRecorder()
+
+	handler.HandleSpendingHeatmap(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+
+	var response domain.SpendingHeatmap
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// 2024-01-02 (rent) is a Tuesday, 2024-01-03 (groceries) a Wednesday
+	if len(response.Matrix) != 7 || response.Matrix[2][0] != 1200 || response.Matrix[3][0] != 85 {
+		t.Errorf("Unexpected heatmap: %+v", response.Matrix)
+	}
+}
+
+func TestSummaryHandler_GetIncomeStability(t *testing.T) {
+	_, handler := setupTestHandlers(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/summary/income-stability", nil)
+	w := httptest.NewRecorder()
+
+	handler.HandleIncomeStability(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+
+	var response domain.IncomeStability
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// A single 2800 salary in January
+	if response.Mean != 2800 || response.CoefficientOfVariation != 0 || len(response.MonthlyIncomes) != 1 {
+		t.Errorf("Unexpected income stability: %+v", response)
+	}
+}
+
+func TestSummaryHandler_GetBurnRate(t *testing.T) {
+	_, handler := setupTestHandlers(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/summary/burn-rate", nil)
+	w := httptest.NewRecorder()
+
+	handler.HandleBurnRate(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+
+	var response domain.BurnRate
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// 1285 of expenses over 2024-01-01..2024-01-03 (3 days)
+	if response.DailyAverageSpend != 428.33 || response.DaysOfRunwayFromSavings == nil {
+		t.Errorf("Unexpected burn rate: %+v", response)
+	}
+}
+
+func TestSummaryHandler_GetTrend(t *testing.T) {
+	handler := NewSummaryHandler(testutil.NewTestService(t, testutil.StandardJSON), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/summary/trend", nil)
+	w := httptest.NewRecorder()
+
+	handler.HandleTrend(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var response domain.TrendAnalysis
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// Income 5600, 6200, 5600 is flat; expenses 1520, 1515, 2715 rise by 597.50 a month
+	if response.IncomeSlopePerMonth != 0 || response.IncomeTrend != domain.TrendStable || response.ExpensesSlopePerMonth != 597.5 {
+		t.Errorf("Unexpected trend: %+v", response)
+	}
+}
+
+func TestSummaryHandler_GetYoY(t *testing.T) {
+	handler := NewSummaryHandler(testutil.NewTestService(t, []byte(`[
+		{"date": "2023-02-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"},
+		{"date": "2023-02-03", "amount": -1000, "category": "rent", "description": "Rent", "type": "expense"},
+		{"date": "2024-01-01", "amount": 3200, "category": "salary", "description": "Salary", "type": "income"},
+		{"date": "2024-02-01", "amount": 3200, "category": "salary", "description": "Salary", "type": "income"},
+		{"date": "2024-02-03", "amount": -1100, "category": "rent", "description": "Rent", "type": "expense"}
+	]`)), nil)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+	}{
+		{"february against the prior february", "?month=2&year=2024", http.StatusOK},
+		{"no prior year data", "?month=1&year=2024", http.StatusUnprocessableEntity},
+		{"missing month", "?year=2024", http.StatusBadRequest},
+		{"month out of range", "?month=13&year=2024", http.StatusBadRequest},
+		{"invalid year", "?month=2&year=24", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/summary/yoy"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.HandleYoY(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response domain.YoYComparison
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response.CurrentYear.Period != "2024-02" || response.PriorYear.Period != "2023-02" || response.Deltas["rent"] != 100 {
+				t.Errorf("Unexpected comparison: %+v", response)
+			}
+		})
+	}
+}
+
+func TestSummaryHandler_MethodNotAllowed(t *testing.T) {
+	_, handler := setupTestHandlers(t)
+
+	tests := []struct {
+		name    string
+		path    string
+		handler http.HandlerFunc
+	}{
+		{"categories POST", "/api/summary/categories", handler.HandleCategorySummary},
+		{"timeline POST", "/api/summary/timeline", handler.HandleTimeline},
+		{"merchants POST", "/api/summary/merchants", handler.HandleMerchantSummary},
+		{"tags POST", "/api/summary/tags", handler.HandleTagSummary},
+		{"heatmap POST", "/api/summary/heatmap", handler.HandleSpendingHeatmap},
+		{"income stability POST", "/api/summary/income-stability", handler.HandleIncomeStability},
+		{"burn rate POST", "/api/summary/burn-rate", handler.HandleBurnRate},
+		{"trend POST", "/api/summary/trend", handler.HandleTrend},
+		{"yoy POST", "/api/summary/yoy", handler.HandleYoY},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			tt.handler(w, req)
+
+			if w.Code != http.StatusMethodNotAllowed {
+				t.Errorf("Expected status 405, got %d", w.Code)
+			}
+		})
+	}
+}
+
+func TestForecastHandler_SavingsGrowth(t *testing.T) {
+	analyticsService := testutil.NewTestService(t, testutil.MinimalJSON)
+	handler := NewForecastHandler(service.NewForecastingService(analyticsService))
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedYears  int
+	}{
+		{"defaults", "", http.StatusOK, 10},
+		{"custom params", "?annualRate=0.05&years=5", http.StatusOK, 5},
+		{"invalid rate", "?annualRate=abc", http.StatusBadRequest, 0},
+		{"invalid years", "?years=ten", http.StatusBadRequest, 0},
+		{"out of range years", "?years=0", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/forecast/savings-growth"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.HandleSavingsGrowth(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response domain.SavingsProjection
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+
+				if len(response.YearlySnapshots) != tt.expectedYears {
+					t.Errorf("Expected %d snapshots, got %d", tt.expectedYears, len(response.YearlySnapshots))
+				}
+			}
+		})
+	}
+}
+
+func TestAnalysisHandler_UpcomingBills(t *testing.T) {
+	analyticsService := testutil.NewTestService(t, testutil.MinimalJSON)
+	handler := NewAnalysisHandler(analyticsService)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+	}{
+		{"default window", "", http.StatusOK},
+		{"custom window", "?days=7", http.StatusOK},
+		{"invalid days", "?days=abc", http.StatusBadRequest},
+		{"non-positive days", "?days=0", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/analysis/upcoming-bills"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.HandleUpcomingBills(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response []domain.UpcomingBill
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalysisHandler_PeriodStats(t *testing.T) {
+	handler := NewAnalysisHandler(testutil.NewTestService(t, testutil.StandardJSON))
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedPeriods int
+	}{
+		{"default monthly", "", http.StatusOK, 3},
+		{"weekly", "?aggregation=weekly", http.StatusOK, 13},
+		{"invalid aggregation", "?aggregation=yearly", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/analysis/stats"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.HandlePeriodStats(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response []domain.PeriodStats
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if len(response) != tt.expectedPeriods {
+					t.Errorf("Expected %d periods, got %d", tt.expectedPeriods, len(response))
+				}
+			}
+		})
+	}
+}
+
+func TestAnalysisHandler_SeasonalPatterns(t *testing.T) {
+	handler := NewAnalysisHandler(testutil.NewTestService(t, testutil.StandardJSON))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analysis/seasonal", nil)
+	w := httptest.NewRecorder()
+
+	handler.HandleSeasonalPatterns(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	// Three months of data is not enough to detect seasonality
+	var patterns []domain.SeasonalPattern
+	if err := json.NewDecoder(w.Body).Decode(&patterns); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(patterns) != 0 {
+		t.Errorf("Expected no seasonal patterns, got %+v", patterns)
+	}
+}
+
+func TestAnalysisHandler_CategoryCorrelations(t *testing.T) {
+	handler := NewAnalysisHandler(testutil.NewTestService(t, testutil.StandardJSON))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analysis/correlations", nil)
+	w := httptest.NewRecorder()
+
+	handler.HandleCategoryCorrelations(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var matrix domain.CorrelationMatrix
+	if err := json.NewDecoder(w.Body).Decode(&matrix); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	for category, row := range matrix {
+		if row[category] != 1 {
+			t.Errorf("Expected %s to correlate perfectly with itself, got %v", category, row[category])
+		}
+	}
+}
+
+func TestAnalysisHandler_SpendingMomentum(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           []byte
+		expectedStatus int
+	}{
+		{"three months", testutil.StandardJSON, http.StatusOK},
+		{"too little history", testutil.MinimalJSON, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewAnalysisHandler(testutil.NewTestService(t, tt.data))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/analysis/spending-momentum", nil)
+			w := httptest.NewRecorder()
+
+			handler.HandleSpendingMomentum(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var momentum domain.SpendingMomentum
+			if err := json.NewDecoder(w.Body).Decode(&momentum); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(momentum.MonthlyDeltas) != 2 || len(momentum.Accelerations) != 1 {
+				t.Errorf("Expected 2 deltas and 1 acceleration, got %+v", momentum)
+			}
+		})
+	}
+}
+
+func TestCategoryHandler_Merge(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		expectedStatus int
+		expectedMerged int
+	}{
+		{"merge", http.MethodPost, `{"source": "rent", "target": "housing"}`, http.StatusOK, 1},
+		{"unknown source", http.MethodPost, `{"source": "travel", "target": "housing"}`, http.StatusOK, 0},
+		{"same category", http.MethodPost, `{"source": "rent", "target": "rent"}`, http.StatusBadRequest, 0},
+		{"blank target", http.MethodPost, `{"source": "rent", "target": ""}`, http.StatusBadRequest, 0},
+		{"invalid body", http.MethodPost, `{`, http.StatusBadRequest, 0},
+		{"wrong method", http.MethodGet, "", http.StatusMethodNotAllowed, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analyticsService := testutil.NewTestService(t, testutil.MinimalJSON)
+			handler := NewCategoryHandler(analyticsService, nil)
+
+			req := httptest.NewRequest(tt.method, "/api/categories/merge", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.HandleMerge(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response mergeCategoriesResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response.Merged != tt.expectedMerged {
+				t.Errorf("Expected %d merged, got %d", tt.expectedMerged, response.Merged)
+			}
+		})
+	}
+}
+
+func TestCategoryHandler_Metadata(t *testing.T) {
+	metadataRepo, err := repository.NewJSONCategoryMetadataRepository(filepath.Join(t.TempDir(), "category_metadata.json"))
+	if err != nil {
+		t.Fatalf("Failed to create metadata repository: %v", err)
+	}
+	handler := NewCategoryHandler(testutil.NewTestService(t, testutil.MinimalJSON), service.NewCategoryMetadataService(metadataRepo))
+
+	r := chi.NewRouter()
+	r.Get("/api/categories/metadata", handler.HandleListMetadata)
+	r.Post("/api/categories/metadata/{name}", handler.HandleOverrideMetadata)
+
+	list := func() map[string]domain.CategoryMetadata {
+		t.Helper()
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/categories/metadata", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+
+		var response categoryMetadataResponse
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		byName := make(map[string]domain.CategoryMetadata)
+		for _, metadata := range response.Categories {
+			byName[metadata.Name] = metadata
+		}
+		return byName
+	}
+
+	groceries := list()["groceries"]
+	if !groceries.IsDefault || groceries.Color == "" || groceries.IconName == "" {
+		t.Fatalf("Expected default groceries metadata, got %+v", groceries)
+	}
+
+	// Only the color changes; the rest of the default entry is kept
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/categories/metadata/groceries", strings.NewReader(`{"color": "#123ABC"}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	overridden := list()["groceries"]
+	want := groceries
+	want.Color, want.IsDefault = "#123ABC", false
+	if overridden != want {
+		t.Errorf("Expected %+v, got %+v", want, overridden)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/categories/metadata/groceries", strings.NewReader(`{"color": "green"}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a named color, got %d", w.Code)
+	}
+}
+
+func TestAnalysisHandler_IncomeGrowth(t *testing.T) {
+	// Three months of data is below the 4-month minimum
+	handler := NewAnalysisHandler(testutil.NewTestService(t, testutil.StandardJSON))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analysis/income-growth", nil)
+	w := httptest.NewRecorder()
+
+	handler.HandleIncomeGrowth(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status 422, got %d", w.Code)
+	}
+
+	var response ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if response.Code != domain.CodeInsufficientData {
+		t.Errorf("Expected code %q, got %q", domain.CodeInsufficientData, response.Code)
+	}
+}
+
+func TestAdviceHandler_AIError(t *testing.T) {
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error": {"message": "Rate limit reached", "type": "requests"}}`))
+	}))
+	defer openAI.Close()
+
+	analyticsService := testutil.NewTestService(t, testutil.MinimalJSON)
+	aiService := service.NewAIService("test-key", "")
+	aiService.SetAPIURL(openAI.URL)
+	aiService.SetHTTPClient(openAI.Client())
+	history, _ := service.NewJSONAdviceRepository("")
+	handler := NewAdviceHandler(analyticsService, aiService, history)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/advice", strings.NewReader(`{"context": "general"}`))
+	w := httptest.NewRecorder()
+
+	handler.GetAdvice(w, req)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status 429, got %d", w.Code)
+	}
+
+	if retryAfter := w.Header().Get("Retry-After"); retryAfter != "30" {
+		t.Errorf("Expected Retry-After '30', got %q", retryAfter)
+	}
+
+	// Failed requests are not recorded
+	if page, _ := history.List(1, 10); page.Total != 0 {
+		t.Errorf("Expected empty history, got %d records", page.Total)
+	}
+}
+
+func TestAdviceHandler_InvalidBody(t *testing.T) {
+	analyticsService := testutil.NewTestService(t, testutil.MinimalJSON)
+	history, _ := service.NewJSONAdviceRepository("")
+	handler := NewAdviceHandler(analyticsService, service.NewAIService("", ""), history)
+
+	tests := []struct {
+		name            string
+		body            string
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{"missing body", "", http.StatusBadRequest, "Request body is required"},
+		{"truncated JSON", "{", http.StatusBadRequest, "Malformed JSON at character position 1"},
+		{"invalid character", `{"context" "general"}`, http.StatusBadRequest, "Malformed JSON at character position 12"},
+		{"wrong field type", `{"context": 42}`, http.StatusUnprocessableEntity, "Field 'context' must be of type string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/advice", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.GetAdvice(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			var response ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode error response: %v", err)
+			}
+			if response.Message != tt.expectedMessage {
+				t.Errorf("Expected message %q, got %q", tt.expectedMessage, response.Message)
+			}
+		})
+	}
+}
+
+func TestAdviceHandler_CircuitOpen(t *testing.T) {
+	calls := 0
+	openAI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer openAI.Close()
+
+	analyticsService := testutil.NewTestService(t, testutil.MinimalJSON)
+	aiService := service.NewAIService("test-key", "")
+	aiService.SetAPIURL(openAI.URL)
+	aiService.SetHTTPClient(openAI.Client())
+	aiService.SetCircuitBreaker(service.NewCircuitBreaker(1, time.Minute, 1))
+	history, _ := service.NewJSONAdviceRepository("")
+	handler := NewAdviceHandler(analyticsService, aiService, history)
+
+	// The first failure still falls back to mock advice, and trips the circuit
+	req := httptest.NewRequest(http.MethodPost, "/api/advice", strings.NewReader(`{"context": "general"}`))
+	w := httptest.NewRecorder()
+	handler.GetAdvice(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	// With the circuit open, OpenAI is not called at all
+	req = httptest.NewRequest(http.MethodPost, "/api/advice", strings.NewReader(`{"context": "general"}`))
+	w = httptest.NewRecorder()
+	handler.GetAdvice(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", w.Code)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call to OpenAI, got %d", calls)
+	}
+
+	var response ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if response.Code != domain.CodeServiceUnavailable {
+		t.Errorf("Expected code %q, got %q", domain.CodeServiceUnavailable, response.Code)
+	}
+}
+
+func TestAdviceHandler_History(t *testing.T) {
+	analyticsService := testutil.NewTestService(t, testutil.MinimalJSON)
+	history, err := service.NewJSONAdviceRepository("")
+	if err != nil {
+		t.Fatalf("Failed to create advice repository: %v", err)
+	}
+	// No API key: the AI service returns mock advice
+	handler := NewAdviceHandler(analyticsService, service.NewAIService("", ""), history)
+
+	getHistory := func(query string) (int, service.AdviceHistoryResponse) {
+		req := httptest.NewRequest(http.MethodGet, "/api/advice/history"+query, nil)
+		w := httptest.NewRecorder()
+		handler.GetAdviceHistory(w, req)
+
+		var response service.AdviceHistoryResponse
+		if w.Code == http.StatusOK {
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+		}
+		return w.Code, response
+	}
+
+	// No history yet: 200 with an empty slice
+	code, empty := getHistory("")
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", code)
+	}
+	if empty.Records == nil || len(empty.Records) != 0 {
+		t.Errorf("Expected empty records slice, got %v", empty.Records)
+	}
+
+	contexts := []string{"general", "savings", "budgeting"}
+	for _, adviceContext := range contexts {
+		req := httptest.NewRequest(http.MethodPost, "/api/advice", strings.NewReader(`{"context": "`+adviceContext+`"}`))
+		w := httptest.NewRecorder()
+		handler.GetAdvice(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200 for %s advice, got %d", adviceContext, w.Code)
+		}
+	}
+
+	code, page := getHistory("?page=1&pageSize=10")
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", code)
+	}
+	if page.Total != 3 || len(page.Records) != 3 {
+		t.Fatalf("Expected 3 records, got %d (total %d)", len(page.Records), page.Total)
+	}
+
+	// Newest first
+	for i, record := range page.Records {
+		expected := contexts[len(contexts)-1-i]
+		if record.Request.Context != expected {
+			t.Errorf("Record %d: expected context %q, got %q", i, expected, record.Request.Context)
+		}
+		if record.ID == "" || record.Response.Advice == "" || record.FinancialSnapshot.Summary.TotalIncome == 0 {
+			t.Errorf("Record %d is incomplete: %+v", i, record)
+		}
+		if i > 0 && record.RequestedAt.After(page.Records[i-1].RequestedAt) {
+			t.Errorf("Record %d is newer than record %d", i, i-1)
+		}
+	}
+
+	// Pagination
+	_, second := getHistory("?page=2&pageSize=2")
+	if len(second.Records) != 1 || second.Records[0].Request.Context != "general" {
+		t.Errorf("Expected the oldest record on page 2, got %+v", second.Records)
+	}
+
+	for _, query := range []string{"?page=0", "?page=abc", "?pageSize=101"} {
+		if code, _ := getHistory(query); code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", query, code)
+		}
+	}
+}
+
+func TestRespondWithError(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	respondWithError(w, http.StatusBadRequest, "Test error message")
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+
+	var response ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+
+	if response.Error != "Bad Request" {
+		t.Errorf("Expected error 'Bad Request', got '%s'", response.Error)
+	}
+
+	if response.Message != "Test error message" {
+		t.Errorf("Expected message 'Test error message', got '%s'", response.Message)
+	}
+}
+
+func TestRespondWithJSON_ContentLength(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"status": "healthy"})
+
+	if got := w.Header().Get("Content-Length"); got != strconv.Itoa(w.Body.Len()) {
+		t.Errorf("Expected Content-Length %d, got %q", w.Body.Len(), got)
+	}
+	if w.Body.String() != "{\"status\":\"healthy\"}\n" {
+		t.Errorf("Unexpected body %q", w.Body.String())
+	}
+
+	// Values that cannot be encoded are reported instead of sending a truncated body
+	w = httptest.NewRecorder()
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{"bad": make(chan int)})
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500 for an unencodable value, got %d", w.Code)
+	}
+}
+
+func TestHandleServiceError_DomainErrorCode(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"invalid date range", domain.ErrInvalidDateRange, http.StatusBadRequest, "INVALID_DATE_RANGE"},
+		{"no transactions", domain.ErrNoTransactions, http.StatusOK, "NO_TRANSACTIONS"},
+		{"wrapped error", fmt.Errorf("loading: %w", domain.ErrInvalidDate), http.StatusBadRequest, "INVALID_DATE"},
+		{"service unavailable", domain.ErrServiceUnavailable, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE"},
+		{"unknown error", errors.New("boom"), http.StatusInternalServerError, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handleServiceError(w, tt.err)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			var response ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode error response: %v", err)
+			}
+
+			if response.Code != tt.expectedCode {
+				t.Errorf("Expected code %q, got %q", tt.expectedCode, response.Code)
+			}
+			if response.Message == "" {
+				t.Error("Expected a human-readable message")
+			}
+		})
+	}
+}
+
+func TestHandleServiceError_ValidationErrors(t *testing.T) {
+	tx := domain.Transaction{Date: "2024-01-01", Amount: 100, Type: "transfer"}
+
+	w := httptest.NewRecorder()
+	handleServiceError(w, tx.Validate())
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status 422, got %d", w.Code)
+	}
+
+	var response struct {
+		Error            string `json:"error"`
+		ValidationErrors []struct {
+			Field   string `json:"field"`
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"validation_errors"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	codes := make(map[string]string)
+	for _, e := range response.ValidationErrors {
+		codes[e.Field] = e.Code
+	}
+
+	if codes["category"] != "INVALID_CATEGORY" || codes["type"] != "INVALID_TYPE" {
+		t.Errorf("Expected category and type errors, got %+v", response.ValidationErrors)
+	}
+	if response.Error != "Unprocessable Entity" {
+		t.Errorf("Expected error field alongside validation errors, got %q", response.Error)
+	}
+}
+
+func TestHandleServiceError_MissingDateAndCategory(t *testing.T) {
+	tx := domain.Transaction{Amount: -50, Description: "Coffee", Type: "expense"}
+
+	w := httptest.NewRecorder()
+	handleServiceError(w, tx.Validate())
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status 422, got %d", w.Code)
+	}
+
+	var response ValidationErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	fields := make(map[string]bool)
+	for _, e := range response.ValidationErrors {
+		fields[e.Field] = true
+	}
+	if len(response.ValidationErrors) != 2 || !fields["date"] || !fields["category"] {
+		t.Errorf("Expected date and category errors, got %+v", response.ValidationErrors)
+	}
+}
+
+func TestHandleServiceError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{
 			name:           "ErrNoTransactions",
 			err:            domain.ErrNoTransactions,
 			expectedStatus: http.StatusOK, // We return 200 for "no results"
@@ -388,12 +2219,612 @@ func TestHandleServiceError(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 
-			handleServiceError(w, tt.err)
+			handleServiceError(w, tt.err)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+// plaidWebhookFixture follows Plaid's transaction webhook format with inlined transactions
+const plaidWebhookFixture = `{
+	"webhook_type": "TRANSACTIONS",
+	"webhook_code": "SYNC_UPDATES_AVAILABLE",
+	"item_id": "wz666MBjYWTp2PDzzggYhM6oWWmBb",
+	"added": [
+		{
+			"transaction_id": "lPNjeW1nR6CDn5okmGQ6hEpMo4lLNoSrzqDje",
+			"amount": 72.1,
+			"iso_currency_code": "USD",
+			"date": "2024-02-10",
+			"name": "Uber 063015 SF**POOL**",
+			"merchant_name": "Uber",
+			"category": ["Travel", "Taxi"],
+			"personal_finance_category": {"primary": "TRANSPORTATION"}
+		},
+		{
+			"transaction_id": "7ZQJ4AqRplT8JmqqqgJJtyboPoAEWqSkByBd6",
+			"amount": -500,
+			"iso_currency_code": "USD",
+			"date": "2024-02-11",
+			"name": "Payroll deposit",
+			"category": ["Transfer", "Payroll"]
+		}
+	]
+}`
+
+func signWebhook(secret, body string) string {
+	return middleware.ComputeWebhookSignature(secret, []byte(body))
+}
+
+func TestWebhookHandler_HandleTransaction(t *testing.T) {
+	const secret = "webhook-secret"
+	oversizedWebhook := plaidWebhookFixture + strings.Repeat(" ", maxWebhookBodySize)
+
+	tests := []struct {
+		name           string
+		signature      string
+		body           string
+		expectedStatus int
+		expectedCount  int // Transactions in the repository afterwards
+	}{
+		{"valid signature", signWebhook(secret, plaidWebhookFixture), plaidWebhookFixture, http.StatusOK, 5},
+		{"missing signature", "", plaidWebhookFixture, http.StatusUnauthorized, 3},
+		{"wrong secret", signWebhook("other-secret", plaidWebhookFixture), plaidWebhookFixture, http.StatusForbidden, 3},
+		{"tampered body", signWebhook(secret, plaidWebhookFixture), strings.Replace(plaidWebhookFixture, "72.1", "7.21", 1), http.StatusForbidden, 3},
+		{"malformed payload", signWebhook(secret, "{"), "{", http.StatusBadRequest, 3},
+		{"oversized payload", signWebhook(secret, oversizedWebhook), oversizedWebhook, http.StatusRequestEntityTooLarge, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := testutil.NewTestRepo(t, testutil.MinimalJSON)
+			handler := NewWebhookHandler(service.NewAnalyticsService(repo))
+			verified := middleware.WebhookVerifier(secret, PlaidVerificationHeader)(http.HandlerFunc(handler.HandleTransaction))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/webhooks/transaction", strings.NewReader(tt.body))
+			if tt.signature != "" {
+				req.Header.Set(PlaidVerificationHeader, tt.signature)
+			}
+			w := httptest.NewRecorder()
+
+			verified.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			if count := repo.Count(); count != tt.expectedCount {
+				t.Errorf("Expected %d stored transactions, got %d", tt.expectedCount, count)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_PersistsConvertedTransaction(t *testing.T) {
+	repo := testutil.NewTestRepo(t, testutil.MinimalJSON)
+	handler := NewWebhookHandler(service.NewAnalyticsService(repo))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhooks/transaction", strings.NewReader(plaidWebhookFixture))
+	handler.HandleTransaction(httptest.NewRecorder(), req)
+
+	uber, err := repo.GetByMerchant("Uber")
+	if err != nil {
+		t.Fatalf("Expected Uber transaction to be stored: %v", err)
+	}
+	if uber[0].Amount != -72.1 || uber[0].Type != "expense" || uber[0].Category != "transportation" {
+		t.Errorf("Unexpected converted expense: %+v", uber[0])
+	}
+
+	income, _ := repo.GetByCategory("transfer")
+	if len(income) != 1 || income[0].Amount != 500 || income[0].Type != "income" {
+		t.Errorf("Unexpected converted income: %+v", income)
+	}
+}
+
+func TestWebhookHandler_RedeliveryIsIdempotent(t *testing.T) {
+	repo := testutil.NewTestRepo(t, testutil.MinimalJSON)
+	handler := NewWebhookHandler(service.NewAnalyticsService(repo))
+
+	expected := []WebhookResponse{{Received: 2}, {Duplicates: 2}}
+	for i, want := range expected {
+		req := httptest.NewRequest(http.MethodPost, "/api/webhooks/transaction", strings.NewReader(plaidWebhookFixture))
+		w := httptest.NewRecorder()
+
+		handler.HandleTransaction(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Delivery %d: expected status 200, got %d: %s", i+1, w.Code, w.Body.String())
+		}
+		var response WebhookResponse
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response != want {
+			t.Errorf("Delivery %d: response = %+v, want %+v", i+1, response, want)
+		}
+	}
+
+	if count := repo.Count(); count != 5 {
+		t.Errorf("Expected the redelivered transactions to be stored once (5 in total), got %d", count)
+	}
+	if _, err := repo.GetByID("plaid-lPNjeW1nR6CDn5okmGQ6hEpMo4lLNoSrzqDje"); err != nil {
+		t.Errorf("Expected the Uber transaction under its Plaid ID: %v", err)
+	}
+}
+
+func TestGamificationHandler_SavingsStreak(t *testing.T) {
+	analyticsService := testutil.NewTestService(t, testutil.MinimalJSON)
+	handler := NewGamificationHandler(analyticsService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/gamification/savings-streak", nil)
+	w := httptest.NewRecorder()
+
+	handler.HandleSavingsStreak(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var streak domain.SavingsStreak
+	if err := json.NewDecoder(w.Body).Decode(&streak); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if streak.TotalMonths == 0 || streak.TotalPositiveMonths > streak.TotalMonths {
+		t.Errorf("Unexpected streak: %+v", streak)
+	}
+}
+
+func TestSummaryHandler_EmptyData(t *testing.T) {
+	handler := NewSummaryHandler(testutil.NewTestService(t, []byte(`[]`)), nil)
+
+	t.Run("categories", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.HandleCategorySummary(w, httptest.NewRequest(http.MethodGet, "/api/summary/categories", nil))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+
+		// Same shape as a populated summary: objects, not null, and no error fields
+		var body map[string]json.RawMessage
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		for _, field := range []string{"income", "expenses", "mom_change"} {
+			if string(body[field]) != "{}" {
+				t.Errorf("Expected %s to be {}, got %s", field, body[field])
+			}
+		}
+		if _, ok := body["error"]; ok {
+			t.Errorf("Expected no error field, got %s", w.Body.String())
+		}
+
+		var summary domain.CategorySummary
+		json.Unmarshal(w.Body.Bytes(), &summary)
+		if summary.Summary != (domain.FinancialSummary{}) {
+			t.Errorf("Expected zero totals, got %+v", summary.Summary)
+		}
+	})
+
+	t.Run("timeline", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.HandleTimeline(w, httptest.NewRequest(http.MethodGet, "/api/summary/timeline", nil))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+
+		var body map[string]json.RawMessage
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if string(body["timeline"]) != "[]" {
+			t.Errorf("Expected timeline to be [], got %s", body["timeline"])
+		}
+		if string(body["aggregation"]) != `"monthly"` {
+			t.Errorf("Expected monthly aggregation, got %s", body["aggregation"])
+		}
+	})
+}
+
+func TestSummaryHandler_BudgetWarnings(t *testing.T) {
+	analyticsService := testutil.NewTestService(t, testutil.MinimalJSON)
+	budgets := repository.NewInMemoryBudgetRepository()
+	budgets.Create(domain.Budget{Category: "groceries", MonthlyLimit: 100})
+	budgets.Create(domain.Budget{Category: "rent", MonthlyLimit: 1000})
+	handler := NewSummaryHandler(analyticsService, service.NewBudgetService(analyticsService, budgets))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/summary/categories", nil)
+	w := httptest.NewRecorder()
+
+	handler.HandleCategorySummary(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var summary domain.CategorySummary
+	if err := json.NewDecoder(w.Body).Decode(&summary); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// Groceries of 85 are 85% of the budget; rent of 1200 is over its 1000 budget
+	if got := summary.Expenses["groceries"].Warning; got != domain.BudgetWarningApproachingLimit {
+		t.Errorf("Expected groceries warning %q, got %q", domain.BudgetWarningApproachingLimit, got)
+	}
+	if got := summary.Expenses["rent"].Warning; got != domain.BudgetWarningOverLimit {
+		t.Errorf("Expected rent warning %q, got %q", domain.BudgetWarningOverLimit, got)
+	}
+}
+
+func TestRationalizationHandler_Rationalize(t *testing.T) {
+	analyticsService := testutil.NewTestService(t, testutil.StandardJSON)
+	handler := NewRationalizationHandler(service.NewRationalizationService(analyticsService))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analysis/rationalize", nil)
+	w := httptest.NewRecorder()
+
+	handler.HandleRationalize(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var suggestions []domain.RationalizationSuggestion
+	if err := json.NewDecoder(w.Body).Decode(&suggestions); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// Rent of 1200 a month against about 5800 monthly income stays under 30%
+	for _, suggestion := range suggestions {
+		if suggestion.Category == "housing" {
+			t.Errorf("Unexpected housing suggestion: %+v", suggestion)
+		}
+	}
+}
+
+func TestRationalizationHandler_Benchmarks(t *testing.T) {
+	analyticsService := testutil.NewTestService(t, testutil.StandardJSON)
+	handler := NewRationalizationHandler(service.NewRationalizationService(analyticsService))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analysis/benchmarks", nil)
+	w := httptest.NewRecorder()
+
+	handler.HandleBenchmarks(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var report domain.BenchmarkReport
+	if err := json.NewDecoder(w.Body).Decode(&report); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if len(report.Entries) != len(service.FinancialBenchmarks) {
+		t.Fatalf("Expected one entry per built-in benchmark, got %+v", report.Entries)
+	}
+	// Rent of 1200 a month against about 5800 monthly income is 20.69%, under the 30% target
+	housing := report.Entries[0]
+	if housing.Category != "housing" || housing.UserPercent != 20.69 || housing.Status != domain.BenchmarkUnder {
+		t.Errorf("Unexpected housing entry: %+v", housing)
+	}
+}
+
+func TestTaxHandler_TaxEstimate(t *testing.T) {
+	analyticsService := testutil.NewTestService(t, testutil.MinimalJSON)
+	handler := NewTaxHandler(service.NewTaxService(analyticsService, []string{"healthcare"}))
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+	}{
+		{"default filing status", "?year=2024", http.StatusOK},
+		{"married filing jointly", "?year=2024&filingStatus=married_joint", http.StatusOK},
+		{"missing year", "", http.StatusBadRequest},
+		{"invalid year", "?year=abc", http.StatusBadRequest},
+		{"unknown filing status", "?year=2024&filingStatus=other", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/analysis/tax-estimate"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.HandleTaxEstimate(w, req)
 
 			if w.Code != tt.expectedStatus {
 				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
 			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response domain.TaxEstimate
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if response.GrossIncome != 2800 || response.Disclaimer == "" {
+					t.Errorf("Unexpected estimate: %+v", response)
+				}
+			}
+		})
+	}
+}
+
+func TestAdviceFeedbackHandler(t *testing.T) {
+	history, err := service.NewJSONAdviceRepository("")
+	if err != nil {
+		t.Fatalf("Failed to create advice repository: %v", err)
+	}
+	if err := history.Save(service.AdviceRecord{ID: "advice-1"}); err != nil {
+		t.Fatalf("Failed to save advice: %v", err)
+	}
+	handler := NewAdviceFeedbackHandler(service.NewAdviceFeedbackService(history))
+
+	r := chi.NewRouter()
+	r.Post("/api/advice/{id}/feedback", handler.HandleSubmitFeedback)
+	r.Get("/api/advice/feedback/stats", handler.HandleFeedbackStats)
+
+	tests := []struct {
+		name           string
+		adviceID       string
+		body           string
+		expectedStatus int
+		expectedField  string
+	}{
+		{"valid feedback", "advice-1", `{"rating": 5, "helpful": true, "comment": "Very useful"}`, http.StatusCreated, ""},
+		{"second feedback", "advice-1", `{"rating": 2, "helpful": false}`, http.StatusCreated, ""},
+		{"unknown advice", "missing", `{"rating": 4, "helpful": true}`, http.StatusNotFound, ""},
+		{"rating too high", "advice-1", `{"rating": 6, "helpful": true}`, http.StatusUnprocessableEntity, "rating"},
+		{"missing rating", "advice-1", `{"helpful": true}`, http.StatusUnprocessableEntity, "rating"},
+		{"comment too long", "advice-1", `{"rating": 3, "comment": "` + strings.Repeat("x", 201) + `"}`, http.StatusUnprocessableEntity, "comment"},
+		{"malformed body", "advice-1", `{`, http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/advice/"+tt.adviceID+"/feedback", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if tt.expectedField != "" {
+				var response ValidationErrorResponse
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if len(response.ValidationErrors) != 1 || response.ValidationErrors[0].Field != tt.expectedField {
+					t.Errorf("Expected a single error on %q, got %+v", tt.expectedField, response.ValidationErrors)
+				}
+			}
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/advice/feedback/stats", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var stats domain.FeedbackStats
+	if err := json.NewDecoder(w.Body).Decode(&stats); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// Ratings 5 and 2, one of them helpful
+	want := domain.FeedbackStats{AverageRating: 3.5, PercentHelpful: 50, TotalResponses: 2}
+	if stats != want {
+		t.Errorf("Feedback stats = %+v, want %+v", stats, want)
+	}
+}
+
+// setupGoldenSummaryHandler builds a SummaryHandler over the full 112-transaction dataset
+func setupGoldenSummaryHandler(t *testing.T) *SummaryHandler {
+	t.Helper()
+
+	data := sampledata.Transactions(t)
+
+	repo := testutil.NewTestRepo(t, data)
+	repo.Enrich(service.NewMerchantEnricher())
+
+	return NewSummaryHandler(service.NewAnalyticsService(repo), nil)
+}
+
+// assertGolden compares a JSON response body with testdata/golden/<name>, ignoring whitespace
+// Run with UPDATE_GOLDEN=1 to rewrite the golden file from the actual output.
+func assertGolden(t *testing.T, name string, body []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", "golden", name)
+
+	if os.Getenv("UPDATE_GOLDEN") == "1" {
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, body, "", "  "); err != nil {
+			t.Fatalf("Response is not valid JSON: %v", err)
+		}
+		pretty.WriteByte('\n')
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, pretty.Bytes(), 0o644); err != nil {
+			t.Fatalf("Failed to write golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read golden file (run with UPDATE_GOLDEN=1 to create it): %v", err)
+	}
+
+	var gotCompact, wantCompact bytes.Buffer
+	if err := json.Compact(&gotCompact, body); err != nil {
+		t.Fatalf("Response is not valid JSON: %v", err)
+	}
+	if err := json.Compact(&wantCompact, want); err != nil {
+		t.Fatalf("Golden file %s is not valid JSON: %v", path, err)
+	}
+
+	if !bytes.Equal(gotCompact.Bytes(), wantCompact.Bytes()) {
+		t.Errorf("Response does not match %s (run with UPDATE_GOLDEN=1 to update)\ngot:  %s\nwant: %s",
+			path, gotCompact.String(), wantCompact.String())
+	}
+}
+
+func TestSummaryHandler_GoldenCategorySummary(t *testing.T) {
+	handler := setupGoldenSummaryHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/summary/categories", nil)
+	w := httptest.NewRecorder()
+
+	handler.HandleCategorySummary(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	assertGolden(t, "category_summary.json", w.Body.Bytes())
+}
+
+func TestSummaryHandler_GoldenTimeline(t *testing.T) {
+	handler := setupGoldenSummaryHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/summary/timeline", nil)
+	w := httptest.NewRecorder()
+
+	handler.HandleTimeline(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	assertGolden(t, "timeline.json", w.Body.Bytes())
+}
+
+func TestDebtHandler(t *testing.T) {
+	handler := NewDebtHandler(service.NewDebtService())
+
+	tests := []struct {
+		name           string
+		method         string
+		query          string
+		expectedStatus int
+		expectedMonths int
+	}{
+		{"minimum payment", http.MethodGet, "?principal=10000&rate=0.15", http.StatusOK, 60},
+		{"interest-free with extra", http.MethodGet, "?principal=6000&extra=100", http.StatusOK, 30},
+		{"missing principal", http.MethodGet, "?rate=0.15", http.StatusBadRequest, 0},
+		{"invalid rate", http.MethodGet, "?principal=10000&rate=abc", http.StatusBadRequest, 0},
+		{"out of range rate", http.MethodGet, "?principal=10000&rate=15", http.StatusBadRequest, 0},
+		{"NaN principal", http.MethodGet, "?principal=NaN&rate=0.15", http.StatusBadRequest, 0},
+		{"infinite rate", http.MethodGet, "?principal=10000&rate=Inf", http.StatusBadRequest, 0},
+		{"negative infinite extra", http.MethodGet, "?principal=10000&extra=-Inf", http.StatusBadRequest, 0},
+		{"wrong method", http.MethodPost, "?principal=10000", http.StatusMethodNotAllowed, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/analysis/debt-payoff"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.HandleDebtPayoff(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var plan domain.DebtPayoffPlan
+				if err := json.NewDecoder(w.Body).Decode(&plan); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if plan.TotalPayoffMonths != tt.expectedMonths {
+					t.Errorf("Expected payoff in %d months, got %d", tt.expectedMonths, plan.TotalPayoffMonths)
+				}
+			}
 		})
 	}
 }
 
+func TestReportHandler_EmailSummary(t *testing.T) {
+	// Nothing listens on a closed port, so every send fails
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	emails := service.NewEmailService("127.0.0.1", port, "reports@stori.test", "")
+	analyticsService := testutil.NewTestService(t, testutil.MinimalJSON)
+	handler := NewReportHandler(analyticsService, service.NewReportService(analyticsService), emails, "ana@example.com")
+
+	// On February 1st the summary covers January, which has transactions
+	handler.now = func() time.Time { return time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC) }
+	w := httptest.NewRecorder()
+	handler.HandleEmailSummary(w, httptest.NewRequest(http.MethodPost, "/api/reports/email", nil))
+	if w.Code != http.StatusBadGateway {
+		t.Errorf("Expected status 502 when the SMTP server is unreachable, got %d", w.Code)
+	}
+
+	// Nothing happened in February, so there is nothing to send on March 1st
+	handler.now = func() time.Time { return time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC) }
+	w = httptest.NewRecorder()
+	handler.HandleEmailSummary(w, httptest.NewRequest(http.MethodPost, "/api/reports/email", nil))
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status 422 for a month without transactions, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.HandleEmailSummary(w, httptest.NewRequest(http.MethodGet, "/api/reports/email", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", w.Code)
+	}
+}
+
+func TestReportHandler_MonthlyPDF(t *testing.T) {
+	analyticsService := testutil.NewTestService(t, testutil.MinimalJSON)
+	handler := NewReportHandler(analyticsService, service.NewReportService(analyticsService), nil, "")
+
+	w := httptest.NewRecorder()
+	handler.HandleMonthlyPDF(w, httptest.NewRequest(http.MethodGet, "/api/reports/pdf?month=2024-01", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "application/pdf" {
+		t.Errorf("Expected Content-Type application/pdf, got %q", contentType)
+	}
+	if !bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF-1.")) {
+		t.Error("Expected a PDF body")
+	}
+
+	tests := []struct {
+		query          string
+		expectedStatus int
+	}{
+		{"", http.StatusBadRequest},
+		{"?month=2024-13", http.StatusBadRequest},
+		{"?month=2019-01", http.StatusUnprocessableEntity}, // No transactions that month
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		handler.HandleMonthlyPDF(w, httptest.NewRequest(http.MethodGet, "/api/reports/pdf"+tt.query, nil))
+		if w.Code != tt.expectedStatus {
+			t.Errorf("Expected status %d for %q, got %d", tt.expectedStatus, tt.query, w.Code)
+		}
+	}
+
+	// Without SMTP settings there is nothing to email with
+	w = httptest.NewRecorder()
+	handler.HandleEmailSummary(w, httptest.NewRequest(http.MethodPost, "/api/reports/email", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 without an email service, got %d", w.Code)
+	}
+}
+
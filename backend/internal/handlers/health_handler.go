@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"runtime"
 	"time"
 
 	"github.com/danntastico/stori-backend/internal/domain"
@@ -38,3 +39,56 @@ func (h *HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// VersionHandler handles build metadata requests
+type VersionHandler struct {
+	info domain.BuildInfo
+}
+
+// NewVersionHandler creates a new version handler
+func NewVersionHandler(info domain.BuildInfo) *VersionHandler {
+	return &VersionHandler{
+		info: info,
+	}
+}
+
+// ServeHTTP handles GET /api/version
+func (h *VersionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, h.info)
+}
+
+// maxGoroutineStackBytes caps the stack dump returned by GoroutineHandler
+const maxGoroutineStackBytes = 64 << 10
+
+// GoroutineHandler handles goroutine dump requests
+type GoroutineHandler struct{}
+
+// NewGoroutineHandler creates a new goroutine dump handler
+func NewGoroutineHandler() *GoroutineHandler {
+	return &GoroutineHandler{}
+}
+
+// ServeHTTP handles GET /debug/goroutines
+func (h *GoroutineHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// runtime.Stack stops at the end of buf; a full buffer means the dump was cut
+	buf := make([]byte, maxGoroutineStackBytes)
+	n := runtime.Stack(buf, true)
+
+	respondWithJSON(w, http.StatusOK, domain.GoroutineDump{
+		GoroutineCount: runtime.NumGoroutine(),
+		Stack:          string(buf[:n]),
+		StackTruncated: n == len(buf),
+	})
+}
+
@@ -1,9 +1,16 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/danntastico/stori-backend/internal/domain"
 )
@@ -11,57 +18,281 @@ import (
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error   string `json:"error"`
+	Code    string `json:"code,omitempty"` // Machine-readable domain error code, e.g., "INVALID_DATE"
 	Message string `json:"message,omitempty"`
 }
 
 // respondWithJSON sends a JSON response with the given status code
+// The body is encoded before anything is sent, so Content-Length is always set and an
+// encoding failure can still be reported as a 500.
 func respondWithJSON(w http.ResponseWriter, statusCode int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-
-	if err := json.NewEncoder(w).Encode(data); err != nil {
-		// If encoding fails, log it but don't try to send another response
-		// as headers have already been written
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(data); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to encode response")
 		return
 	}
+
+	writeJSONBody(w, statusCode, body.Bytes())
 }
 
 // respondWithError sends an error response with the given status code and message
 func respondWithError(w http.ResponseWriter, statusCode int, message string) {
+	respondWithCodedError(w, statusCode, "", message)
+}
+
+// respondWithCodedError sends an error response that includes a machine-readable code
+func respondWithCodedError(w http.ResponseWriter, statusCode int, code, message string) {
 	response := ErrorResponse{
 		Error:   http.StatusText(statusCode),
+		Code:    code,
 		Message: message,
 	}
 
+	// ErrorResponse only holds strings, so encoding cannot fail
+	var body bytes.Buffer
+	json.NewEncoder(&body).Encode(response)
+	writeJSONBody(w, statusCode, body.Bytes())
+}
+
+// readBody reads the request body, refusing more than limit bytes
+// When it cannot, it responds with 413 for an oversized body or 400 otherwise and returns false.
+func readBody(w http.ResponseWriter, r *http.Request, limit int64) ([]byte, bool) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		respondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body exceeds %d bytes", limit))
+		return nil, false
+	}
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Failed to read request body")
+		return nil, false
+	}
+	return data, true
+}
+
+// decodeJSONBody decodes the request body into v
+// When it cannot, it responds with the reason and returns false: 400 for a missing body or
+// malformed JSON, with the character position, and 422 for a field of the wrong type.
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Failed to read request body")
+		return false
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		respondWithError(w, http.StatusBadRequest, "Request body is required")
+		return false
+	}
+
+	err = json.Unmarshal(data, v)
+	if err == nil {
+		return true
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("Malformed JSON at character position %d", syntaxErr.Offset))
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		respondWithError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Field '%s' must be of type %s", typeErr.Field, typeErr.Type))
+	default:
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+	}
+	return false
+}
+
+// writeJSONBody sends an encoded JSON body with its Content-Length
+func writeJSONBody(w http.ResponseWriter, statusCode int, body []byte) {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
 	w.WriteHeader(statusCode)
+	w.Write(body)
+}
+
+// negotiate returns the entry of supported that best matches the request's Accept header
+// Quality factors are honoured and more specific media ranges take precedence
+// (text/csv over text/* over */*). Ties go to the earlier entry in supported, so a
+// missing Accept header or */* selects supported[0]. Returns "" if nothing is acceptable.
+func negotiate(r *http.Request, supported []string) string {
+	accept := r.Header.Get("Accept")
+	if strings.TrimSpace(accept) == "" {
+		return supported[0]
+	}
+
+	best := ""
+	bestQuality := 0.0
+	for _, mediaType := range supported {
+		if quality := acceptQuality(accept, mediaType); quality > bestQuality {
+			best = mediaType
+			bestQuality = quality
+		}
+	}
 
-	json.NewEncoder(w).Encode(response)
+	return best
+}
+
+// acceptQuality returns the q-value the Accept header assigns to mediaType
+func acceptQuality(accept, mediaType string) float64 {
+	mainType, _, _ := strings.Cut(mediaType, "/")
+
+	quality := 0.0
+	specificity := -1
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mediaRange := strings.ToLower(strings.TrimSpace(params[0]))
+
+		rangeSpecificity := -1
+		switch {
+		case mediaRange == mediaType:
+			rangeSpecificity = 2
+		case mediaRange == mainType+"/*":
+			rangeSpecificity = 1
+		case mediaRange == "*/*":
+			rangeSpecificity = 0
+		}
+		if rangeSpecificity <= specificity {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range params[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.TrimSpace(name) == "q" {
+				parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+				if err != nil {
+					parsed = 0
+				}
+				q = parsed
+			}
+		}
+
+		quality = q
+		specificity = rangeSpecificity
+	}
+
+	return quality
+}
+
+// ValidationErrorResponse lists every invalid field of a request
+type ValidationErrorResponse struct {
+	Error            string                  `json:"error"`
+	ValidationErrors domain.ValidationErrors `json:"validation_errors"`
 }
 
 // handleServiceError maps domain errors to HTTP status codes and sends appropriate responses
 func handleServiceError(w http.ResponseWriter, err error) {
-	// Map domain errors to HTTP status codes
-	switch {
-	case errors.Is(err, domain.ErrNoTransactions):
+	// Report every validation failure at once
+	var validationErrs *domain.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		respondWithJSON(w, http.StatusUnprocessableEntity, ValidationErrorResponse{
+			Error:            http.StatusText(http.StatusUnprocessableEntity),
+			ValidationErrors: *validationErrs,
+		})
+		return
+	}
+
+	// Forward upstream rate limiting so clients know when to retry
+	var httpErr *domain.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusTooManyRequests {
+		if httpErr.RetryAfter != "" {
+			w.Header().Set("Retry-After", httpErr.RetryAfter)
+		}
+		respondWithError(w, http.StatusTooManyRequests, "AI service is rate limited, please retry later")
+		return
+	}
+
+	var domainErr *domain.DomainError
+	if !errors.As(err, &domainErr) {
+		// Unknown error - return 500 Internal Server Error
+		respondWithError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Map domain error codes to HTTP status codes
+	switch domainErr.Code {
+	case domain.CodeNoTransactions:
 		// Return 200 with empty data structure rather than 404
 		// This is more RESTful for "no results found" scenarios
-		respondWithError(w, http.StatusOK, "No transactions found")
+		respondWithCodedError(w, http.StatusOK, domainErr.Code, "No transactions found")
+
+	case domain.CodeInvalidDateRange:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, "Invalid date range: start date must be before end date")
+
+	case domain.CodeInvalidAmountRange:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, "Invalid amount range: minimum must not be negative or greater than maximum")
+
+	case domain.CodeInvalidExportField, domain.CodeInvalidImportFile, domain.CodeInvalidCategoryMeta:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, err.Error())
 
-	case errors.Is(err, domain.ErrInvalidDateRange):
-		respondWithError(w, http.StatusBadRequest, "Invalid date range: start date must be before end date")
+	case domain.CodeInvalidDate:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, "Invalid date format, expected YYYY-MM-DD")
 
-	case errors.Is(err, domain.ErrInvalidDate):
-		respondWithError(w, http.StatusBadRequest, "Invalid date format, expected YYYY-MM-DD")
+	case domain.CodeInvalidCategory:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, "Category cannot be empty")
 
-	case errors.Is(err, domain.ErrInvalidCategory):
-		respondWithError(w, http.StatusBadRequest, "Category cannot be empty")
+	case domain.CodeInvalidType:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, "Type must be either 'income' or 'expense'")
 
-	case errors.Is(err, domain.ErrInvalidType):
-		respondWithError(w, http.StatusBadRequest, "Type must be either 'income' or 'expense'")
+	case domain.CodeInvalidAmount:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, "Amount sign must match transaction type")
 
-	case errors.Is(err, domain.ErrInvalidAmount):
-		respondWithError(w, http.StatusBadRequest, "Amount sign must match transaction type")
+	case domain.CodeInvalidTag:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, "Tags cannot be blank")
+
+	case domain.CodeInvalidPaymentMethod:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, "Payment method must be one of: credit_card, debit_card, cash, bank_transfer")
+
+	case domain.CodeInvalidCurrency:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, "Currency must be a valid ISO 4217 code")
+
+	case domain.CodeUnsupportedCurrency:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, err.Error())
+
+	case domain.CodeInvalidProjection:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, "Years must be between 1 and 50 and annualRate must not be negative")
+
+	case domain.CodeInvalidDebt:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, "Principal must be positive, rate between 0 and 1 and extra must not be negative")
+
+	case domain.CodeInvalidCursor:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, "Invalid pagination cursor")
+
+	case domain.CodeInvalidPage:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, "Page and pageSize must be at least 1")
+
+	case domain.CodeInvalidAggregation:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, "Aggregation must be either 'monthly' or 'weekly'")
+
+	case domain.CodeInvalidFilingStatus:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, err.Error())
+
+	case domain.CodeInvalidBudget:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, "Budget monthly limit must be positive")
+
+	case domain.CodeBudgetNotFound:
+		respondWithCodedError(w, http.StatusNotFound, domainErr.Code, "No budget set for category")
+
+	case domain.CodeAdviceNotFound:
+		respondWithCodedError(w, http.StatusNotFound, domainErr.Code, "Advice not found")
+
+	case domain.CodeTransactionNotFound:
+		respondWithCodedError(w, http.StatusNotFound, domainErr.Code, "Transaction not found")
+
+	case domain.CodeDuplicateID:
+		respondWithCodedError(w, http.StatusConflict, domainErr.Code, err.Error())
+
+	case domain.CodeCategoryNotFound:
+		respondWithCodedError(w, http.StatusNotFound, domainErr.Code, "Category not found")
+
+	case domain.CodeInvalidCategoryMerge:
+		respondWithCodedError(w, http.StatusBadRequest, domainErr.Code, "Source and target categories must be different")
+
+	case domain.CodeInsufficientData:
+		respondWithCodedError(w, http.StatusUnprocessableEntity, domainErr.Code, err.Error())
+
+	case domain.CodeServiceUnavailable:
+		respondWithCodedError(w, http.StatusServiceUnavailable, domainErr.Code, "AI service is temporarily unavailable, please retry later")
 
 	default:
 		// Unknown error - return 500 Internal Server Error
@@ -69,3 +300,119 @@ func handleServiceError(w http.ResponseWriter, err error) {
 	}
 }
 
+// ParseTransactionFilter builds a TransactionFilter from the request's query parameters
+// Supported parameters:
+//   - startDate, endDate: ISO 8601 dates (YYYY-MM-DD), inclusive
+//   - type: "income" or "expense", repeatable; any listed type matches
+//   - category: exact category name, repeatable; any listed category matches
+//   - merchant: exact merchant name (case-sensitive)
+//   - minAmount, maxAmount: bounds on the absolute amount
+//   - q: case-insensitive search in the description
+//   - tag: tag name, repeatable; every tag must be present
+//   - paymentMethod: credit_card, debit_card, cash or bank_transfer
+//
+// The returned error message is suitable for a 400 response
+func ParseTransactionFilter(r *http.Request) (domain.TransactionFilter, error) {
+	query := r.URL.Query()
+	var filter domain.TransactionFilter
+
+	if value := query.Get("startDate"); value != "" {
+		startDate, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return filter, errors.New("Invalid startDate format, expected YYYY-MM-DD")
+		}
+		filter.StartDate = &startDate
+	}
+
+	if value := query.Get("endDate"); value != "" {
+		endDate, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return filter, errors.New("Invalid endDate format, expected YYYY-MM-DD")
+		}
+		filter.EndDate = &endDate
+	}
+
+	if filter.StartDate != nil && filter.EndDate != nil && filter.StartDate.After(*filter.EndDate) {
+		return filter, errors.New("Invalid date range: start date must be before end date")
+	}
+
+	for _, txType := range query["type"] {
+		if txType == "" {
+			continue
+		}
+		if txType != "income" && txType != "expense" {
+			return filter, errors.New("Type must be either 'income' or 'expense'")
+		}
+		filter.Types = append(filter.Types, txType)
+	}
+
+	var err error
+	if filter.MinAmount, err = parseAmountParam(query.Get("minAmount"), "minAmount"); err != nil {
+		return filter, err
+	}
+	if filter.MaxAmount, err = parseAmountParam(query.Get("maxAmount"), "maxAmount"); err != nil {
+		return filter, err
+	}
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return filter, errors.New("minAmount must not be greater than maxAmount")
+	}
+
+	filter.PaymentMethod = query.Get("paymentMethod")
+	if filter.PaymentMethod != "" && !domain.IsValidPaymentMethod(filter.PaymentMethod) {
+		return filter, errors.New("paymentMethod must be one of: credit_card, debit_card, cash, bank_transfer")
+	}
+
+	for _, category := range query["category"] {
+		if category != "" {
+			filter.Categories = append(filter.Categories, category)
+		}
+	}
+	filter.Merchant = query.Get("merchant")
+	filter.Query = strings.TrimSpace(query.Get("q"))
+
+	for _, tag := range query["tag"] {
+		if tag != "" {
+			filter.Tags = append(filter.Tags, tag)
+		}
+	}
+
+	return filter, nil
+}
+
+// parseList splits a comma-separated query parameter, trimming whitespace and dropping empty entries
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseAmountParam parses an optional non-negative amount query parameter
+func parseAmountParam(value, name string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	amount, err := parseFiniteFloat(value)
+	if err != nil || amount < 0 {
+		return nil, fmt.Errorf("%s must be a non-negative number", name)
+	}
+
+	return &amount, nil
+}
+
+// parseFiniteFloat parses a number, rejecting NaN and infinities, which strconv accepts
+func parseFiniteFloat(value string) (float64, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%q is not a finite number", value)
+	}
+	return f, nil
+}
+
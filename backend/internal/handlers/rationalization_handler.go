@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/danntastico/stori-backend/internal/service"
+)
+
+// RationalizationHandler handles spending reduction suggestion requests
+type RationalizationHandler struct {
+	rationalizationService *service.RationalizationService
+}
+
+// NewRationalizationHandler creates a new rationalization handler
+func NewRationalizationHandler(rationalizationService *service.RationalizationService) *RationalizationHandler {
+	return &RationalizationHandler{
+		rationalizationService: rationalizationService,
+	}
+}
+
+// HandleRationalize handles GET /api/analysis/rationalize
+// Returns categories whose spending exceeds common income-based benchmarks
+func (h *RationalizationHandler) HandleRationalize(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	suggestions, err := h.rationalizationService.GetRationalizationSuggestions()
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, suggestions)
+}
+
+// HandleBenchmarks handles GET /api/analysis/benchmarks
+// Returns spending per benchmark group as a share of income, rated against its target
+func (h *RationalizationHandler) HandleBenchmarks(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	report, err := h.rationalizationService.GetBenchmarkReport()
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, report)
+}
+
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/service"
+)
+
+// ReportHandler handles report generation and delivery requests
+type ReportHandler struct {
+	analyticsService *service.AnalyticsService
+	reportService    *service.ReportService
+	emailService     *service.EmailService // nil when summary emails are disabled
+	recipient        string
+	now              func() time.Time // Decides which month has just ended
+}
+
+// NewReportHandler creates a new report handler that emails summaries to recipient
+// emailService may be nil when no SMTP server is configured.
+func NewReportHandler(analyticsService *service.AnalyticsService, reportService *service.ReportService, emailService *service.EmailService, recipient string) *ReportHandler {
+	return &ReportHandler{
+		analyticsService: analyticsService,
+		reportService:    reportService,
+		emailService:     emailService,
+		recipient:        recipient,
+		now:              time.Now,
+	}
+}
+
+// HandleEmailSummary handles POST /api/reports/email
+// Emails the summary of the calendar month that just ended to the configured recipient
+// right away instead of waiting for the monthly schedule.
+func (h *ReportHandler) HandleEmailSummary(w http.ResponseWriter, r *http.Request) {
+	// Only allow POST method
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	if h.emailService == nil {
+		handleServiceError(w, domain.ErrServiceUnavailable)
+		return
+	}
+
+	now := h.now()
+	summary, err := h.analyticsService.GetMonthlyCategorySummary(now.AddDate(0, 0, -now.Day()))
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	if err := h.emailService.SendMonthlySummary(r.Context(), h.recipient, summary); err != nil {
+		log.Printf("Error sending summary email: %v", err)
+		respondWithError(w, http.StatusBadGateway, "Failed to send summary email")
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, domain.ReportDelivery{
+		Recipient: h.recipient,
+		SentAt:    time.Now().UTC(),
+	})
+}
+
+// HandleMonthlyPDF handles GET /api/reports/pdf?month=2024-01
+// Returns the month's report as a PDF download.
+// Query parameters:
+//   - month: the month to report on, as YYYY-MM (required)
+func (h *ReportHandler) HandleMonthlyPDF(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	month, err := time.Parse("2006-01", r.URL.Query().Get("month"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid month, expected YYYY-MM")
+		return
+	}
+
+	// Render fully before sending so a failure can still be reported as JSON
+	var pdf bytes.Buffer
+	if err := h.reportService.GenerateMonthlyPDF(month, &pdf); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `attachment; filename="report-`+month.Format("2006-01")+`.pdf"`)
+	w.Header().Set("Content-Length", strconv.Itoa(pdf.Len()))
+	w.WriteHeader(http.StatusOK)
+	w.Write(pdf.Bytes())
+}
+
@@ -1,25 +1,38 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/danntastico/stori-backend/internal/domain"
 	"github.com/danntastico/stori-backend/internal/service"
 )
 
 // SummaryHandler handles financial summary requests
 type SummaryHandler struct {
 	analyticsService *service.AnalyticsService
+	budgetService    *service.BudgetService // Optional; enables budget warnings in category summaries
 }
 
 // NewSummaryHandler creates a new summary handler
-func NewSummaryHandler(analyticsService *service.AnalyticsService) *SummaryHandler {
+// budgetService may be nil, in which case category summaries carry no budget warnings.
+func NewSummaryHandler(analyticsService *service.AnalyticsService, budgetService *service.BudgetService) *SummaryHandler {
 	return &SummaryHandler{
 		analyticsService: analyticsService,
+		budgetService:    budgetService,
 	}
 }
 
 // HandleCategorySummary handles GET /api/summary/categories
-// Returns aggregated spending breakdown by category with totals and percentages
+// Returns aggregated spending breakdown by category with totals and percentages;
+// without transactions the summary is empty (200 with empty maps and zero totals)
+// Expense categories near or over their monthly budget carry a warning; budgets are in
+// the base currency, so converted summaries have no warnings.
+// Query parameters:
+//   - currency: ISO 4217 code to convert all amounts into - optional, overrides X-Preferred-Currency
 func (h *SummaryHandler) HandleCategorySummary(w http.ResponseWriter, r *http.Request) {
 	// Only allow GET method
 	if r.Method != http.MethodGet {
@@ -27,8 +40,27 @@ func (h *SummaryHandler) HandleCategorySummary(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// Get category summary from analytics service
-	summary, err := h.analyticsService.GetCategorySummary()
+	// Get category summary from analytics service, converted if requested
+	// via the query or the X-Preferred-Currency header (see middleware.FinancialContext)
+	var summary *domain.CategorySummary
+	var err error
+	currency := strings.ToUpper(r.URL.Query().Get("currency"))
+	if currency != "" || domain.GetPreferredCurrency(r.Context()) != "" {
+		summary, err = h.analyticsService.GetCategorySummaryInCurrency(r.Context(), currency)
+	} else {
+		var budgets map[string]float64
+		if h.budgetService != nil {
+			if budgets, err = h.budgetService.MonthlyLimits(); err != nil {
+				handleServiceError(w, err)
+				return
+			}
+		}
+		summary, err = h.analyticsService.GetCategorySummaryWithBudgets(budgets)
+	}
+	// No data is an empty summary, not an error, so clients always get the same shape
+	if errors.Is(err, domain.ErrNoTransactions) {
+		summary, err = domain.EmptyCategorySummary(), nil
+	}
 	if err != nil {
 		handleServiceError(w, err)
 		return
@@ -39,7 +71,7 @@ func (h *SummaryHandler) HandleCategorySummary(w http.ResponseWriter, r *http.Re
 }
 
 // HandleTimeline handles GET /api/summary/timeline
-// Returns monthly income vs expenses over time
+// Returns monthly income vs expenses over time; an empty timeline without transactions
 func (h *SummaryHandler) HandleTimeline(w http.ResponseWriter, r *http.Request) {
 	// Only allow GET method
 	if r.Method != http.MethodGet {
@@ -49,6 +81,9 @@ func (h *SummaryHandler) HandleTimeline(w http.ResponseWriter, r *http.Request)
 
 	// Get timeline from analytics service
 	timeline, err := h.analyticsService.GetTimeline()
+	if errors.Is(err, domain.ErrNoTransactions) {
+		timeline, err = domain.EmptyTimelineResponse(), nil
+	}
 	if err != nil {
 		handleServiceError(w, err)
 		return
@@ -58,3 +93,173 @@ func (h *SummaryHandler) HandleTimeline(w http.ResponseWriter, r *http.Request)
 	respondWithJSON(w, http.StatusOK, timeline)
 }
 
+// HandleMerchantSummary handles GET /api/summary/merchants
+// Returns aggregated spending breakdown by merchant with totals and percentages
+func (h *SummaryHandler) HandleMerchantSummary(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Get merchant summary from analytics service
+	merchants, err := h.analyticsService.GetMerchantSummary()
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, merchants)
+}
+
+// HandleSpendingHeatmap handles GET /api/summary/heatmap
+// Returns a 7x24 matrix of expense totals by day of week (Sunday = 0) and hour of day
+func (h *SummaryHandler) HandleSpendingHeatmap(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Get heatmap from analytics service
+	heatmap, err := h.analyticsService.GetSpendingHeatmap()
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, heatmap)
+}
+
+// HandleIncomeStability handles GET /api/summary/income-stability
+// Returns the mean, spread and coefficient of variation of monthly income
+func (h *SummaryHandler) HandleIncomeStability(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	stability, err := h.analyticsService.GetIncomeStability()
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, stability)
+}
+
+// HandleBurnRate handles GET /api/summary/burn-rate
+// Returns average spending per day, week and month and the resulting runway
+func (h *SummaryHandler) HandleBurnRate(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	burnRate, err := h.analyticsService.GetBurnRate()
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, burnRate)
+}
+
+// HandleTrend handles GET /api/summary/trend
+// Returns least-squares trend lines for monthly income and expenses
+func (h *SummaryHandler) HandleTrend(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	trend, err := h.analyticsService.GetTrendLine()
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, trend)
+}
+
+// HandleYoY handles GET /api/summary/yoy
+// Compares a calendar month with the same month of the prior year
+// Query parameters:
+//   - month: calendar month, 1 to 12 (required)
+//   - year: four-digit year, e.g., 2024 (required)
+func (h *SummaryHandler) HandleYoY(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	month, err := strconv.Atoi(r.URL.Query().Get("month"))
+	if err != nil || month < 1 || month > 12 {
+		respondWithError(w, http.StatusBadRequest, "Invalid month, expected a number from 1 to 12")
+		return
+	}
+
+	year, err := strconv.Atoi(r.URL.Query().Get("year"))
+	if err != nil || year < 1900 {
+		respondWithError(w, http.StatusBadRequest, "Invalid year, expected a four-digit year such as 2024")
+		return
+	}
+
+	comparison, err := h.analyticsService.GetYoYComparison(time.Month(month), year)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, comparison)
+}
+
+// HandleTagSummary handles GET /api/summary/tags
+// Returns aggregated spending breakdown by tag across categories
+func (h *SummaryHandler) HandleTagSummary(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Get tag summary from analytics service
+	tags, err := h.analyticsService.GetTagSummary()
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, tags)
+}
+
+// HandlePaymentMethodSummary handles GET /api/summary/payment-methods
+// Returns expense totals and counts grouped by payment method
+func (h *SummaryHandler) HandlePaymentMethodSummary(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	summary, err := h.analyticsService.GetPaymentMethodSummary()
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, summary)
+}
+
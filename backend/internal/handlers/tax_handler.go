@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/danntastico/stori-backend/internal/service"
+)
+
+// TaxHandler handles tax estimation requests
+type TaxHandler struct {
+	taxService *service.TaxService
+}
+
+// NewTaxHandler creates a new tax handler
+func NewTaxHandler(taxService *service.TaxService) *TaxHandler {
+	return &TaxHandler{
+		taxService: taxService,
+	}
+}
+
+// HandleTaxEstimate handles GET /api/analysis/tax-estimate
+// DISCLAIMER: the result is a rough planning estimate, not tax advice. It only considers
+// recorded transactions, the configured deductible categories and federal brackets;
+// every response carries a disclaimer field saying so.
+// Query parameters:
+//   - year: tax year, e.g., 2024 (required)
+//   - filingStatus: single (default), married_joint, married_separate or head_of_household
+func (h *TaxHandler) HandleTaxEstimate(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	year, err := strconv.Atoi(r.URL.Query().Get("year"))
+	if err != nil || year < 1900 {
+		respondWithError(w, http.StatusBadRequest, "Invalid year, expected a four-digit year such as 2024")
+		return
+	}
+
+	filingStatus := r.URL.Query().Get("filingStatus")
+	if filingStatus == "" {
+		filingStatus = "single"
+	}
+
+	estimate, err := h.taxService.EstimateTaxLiability(year, filingStatus)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, estimate)
+}
+
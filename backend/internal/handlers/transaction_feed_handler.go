@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/danntastico/stori-backend/internal/middleware"
+)
+
+// TransactionFeedHandler streams newly created transactions over WebSocket
+type TransactionFeedHandler struct {
+	hub       *TransactionHub
+	readLimit int64
+	upgrader  websocket.Upgrader
+}
+
+// NewTransactionFeedHandler creates a feed handler for the hub's clients
+// readLimit caps the size of messages clients may send; browser upgrades are only accepted
+// from allowedOrigins (same rules as CORS), while clients without an Origin are always accepted.
+func NewTransactionFeedHandler(hub *TransactionHub, readLimit int64, allowedOrigins []string) *TransactionFeedHandler {
+	return &TransactionFeedHandler{
+		hub:       hub,
+		readLimit: readLimit,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				return origin == "" || middleware.IsOriginAllowed(origin, allowedOrigins)
+			},
+		},
+	}
+}
+
+// ServeHTTP handles GET /api/ws/transactions
+// Upgrades to WebSocket and sends every transaction created from then on as a JSON text
+// message. The server pings periodically; clients that stop answering are disconnected.
+func (h *TransactionFeedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already replied with an HTTP error
+		return
+	}
+
+	conn.SetReadLimit(h.readLimit)
+	pongWait := h.hub.pongWait()
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	h.hub.Register(conn)
+	defer h.hub.Unregister(conn)
+
+	// Clients have nothing to say; reading processes pongs and notices disconnects
+	for {
+		if _, _, err := conn.NextReader(); err != nil {
+			return
+		}
+	}
+}
+
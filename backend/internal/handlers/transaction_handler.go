@@ -1,31 +1,55 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"net/http"
-	"time"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
 
 	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/importer"
 	"github.com/danntastico/stori-backend/internal/service"
 )
 
 // TransactionHandler handles transaction-related requests
 type TransactionHandler struct {
 	analyticsService *service.AnalyticsService
+	exportService    *service.ExportService
 }
 
+// Media types GET /api/transactions can produce, in order of preference
+const (
+	mediaTypeJSON = "application/json"
+	mediaTypeCSV  = "text/csv"
+)
+
+var transactionMediaTypes = []string{mediaTypeJSON, mediaTypeCSV}
+
+// maxBulkImportBodySize limits bulk import payloads to 5 MB
+const maxBulkImportBodySize = 5 << 20
+
+// maxCreateBodySize limits a single created transaction to 64 KB
+const maxCreateBodySize = 64 << 10
+
 // NewTransactionHandler creates a new transaction handler
 func NewTransactionHandler(analyticsService *service.AnalyticsService) *TransactionHandler {
 	return &TransactionHandler{
 		analyticsService: analyticsService,
+		exportService:    service.NewExportService(),
 	}
 }
 
 // ServeHTTP handles GET /api/transactions
-// Query parameters:
-//   - startDate: ISO 8601 date (YYYY-MM-DD) - optional
-//   - endDate: ISO 8601 date (YYYY-MM-DD) - optional
-//   - type: "income" or "expense" - optional (future use)
-//   - category: category name - optional (future use)
+// Query parameters are documented on ParseTransactionFilter; all are optional and combinable
+// Pagination (optional, results sorted by date):
+//   - cursor: opaque cursor from a previous next_cursor/prev_cursor
+//   - limit: page size, 1-500 (default 50)
+//
+// The response is JSON by default; clients sending Accept: text/csv receive CSV instead,
+// limited to the columns in ?fields= when given (see HandleExport)
 func (h *TransactionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Only allow GET method
 	if r.Method != http.MethodGet {
@@ -33,41 +57,301 @@ func (h *TransactionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parse query parameters
-	query := r.URL.Query()
-	startDateStr := query.Get("startDate")
-	endDateStr := query.Get("endDate")
+	// Pick the response format before doing any work
+	mediaType := negotiate(r, transactionMediaTypes)
+	if mediaType == "" {
+		respondWithError(w, http.StatusNotAcceptable, "Supported formats: application/json, text/csv")
+		return
+	}
+
+	h.serve(w, r, mediaType)
+}
+
+// HandleExport handles GET /api/transactions/export
+// Always responds with a CSV download, accepting the same filters and pagination as
+// GET /api/transactions plus:
+//   - fields: comma-separated columns in output order, e.g., "date,amount,category";
+//     names are the transaction's JSON fields (default: date through tags)
+func (h *TransactionHandler) HandleExport(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="transactions.csv"`)
+	h.serve(w, r, mediaTypeCSV)
+}
+
+// HandleDuplicates handles GET /api/transactions/duplicates
+// Returns groups of transactions suspected to be recorded more than once
+func (h *TransactionHandler) HandleDuplicates(w http.ResponseWriter, r *http.Request) {
+	// Only allow GET method
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	duplicates, err := h.analyticsService.FindDuplicates()
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, duplicates)
+}
+
+// HandleCreate handles POST /api/transactions
+// The body is a single transaction; the stored transaction, with its ID, is returned with 201.
+// An invalid transaction yields 422 listing every problem, and a body over 64 KB 413.
+func (h *TransactionHandler) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	// Only allow POST method
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	body, ok := readBody(w, r, maxCreateBodySize)
+	if !ok {
+		return
+	}
+	var tx domain.Transaction
+	if err := json.Unmarshal(body, &tx); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body, expected a JSON transaction")
+		return
+	}
+
+	created, err := h.analyticsService.CreateTransaction(tx)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusCreated, created)
+}
+
+// HandlePatch handles PATCH /api/transactions/{id}
+// The body holds only the fields to change, e.g., {"description": "Team lunch"};
+// patchable fields are date, amount, category, description, type, tags and notes.
+// Unknown fields are rejected with 400, a body over 64 KB with 413 and an invalid merged
+// transaction with 422.
+func (h *TransactionHandler) HandlePatch(w http.ResponseWriter, r *http.Request) {
+	// Only allow PATCH method
+	if r.Method != http.MethodPatch {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	body, ok := readBody(w, r, maxCreateBodySize)
+	if !ok {
+		return
+	}
+	var patch domain.TransactionPatch
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patch); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	tx, err := h.analyticsService.PatchTransaction(chi.URLParam(r, "id"), patch)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, tx)
+}
+
+// HandleBulkImport handles POST /api/transactions/bulk
+// The body is a JSON array of transactions. Each one is validated on its own: valid
+// transactions are stored and invalid ones are reported, so the response is always
+// 207 Multi-Status with a result per item. A body over 5 MB is rejected with 413.
+// Query parameters:
+//   - dryRun: "true" validates and summarizes without storing anything
+//   - onConflict: what to do with a transaction whose date, amount and description match
+//     one already stored; "skip", "overwrite" or "append" (suffixes the description); by
+//     default conflicts are reported and stored unchanged
+func (h *TransactionHandler) HandleBulkImport(w http.ResponseWriter, r *http.Request) {
+	// Only allow POST method
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	opts, err := parseImportOptions(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	body, ok := readBody(w, r, maxBulkImportBodySize)
+	if !ok {
+		return
+	}
+	var transactions []domain.Transaction
+	if err := json.Unmarshal(body, &transactions); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body, expected a JSON array of transactions")
+		return
+	}
+
+	report, err := h.analyticsService.ImportTransactions(transactions, opts)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusMultiStatus, report)
+}
+
+// HandleImport handles POST /api/transactions/import
+// The body is a file exported from another finance app; once parsed, its transactions
+// are imported like POST /api/transactions/bulk and the same 207 report is returned.
+// A file over 5 MB is rejected with 413.
+// Query parameters:
+//   - format: the exporting app; "mint" (Mint.com CSV) is the only format supported
+//   - dryRun, onConflict: as for POST /api/transactions/bulk
+func (h *TransactionHandler) HandleImport(w http.ResponseWriter, r *http.Request) {
+	// Only allow POST method
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	parse, ok := importParsers[r.URL.Query().Get("format")]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "format must be one of: mint")
+		return
+	}
+
+	opts, err := parseImportOptions(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, ok := readBody(w, r, maxBulkImportBodySize)
+	if !ok {
+		return
+	}
+
+	transactions, err := parse(data)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	report, err := h.analyticsService.ImportTransactions(transactions, opts)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusMultiStatus, report)
+}
 
-	var response *domain.TransactionsResponse
-	var err error
+// importParsers maps each supported import format to its parser
+var importParsers = map[string]func(data []byte) ([]domain.Transaction, error){
+	"mint": importer.ParseMintCSV,
+}
+
+// parseImportOptions reads the optional dryRun and onConflict query parameters; dryRun
+// defaults to false and without onConflict conflicts are reported and stored unchanged
+func parseImportOptions(r *http.Request) (domain.ImportOptions, error) {
+	var opts domain.ImportOptions
 
-	// If date range provided, filter by date range
-	if startDateStr != "" && endDateStr != "" {
-		startDate, err := time.Parse("2006-01-02", startDateStr)
+	if value := r.URL.Query().Get("dryRun"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
 		if err != nil {
-			respondWithError(w, http.StatusBadRequest, "Invalid startDate format, expected YYYY-MM-DD")
-			return
+			return opts, errors.New("dryRun must be true or false")
+		}
+		opts.DryRun = dryRun
+	}
+
+	if value := r.URL.Query().Get("onConflict"); value != "" {
+		opts.ConflictResolution = domain.ConflictResolution(value)
+		if !opts.ConflictResolution.IsValid() {
+			return opts, errors.New("onConflict must be one of: skip, overwrite, append")
+		}
+	}
+
+	return opts, nil
+}
+
+// serve filters (and optionally paginates) transactions and writes them as mediaType
+func (h *TransactionHandler) serve(w http.ResponseWriter, r *http.Request, mediaType string) {
+	// Parse query parameters
+	filter, err := ParseTransactionFilter(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	query := r.URL.Query()
+	columns := parseList(query.Get("fields"))
+	if err := h.exportService.ValidateColumns(columns); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	// Paginate when the client asks for it
+	if query.Has("cursor") || query.Has("limit") {
+		limit := service.DefaultPageLimit
+		if limitStr := query.Get("limit"); limitStr != "" {
+			limit, err = strconv.Atoi(limitStr)
+			if err != nil || limit < 1 || limit > service.MaxPageLimit {
+				respondWithError(w, http.StatusBadRequest, "limit must be an integer between 1 and 500")
+				return
+			}
 		}
 
-		endDate, err := time.Parse("2006-01-02", endDateStr)
+		response, err := h.analyticsService.GetTransactionsByFilter(filter, query.Get("cursor"), limit)
 		if err != nil {
-			respondWithError(w, http.StatusBadRequest, "Invalid endDate format, expected YYYY-MM-DD")
+			handleServiceError(w, err)
 			return
 		}
 
-		response, _ = h.analyticsService.GetTransactionsByDateRange(startDate, endDate)
-	} else {
-		// Get all transactions
-		response, err = h.analyticsService.GetTransactions()
+		h.respond(w, mediaType, response, columns)
+		return
 	}
 
-	// Handle errors
+	// Apply every filter at once (AND semantics)
+	transactions, err := h.analyticsService.FilterTransactions(filter)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+	response, err := h.analyticsService.NewTransactionsResponse(transactions)
 	if err != nil {
 		handleServiceError(w, err)
 		return
 	}
 
 	// Send successful response
-	respondWithJSON(w, http.StatusOK, response)
+	h.respond(w, mediaType, response, columns)
+}
+
+// respond writes the transactions in the negotiated media type
+// columns selects the CSV columns and has no effect on JSON.
+func (h *TransactionHandler) respond(w http.ResponseWriter, mediaType string, response *domain.TransactionsResponse, columns []string) {
+	if mediaType != mediaTypeCSV {
+		respondWithJSON(w, http.StatusOK, response)
+		return
+	}
+
+	var transactions []domain.Transaction
+	if response != nil {
+		transactions = response.Transactions
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	// Headers are already sent, so a write error cannot be reported to the client
+	h.exportService.ExportCSV(w, transactions, columns)
 }
 
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+const (
+	// feedClientBuffer is how many transactions may queue for a client before it is dropped
+	feedClientBuffer = 16
+
+	// feedWriteWait limits how long a single write to a client may take
+	feedWriteWait = 10 * time.Second
+)
+
+// TransactionHub forwards created transactions to every connected WebSocket client
+// Its methods are safe for concurrent use: the set of clients is owned by Run and
+// only reached through channels. Clients that fall behind are disconnected rather
+// than slowing down the rest.
+type TransactionHub struct {
+	pingInterval time.Duration
+	register     chan *feedClient
+	unregister   chan *websocket.Conn
+	broadcast    chan domain.Transaction
+	done         chan struct{} // Closed when Run returns
+}
+
+// feedClient is one connection and the transactions waiting to be written to it
+type feedClient struct {
+	conn *websocket.Conn
+	send chan domain.Transaction
+}
+
+// NewTransactionHub creates a hub that pings clients every pingInterval
+// Nothing is delivered until Run is started.
+func NewTransactionHub(pingInterval time.Duration) *TransactionHub {
+	return &TransactionHub{
+		pingInterval: pingInterval,
+		register:     make(chan *feedClient),
+		unregister:   make(chan *websocket.Conn),
+		broadcast:    make(chan domain.Transaction, feedClientBuffer),
+		done:         make(chan struct{}),
+	}
+}
+
+// Run delivers registrations and broadcasts until ctx is done, then disconnects every client
+func (h *TransactionHub) Run(ctx context.Context) {
+	clients := make(map[*websocket.Conn]*feedClient)
+	defer func() {
+		for _, client := range clients {
+			close(client.send)
+		}
+		close(h.done)
+	}()
+
+	for {
+		select {
+		case client := <-h.register:
+			clients[client.conn] = client
+
+		case conn := <-h.unregister:
+			if client, ok := clients[conn]; ok {
+				delete(clients, conn)
+				close(client.send)
+			}
+
+		case tx := <-h.broadcast:
+			for conn, client := range clients {
+				select {
+				case client.send <- tx:
+				default:
+					delete(clients, conn)
+					close(client.send)
+				}
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Register starts sending broadcasts to conn
+// The hub writes to conn from then on; callers may only read from it.
+func (h *TransactionHub) Register(conn *websocket.Conn) {
+	client := &feedClient{conn: conn, send: make(chan domain.Transaction, feedClientBuffer)}
+
+	select {
+	case h.register <- client:
+		go h.writePump(client)
+	case <-h.done:
+		conn.Close()
+	}
+}
+
+// Unregister stops sending to conn and closes it
+func (h *TransactionHub) Unregister(conn *websocket.Conn) {
+	select {
+	case h.unregister <- conn:
+	case <-h.done:
+		conn.Close()
+	}
+}
+
+// Broadcast sends tx to every registered client
+// Implements service.TransactionBroadcaster.
+func (h *TransactionHub) Broadcast(tx domain.Transaction) {
+	select {
+	case h.broadcast <- tx:
+	case <-h.done:
+	}
+}
+
+// pongWait is how long a client may stay silent before it is considered gone
+func (h *TransactionHub) pongWait() time.Duration {
+	return 2 * h.pingInterval
+}
+
+// writePump is the only writer of a client's connection: it sends queued transactions as
+// JSON text messages and pings at every interval, closing the connection when done
+func (h *TransactionHub) writePump(client *feedClient) {
+	ticker := time.NewTicker(h.pingInterval)
+	defer func() {
+		ticker.Stop()
+		client.conn.Close()
+	}()
+
+	for {
+		select {
+		case tx, ok := <-client.send:
+			client.conn.SetWriteDeadline(time.Now().Add(feedWriteWait))
+			if !ok {
+				// The hub dropped the client
+				client.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+			if err := client.conn.WriteJSON(tx); err != nil {
+				return
+			}
+
+		case <-ticker.C:
+			client.conn.SetWriteDeadline(time.Now().Add(feedWriteWait))
+			if err := client.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/service"
+)
+
+// PlaidVerificationHeader carries hex(HMAC-SHA256(secret, body)) on webhook requests
+// Signatures are checked by middleware.WebhookVerifier in front of the handler.
+const PlaidVerificationHeader = "Plaid-Verification"
+
+// maxWebhookBodySize limits webhook payloads to 1 MB
+const maxWebhookBodySize = 1 << 20
+
+// WebhookHandler ingests transactions pushed by bank aggregators
+// It trusts its input, so it must only be mounted behind middleware.WebhookVerifier.
+type WebhookHandler struct {
+	analyticsService *service.AnalyticsService
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(analyticsService *service.AnalyticsService) *WebhookHandler {
+	return &WebhookHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// PlaidTransactionWebhook is the supported payload: a Plaid TRANSACTIONS webhook with the
+// new transactions inlined in "added", using the transaction schema of /transactions/sync
+type PlaidTransactionWebhook struct {
+	WebhookType string             `json:"webhook_type"` // "TRANSACTIONS"
+	WebhookCode string             `json:"webhook_code"` // e.g., "SYNC_UPDATES_AVAILABLE"
+	ItemID      string             `json:"item_id"`
+	Added       []PlaidTransaction `json:"added"`
+}
+
+// PlaidTransaction is a single transaction in Plaid's schema
+// Plaid amounts are positive when money leaves the account and negative for deposits
+type PlaidTransaction struct {
+	TransactionID           string   `json:"transaction_id"`
+	Amount                  float64  `json:"amount"`
+	ISOCurrencyCode         string   `json:"iso_currency_code"`
+	Date                    string   `json:"date"` // YYYY-MM-DD
+	Name                    string   `json:"name"`
+	MerchantName            string   `json:"merchant_name"`
+	Category                []string `json:"category"` // Legacy category hierarchy
+	PersonalFinanceCategory *struct {
+		Primary string `json:"primary"` // e.g., "FOOD_AND_DRINK"
+	} `json:"personal_finance_category"`
+}
+
+// WebhookResponse acknowledges a processed webhook
+type WebhookResponse struct {
+	Received   int `json:"received"`   // Number of transactions stored by this delivery
+	Duplicates int `json:"duplicates"` // Transactions already stored by an earlier delivery
+}
+
+// HandleTransaction handles POST /api/webhooks/transaction
+// Returns 400 for malformed payloads, 413 for payloads over 1 MB and 422 if any transaction is invalid (nothing is
+// stored in that case)
+// Transactions are stored under "plaid-" + their Plaid transaction_id, so a redelivered or
+// replayed webhook only counts them as duplicates instead of storing them again.
+func (h *WebhookHandler) HandleTransaction(w http.ResponseWriter, r *http.Request) {
+	// Only allow POST method
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	body, ok := readBody(w, r, maxWebhookBodySize)
+	if !ok {
+		return
+	}
+
+	var payload PlaidTransactionWebhook
+	if err := json.Unmarshal(body, &payload); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid webhook payload")
+		return
+	}
+
+	// Other webhook types are acknowledged but ignored
+	if payload.WebhookType != "TRANSACTIONS" {
+		respondWithJSON(w, http.StatusOK, WebhookResponse{Received: 0})
+		return
+	}
+
+	// Validate everything first so a bad transaction doesn't leave a partial import
+	transactions := make([]domain.Transaction, len(payload.Added))
+	for i, plaidTx := range payload.Added {
+		transactions[i] = plaidTx.toTransaction()
+		if err := transactions[i].Validate(); err != nil {
+			handleServiceError(w, err)
+			return
+		}
+	}
+
+	var response WebhookResponse
+	for _, tx := range transactions {
+		_, err := h.analyticsService.CreateTransaction(tx)
+		if errors.Is(err, domain.ErrDuplicateID) {
+			response.Duplicates++
+			continue
+		}
+		if err != nil {
+			handleServiceError(w, err)
+			return
+		}
+		response.Received++
+	}
+
+	// Send successful response
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// plaidIDPrefix namespaces the IDs of transactions received from Plaid
+const plaidIDPrefix = "plaid-"
+
+// toTransaction converts a Plaid transaction to the domain model
+// The ID is derived from Plaid's transaction_id, e.g., "plaid-lPNjeW1n..."; without one the
+// repository assigns an ID as usual.
+func (p PlaidTransaction) toTransaction() domain.Transaction {
+	tx := domain.Transaction{
+		Date:        p.Date,
+		Description: p.Name,
+		Merchant:    p.MerchantName,
+		Currency:    p.ISOCurrencyCode,
+		Category:    plaidCategory(p),
+	}
+	if p.TransactionID != "" {
+		tx.ID = plaidIDPrefix + p.TransactionID
+	}
+
+	// Plaid reports outflows as positive amounts
+	if p.Amount > 0 {
+		tx.Type = "expense"
+		tx.Amount = -p.Amount
+	} else {
+		tx.Type = "income"
+		tx.Amount = math.Abs(p.Amount)
+	}
+
+	return tx
+}
+
+// plaidCategory maps Plaid's category fields to a lowercase category name
+// e.g., "FOOD_AND_DRINK" -> "food_and_drink", ["Travel", "Taxi"] -> "travel"
+func plaidCategory(p PlaidTransaction) string {
+	if p.PersonalFinanceCategory != nil && p.PersonalFinanceCategory.Primary != "" {
+		return strings.ToLower(p.PersonalFinanceCategory.Primary)
+	}
+	if len(p.Category) > 0 && p.Category[0] != "" {
+		return strings.ToLower(strings.ReplaceAll(p.Category[0], " ", "_"))
+	}
+	return "uncategorized"
+}
+
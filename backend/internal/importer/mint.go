@@ -0,0 +1,149 @@
+// Package importer converts transaction exports from other finance apps into domain transactions
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/categorizer"
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// Columns of a Mint.com export, as named in its header row
+const (
+	mintDate                = "Date"
+	mintDescription         = "Description"
+	mintOriginalDescription = "Original Description"
+	mintAmount              = "Amount"
+	mintTransactionType     = "Transaction Type"
+	mintCategory            = "Category"
+	mintAccountName         = "Account Name"
+	mintLabels              = "Labels"
+	mintNotes               = "Notes"
+)
+
+// mintRequiredColumns must appear in the header; the other columns are optional
+var mintRequiredColumns = []string{mintDate, mintDescription, mintAmount, mintTransactionType, mintCategory}
+
+// mintDateLayout is Mint's M/D/YYYY date format, e.g., "1/15/2024"
+const mintDateLayout = "1/2/2006"
+
+// ParseMintCSV converts a Mint.com CSV export into transactions
+// Mint amounts are unsigned: "credit" rows become income and "debit" rows become
+// expenses with a negative amount. Categories are lowercased to match the stored ones, e.g.,
+// "Groceries" -> "groceries", and blank ones are inferred from the description,
+// comma-separated Labels become tags and Notes are copied as-is.
+// Rows are not validated, so an unknown transaction type or date is reported when the
+// transactions are imported. Returns domain.ErrInvalidImportFile if the file is not a
+// Mint export or a row cannot be read.
+func ParseMintCSV(data []byte) ([]domain.Transaction, error) {
+	// Infers categories for rows Mint left uncategorized
+	autoCategorizer, err := categorizer.New()
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: file is empty", domain.ErrInvalidImportFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidImportFile, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	var missing []string
+	for _, name := range mintRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: missing Mint columns: %s", domain.ErrInvalidImportFile, strings.Join(missing, ", "))
+	}
+
+	transactions := []domain.Transaction{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidImportFile, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		value := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		tx, err := mintTransaction(value, autoCategorizer)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", domain.ErrInvalidImportFile, line, err)
+		}
+		transactions = append(transactions, tx)
+	}
+
+	return transactions, nil
+}
+
+// mintTransaction builds a transaction from one Mint row, read through value
+func mintTransaction(value func(column string) string, autoCategorizer *categorizer.Categorizer) (domain.Transaction, error) {
+	amount, err := strconv.ParseFloat(strings.NewReplacer("$", "", ",", "").Replace(value(mintAmount)), 64)
+	if err != nil {
+		return domain.Transaction{}, errors.New("amount must be a number")
+	}
+
+	tx := domain.Transaction{
+		Date:        value(mintDate),
+		Amount:      math.Abs(amount),
+		Category:    strings.ToLower(value(mintCategory)),
+		Description: value(mintDescription),
+		AccountID:   value(mintAccountName),
+		Notes:       value(mintNotes),
+	}
+
+	// Keep unparsable dates as written so validation reports them
+	if date, err := time.Parse(mintDateLayout, tx.Date); err == nil {
+		tx.Date = date.Format("2006-01-02")
+	}
+
+	switch transactionType := strings.ToLower(value(mintTransactionType)); transactionType {
+	case "credit":
+		tx.Type = "income"
+	case "debit":
+		tx.Type = "expense"
+		tx.Amount = -tx.Amount
+	default:
+		tx.Type = transactionType
+	}
+
+	if tx.Category == "" {
+		tx.Category = autoCategorizer.Categorize(tx.Description + " " + value(mintOriginalDescription))
+	}
+
+	for _, label := range strings.Split(value(mintLabels), ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			tx.Tags = append(tx.Tags, label)
+		}
+	}
+
+	return tx, nil
+}
+
@@ -0,0 +1,115 @@
+package importer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func TestParseMintCSV_Sample(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "mint_sample.csv"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	transactions, err := ParseMintCSV(data)
+	if err != nil {
+		t.Fatalf("ParseMintCSV() error = %v", err)
+	}
+	if len(transactions) != 5 {
+		t.Fatalf("Expected 5 transactions, got %d", len(transactions))
+	}
+
+	// Credits are income with a positive amount
+	salary := transactions[0]
+	want := domain.Transaction{
+		Date:        "2024-01-15",
+		Amount:      4250,
+		Category:    "paycheck",
+		Description: "Acme Corp",
+		Type:        "income",
+		AccountID:   "Checking",
+		Notes:       "January salary",
+	}
+	if !reflect.DeepEqual(salary, want) {
+		t.Errorf("Credit row = %+v, want %+v", salary, want)
+	}
+
+	// Debits are expenses with a negative amount
+	groceries := transactions[1]
+	if groceries.Type != "expense" || groceries.Amount != -87.43 {
+		t.Errorf("Debit row type/amount = %s/%v, want expense/-87.43", groceries.Type, groceries.Amount)
+	}
+	if groceries.Category != "groceries" {
+		t.Errorf("Debit row category = %q, want Mint's Groceries lowercased", groceries.Category)
+	}
+	if !reflect.DeepEqual(groceries.Tags, []string{"Household"}) || groceries.Notes != "Weekly groceries" {
+		t.Errorf("Debit row tags/notes = %v/%q, want [Household]/\"Weekly groceries\"", groceries.Tags, groceries.Notes)
+	}
+
+	// Blank categories are classified from the description
+	gas := transactions[2]
+	if gas.Category != "transportation" {
+		t.Errorf("Uncategorized row category = %q, want transportation", gas.Category)
+	}
+	if !reflect.DeepEqual(gas.Tags, []string{"Car", "Commute"}) {
+		t.Errorf("Uncategorized row tags = %v, want [Car Commute]", gas.Tags)
+	}
+
+	// Zero-padded dates parse too
+	if transactions[4].Date != "2024-01-31" {
+		t.Errorf("Padded date = %q, want 2024-01-31", transactions[4].Date)
+	}
+
+	for i, tx := range transactions {
+		if err := tx.Validate(); err != nil {
+			t.Errorf("Transaction %d is invalid: %v", i, err)
+		}
+	}
+}
+
+func TestParseMintCSV_UnknownTypeLeftForValidation(t *testing.T) {
+	data := "Date,Description,Amount,Transaction Type,Category\n2/3/2024,Refund,10.00,transfer,Shopping\n"
+
+	transactions, err := ParseMintCSV([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseMintCSV() error = %v", err)
+	}
+	if transactions[0].Type != "transfer" {
+		t.Errorf("Type = %q, want the Mint value kept", transactions[0].Type)
+	}
+	if err := transactions[0].Validate(); err == nil {
+		t.Error("Expected validation to reject an unknown type")
+	}
+}
+
+func TestParseMintCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"empty file", "", "file is empty"},
+		{"not a mint export", "date,amount,category\n2024-01-15,10,food\n", "missing Mint columns"},
+		{"bad amount", "Date,Description,Amount,Transaction Type,Category\n1/15/2024,Coffee,ten,debit,Dining\n", "line 2: amount must be a number"},
+		{"malformed csv", "Date,Description,Amount,Transaction Type,Category\n1/15/2024,\"Coffee,3.50,debit,Dining\n", "invalid import file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseMintCSV([]byte(tt.data))
+			if !errors.Is(err, domain.ErrInvalidImportFile) {
+				t.Fatalf("ParseMintCSV() error = %v, want ErrInvalidImportFile", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseMintCSV() error = %q, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// AuditEntry records a single state-changing API call
+// Only a hash of the request body is kept so audit logs never hold financial data.
+type AuditEntry struct {
+	Timestamp  time.Time `json:"timestamp"`         // When the response was sent (UTC)
+	Method     string    `json:"method"`            // POST, PUT, PATCH or DELETE
+	Path       string    `json:"path"`              // Request path without query
+	StatusCode int       `json:"status_code"`       // HTTP status code of the response
+	Status     string    `json:"status"`            // Status text, e.g., "Created"
+	UserID     string    `json:"user_id,omitempty"` // Caller identity, when authenticated
+	BodyHash   string    `json:"body_hash"`         // Hex SHA-256 of the request body
+}
+
+// MaxAuditedBodySize is the largest request body AuditLog reads; larger bodies get 413
+// It matches the largest body a handler accepts, the bulk and CSV transaction imports.
+const MaxAuditedBodySize = 5 << 20
+
+// AuditLogger persists audit entries
+type AuditLogger interface {
+	Log(entry AuditEntry) error
+}
+
+// AuditLog middleware records an AuditEntry after every POST, PUT, PATCH or DELETE response
+// Failures to write the entry are logged and never affect the response.
+func AuditLog(logger AuditLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isStateChanging(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxAuditedBodySize))
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			if err != nil {
+				http.Error(w, "Failed to read request body", http.StatusBadRequest)
+				return
+			}
+			r.Body.Close()
+			// Restore the body for downstream handlers
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			bodyHash := sha256.Sum256(body)
+
+			// Wrap response writer to capture status code
+			wrapped := newResponseWriter(w)
+
+			// Process request
+			next.ServeHTTP(wrapped, r)
+
+			userID, _ := domain.GetUserID(r.Context())
+			entry := AuditEntry{
+				Timestamp:  now().UTC(),
+				Method:     r.Method,
+				Path:       r.URL.Path,
+				StatusCode: wrapped.statusCode,
+				Status:     http.StatusText(wrapped.statusCode),
+				UserID:     userID,
+				BodyHash:   hex.EncodeToString(bodyHash[:]),
+			}
+			if err := logger.Log(entry); err != nil {
+				log.Printf("⚠️  Failed to write audit entry for %s %s: %v", r.Method, r.URL.Path, err)
+			}
+		})
+	}
+}
+
+// isStateChanging reports whether requests with method modify server state
+func isStateChanging(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// FileAuditLogger appends audit entries to a file as JSON lines
+type FileAuditLogger struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+// NewFileAuditLogger opens (or creates) path for appending audit entries
+func NewFileAuditLogger(path string) (*FileAuditLogger, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileAuditLogger{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+// Log writes entry as a single JSON line
+func (l *FileAuditLogger) Log(entry AuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.encoder.Encode(entry)
+}
+
+// Close closes the underlying file
+func (l *FileAuditLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+// NoopAuditLogger discards every entry
+type NoopAuditLogger struct{}
+
+// Log does nothing
+func (NoopAuditLogger) Log(AuditEntry) error {
+	return nil
+}
+
@@ -0,0 +1,173 @@
+package middleware
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// recordingAuditLogger keeps entries in memory for assertions
+type recordingAuditLogger struct {
+	entries []AuditEntry
+	err     error
+}
+
+func (l *recordingAuditLogger) Log(entry AuditEntry) error {
+	l.entries = append(l.entries, entry)
+	return l.err
+}
+
+func TestAuditLog_RecordsPost(t *testing.T) {
+	fixedNow := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	now = func() time.Time { return fixedNow }
+	defer func() { now = time.Now }()
+
+	logger := &recordingAuditLogger{}
+	var handlerBody string
+	handler := AuditLog(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The handler still sees the full body
+		body, _ := io.ReadAll(r.Body)
+		handlerBody = string(body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	body := `{"context":"savings"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/advice?debug=1", strings.NewReader(body))
+	req = req.WithContext(domain.SetUserID(req.Context(), "user-42"))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if handlerBody != body {
+		t.Errorf("Handler received body %q, want %q", handlerBody, body)
+	}
+	if len(logger.entries) != 1 {
+		t.Fatalf("Expected 1 audit entry, got %d", len(logger.entries))
+	}
+
+	sum := sha256.Sum256([]byte(body))
+	want := AuditEntry{
+		Timestamp:  fixedNow,
+		Method:     http.MethodPost,
+		Path:       "/api/advice",
+		StatusCode: http.StatusCreated,
+		Status:     "Created",
+		UserID:     "user-42",
+		BodyHash:   hex.EncodeToString(sum[:]),
+	}
+	if logger.entries[0] != want {
+		t.Errorf("Audit entry = %+v, want %+v", logger.entries[0], want)
+	}
+}
+
+func TestAuditLog_SkipsReadOnlyMethods(t *testing.T) {
+	logger := &recordingAuditLogger{}
+	handler := AuditLog(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodOptions} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/api/transactions", nil))
+	}
+	for _, method := range []string{http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/api/transactions", nil))
+	}
+
+	if len(logger.entries) != 3 {
+		t.Fatalf("Expected 3 audit entries, got %d", len(logger.entries))
+	}
+	if logger.entries[0].UserID != "" {
+		t.Errorf("Expected no user ID without identity in context, got %q", logger.entries[0].UserID)
+	}
+}
+
+func TestAuditLog_LoggerErrorDoesNotAffectResponse(t *testing.T) {
+	logger := &recordingAuditLogger{err: errors.New("disk full")}
+	handler := AuditLog(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/webhooks/transaction", strings.NewReader("{}")))
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("Expected status 202, got %d", w.Code)
+	}
+}
+
+func TestAuditLog_RejectsOversizedBody(t *testing.T) {
+	logger := &recordingAuditLogger{}
+	called := false
+	handler := AuditLog(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	body := strings.NewReader(strings.Repeat("x", MaxAuditedBodySize+1))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/transactions/bulk", body))
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413, got %d", rec.Code)
+	}
+	if called || len(logger.entries) != 0 {
+		t.Errorf("Expected the request rejected before the handler, called=%v entries=%d", called, len(logger.entries))
+	}
+}
+
+func TestFileAuditLogger_WritesJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	logger, err := NewFileAuditLogger(path)
+	if err != nil {
+		t.Fatalf("NewFileAuditLogger() error = %v", err)
+	}
+
+	handler := AuditLog(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/advice", strings.NewReader("{")))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/api/advice/1", nil))
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open audit log: %v", err)
+	}
+	defer file.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(entries))
+	}
+	if entries[0].Method != http.MethodPost || entries[0].StatusCode != http.StatusBadRequest {
+		t.Errorf("Unexpected first entry: %+v", entries[0])
+	}
+	if entries[1].Method != http.MethodDelete || entries[1].Path != "/api/advice/1" {
+		t.Errorf("Unexpected second entry: %+v", entries[1])
+	}
+}
+
+func TestNoopAuditLogger(t *testing.T) {
+	if err := (NoopAuditLogger{}).Log(AuditEntry{Method: http.MethodPost}); err != nil {
+		t.Errorf("NoopAuditLogger.Log() = %v, want nil", err)
+	}
+}
+
@@ -2,26 +2,40 @@ package middleware
 
 import (
 	"net/http"
+	"net/url"
 	"strings"
 )
 
+// allowedRequestHeaders are the request headers browsers may send cross-origin, including
+// the ones read by FinancialContext and IdempotencyKey
+var allowedRequestHeaders = strings.Join([]string{
+	"Accept", "Content-Type", "Content-Length", "Accept-Encoding", "Authorization",
+	PreferredCurrencyHeader, LocaleHeader, FiscalYearStartHeader, IdempotencyKeyHeader,
+}, ", ")
+
 // CORS middleware handles Cross-Origin Resource Sharing
-// Allows the frontend (running on different origin) to access our API
-func CORS(allowedOrigins []string) func(http.Handler) http.Handler {
+// Allows the frontend (running on different origin) to access our API.
+// exposedHeaders lists the response headers browser scripts may read, e.g., "Retry-After".
+func CORS(allowedOrigins, exposedHeaders []string) func(http.Handler) http.Handler {
+	exposed := strings.Join(exposedHeaders, ", ")
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			origin := r.Header.Get("Origin")
 
 			// Check if origin is in allowed list
-			if isOriginAllowed(origin, allowedOrigins) {
+			if IsOriginAllowed(origin, allowedOrigins) {
 				w.Header().Set("Access-Control-Allow-Origin", origin)
 			}
 
-			// Set CORS headers
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization")
+			// Set CORS headers; methods match the routes the API serves
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", allowedRequestHeaders)
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
 			w.Header().Set("Access-Control-Max-Age", "86400") // 24 hours
+			if exposed != "" {
+				w.Header().Set("Access-Control-Expose-Headers", exposed)
+			}
 
 			// Handle preflight OPTIONS request
 			if r.Method == "OPTIONS" {
@@ -35,8 +49,11 @@ func CORS(allowedOrigins []string) func(http.Handler) http.Handler {
 	}
 }
 
-// isOriginAllowed checks if the origin is in the allowed list
-func isOriginAllowed(origin string, allowedOrigins []string) bool {
+// IsOriginAllowed checks if the origin is in the allowed list, e.g., to vet WebSocket upgrades
+// Entries of the form "*.example.com" match any subdomain (at any depth) of example.com,
+// but not example.com itself, on any scheme and port. Entries of the form
+// "https://*.example.com" also require the scheme and port to match.
+func IsOriginAllowed(origin string, allowedOrigins []string) bool {
 	if len(allowedOrigins) == 0 {
 		return false
 	}
@@ -50,7 +67,37 @@ func isOriginAllowed(origin string, allowedOrigins []string) bool {
 		if origin == allowed || origin == strings.TrimSuffix(allowed, "/") {
 			return true
 		}
+		if strings.HasPrefix(allowed, "*.") && isSubdomainOf(origin, strings.TrimPrefix(allowed, "*.")) {
+			return true
+		}
+		if strings.Contains(allowed, "://*.") && matchesOriginPattern(origin, allowed) {
+			return true
+		}
 	}
 
 	return false
 }
+
+// isSubdomainOf reports whether the origin's hostname is a strict subdomain of baseDomain
+func isSubdomainOf(origin, baseDomain string) bool {
+	parsed, err := url.Parse(origin)
+	if err != nil || parsed.Hostname() == "" {
+		return false
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	return strings.HasSuffix(host, "."+strings.ToLower(strings.TrimSuffix(baseDomain, "/")))
+}
+
+// matchesOriginPattern reports whether origin matches a "scheme://*.domain[:port]" pattern
+func matchesOriginPattern(origin, pattern string) bool {
+	scheme, hostPattern, _ := strings.Cut(strings.TrimSuffix(pattern, "/"), "://")
+	baseDomain, port, _ := strings.Cut(strings.TrimPrefix(hostPattern, "*."), ":")
+
+	parsed, err := url.Parse(origin)
+	if err != nil || !strings.EqualFold(parsed.Scheme, scheme) || parsed.Port() != port {
+		return false
+	}
+	return isSubdomainOf(origin, baseDomain)
+}
+
@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// RequestIDHeader is the response header carrying the request ID
+const RequestIDHeader = "X-Request-Id"
+
+// Envelope statuses
+const (
+	EnvelopeStatusSuccess = "success"
+	EnvelopeStatusError   = "error"
+)
+
+// EnvelopedResponse wraps a JSON response body for clients that expect a uniform shape
+// e.g., {"status":"success","data":{...},"request_id":"host/abc-000001","timestamp":"..."}
+type EnvelopedResponse struct {
+	Status    string          `json:"status"`               // "success", or "error" for 4xx/5xx responses
+	Data      json.RawMessage `json:"data,omitempty"`       // Original body of a successful response
+	Error     json.RawMessage `json:"error,omitempty"`      // Original body of an error response
+	RequestID string          `json:"request_id,omitempty"` // Same value as the X-Request-Id header
+	Timestamp string          `json:"timestamp"`            // When the response was sent, RFC 3339 in UTC
+}
+
+// RequestID echoes the request ID into the X-Request-Id response header
+// Place after chi's RequestID middleware, which assigns the ID (or reuses the client's).
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestID := chimiddleware.GetReqID(r.Context()); requestID != "" {
+			w.Header().Set(RequestIDHeader, requestID)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ResponseEnvelope wraps every JSON response in an EnvelopedResponse
+// Handlers keep writing their usual bodies; non-JSON responses such as CSV exports
+// and metrics pass through unchanged. Place after chi's RequestID middleware.
+func ResponseEnvelope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wrapped := &envelopeWriter{ResponseWriter: w}
+
+		next.ServeHTTP(wrapped, r)
+
+		wrapped.finish(chimiddleware.GetReqID(r.Context()), time.Now())
+	})
+}
+
+// envelopeWriter holds back JSON bodies until the handler is done so they can be wrapped
+type envelopeWriter struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	buffering   bool // The response is JSON and is being collected in body
+	body        bytes.Buffer
+}
+
+// WriteHeader decides from the Content-Type whether the body is wrapped
+func (ew *envelopeWriter) WriteHeader(code int) {
+	if ew.wroteHeader {
+		return
+	}
+	ew.statusCode = code
+	ew.wroteHeader = true
+
+	mediaType, _, _ := mime.ParseMediaType(ew.Header().Get("Content-Type"))
+	if mediaType == "application/json" {
+		ew.buffering = true
+		return
+	}
+	ew.ResponseWriter.WriteHeader(code)
+}
+
+// Write collects JSON bodies and passes everything else through
+func (ew *envelopeWriter) Write(b []byte) (int, error) {
+	if !ew.wroteHeader {
+		ew.WriteHeader(http.StatusOK)
+	}
+	if ew.buffering {
+		return ew.body.Write(b)
+	}
+	return ew.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the original writer, for http.ResponseController
+func (ew *envelopeWriter) Unwrap() http.ResponseWriter {
+	return ew.ResponseWriter
+}
+
+// Flush passes through for streamed responses; buffered JSON is only sent once complete
+func (ew *envelopeWriter) Flush() {
+	if !ew.wroteHeader {
+		ew.WriteHeader(http.StatusOK)
+	}
+	if ew.buffering {
+		return
+	}
+	if flusher, ok := ew.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack hands the connection over, e.g., for a WebSocket upgrade, and skips the envelope
+func (ew *envelopeWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := ew.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("middleware: response writer does not support hijacking")
+	}
+
+	conn, buf, err := hijacker.Hijack()
+	if err == nil {
+		ew.wroteHeader, ew.buffering = true, false
+	}
+	return conn, buf, err
+}
+
+// finish sends the collected JSON body inside an envelope
+// Bodies that are not valid JSON are sent as written.
+func (ew *envelopeWriter) finish(requestID string, now time.Time) {
+	if !ew.buffering {
+		return
+	}
+
+	body := ew.body.Bytes()
+	trimmed := bytes.TrimSpace(body)
+	if json.Valid(trimmed) {
+		envelope := EnvelopedResponse{
+			Status:    EnvelopeStatusSuccess,
+			Data:      trimmed,
+			RequestID: requestID,
+			Timestamp: now.UTC().Format(time.RFC3339),
+		}
+		if ew.statusCode >= http.StatusBadRequest {
+			envelope.Status = EnvelopeStatusError
+			envelope.Data, envelope.Error = nil, trimmed
+		}
+
+		// The envelope only holds valid JSON and strings, so encoding cannot fail
+		var wrapped bytes.Buffer
+		json.NewEncoder(&wrapped).Encode(envelope)
+		body = wrapped.Bytes()
+	}
+
+	ew.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	ew.ResponseWriter.WriteHeader(ew.statusCode)
+	ew.ResponseWriter.Write(body)
+}
+
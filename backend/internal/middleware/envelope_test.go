@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+func TestResponseEnvelope(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/csv":
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.Write([]byte("date,amount\n"))
+		case "/missing":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Not Found","code":"ADVICE_NOT_FOUND"}` + "\n"))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Length", "15")
+			w.Write([]byte(`{"status":"ok"}`))
+		}
+	})
+
+	// Mirrors the router: the envelope is only registered when enabled
+	chain := func(enabled bool) http.Handler {
+		next := http.Handler(handler)
+		if enabled {
+			next = ResponseEnvelope(next)
+		}
+		return chimiddleware.RequestID(RequestID(next))
+	}
+
+	t.Run("enabled wraps success bodies", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		chain(true).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", w.Code)
+		}
+
+		var envelope EnvelopedResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil {
+			t.Fatalf("Failed to decode envelope: %v", err)
+		}
+		if envelope.Status != EnvelopeStatusSuccess || string(envelope.Data) != `{"status":"ok"}` || envelope.Error != nil {
+			t.Errorf("Unexpected envelope %+v", envelope)
+		}
+		if requestID := w.Header().Get(RequestIDHeader); requestID == "" || envelope.RequestID != requestID {
+			t.Errorf("Envelope request ID = %q, want the %s header %q", envelope.RequestID, RequestIDHeader, requestID)
+		}
+		if _, err := time.Parse(time.RFC3339, envelope.Timestamp); err != nil {
+			t.Errorf("Timestamp %q is not RFC 3339: %v", envelope.Timestamp, err)
+		}
+		if got := w.Header().Get("Content-Length"); got != strconv.Itoa(w.Body.Len()) {
+			t.Errorf("Content-Length = %s, want the envelope length %d", got, w.Body.Len())
+		}
+	})
+
+	t.Run("enabled wraps error bodies", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+		req.Header.Set(RequestIDHeader, "client-id-42")
+		w := httptest.NewRecorder()
+		chain(true).ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("Expected status 404, got %d", w.Code)
+		}
+
+		var envelope EnvelopedResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil {
+			t.Fatalf("Failed to decode envelope: %v", err)
+		}
+		if envelope.Status != EnvelopeStatusError || envelope.Data != nil || !strings.Contains(string(envelope.Error), "ADVICE_NOT_FOUND") {
+			t.Errorf("Unexpected envelope %+v", envelope)
+		}
+		if envelope.RequestID != "client-id-42" || w.Header().Get(RequestIDHeader) != "client-id-42" {
+			t.Errorf("Expected the client's request ID in body and header, got %q and %q", envelope.RequestID, w.Header().Get(RequestIDHeader))
+		}
+	})
+
+	t.Run("enabled leaves non-JSON bodies alone", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		chain(true).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/csv", nil))
+
+		if w.Body.String() != "date,amount\n" {
+			t.Errorf("Expected the CSV body unchanged, got %q", w.Body.String())
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		chain(false).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		if w.Body.String() != `{"status":"ok"}` {
+			t.Errorf("Expected the body unwrapped, got %q", w.Body.String())
+		}
+		if w.Header().Get(RequestIDHeader) == "" {
+			t.Errorf("Expected the %s header without the envelope too", RequestIDHeader)
+		}
+	})
+}
+
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// Request headers read by FinancialContext
+const (
+	PreferredCurrencyHeader = "X-Preferred-Currency" // ISO 4217 code, e.g., "MXN"
+	LocaleHeader            = "X-Locale"             // BCP 47 tag, e.g., "es-MX"
+	FiscalYearStartHeader   = "X-Fiscal-Year-Start"  // Month number, 1 (January) to 12
+)
+
+// FinancialContext middleware stores the client's preferred currency, locale and fiscal
+// year start in the request context (see domain.GetPreferredCurrency and friends)
+// Missing or invalid headers fall back to defaultLocale and January; without a valid
+// currency header none is stored, so handlers report unconverted base-currency amounts.
+func FinancialContext(defaultLocale string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			currency := strings.ToUpper(strings.TrimSpace(r.Header.Get(PreferredCurrencyHeader)))
+			if !domain.IsValidCurrency(currency) {
+				currency = ""
+			}
+
+			locale := strings.TrimSpace(r.Header.Get(LocaleHeader))
+			if locale == "" {
+				locale = defaultLocale
+			}
+
+			fiscalYearStart := time.January
+			if month, err := strconv.Atoi(strings.TrimSpace(r.Header.Get(FiscalYearStartHeader))); err == nil && month >= 1 && month <= 12 {
+				fiscalYearStart = time.Month(month)
+			}
+
+			ctx := domain.SetPreferredCurrency(r.Context(), currency)
+			ctx = domain.SetLocale(ctx, locale)
+			ctx = domain.SetFiscalYearStart(ctx, fiscalYearStart)
+
+			// Continue to next handler
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func TestFinancialContext(t *testing.T) {
+	tests := []struct {
+		name                string
+		headers             map[string]string
+		wantCurrency        string
+		wantLocale          string
+		wantFiscalYearStart time.Month
+	}{
+		{
+			name:                "defaults without headers",
+			wantCurrency:        "",
+			wantLocale:          "en-US",
+			wantFiscalYearStart: time.January,
+		},
+		{
+			name: "all headers set",
+			headers: map[string]string{
+				PreferredCurrencyHeader: "mxn",
+				LocaleHeader:            "es-MX",
+				FiscalYearStartHeader:   "4",
+			},
+			wantCurrency:        "MXN",
+			wantLocale:          "es-MX",
+			wantFiscalYearStart: time.April,
+		},
+		{
+			name: "invalid values fall back to defaults",
+			headers: map[string]string{
+				PreferredCurrencyHeader: "ABC",
+				LocaleHeader:            "   ",
+				FiscalYearStartHeader:   "13",
+			},
+			wantCurrency:        "",
+			wantLocale:          "en-US",
+			wantFiscalYearStart: time.January,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var currency, locale string
+			var fiscalYearStart time.Month
+			handler := FinancialContext("en-US")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				currency = domain.GetPreferredCurrency(r.Context())
+				locale = domain.GetLocale(r.Context())
+				fiscalYearStart = domain.GetFiscalYearStart(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if currency != tt.wantCurrency {
+				t.Errorf("GetPreferredCurrency() = %q, want %q", currency, tt.wantCurrency)
+			}
+			if locale != tt.wantLocale {
+				t.Errorf("GetLocale() = %q, want %q", locale, tt.wantLocale)
+			}
+			if fiscalYearStart != tt.wantFiscalYearStart {
+				t.Errorf("GetFiscalYearStart() = %v, want %v", fiscalYearStart, tt.wantFiscalYearStart)
+			}
+		})
+	}
+}
+
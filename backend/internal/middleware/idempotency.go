@@ -0,0 +1,235 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// IdempotencyKeyHeader is the request header clients use to make a POST safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyReplayedHeader is set on responses served from the idempotency cache
+const IdempotencyReplayedHeader = "X-Idempotency-Replayed"
+
+// DefaultIdempotencyTTL is how long an IdempotencyStore should keep a response for replay
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// IdempotencyStore caches responses by idempotency key
+// The store decides how long entries are replayed; expired keys are processed again.
+type IdempotencyStore interface {
+	// GetOrSet returns the cached value for key, or runs fn and caches its result.
+	// The bool reports whether the value was served from the cache. When fn returns an
+	// error nothing is cached and fn's result is returned along with the error.
+	// Concurrent callers with the same key wait for the first fn to finish.
+	GetOrSet(key string, fn func() ([]byte, int, error)) ([]byte, int, bool, error)
+}
+
+// errNotCacheable marks responses that must not be replayed (anything but 2xx)
+var errNotCacheable = errors.New("response not cacheable")
+
+// idempotentResponse is what the middleware stores for each key
+type idempotentResponse struct {
+	Fingerprint string      `json:"fingerprint"` // Hash of method, path and body of the original request
+	Header      http.Header `json:"header"`
+	Body        []byte      `json:"body"`
+}
+
+// IdempotencyKey middleware replays the original response when a request is retried
+// with the same Idempotency-Key header while store still holds it. Replayed responses
+// have status 200 and carry X-Idempotency-Replayed: true. Reusing a key with a different
+// request body returns 422. Requests without the header, and responses other than 2xx, are
+// never cached, so a client retrying after e.g. a 429 or a validation error is processed again.
+func IdempotencyKey(store IdempotencyStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, "Failed to read request body", http.StatusBadRequest)
+				return
+			}
+			r.Body.Close()
+			fingerprint := requestFingerprint(r, body)
+
+			execute := func() ([]byte, int, error) {
+				// Restore the body for downstream handlers
+				r.Body = io.NopCloser(bytes.NewReader(body))
+
+				rec := newBufferedResponseWriter()
+				next.ServeHTTP(rec, r)
+
+				data, err := json.Marshal(idempotentResponse{
+					Fingerprint: fingerprint,
+					Header:      rec.header,
+					Body:        rec.body.Bytes(),
+				})
+				if err != nil {
+					return nil, http.StatusInternalServerError, err
+				}
+				if rec.statusCode < 200 || rec.statusCode > 299 {
+					return data, rec.statusCode, errNotCacheable
+				}
+				return data, rec.statusCode, nil
+			}
+
+			data, statusCode, replayed, err := store.GetOrSet(key, execute)
+			var cached idempotentResponse
+			if err == nil || errors.Is(err, errNotCacheable) {
+				err = json.Unmarshal(data, &cached)
+			}
+
+			if err != nil {
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+
+			if replayed && cached.Fingerprint != fingerprint {
+				http.Error(w, "Idempotency-Key was already used with a different request", http.StatusUnprocessableEntity)
+				return
+			}
+
+			for name, values := range cached.Header {
+				w.Header()[name] = values
+			}
+			if replayed {
+				w.Header().Set(IdempotencyReplayedHeader, "true")
+				statusCode = http.StatusOK
+			}
+			w.WriteHeader(statusCode)
+			w.Write(cached.Body)
+		})
+	}
+}
+
+// requestFingerprint identifies a request by method, path and body
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// bufferedResponseWriter captures a handler's response so it can be cached
+type bufferedResponseWriter struct {
+	header     http.Header
+	body       bytes.Buffer
+	statusCode int
+}
+
+func newBufferedResponseWriter() *bufferedResponseWriter {
+	return &bufferedResponseWriter{
+		header:     make(http.Header),
+		statusCode: http.StatusOK,
+	}
+}
+
+// Header returns the captured response headers
+func (b *bufferedResponseWriter) Header() http.Header {
+	return b.header
+}
+
+// WriteHeader captures the status code
+func (b *bufferedResponseWriter) WriteHeader(code int) {
+	b.statusCode = code
+}
+
+// Write captures the response body
+func (b *bufferedResponseWriter) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+// InMemoryIdempotencyStore is a process-local IdempotencyStore
+// Entries older than ttl are evicted lazily: a sweep runs at most once per ttl
+// whenever GetOrSet is called, so memory stays bounded without a background goroutine.
+type InMemoryIdempotencyStore struct {
+	entries   sync.Map // key -> *idempotencyEntry
+	ttl       time.Duration
+	mu        sync.Mutex // Guards lastSweep
+	lastSweep time.Time
+}
+
+// idempotencyEntry holds a cached result; done is closed once it is ready
+type idempotencyEntry struct {
+	done       chan struct{}
+	createdAt  time.Time
+	data       []byte
+	statusCode int
+	err        error
+}
+
+// NewInMemoryIdempotencyStore creates an empty in-memory store that keeps entries for ttl
+func NewInMemoryIdempotencyStore(ttl time.Duration) *InMemoryIdempotencyStore {
+	return &InMemoryIdempotencyStore{
+		ttl:       ttl,
+		lastSweep: now(),
+	}
+}
+
+// GetOrSet implements IdempotencyStore
+func (s *InMemoryIdempotencyStore) GetOrSet(key string, fn func() ([]byte, int, error)) ([]byte, int, bool, error) {
+	s.sweepExpired()
+
+	entry := &idempotencyEntry{done: make(chan struct{}), createdAt: now()}
+	if existing, loaded := s.entries.LoadOrStore(key, entry); loaded {
+		cached := existing.(*idempotencyEntry)
+
+		// Wait for an in-flight request with the same key
+		<-cached.done
+		if cached.err == nil && !s.expired(cached) {
+			return cached.data, cached.statusCode, true, nil
+		}
+		// The first attempt failed or has expired, so this caller runs fn itself
+		s.entries.CompareAndDelete(key, cached)
+		return s.GetOrSet(key, fn)
+	}
+
+	entry.data, entry.statusCode, entry.err = fn()
+	if entry.err != nil {
+		s.entries.CompareAndDelete(key, entry)
+	}
+	close(entry.done)
+
+	return entry.data, entry.statusCode, false, entry.err
+}
+
+// expired reports whether a completed entry is older than the store's ttl
+func (s *InMemoryIdempotencyStore) expired(entry *idempotencyEntry) bool {
+	return now().Sub(entry.createdAt) > s.ttl
+}
+
+// sweepExpired removes completed entries older than ttl, at most once per ttl
+func (s *InMemoryIdempotencyStore) sweepExpired() {
+	s.mu.Lock()
+	if now().Sub(s.lastSweep) < s.ttl {
+		s.mu.Unlock()
+		return
+	}
+	s.lastSweep = now()
+	s.mu.Unlock()
+
+	s.entries.Range(func(key, value any) bool {
+		entry := value.(*idempotencyEntry)
+		select {
+		case <-entry.done:
+			if s.expired(entry) {
+				s.entries.CompareAndDelete(key, entry)
+			}
+		default:
+			// Still in flight
+		}
+		return true
+	})
+}
+
@@ -0,0 +1,234 @@
+package middleware
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newIdempotentRequest(key, body string) *http.Request {
+	req := httptest.NewRequest("POST", "/api/advice", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	return req
+}
+
+func newCountingHandler(calls *int32, statusCode int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(calls, 1)
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		fmt.Fprintf(w, `{"call":%d,"body":%s}`, n, body)
+	})
+}
+
+func TestIdempotency_ReplaysCachedResponse(t *testing.T) {
+	var calls int32
+	handler := IdempotencyKey(NewInMemoryIdempotencyStore(time.Hour))(newCountingHandler(&calls, http.StatusCreated))
+
+	body := `{"question":"how can I save?"}`
+
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, newIdempotentRequest("key-1", body))
+
+	second := httptest.NewRecorder()
+	handler.ServeHTTP(second, newIdempotentRequest("key-1", body))
+
+	if calls != 1 {
+		t.Fatalf("Expected handler to run once, ran %d times", calls)
+	}
+
+	// The original request created something; the replay only reports it again
+	if first.Code != http.StatusCreated || second.Code != http.StatusOK {
+		t.Errorf("Expected 201 then a replayed 200, got %d and %d", first.Code, second.Code)
+	}
+	if second.Body.String() != first.Body.String() {
+		t.Errorf("Expected replayed body %q, got %q", first.Body.String(), second.Body.String())
+	}
+	if second.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("Expected replayed Content-Type header, got %q", second.Header().Get("Content-Type"))
+	}
+	if second.Header().Get(IdempotencyReplayedHeader) != "true" {
+		t.Error("Expected replayed response to be marked")
+	}
+	if first.Header().Get(IdempotencyReplayedHeader) != "" {
+		t.Error("Expected original response not to be marked as replayed")
+	}
+}
+
+func TestIdempotency_BodyMismatch(t *testing.T) {
+	var calls int32
+	handler := IdempotencyKey(NewInMemoryIdempotencyStore(time.Hour))(newCountingHandler(&calls, http.StatusOK))
+
+	handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("key-1", `{"amount":10}`))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newIdempotentRequest("key-1", `{"amount":20}`))
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status 422, got %d", w.Code)
+	}
+	if calls != 1 {
+		t.Errorf("Expected handler to run once, ran %d times", calls)
+	}
+}
+
+func TestIdempotency_NotCached(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        string
+		statusCode int
+	}{
+		{"no idempotency key", "", http.StatusOK},
+		{"server error", "key-1", http.StatusInternalServerError},
+		{"rate limited", "key-1", http.StatusTooManyRequests},
+		{"validation failure", "key-1", http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			handler := IdempotencyKey(NewInMemoryIdempotencyStore(time.Hour))(newCountingHandler(&calls, tt.statusCode))
+
+			for i := 0; i < 2; i++ {
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, newIdempotentRequest(tt.key, `{}`))
+
+				if w.Code != tt.statusCode {
+					t.Errorf("Expected status %d, got %d", tt.statusCode, w.Code)
+				}
+				if w.Header().Get(IdempotencyReplayedHeader) != "" {
+					t.Error("Expected response not to be replayed")
+				}
+			}
+
+			if calls != 2 {
+				t.Errorf("Expected handler to run twice, ran %d times", calls)
+			}
+		})
+	}
+}
+
+func TestIdempotency_RetryAfterRateLimit(t *testing.T) {
+	var calls int32
+	statusCode := http.StatusTooManyRequests
+	handler := IdempotencyKey(NewInMemoryIdempotencyStore(time.Hour))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if statusCode == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "30")
+		}
+		w.WriteHeader(statusCode)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newIdempotentRequest("key-1", `{}`))
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "30" {
+		t.Fatalf("Expected the upstream 429 with Retry-After, got %d", w.Code)
+	}
+
+	// The retry with the same key reaches the handler instead of replaying the 429
+	statusCode = http.StatusCreated
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newIdempotentRequest("key-1", `{}`))
+	if w.Code != http.StatusCreated || w.Header().Get(IdempotencyReplayedHeader) != "" {
+		t.Errorf("Expected a fresh 201 after the rate limit, got %d (replayed=%q)", w.Code, w.Header().Get(IdempotencyReplayedHeader))
+	}
+	if calls != 2 {
+		t.Errorf("Expected handler to run twice, ran %d times", calls)
+	}
+}
+
+func TestIdempotency_Expiry(t *testing.T) {
+	fixedNow := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixedNow }
+	defer func() { now = time.Now }()
+
+	var calls int32
+	handler := IdempotencyKey(NewInMemoryIdempotencyStore(time.Hour))(newCountingHandler(&calls, http.StatusOK))
+
+	handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("key-1", `{}`))
+
+	// After the ttl the key is processed again, even with a different body
+	fixedNow = fixedNow.Add(2 * time.Hour)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newIdempotentRequest("key-1", `{"retry":true}`))
+
+	if w.Code != http.StatusOK || w.Header().Get(IdempotencyReplayedHeader) != "" {
+		t.Errorf("Expected a fresh 200 response, got %d (replayed=%q)", w.Code, w.Header().Get(IdempotencyReplayedHeader))
+	}
+	if calls != 2 {
+		t.Errorf("Expected handler to run twice, ran %d times", calls)
+	}
+}
+
+func TestIdempotencyKey_Lifecycle(t *testing.T) {
+	fixedNow := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixedNow }
+	defer func() { now = time.Now }()
+
+	var calls int32
+	store := NewInMemoryIdempotencyStore(DefaultIdempotencyTTL)
+	handler := IdempotencyKey(store)(newCountingHandler(&calls, http.StatusOK))
+
+	key := "6f1c3a52-8e0b-4c2e-9a51-2d7f0c9b1e44"
+	body := `{"context":"savings"}`
+
+	// First request is processed and stored
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, newIdempotentRequest(key, body))
+	if calls != 1 || first.Header().Get(IdempotencyReplayedHeader) != "" {
+		t.Fatalf("Expected first request to be processed, calls=%d", calls)
+	}
+
+	// Second request within 24 hours is served from the cache
+	fixedNow = fixedNow.Add(23 * time.Hour)
+	second := httptest.NewRecorder()
+	handler.ServeHTTP(second, newIdempotentRequest(key, body))
+	if calls != 1 {
+		t.Errorf("Expected cached response, handler ran %d times", calls)
+	}
+	if second.Code != http.StatusOK || second.Header().Get(IdempotencyReplayedHeader) != "true" {
+		t.Errorf("Expected replayed 200, got %d (replay=%q)", second.Code, second.Header().Get(IdempotencyReplayedHeader))
+	}
+	if second.Body.String() != first.Body.String() {
+		t.Errorf("Expected cached body %q, got %q", first.Body.String(), second.Body.String())
+	}
+
+	// Third request after the TTL is processed normally
+	fixedNow = fixedNow.Add(2 * time.Hour)
+	third := httptest.NewRecorder()
+	handler.ServeHTTP(third, newIdempotentRequest(key, body))
+	if calls != 2 {
+		t.Errorf("Expected request to be processed again, handler ran %d times", calls)
+	}
+	if third.Header().Get(IdempotencyReplayedHeader) != "" {
+		t.Error("Expected fresh response not to be marked as replayed")
+	}
+}
+
+func TestInMemoryIdempotencyStore_SweepsExpiredEntries(t *testing.T) {
+	fixedNow := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixedNow }
+	defer func() { now = time.Now }()
+
+	store := NewInMemoryIdempotencyStore(time.Hour)
+	store.GetOrSet("old", func() ([]byte, int, error) { return []byte("a"), http.StatusOK, nil })
+
+	fixedNow = fixedNow.Add(2 * time.Hour)
+	store.GetOrSet("new", func() ([]byte, int, error) { return []byte("b"), http.StatusOK, nil })
+
+	if _, exists := store.entries.Load("old"); exists {
+		t.Error("Expected expired entry to be swept")
+	}
+	if _, exists := store.entries.Load("new"); !exists {
+		t.Error("Expected fresh entry to be kept")
+	}
+}
+
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// RealIP middleware rewrites RemoteAddr to the client address reported by a trusted
+// reverse proxy. Forwarding headers are honored only when the socket peer is inside one
+// of trustedProxyCIDRs; X-Forwarded-For is read right to left, skipping trusted hops, so
+// a client cannot prepend a forged address. Everyone else keeps their socket address,
+// which is what IPAllowlist checks. Panics on a malformed CIDR; validate
+// configuration with ParseCIDRs at startup.
+func RealIP(trustedProxyCIDRs []string) func(http.Handler) http.Handler {
+	trusted, err := ParseCIDRs(trustedProxyCIDRs)
+	if err != nil {
+		panic(err)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isIPInNetworks(clientIP(r), trusted) {
+				if ip := forwardedIP(r, trusted); ip != nil {
+					r.RemoteAddr = ip.String()
+				}
+			}
+
+			// Continue to next handler
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedIP returns the first untrusted hop of X-Forwarded-For, counting from the
+// proxy closest to the server, falling back to X-Real-IP
+func forwardedIP(r *http.Request, trusted []*net.IPNet) net.IP {
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			// A malformed hop means everything further left is unverifiable
+			break
+		}
+		if !isIPInNetworks(ip, trusted) {
+			return ip
+		}
+	}
+	return net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP")))
+}
+
+// IPAllowlist middleware restricts access to clients inside the allowed CIDR ranges
+// (e.g., "10.0.0.0/8") or matching a bare IP, returning 403 for everyone else. Register it
+// after RealIP. Panics on a malformed entry; validate configuration with ParseCIDRs at
+// startup.
+func IPAllowlist(cidrs []string) func(http.Handler) http.Handler {
+	networks, err := ParseCIDRs(cidrs)
+	if err != nil {
+		panic(err)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isIPInNetworks(clientIP(r), networks) {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+
+			// Continue to next handler
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ParseCIDRs parses CIDR ranges such as "127.0.0.0/8" or "fd00::/8"; a bare IP such as
+// "::1" is treated as a single-host range
+func ParseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if ip := net.ParseIP(cidr); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// clientIP extracts the client IP from the request remote address
+func clientIP(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		// RealIP rewrites RemoteAddr without a port
+		host = r.RemoteAddr
+	}
+	return net.ParseIP(host)
+}
+
+// isIPInNetworks checks if the IP belongs to any of the networks
+func isIPInNetworks(ip net.IP, networks []*net.IPNet) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
@@ -1,16 +1,67 @@
 package middleware
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
 	"log"
+	"net"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// Supported log formats
+const (
+	LogFormatText = "text" // Human-readable (default)
+	LogFormatJSON = "json" // Newline-delimited JSON with ECS field names
 )
 
-// responseWriter wraps http.ResponseWriter to capture status code
+// ServiceName identifies this API in structured logs
+const ServiceName = "stori-backend"
+
+// ecsLogEntry is a log line using Elastic Common Schema (ECS) field names
+// Dotted keys are accepted by Elasticsearch and Loki as flattened ECS fields
+type ecsLogEntry struct {
+	Timestamp   string `json:"@timestamp"`
+	Level       string `json:"log.level"`
+	Message     string `json:"message"`
+	ServiceName string `json:"service.name"`
+	TraceID     string `json:"trace.id,omitempty"`
+	Method      string `json:"http.request.method,omitempty"`
+	Path        string `json:"url.path,omitempty"`
+	StatusCode  int    `json:"http.response.status_code,omitempty"`
+	Duration    int64  `json:"event.duration,omitempty"` // Nanoseconds
+	BytesSent   int    `json:"http.response.body.bytes"` // Response body size; 0 for empty bodies
+}
+
+// ecsEncoder serializes log entries as newline-delimited JSON
+// json.Encoder is not safe for concurrent use, so writes are serialized
+type ecsEncoder struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+func newECSEncoder(output io.Writer) *ecsEncoder {
+	return &ecsEncoder{encoder: json.NewEncoder(output)}
+}
+
+func (e *ecsEncoder) encode(entry ecsLogEntry) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.encoder.Encode(entry)
+}
+
+// responseWriter wraps http.ResponseWriter to capture status code and body size
 type responseWriter struct {
 	http.ResponseWriter
-	statusCode int
-	written    bool
+	statusCode   int
+	written      bool
+	bytesWritten int // Body bytes accepted by the underlying writer
 }
 
 // newResponseWriter creates a new response writer wrapper
@@ -31,15 +82,53 @@ func (rw *responseWriter) WriteHeader(code int) {
 	}
 }
 
-// Write ensures WriteHeader is called
+// Write ensures WriteHeader is called and counts the bytes written
 func (rw *responseWriter) Write(b []byte) (int, error) {
 	if !rw.written {
 		rw.WriteHeader(http.StatusOK)
 	}
-	return rw.ResponseWriter.Write(b)
+	n, err := rw.ResponseWriter.Write(b)
+	rw.bytesWritten += n
+	return n, err
 }
 
-// Logger middleware logs HTTP requests with method, path, status, and duration
+// Flush sends buffered data to the client, e.g., for Server-Sent Events
+func (rw *responseWriter) Flush() {
+	if !rw.written {
+		rw.WriteHeader(http.StatusOK)
+	}
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the original writer, for http.ResponseController
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// Hijack hands the connection over, e.g., for a WebSocket upgrade
+// The upgrade is recorded as 101 Switching Protocols.
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("middleware: response writer does not support hijacking")
+	}
+
+	conn, buf, err := hijacker.Hijack()
+	if err == nil && !rw.written {
+		rw.statusCode = http.StatusSwitchingProtocols
+		rw.written = true
+	}
+	return conn, buf, err
+}
+
+// BytesWritten returns the number of response body bytes written so far
+func (rw *responseWriter) BytesWritten() int {
+	return rw.bytesWritten
+}
+
+// Logger middleware logs HTTP requests with method, path, status, duration and bytes sent
 func Logger(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -55,13 +144,88 @@ func Logger(next http.Handler) http.Handler {
 
 		// Log request details
 		log.Printf(
-			"[%s] %s %s - Status: %d - Duration: %v",
+			"[%s] %s %s - Status: %d - Duration: %v - Bytes Sent: %d",
 			r.Method,
 			r.RequestURI,
 			r.RemoteAddr,
 			wrapped.statusCode,
 			duration,
+			wrapped.BytesWritten(),
 		)
 	})
 }
 
+// RequestLogger returns a request logging middleware for the given format
+// "json" writes ECS-compatible JSON lines to output; any other value falls back to Logger
+func RequestLogger(format string, output io.Writer) func(http.Handler) http.Handler {
+	if format != LogFormatJSON {
+		return Logger
+	}
+
+	encoder := newECSEncoder(output)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			// Wrap response writer to capture status code
+			wrapped := newResponseWriter(w)
+
+			// Process request
+			next.ServeHTTP(wrapped, r)
+
+			duration := time.Since(start)
+
+			encoder.encode(ecsLogEntry{
+				Timestamp:   start.UTC().Format(time.RFC3339Nano),
+				Level:       levelForStatus(wrapped.statusCode),
+				Message:     r.Method + " " + r.URL.Path,
+				ServiceName: ServiceName,
+				TraceID:     chimiddleware.GetReqID(r.Context()),
+				Method:      r.Method,
+				Path:        r.URL.Path,
+				StatusCode:  wrapped.statusCode,
+				Duration:    duration.Nanoseconds(),
+				BytesSent:   wrapped.BytesWritten(),
+			})
+		})
+	}
+}
+
+// levelForStatus maps an HTTP status code to a log level
+func levelForStatus(statusCode int) string {
+	switch {
+	case statusCode >= 500:
+		return "error"
+	case statusCode >= 400:
+		return "warn"
+	default:
+		return "info"
+	}
+}
+
+// ECSWriter adapts the standard library logger to emit ECS JSON lines
+// Use with log.SetFlags(0) so each message is written without a text prefix
+type ECSWriter struct {
+	encoder *ecsEncoder
+}
+
+// NewECSWriter creates a writer that wraps each log message in an ECS JSON entry
+func NewECSWriter(output io.Writer) *ECSWriter {
+	return &ECSWriter{encoder: newECSEncoder(output)}
+}
+
+// Write implements io.Writer for use with log.SetOutput
+func (w *ECSWriter) Write(p []byte) (int, error) {
+	err := w.encoder.encode(ecsLogEntry{
+		Timestamp:   time.Now().UTC().Format(time.RFC3339Nano),
+		Level:       "info",
+		Message:     strings.TrimRight(string(p), "\n"),
+		ServiceName: ServiceName,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Error sources for the http_5xx_total counter
+const (
+	ErrorSourceAnalytics = "analytics"
+	ErrorSourceAI        = "ai"
+	ErrorSourceUnknown   = "unknown"
+)
+
+// errorSourceKey is the context key holding the request's *errorSource
+type errorSourceKey struct{}
+
+// errorSource is shared between the metrics middleware and the handler
+// The handler runs with a derived context, so the middleware reads the value through this pointer
+type errorSource struct {
+	value string
+}
+
+// SetErrorSource records which component caused a server error (e.g., ErrorSourceAI)
+// When the request passed through Metrics.Middleware the value is visible to it after
+// the handler returns; the returned context also carries the source for downstream code.
+func SetErrorSource(ctx context.Context, source string) context.Context {
+	if holder, ok := ctx.Value(errorSourceKey{}).(*errorSource); ok {
+		holder.value = source
+		return ctx
+	}
+	return context.WithValue(ctx, errorSourceKey{}, &errorSource{value: source})
+}
+
+// Metrics holds the Prometheus collectors for HTTP traffic and the goroutine count
+type Metrics struct {
+	registry      *prometheus.Registry
+	requestsTotal *prometheus.CounterVec
+	errorsTotal   *prometheus.CounterVec
+	serverErrors  *prometheus.CounterVec
+	goroutines    prometheus.Gauge
+}
+
+// NewMetrics creates the collectors and registers them on a new registry
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests by route, method and status code.",
+		}, []string{"route", "method", "status"}),
+		errorsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_errors_total",
+			Help: "HTTP error responses by route and status class (4xx or 5xx).",
+		}, []string{"route", "status_class"}),
+		serverErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_5xx_total",
+			Help: "HTTP 5xx responses by route and the component that failed.",
+		}, []string{"route", "error_source"}),
+		goroutines: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "goroutine_count",
+			Help: "Goroutines alive at the last sample; steady growth points to a leak.",
+		}),
+	}
+
+	m.registry.MustRegister(m.requestsTotal, m.errorsTotal, m.serverErrors, m.goroutines)
+	return m
+}
+
+// Registry exposes the registry so other collectors can be added
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// SampleGoroutines sets the goroutine_count gauge to the current number of goroutines
+func (m *Metrics) SampleGoroutines() {
+	m.goroutines.Set(float64(runtime.NumGoroutine()))
+}
+
+// Handler serves the metrics in the Prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Middleware counts every request, labelling it with the chi route pattern
+// to keep label cardinality bounded (e.g., "/api/summary/{kind}" rather than raw paths)
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		source := &errorSource{}
+		r = r.WithContext(context.WithValue(r.Context(), errorSourceKey{}, source))
+
+		// Wrap response writer to capture status code
+		wrapped := newResponseWriter(w)
+
+		// Process request
+		next.ServeHTTP(wrapped, r)
+
+		route := routePattern(r)
+		status := wrapped.statusCode
+		m.requestsTotal.WithLabelValues(route, r.Method, strconv.Itoa(status)).Inc()
+
+		switch {
+		case status >= 500:
+			m.errorsTotal.WithLabelValues(route, "5xx").Inc()
+
+			errSource := source.value
+			if errSource == "" {
+				errSource = ErrorSourceUnknown
+			}
+			m.serverErrors.WithLabelValues(route, errSource).Inc()
+
+		case status >= 400:
+			m.errorsTotal.WithLabelValues(route, "4xx").Inc()
+		}
+	})
+}
+
+// routePattern returns the matched chi route, or "unmatched" for unknown paths
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+	return "unmatched"
+}
+
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newMetricsRouter(m *Metrics) http.Handler {
+	r := chi.NewRouter()
+	r.Use(m.Middleware)
+
+	r.Get("/api/advice", func(w http.ResponseWriter, r *http.Request) {
+		SetErrorSource(r.Context(), ErrorSourceAI)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	r.Get("/api/summary/categories", func(w http.ResponseWriter, r *http.Request) {
+		SetErrorSource(r.Context(), ErrorSourceAnalytics)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	r.Get("/api/forecast/savings-growth", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	r.Get("/api/transactions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	return r
+}
+
+func TestMetrics_ErrorSourceCounters(t *testing.T) {
+	m := NewMetrics()
+	router := newMetricsRouter(m)
+
+	for _, path := range []string{"/api/advice", "/api/advice", "/api/summary/categories", "/api/forecast/savings-growth", "/api/transactions"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	// One series per route and error source that actually failed
+	if got := testutil.CollectAndCount(m.serverErrors); got != 3 {
+		t.Errorf("Expected 3 http_5xx_total series, got %d", got)
+	}
+
+	tests := []struct {
+		route    string
+		source   string
+		expected float64
+	}{
+		{"/api/advice", ErrorSourceAI, 2},
+		{"/api/advice", ErrorSourceAnalytics, 0},
+		{"/api/summary/categories", ErrorSourceAnalytics, 1},
+		{"/api/forecast/savings-growth", ErrorSourceUnknown, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.route+"/"+tt.source, func(t *testing.T) {
+			got := testutil.ToFloat64(m.serverErrors.WithLabelValues(tt.route, tt.source))
+			if got != tt.expected {
+				t.Errorf("http_5xx_total{route=%q,error_source=%q} = %v, want %v", tt.route, tt.source, got, tt.expected)
+			}
+		})
+	}
+
+	if got := testutil.ToFloat64(m.errorsTotal.WithLabelValues("/api/advice", "5xx")); got != 2 {
+		t.Errorf("Expected 2 5xx errors for /api/advice, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.errorsTotal.WithLabelValues("/api/transactions", "4xx")); got != 1 {
+		t.Errorf("Expected 1 4xx error for /api/transactions, got %v", got)
+	}
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	m := NewMetrics()
+	router := newMetricsRouter(m)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/transactions", nil))
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := w.Body.String()
+	expected := `http_requests_total{method="GET",route="/api/transactions",status="400"} 1`
+	if !strings.Contains(body, expected) {
+		t.Errorf("Expected metrics output to contain %q, got:\n%s", expected, body)
+	}
+}
+
+func TestMetrics_SampleGoroutines(t *testing.T) {
+	m := NewMetrics()
+	m.SampleGoroutines()
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := w.Body.String()
+	if !strings.Contains(body, "\ngoroutine_count ") || strings.Contains(body, "\ngoroutine_count 0\n") {
+		t.Errorf("Expected a non-zero goroutine_count gauge, got:\n%s", body)
+	}
+}
+
@@ -1,14 +1,23 @@
 package middleware
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
 	"testing"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
 )
 
 func TestCORS(t *testing.T) {
 	allowedOrigins := []string{"http://localhost:5173", "http://localhost:3000"}
-	middleware := CORS(allowedOrigins)
+	middleware := CORS(allowedOrigins, nil)
 
 	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -30,8 +39,8 @@ func TestCORS(t *testing.T) {
 			method:        "GET",
 			expectOrigin:  "http://localhost:5173",
 			expectStatus:  http.StatusOK,
-			expectMethods: "GET, POST, PUT, DELETE, OPTIONS",
-			expectHeaders: "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization",
+			expectMethods: "GET, POST, PATCH, OPTIONS",
+			expectHeaders: "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization, X-Preferred-Currency, X-Locale, X-Fiscal-Year-Start, Idempotency-Key",
 		},
 		{
 			name:          "allowed origin - localhost:3000",
@@ -39,8 +48,8 @@ func TestCORS(t *testing.T) {
 			method:        "GET",
 			expectOrigin:  "http://localhost:3000",
 			expectStatus:  http.StatusOK,
-			expectMethods: "GET, POST, PUT, DELETE, OPTIONS",
-			expectHeaders: "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization",
+			expectMethods: "GET, POST, PATCH, OPTIONS",
+			expectHeaders: "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization, X-Preferred-Currency, X-Locale, X-Fiscal-Year-Start, Idempotency-Key",
 		},
 		{
 			name:          "disallowed origin",
@@ -48,8 +57,8 @@ func TestCORS(t *testing.T) {
 			method:        "GET",
 			expectOrigin:  "",
 			expectStatus:  http.StatusOK,
-			expectMethods: "GET, POST, PUT, DELETE, OPTIONS",
-			expectHeaders: "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization",
+			expectMethods: "GET, POST, PATCH, OPTIONS",
+			expectHeaders: "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization, X-Preferred-Currency, X-Locale, X-Fiscal-Year-Start, Idempotency-Key",
 		},
 		{
 			name:          "OPTIONS preflight request",
@@ -57,8 +66,8 @@ func TestCORS(t *testing.T) {
 			method:        "OPTIONS",
 			expectOrigin:  "http://localhost:5173",
 			expectStatus:  http.StatusOK,
-			expectMethods: "GET, POST, PUT, DELETE, OPTIONS",
-			expectHeaders: "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization",
+			expectMethods: "GET, POST, PATCH, OPTIONS",
+			expectHeaders: "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization, X-Preferred-Currency, X-Locale, X-Fiscal-Year-Start, Idempotency-Key",
 		},
 	}
 
@@ -106,7 +115,7 @@ func TestCORS(t *testing.T) {
 
 func TestCORS_Wildcard(t *testing.T) {
 	allowedOrigins := []string{"*"}
-	middleware := CORS(allowedOrigins)
+	middleware := CORS(allowedOrigins, nil)
 
 	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -126,7 +135,7 @@ func TestCORS_Wildcard(t *testing.T) {
 
 func TestCORS_EmptyAllowedOrigins(t *testing.T) {
 	allowedOrigins := []string{}
-	middleware := CORS(allowedOrigins)
+	middleware := CORS(allowedOrigins, nil)
 
 	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -144,6 +153,63 @@ func TestCORS_EmptyAllowedOrigins(t *testing.T) {
 	}
 }
 
+func TestCORS_PatchPreflight(t *testing.T) {
+	var called bool
+	handler := CORS([]string{"http://localhost:5173"}, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	req := httptest.NewRequest("OPTIONS", "/api/transactions/tx-1", nil)
+	req.Header.Set("Origin", "http://localhost:5173")
+	req.Header.Set("Access-Control-Request-Method", "PATCH")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || called {
+		t.Errorf("Expected the preflight to be answered by CORS with 200, got %d (handler called=%v)", w.Code, called)
+	}
+	methods := strings.Split(w.Header().Get("Access-Control-Allow-Methods"), ", ")
+	if !slices.Contains(methods, "PATCH") {
+		t.Errorf("Expected PATCH to be allowed, got %v", methods)
+	}
+	if slices.Contains(methods, "PUT") || slices.Contains(methods, "DELETE") {
+		t.Errorf("Expected no methods without routes, got %v", methods)
+	}
+}
+
+func TestCORS_ExposeHeaders(t *testing.T) {
+	middleware := CORS([]string{"https://*.example.com"}, []string{"X-Request-ID", "X-RateLimit-Remaining"})
+
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "41")
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Origin", "https://staging.example.com")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://staging.example.com" {
+		t.Errorf("Expected subdomain origin to be allowed, got '%s'", got)
+	}
+	if got := w.Header().Get("Access-Control-Expose-Headers"); got != "X-Request-ID, X-RateLimit-Remaining" {
+		t.Errorf("Expected exposed headers, got '%s'", got)
+	}
+	if got := w.Header().Get("X-RateLimit-Remaining"); got != "41" {
+		t.Errorf("Expected handler header to pass through, got '%s'", got)
+	}
+
+	// Without configured headers, nothing is exposed
+	w = httptest.NewRecorder()
+	CORS([]string{"*"}, nil)(http.NotFoundHandler()).ServeHTTP(w, req)
+	if got := w.Header().Get("Access-Control-Expose-Headers"); got != "" {
+		t.Errorf("Expected no exposed headers, got '%s'", got)
+	}
+}
+
 func TestIsOriginAllowed(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -181,11 +247,83 @@ func TestIsOriginAllowed(t *testing.T) {
 			allowedOrigins: []string{"http://localhost:5173/"},
 			expected:       true,
 		},
+		{
+			name:           "wildcard subdomain matches",
+			origin:         "https://tenant1.app.com",
+			allowedOrigins: []string{"*.app.com"},
+			expected:       true,
+		},
+		{
+			name:           "wildcard subdomain does not match base domain",
+			origin:         "https://app.com",
+			allowedOrigins: []string{"*.app.com"},
+			expected:       false,
+		},
+		{
+			name:           "wildcard subdomain does not match unrelated domain",
+			origin:         "https://evil.notapp.com",
+			allowedOrigins: []string{"*.app.com"},
+			expected:       false,
+		},
+		{
+			name:           "wildcard subdomain matches nested subdomain",
+			origin:         "https://a.b.app.com",
+			allowedOrigins: []string{"*.app.com"},
+			expected:       true,
+		},
+		{
+			name:           "wildcard subdomain ignores port",
+			origin:         "http://tenant1.app.com:5173",
+			allowedOrigins: []string{"*.app.com"},
+			expected:       true,
+		},
+		{
+			name:           "scheme wildcard matches preview deployment",
+			origin:         "https://pr-42.preview.example.com",
+			allowedOrigins: []string{"https://*.example.com"},
+			expected:       true,
+		},
+		{
+			name:           "scheme wildcard rejects other scheme",
+			origin:         "http://pr-42.example.com",
+			allowedOrigins: []string{"https://*.example.com"},
+			expected:       false,
+		},
+		{
+			name:           "scheme wildcard rejects base domain",
+			origin:         "https://example.com",
+			allowedOrigins: []string{"https://*.example.com"},
+			expected:       false,
+		},
+		{
+			name:           "scheme wildcard rejects lookalike domain",
+			origin:         "https://staging.evilexample.com",
+			allowedOrigins: []string{"https://*.example.com"},
+			expected:       false,
+		},
+		{
+			name:           "scheme wildcard rejects suffix attack",
+			origin:         "https://staging.example.com.evil.com",
+			allowedOrigins: []string{"https://*.example.com"},
+			expected:       false,
+		},
+		{
+			name:           "scheme wildcard requires matching port",
+			origin:         "https://staging.example.com:8443",
+			allowedOrigins: []string{"https://*.example.com"},
+			expected:       false,
+		},
+		{
+			name:           "scheme wildcard with port",
+			origin:         "https://staging.example.com:8443",
+			allowedOrigins: []string{"https://*.example.com:8443"},
+			expected:       true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := isOriginAllowed(tt.origin, tt.allowedOrigins)
+			result := IsOriginAllowed(tt.origin, tt.allowedOrigins)
 			if result != tt.expected {
 				t.Errorf("Expected %v, got %v", tt.expected, result)
 			}
@@ -276,11 +414,75 @@ func TestRecovery(t *testing.T) {
 	}
 
 	body := w.Body.String()
-	if body != "Internal Server Error\n" {
+	if body != "{\"error\":\"Internal Server Error\"}\n" {
 		t.Errorf("Expected 'Internal Server Error' message, got '%s'", body)
 	}
 }
 
+func TestRecovery_DebugAndProductionBodies(t *testing.T) {
+	var handled interface{}
+	panicking := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("nil map write")
+	})
+
+	tests := []struct {
+		name      string
+		debug     bool
+		wantPanic bool
+	}{
+		{"production", false, false},
+		{"debug", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled = nil
+			recovery := NewRecovery(RecoveryOptions{
+				Debug: tt.debug,
+				PanicHandler: func(v interface{}, stack []byte, r *http.Request) {
+					handled = v
+				},
+			})
+			handler := chimiddleware.RequestID(recovery(panicking))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest("GET", "/test", nil))
+
+			if w.Code != http.StatusInternalServerError {
+				t.Errorf("Expected status 500, got %d", w.Code)
+			}
+			if handled != "nil map write" {
+				t.Errorf("Expected PanicHandler to receive the panic value, got %v", handled)
+			}
+
+			var body map[string]interface{}
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+
+			if body["error"] != "Internal Server Error" || body["request_id"] == "" || body["request_id"] == nil {
+				t.Errorf("Expected error and request_id in body, got %v", body)
+			}
+
+			_, hasPanic := body["panic"]
+			_, hasStack := body["stack"]
+			if hasPanic != tt.wantPanic || hasStack != tt.wantPanic {
+				t.Errorf("Expected panic details = %v, got body %v", tt.wantPanic, body)
+			}
+
+			if tt.debug {
+				stack := body["stack"].([]interface{})
+				if len(stack) == 0 || !strings.Contains(stack[0].(string), "middleware_test.go") {
+					t.Errorf("Expected stack to start at the panicking handler, got %v", stack)
+				}
+				if strings.Contains(fmt.Sprint(stack), "+0x") {
+					t.Errorf("Expected program counter offsets to be stripped, got %v", stack)
+				}
+			}
+		})
+	}
+}
+
 func TestRecovery_NoPanic(t *testing.T) {
 	handler := Recovery(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -356,3 +558,321 @@ func TestResponseWriter_Write(t *testing.T) {
 	}
 }
 
+func TestResponseWriter_BytesWritten(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []string
+	}{
+		{"empty body", nil},
+		{"single write", []string{`{"status":"ok"}`}},
+		{"multiple writes", []string{"id,amount\n", "1,42.50\n", "2,13.00\n"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			rw := newResponseWriter(w)
+
+			for _, chunk := range tt.chunks {
+				rw.Write([]byte(chunk))
+			}
+
+			if rw.BytesWritten() != w.Body.Len() {
+				t.Errorf("Expected %d bytes written, got %d", w.Body.Len(), rw.BytesWritten())
+			}
+		})
+	}
+}
+
+func TestRequestLogger_BytesSent(t *testing.T) {
+	body := []byte(`{"transactions":[],"count":0}`)
+
+	var buf bytes.Buffer
+	handler := RequestLogger(LogFormatJSON, &buf)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/transactions", nil))
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Log line is not valid JSON: %v", err)
+	}
+	if entry["http.response.body.bytes"] != float64(len(body)) {
+		t.Errorf("Expected http.response.body.bytes %d, got %v", len(body), entry["http.response.body.bytes"])
+	}
+
+	// The text format reports the same count
+	var text bytes.Buffer
+	log.SetOutput(&text)
+	defer log.SetOutput(os.Stderr)
+
+	Logger(handler).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/transactions", nil))
+	if want := fmt.Sprintf("Bytes Sent: %d", len(body)); !strings.Contains(text.String(), want) {
+		t.Errorf("Expected text log to contain %q, got %q", want, text.String())
+	}
+}
+
+func TestIPAllowlist_BareIPs(t *testing.T) {
+	handler := IPAllowlist([]string{"127.0.0.1", "::1"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		expectStatus int
+	}{
+		{"ipv4 loopback", "127.0.0.1:12345", http.StatusOK},
+		{"ipv6 loopback", "[::1]:12345", http.StatusOK},
+		{"address without port", "127.0.0.1", http.StatusOK},
+		{"neighbouring loopback address", "127.0.0.2:12345", http.StatusForbidden},
+		{"external address", "203.0.113.7:12345", http.StatusForbidden},
+		{"malformed address", "not-an-ip", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/debug/pprof/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestIPAllowlist(t *testing.T) {
+	handler := IPAllowlist([]string{"127.0.0.0/8", "10.0.0.0/8", "fd00::/8"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		expectStatus int
+	}{
+		{"loopback", "127.0.0.1:12345", http.StatusOK},
+		{"other loopback address", "127.10.0.1:12345", http.StatusOK},
+		{"private network", "10.1.2.3", http.StatusOK},
+		{"ipv6 unique local", "[fd12::1]:443", http.StatusOK},
+		{"public address", "8.8.8.8:53", http.StatusForbidden},
+		{"ipv6 loopback not listed", "[::1]:12345", http.StatusForbidden},
+		{"malformed address", "not-an-ip", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/admin", nil)
+			req.RemoteAddr = tt.remoteAddr
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestRealIP(t *testing.T) {
+	handler := RealIP([]string{"10.0.0.0/8"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.RemoteAddr))
+	}))
+
+	tests := []struct {
+		name          string
+		remoteAddr    string
+		forwardedFor  string
+		realIP        string
+		expectAddress string
+	}{
+		{"direct client keeps socket address", "203.0.113.7:12345", "", "", "203.0.113.7:12345"},
+		{"untrusted peer cannot forge X-Forwarded-For", "203.0.113.7:12345", "127.0.0.1", "", "203.0.113.7:12345"},
+		{"untrusted peer cannot forge X-Real-IP", "203.0.113.7:12345", "", "127.0.0.1", "203.0.113.7:12345"},
+		{"trusted proxy", "10.0.0.2:12345", "198.51.100.4", "", "198.51.100.4"},
+		{"trusted proxy chain", "10.0.0.2:12345", "198.51.100.4, 10.0.0.3", "", "198.51.100.4"},
+		{"client-supplied hop is skipped", "10.0.0.2:12345", "127.0.0.1, 198.51.100.4", "", "198.51.100.4"},
+		{"trusted proxy X-Real-IP", "10.0.0.2:12345", "", "198.51.100.4", "198.51.100.4"},
+		{"trusted proxy without headers", "10.0.0.2:12345", "", "", "10.0.0.2:12345"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			if tt.realIP != "" {
+				req.Header.Set("X-Real-IP", tt.realIP)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Body.String() != tt.expectAddress {
+				t.Errorf("RemoteAddr = %q, want %q", w.Body.String(), tt.expectAddress)
+			}
+		})
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	handler := BearerToken("0123456789abcdef")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name          string
+		authorization string
+		expectStatus  int
+	}{
+		{"matching token", "Bearer 0123456789abcdef", http.StatusOK},
+		{"wrong token", "Bearer fedcba9876543210", http.StatusUnauthorized},
+		{"token prefix", "Bearer 0123456789", http.StatusUnauthorized},
+		{"basic scheme", "Basic 0123456789abcdef", http.StatusUnauthorized},
+		{"missing header", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/debug/goroutines", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectStatus, w.Code)
+			}
+			if tt.expectStatus == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") != "Bearer" {
+				t.Error("Expected a WWW-Authenticate challenge")
+			}
+		})
+	}
+}
+
+func TestParseCIDRs(t *testing.T) {
+	if _, err := ParseCIDRs([]string{"127.0.0.0/8", " ::1/128 "}); err != nil {
+		t.Errorf("ParseCIDRs() error = %v", err)
+	}
+
+	// Bare IPs become single-host ranges
+	networks, err := ParseCIDRs([]string{"127.0.0.1", "::1"})
+	if err != nil {
+		t.Fatalf("ParseCIDRs() error = %v", err)
+	}
+	if got := networks[0].String() + " " + networks[1].String(); got != "127.0.0.1/32 ::1/128" {
+		t.Errorf("ParseCIDRs() = %s, want 127.0.0.1/32 ::1/128", got)
+	}
+
+	for _, cidr := range []string{"10.0.0.1/", "10.0.0.0/33", "not-a-cidr"} {
+		if _, err := ParseCIDRs([]string{cidr}); err == nil {
+			t.Errorf("Expected error for %q", cidr)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected IPAllowlist to panic on a malformed CIDR")
+		}
+	}()
+	IPAllowlist([]string{"10.0.0.0/33"})
+}
+
+func TestRequestLogger_JSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := RequestLogger(LogFormatJSON, &buf)
+
+	handler := chimiddleware.RequestID(logger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	for _, path := range []string{"/api/health", "/missing"} {
+		req := httptest.NewRequest("GET", path, nil)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log lines, got %d: %q", len(lines), buf.String())
+	}
+
+	required := []string{
+		"@timestamp", "log.level", "message", "service.name", "trace.id",
+		"http.request.method", "url.path", "http.response.status_code", "event.duration",
+	}
+
+	for i, line := range lines {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Line %d is not valid JSON: %v", i, err)
+		}
+
+		for _, field := range required {
+			if _, exists := entry[field]; !exists {
+				t.Errorf("Line %d missing field '%s'", i, field)
+			}
+		}
+
+		if entry["service.name"] != ServiceName {
+			t.Errorf("Expected service.name %q, got %v", ServiceName, entry["service.name"])
+		}
+	}
+
+	var notFound map[string]interface{}
+	json.Unmarshal([]byte(lines[1]), &notFound)
+	if notFound["log.level"] != "warn" {
+		t.Errorf("Expected log.level 'warn' for 404, got %v", notFound["log.level"])
+	}
+	if notFound["http.response.status_code"] != float64(http.StatusNotFound) {
+		t.Errorf("Expected status code 404, got %v", notFound["http.response.status_code"])
+	}
+}
+
+func TestRequestLogger_TextFormatFallsBack(t *testing.T) {
+	var buf bytes.Buffer
+	logger := RequestLogger(LogFormatText, &buf)
+
+	handler := logger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/test", nil))
+
+	// Text mode uses the standard logger, not the JSON output
+	if buf.Len() != 0 {
+		t.Errorf("Expected no JSON output in text mode, got %q", buf.String())
+	}
+}
+
+func TestECSWriter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(NewECSWriter(&buf), "", 0)
+
+	logger.Println("server started")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+
+	if entry["message"] != "server started" {
+		t.Errorf("Expected message 'server started', got %v", entry["message"])
+	}
+
+	if entry["log.level"] != "info" {
+		t.Errorf("Expected log.level 'info', got %v", entry["log.level"])
+	}
+}
+
@@ -1,27 +1,116 @@
 package middleware
 
 import (
+	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"path/filepath"
 	"runtime/debug"
+	"strings"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
 )
 
+// PanicHandler receives every recovered panic, e.g., to forward it to an error tracker
+type PanicHandler func(v interface{}, stack []byte, r *http.Request)
+
+// RecoveryOptions configures NewRecovery
+type RecoveryOptions struct {
+	// Debug includes the panic value and a sanitized stack trace in the response body
+	// Never enable in production: it exposes internals to clients
+	Debug bool
+
+	// PanicHandler is called after the panic is logged (optional)
+	PanicHandler PanicHandler
+}
+
+// panicResponse is the JSON body returned after a panic
+type panicResponse struct {
+	Error     string   `json:"error"`
+	RequestID string   `json:"request_id,omitempty"`
+	Panic     string   `json:"panic,omitempty"` // Debug mode only
+	Stack     []string `json:"stack,omitempty"` // Debug mode only
+}
+
 // Recovery middleware recovers from panics and logs the error
 // Prevents the server from crashing on unexpected errors
 func Recovery(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		defer func() {
-			if err := recover(); err != nil {
-				// Log the panic with stack trace
-				log.Printf("PANIC: %v\n%s", err, debug.Stack())
-
-				// Return 500 Internal Server Error
-				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			}
-		}()
-
-		// Continue to next handler
-		next.ServeHTTP(w, r)
-	})
+	return NewRecovery(RecoveryOptions{})(next)
+}
+
+// NewRecovery returns a Recovery middleware with the given options
+// Full details (panic value, request ID, route and stack) are always logged; clients only
+// see the request ID unless Debug is set. Place after chi's RequestID middleware so the
+// request ID is available.
+func NewRecovery(opts RecoveryOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if v := recover(); v != nil {
+					stack := debug.Stack()
+					requestID := chimiddleware.GetReqID(r.Context())
+
+					// Log the panic with request context and stack trace
+					log.Printf("PANIC: %v (request_id=%s route=%s %s)\n%s", v, requestID, r.Method, routePattern(r), stack)
+
+					if opts.PanicHandler != nil {
+						opts.PanicHandler(v, stack, r)
+					}
+
+					response := panicResponse{
+						Error:     http.StatusText(http.StatusInternalServerError),
+						RequestID: requestID,
+					}
+					if opts.Debug {
+						response.Panic = fmt.Sprint(v)
+						response.Stack = sanitizeStack(stack)
+					}
+
+					// Return 500 Internal Server Error
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusInternalServerError)
+					json.NewEncoder(w).Encode(response)
+				}
+			}()
+
+			// Continue to next handler
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// sanitizeStack turns a debug.Stack dump into "function (file.go:line)" frames
+// Frames before the panic (the recovery machinery itself) are dropped, directories are
+// trimmed to the last package element and program counter offsets are removed.
+func sanitizeStack(stack []byte) []string {
+	lines := strings.Split(strings.TrimSpace(string(stack)), "\n")
+
+	// Skip the goroutine header and everything up to and including the runtime panic frame
+	start := 1
+	for i := 1; i+1 < len(lines); i += 2 {
+		if strings.HasPrefix(lines[i], "panic(") {
+			start = i + 2
+			break
+		}
+	}
+
+	frames := []string{}
+	for i := start; i+1 < len(lines); i += 2 {
+		function := strings.TrimSpace(lines[i])
+		if paren := strings.LastIndex(function, "("); paren > 0 {
+			function = function[:paren]
+		}
+
+		location := strings.TrimSpace(lines[i+1])
+		if offset := strings.Index(location, " +0x"); offset >= 0 {
+			location = location[:offset]
+		}
+		location = filepath.Join(filepath.Base(filepath.Dir(location)), filepath.Base(location))
+
+		frames = append(frames, function+" ("+location+")")
+	}
+
+	return frames
 }
 
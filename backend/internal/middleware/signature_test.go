@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "test-secret"
+
+func newSignedRequest(body, signedBody string, signedAt time.Time) *http.Request {
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+
+	req := httptest.NewRequest("POST", "/api/transactions", strings.NewReader(body))
+	req.Header.Set("X-Stori-Signature", ComputeSignature(testSecret, timestamp, []byte(signedBody)))
+	req.Header.Set(SignatureTimestampHeader, timestamp)
+	return req
+}
+
+func TestHMACSignatureVerifier(t *testing.T) {
+	fixedNow := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixedNow }
+	defer func() { now = time.Now }()
+
+	var receivedBody string
+	handler := HMACSignatureVerifier(testSecret, "X-Stori-Signature")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receivedBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	body := `{"amount": -50, "category": "dining"}`
+	oversized := strings.Repeat(" ", MaxSignedBodySize) + body
+
+	tests := []struct {
+		name         string
+		req          *http.Request
+		expectStatus int
+	}{
+		{
+			name:         "correct signature",
+			req:          newSignedRequest(body, body, fixedNow.Add(-time.Minute)),
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "tampered body",
+			req:          newSignedRequest(`{"amount": -5000, "category": "dining"}`, body, fixedNow),
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:         "stale timestamp",
+			req:          newSignedRequest(body, body, fixedNow.Add(-6*time.Minute)),
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name: "missing signature header",
+			req: func() *http.Request {
+				req := newSignedRequest(body, body, fixedNow)
+				req.Header.Del("X-Stori-Signature")
+				return req
+			}(),
+			expectStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "missing timestamp header",
+			req: func() *http.Request {
+				req := newSignedRequest(body, body, fixedNow)
+				req.Header.Del(SignatureTimestampHeader)
+				return req
+			}(),
+			expectStatus: http.StatusUnauthorized,
+		},
+		{
+			name:         "oversized body",
+			req:          newSignedRequest(oversized, oversized, fixedNow),
+			expectStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receivedBody = ""
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, tt.req)
+
+			if w.Code != tt.expectStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectStatus, w.Code)
+			}
+
+			// The downstream handler must still be able to read the body
+			if tt.expectStatus == http.StatusOK && receivedBody != body {
+				t.Errorf("Expected downstream body %q, got %q", body, receivedBody)
+			}
+		})
+	}
+}
+
+func TestWebhookVerifier(t *testing.T) {
+	var receivedBody string
+	handler := WebhookVerifier(testSecret, "Plaid-Verification")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receivedBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	body := `{"webhook_type": "TRANSACTIONS", "added": [{"amount": 72.1}]}`
+	signature := ComputeWebhookSignature(testSecret, []byte(body))
+	oversized := strings.Repeat(" ", MaxSignedBodySize) + body
+
+	tests := []struct {
+		name           string
+		body           string
+		signature      string
+		expectedStatus int
+	}{
+		{"valid signature", body, signature, http.StatusOK},
+		{"upper-case hex", body, strings.ToUpper(signature), http.StatusOK},
+		{"one byte changed", strings.Replace(body, "72.1", "72.2", 1), signature, http.StatusForbidden},
+		{"wrong secret", body, ComputeWebhookSignature("other-secret", []byte(body)), http.StatusForbidden},
+		{"missing header", body, "", http.StatusUnauthorized},
+		{"oversized body", oversized, ComputeWebhookSignature(testSecret, []byte(oversized)), http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receivedBody = ""
+			req := httptest.NewRequest(http.MethodPost, "/api/webhooks/transaction", strings.NewReader(tt.body))
+			if tt.signature != "" {
+				req.Header.Set("Plaid-Verification", tt.signature)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			// The downstream handler still sees the full body
+			if tt.expectedStatus == http.StatusOK && receivedBody != tt.body {
+				t.Errorf("Downstream handler received %q, want %q", receivedBody, tt.body)
+			}
+			if tt.expectedStatus != http.StatusOK && receivedBody != "" {
+				t.Error("Downstream handler ran for a rejected request")
+			}
+		})
+	}
+}
+
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// Reasons reported for a duplicate group, by the fields its transactions share
+const (
+	duplicateReasonCategory    = "same date, amount and category"
+	duplicateReasonDescription = "same date, amount and description"
+	duplicateReasonBoth        = "same date, amount, category and description"
+)
+
+// findDuplicates groups transactions sharing a date and absolute amount (to the cent) and
+// either their category or their trimmed, lowercased description
+// Transactions matching on both are reported once; a transaction can still appear in two
+// groups when its category matches some transactions and its description others.
+func findDuplicates(transactions []domain.Transaction) []domain.DuplicateGroup {
+	byCategory := groupIndexes(transactions, func(tx domain.Transaction) string {
+		return tx.Category
+	})
+	byDescription := groupIndexes(transactions, func(tx domain.Transaction) string {
+		return strings.ToLower(strings.TrimSpace(tx.Description))
+	})
+
+	type group struct {
+		members []int
+		reason  string
+	}
+	var groups []group
+
+	descriptionGroups := make(map[string]bool, len(byDescription))
+	for _, members := range byDescription {
+		descriptionGroups[fmt.Sprint(members)] = true
+	}
+	for _, members := range byCategory {
+		reason := duplicateReasonCategory
+		if key := fmt.Sprint(members); descriptionGroups[key] {
+			reason = duplicateReasonBoth
+			delete(descriptionGroups, key)
+		}
+		groups = append(groups, group{members: members, reason: reason})
+	}
+	for _, members := range byDescription {
+		if descriptionGroups[fmt.Sprint(members)] {
+			groups = append(groups, group{members: members, reason: duplicateReasonDescription})
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].members[0] < groups[j].members[0]
+	})
+
+	result := make([]domain.DuplicateGroup, 0, len(groups))
+	for _, g := range groups {
+		duplicate := domain.DuplicateGroup{Reason: g.reason}
+		for _, i := range g.members {
+			duplicate.Transactions = append(duplicate.Transactions, transactions[i])
+		}
+		result = append(result, duplicate)
+	}
+	return result
+}
+
+// groupIndexes returns the indexes of transactions sharing a date, absolute amount and
+// field, for every such set of two or more, in order of first appearance
+func groupIndexes(transactions []domain.Transaction, field func(domain.Transaction) string) [][]int {
+	var keys []string
+	indexes := make(map[string][]int)
+	for i, tx := range transactions {
+		key := tx.Date + "|" + strconv.FormatFloat(math.Abs(tx.Amount), 'f', 2, 64) + "|" + field(tx)
+		if _, ok := indexes[key]; !ok {
+			keys = append(keys, key)
+		}
+		indexes[key] = append(indexes[key], i)
+	}
+
+	var groups [][]int
+	for _, key := range keys {
+		if len(indexes[key]) > 1 {
+			groups = append(groups, indexes[key])
+		}
+	}
+	return groups
+}
+
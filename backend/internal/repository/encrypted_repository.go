@@ -0,0 +1,428 @@
+package repository
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// encryptedPrefix marks a field value as AES-GCM ciphertext ("enc:" + base64(nonce || sealed))
+const encryptedPrefix = "enc:"
+
+// sealedFields is the plaintext EncryptedRepository seals into a stored record's Description
+type sealedFields struct {
+	Amount      float64 `json:"amount"`
+	Description string  `json:"description"`
+}
+
+// ErrMissingEncryptionKey is returned when an encrypted repository is created without a key
+var ErrMissingEncryptionKey = errors.New("encryption key is required")
+
+// rewriter is implemented by repositories that can modify stored transactions in place
+// EncryptedRepository uses it to re-encrypt records still sealed with the old key
+type rewriter interface {
+	Rewrite(fn func(tx *domain.Transaction))
+}
+
+// EncryptedRepository wraps a TransactionRepository and encrypts Amount and Description
+// with AES-256-GCM on write, decrypting them again on read
+// The inner repository stores both fields sealed together in Description, with Amount zeroed.
+// Plaintext records, such as the embedded seed data, are returned unchanged.
+type EncryptedRepository struct {
+	inner     TransactionRepository
+	aead      cipher.AEAD
+	oldKey    cipher.AEAD // Optional; only used for decryption during key rotation
+	enrichers []Enricher  // Applied to plaintext before transactions are sealed
+}
+
+// ParseEncryptionKey decodes a base64-encoded 32-byte AES-256 key
+func ParseEncryptionKey(encoded string) ([]byte, error) {
+	if encoded == "" {
+		return nil, ErrMissingEncryptionKey
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("encryption key must be base64: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("encryption key must be 32 bytes, got %d", len(key))
+	}
+
+	return key, nil
+}
+
+// NewEncryptedRepository wraps inner, encrypting with key
+// oldKey may be nil; when set, values that fail to decrypt with key are decrypted with
+// oldKey and re-encrypted with key (if inner supports in-place rewrites).
+// Returns ErrMissingEncryptionKey if key is empty.
+func NewEncryptedRepository(inner TransactionRepository, key, oldKey []byte) (*EncryptedRepository, error) {
+	if len(key) == 0 {
+		return nil, ErrMissingEncryptionKey
+	}
+
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &EncryptedRepository{inner: inner, aead: aead}
+	if len(oldKey) > 0 {
+		if repo.oldKey, err = newGCM(oldKey); err != nil {
+			return nil, fmt.Errorf("old encryption key: %w", err)
+		}
+	}
+
+	return repo, nil
+}
+
+// newGCM creates an AES-GCM cipher for a 32-byte key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("encryption key must be 32 bytes, got %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// GetAll returns all transactions, decrypted
+func (r *EncryptedRepository) GetAll() ([]domain.Transaction, error) {
+	return r.decryptAll(r.inner.GetAll())
+}
+
+// GetByDateRange returns transactions within the date range, decrypted
+func (r *EncryptedRepository) GetByDateRange(start, end time.Time) ([]domain.Transaction, error) {
+	return r.decryptAll(r.inner.GetByDateRange(start, end))
+}
+
+// GetByType returns transactions of a type, decrypted
+func (r *EncryptedRepository) GetByType(txType string) ([]domain.Transaction, error) {
+	return r.decryptAll(r.inner.GetByType(txType))
+}
+
+// GetByCategory returns transactions in a category, decrypted
+func (r *EncryptedRepository) GetByCategory(category string) ([]domain.Transaction, error) {
+	return r.decryptAll(r.inner.GetByCategory(category))
+}
+
+// GetByTag returns transactions with a tag, decrypted
+func (r *EncryptedRepository) GetByTag(tag string) ([]domain.Transaction, error) {
+	return r.decryptAll(r.inner.GetByTag(tag))
+}
+
+// GetByAmountRange returns transactions within an absolute amount range, decrypted
+// Amounts are sealed in storage, so the comparison runs after decryption.
+func (r *EncryptedRepository) GetByAmountRange(minAbs, maxAbs float64) ([]domain.Transaction, error) {
+	if minAbs < 0 || minAbs > maxAbs {
+		return nil, domain.ErrInvalidAmountRange
+	}
+
+	transactions, err := r.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []domain.Transaction
+	for _, tx := range transactions {
+		amount := tx.AbsoluteAmount()
+		if amount >= minAbs && amount <= maxAbs {
+			filtered = append(filtered, tx)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil, domain.ErrNoTransactions
+	}
+
+	return filtered, nil
+}
+
+// GetByMerchant returns transactions for a merchant, decrypted
+func (r *EncryptedRepository) GetByMerchant(merchant string) ([]domain.Transaction, error) {
+	return r.decryptAll(r.inner.GetByMerchant(merchant))
+}
+
+// GetByPaymentMethod returns transactions paid with a method, decrypted
+func (r *EncryptedRepository) GetByPaymentMethod(method string) ([]domain.Transaction, error) {
+	return r.decryptAll(r.inner.GetByPaymentMethod(method))
+}
+
+// Filter returns the transactions matching filter, decrypted
+// Amounts and descriptions are sealed in storage, so matching runs after decryption.
+func (r *EncryptedRepository) Filter(filter domain.TransactionFilter) ([]domain.Transaction, error) {
+	transactions, err := r.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	match := filter.Predicate()
+	filtered := []domain.Transaction{}
+	for _, tx := range transactions {
+		if match(tx) {
+			filtered = append(filtered, tx)
+		}
+	}
+
+	return filtered, nil
+}
+
+// RenameCategory renames the category in the inner repository
+// Categories are stored in plaintext, so no decryption is needed.
+func (r *EncryptedRepository) RenameCategory(source, target string) (int, error) {
+	return r.inner.RenameCategory(source, target)
+}
+
+// FindDuplicates groups suspected duplicate transactions, decrypted
+// Amounts and descriptions are sealed with random nonces, so matching runs after decryption.
+func (r *EncryptedRepository) FindDuplicates() ([]domain.DuplicateGroup, error) {
+	transactions, err := r.GetAll()
+	if errors.Is(err, domain.ErrNoTransactions) {
+		return []domain.DuplicateGroup{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return findDuplicates(transactions), nil
+}
+
+// Enrich applies the given enrichers to every stored transaction and to transactions added later
+// Enrichers always see plaintext, so register them here rather than on the inner repository.
+func (r *EncryptedRepository) Enrich(enrichers ...Enricher) {
+	r.enrichers = append(r.enrichers, enrichers...)
+
+	inner, ok := r.inner.(rewriter)
+	if !ok {
+		return
+	}
+
+	inner.Rewrite(func(tx *domain.Transaction) {
+		plain, _, err := r.decrypt(*tx)
+		if err != nil {
+			return
+		}
+		for _, enricher := range enrichers {
+			enricher.Enrich(&plain)
+		}
+
+		if !isSealed(tx.Description) {
+			*tx = plain
+		} else if sealed, err := r.encrypt(plain); err == nil {
+			*tx = sealed
+		}
+	})
+}
+
+// Create validates the plaintext transaction, then stores it encrypted
+// The returned transaction is decrypted again.
+func (r *EncryptedRepository) Create(tx domain.Transaction) (domain.Transaction, error) {
+	if err := tx.Validate(); err != nil {
+		return domain.Transaction{}, err
+	}
+	r.enrich(&tx)
+
+	encrypted, err := r.encrypt(tx)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	stored, err := r.inner.Create(encrypted)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+
+	plain, _, err := r.decrypt(stored)
+	return plain, err
+}
+
+// BulkInsert validates the plaintext transactions, then stores them all encrypted
+func (r *EncryptedRepository) BulkInsert(transactions []domain.Transaction) error {
+	encrypted := make([]domain.Transaction, len(transactions))
+	for i, tx := range transactions {
+		if err := tx.Validate(); err != nil {
+			return err
+		}
+		r.enrich(&tx)
+
+		var err error
+		if encrypted[i], err = r.encrypt(tx); err != nil {
+			return err
+		}
+	}
+	return r.inner.BulkInsert(encrypted)
+}
+
+// GetByID returns the transaction with the given ID, decrypted
+func (r *EncryptedRepository) GetByID(id string) (domain.Transaction, error) {
+	tx, err := r.inner.GetByID(id)
+	if err != nil {
+		return tx, err
+	}
+
+	transactions, err := r.decryptAll([]domain.Transaction{tx}, nil)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	return transactions[0], nil
+}
+
+// Update validates the plaintext transaction, then stores it encrypted in place of id
+func (r *EncryptedRepository) Update(id string, tx domain.Transaction) error {
+	if err := tx.Validate(); err != nil {
+		return err
+	}
+	r.enrich(&tx)
+
+	encrypted, err := r.encrypt(tx)
+	if err != nil {
+		return err
+	}
+	return r.inner.Update(id, encrypted)
+}
+
+// enrich applies the registered enrichers to a plaintext transaction
+func (r *EncryptedRepository) enrich(tx *domain.Transaction) {
+	for _, enricher := range r.enrichers {
+		enricher.Enrich(tx)
+	}
+}
+
+// encrypt seals Amount and Description with the current key
+func (r *EncryptedRepository) encrypt(tx domain.Transaction) (domain.Transaction, error) {
+	fields, err := json.Marshal(sealedFields{Amount: tx.Amount, Description: tx.Description})
+	if err != nil {
+		return tx, err
+	}
+	sealed, err := r.seal(string(fields))
+	if err != nil {
+		return tx, err
+	}
+
+	tx.Amount = 0
+	tx.Description = sealed
+	return tx, nil
+}
+
+// decrypt restores Amount and Description
+// usedOldKey reports whether the record could only be opened with the old key.
+func (r *EncryptedRepository) decrypt(tx domain.Transaction) (result domain.Transaction, usedOldKey bool, err error) {
+	if !isSealed(tx.Description) {
+		return tx, false, nil
+	}
+
+	plain, usedOldKey, err := r.open(tx.Description)
+	if err != nil {
+		return tx, false, err
+	}
+	var fields sealedFields
+	if err := json.Unmarshal([]byte(plain), &fields); err != nil {
+		return tx, false, fmt.Errorf("decrypted record is malformed: %w", err)
+	}
+
+	tx.Amount = fields.Amount
+	tx.Description = fields.Description
+	return tx, usedOldKey, nil
+}
+
+// decryptAll decrypts the result of an inner repository call
+// Records still sealed with the old key trigger a re-encryption pass with the new key.
+func (r *EncryptedRepository) decryptAll(transactions []domain.Transaction, err error) ([]domain.Transaction, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	rotate := false
+	for i := range transactions {
+		var usedOldKey bool
+		if transactions[i], usedOldKey, err = r.decrypt(transactions[i]); err != nil {
+			return nil, err
+		}
+		rotate = rotate || usedOldKey
+	}
+
+	if rotate {
+		r.reencrypt()
+	}
+
+	return transactions, nil
+}
+
+// reencrypt rewrites every record sealed with the old key using the new key
+// Records that fail to decrypt are left untouched; reads will keep reporting them.
+func (r *EncryptedRepository) reencrypt() {
+	inner, ok := r.inner.(rewriter)
+	if !ok {
+		return
+	}
+
+	inner.Rewrite(func(tx *domain.Transaction) {
+		plain, usedOldKey, err := r.decrypt(*tx)
+		if err != nil || !usedOldKey {
+			return
+		}
+		if sealed, err := r.encrypt(plain); err == nil {
+			*tx = sealed
+		}
+	})
+}
+
+// seal encrypts plaintext with a random nonce
+func (r *EncryptedRepository) seal(plaintext string) (string, error) {
+	nonce := make([]byte, r.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := r.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// open decrypts a sealed value with the current key, falling back to the old key
+func (r *EncryptedRepository) open(value string) (plaintext string, usedOldKey bool, err error) {
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil {
+		return "", false, fmt.Errorf("malformed ciphertext: %w", err)
+	}
+
+	if plain, err := openWith(r.aead, sealed); err == nil {
+		return plain, false, nil
+	}
+	if r.oldKey != nil {
+		if plain, err := openWith(r.oldKey, sealed); err == nil {
+			return plain, true, nil
+		}
+	}
+
+	return "", false, errors.New("failed to decrypt transaction field: wrong key or corrupted data")
+}
+
+// openWith decrypts nonce || ciphertext with aead
+func openWith(aead cipher.AEAD, sealed []byte) (string, error) {
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("ciphertext too short")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// isSealed reports whether a stored field holds EncryptedRepository ciphertext
+func isSealed(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+var (
+	testEncryptionKey    = bytes.Repeat([]byte{0x01}, 32)
+	testEncryptionKeyOld = bytes.Repeat([]byte{0x02}, 32)
+)
+
+func newTestEncryptedRepository(t *testing.T, key, oldKey []byte) (*EncryptedRepository, *JSONRepository) {
+	t.Helper()
+
+	inner, err := NewJSONRepository([]byte(`[]`))
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	repo, err := NewEncryptedRepository(inner, key, oldKey)
+	if err != nil {
+		t.Fatalf("Failed to create encrypted repository: %v", err)
+	}
+	return repo, inner
+}
+
+func testSensitiveTransaction() domain.Transaction {
+	return domain.Transaction{
+		Date:        "2024-03-05",
+		Amount:      -1234.56,
+		Category:    "healthcare",
+		Description: "Dr. Smith therapy session",
+		Type:        "expense",
+	}
+}
+
+func TestEncryptedRepository_RoundTrip(t *testing.T) {
+	repo, _ := newTestEncryptedRepository(t, testEncryptionKey, nil)
+	tx := testSensitiveTransaction()
+
+	created, err := repo.Create(tx)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if created.ID == "" || created.Amount != tx.Amount || created.Description != tx.Description {
+		t.Errorf("Expected Create() to return the decrypted transaction with an ID, got %+v", created)
+	}
+
+	transactions, err := repo.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(transactions) != 1 {
+		t.Fatalf("Expected 1 transaction, got %d", len(transactions))
+	}
+
+	got := transactions[0]
+	if got.Amount != tx.Amount {
+		t.Errorf("Amount = %v, want %v", got.Amount, tx.Amount)
+	}
+	if got.Description != tx.Description {
+		t.Errorf("Description = %q, want %q", got.Description, tx.Description)
+	}
+
+	// Filtered reads decrypt as well
+	byCategory, err := repo.GetByCategory("healthcare")
+	if err != nil || len(byCategory) != 1 || byCategory[0].Amount != tx.Amount {
+		t.Errorf("GetByCategory() = %v, %v; want decrypted transaction", byCategory, err)
+	}
+
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	byRange, err := repo.GetByDateRange(start, end)
+	if err != nil || len(byRange) != 1 || byRange[0].Description != tx.Description {
+		t.Errorf("GetByDateRange() = %v, %v; want decrypted transaction", byRange, err)
+	}
+
+	// Amount ranges compare the decrypted amount, not the zeroed stored one
+	byAmount, err := repo.GetByAmountRange(1000, 2000)
+	if err != nil || len(byAmount) != 1 || byAmount[0].Amount != tx.Amount {
+		t.Errorf("GetByAmountRange() = %v, %v; want decrypted transaction", byAmount, err)
+	}
+}
+
+func TestEncryptedRepository_Update(t *testing.T) {
+	repo, inner := newTestEncryptedRepository(t, testEncryptionKey, nil)
+	if _, err := repo.Create(testSensitiveTransaction()); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	tx, err := repo.GetByID("tx-1")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	tx.Amount = -99.5
+	tx.Description = "Dr. Jones follow-up"
+	if err := repo.Update("tx-1", tx); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, err := repo.GetByID("tx-1")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Amount != -99.5 || got.Description != "Dr. Jones follow-up" {
+		t.Errorf("GetByID() after Update() = %+v", got)
+	}
+
+	stored, _ := inner.GetByID("tx-1")
+	if stored.Amount != 0 || !isSealed(stored.Description) {
+		t.Errorf("Expected the update to be stored encrypted, got %+v", stored)
+	}
+}
+
+func TestEncryptedRepository_StorageHoldsOnlyCiphertext(t *testing.T) {
+	repo, inner := newTestEncryptedRepository(t, testEncryptionKey, nil)
+	tx := testSensitiveTransaction()
+
+	if _, err := repo.Create(tx); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	stored, _ := inner.GetAll()
+	raw, err := json.Marshal(stored)
+	if err != nil {
+		t.Fatalf("Failed to marshal stored transactions: %v", err)
+	}
+
+	for _, plaintext := range []string{"Dr. Smith", "therapy", "1234.56"} {
+		if bytes.Contains(raw, []byte(plaintext)) {
+			t.Errorf("Stored JSON contains plaintext %q: %s", plaintext, raw)
+		}
+	}
+	if stored[0].Amount != 0 {
+		t.Errorf("Expected stored Amount to be zeroed, got %v", stored[0].Amount)
+	}
+	if !isSealed(stored[0].Description) {
+		t.Errorf("Expected the amount and description sealed in Description, got %q", stored[0].Description)
+	}
+}
+
+func TestEncryptedRepository_PlaintextPassesThrough(t *testing.T) {
+	inner, _ := NewJSONRepository(testJSON)
+	repo, err := NewEncryptedRepository(inner, testEncryptionKey, nil)
+	if err != nil {
+		t.Fatalf("Failed to create encrypted repository: %v", err)
+	}
+
+	transactions, err := repo.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if transactions[0].Amount != 2800 || transactions[0].Description != "Bi-weekly salary" {
+		t.Errorf("Expected seed data unchanged, got %+v", transactions[0])
+	}
+}
+
+func TestEncryptedRepository_Enrich(t *testing.T) {
+	inner, _ := NewJSONRepository(testJSON)
+	repo, err := NewEncryptedRepository(inner, testEncryptionKey, nil)
+	if err != nil {
+		t.Fatalf("Failed to create encrypted repository: %v", err)
+	}
+
+	if _, err := repo.Create(testSensitiveTransaction()); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	repo.Enrich(descriptionEnricher{})
+	later := testSensitiveTransaction()
+	later.Description = "Dr. Jones follow-up"
+	if _, err := repo.Create(later); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// Seed data, records stored before Enrich and records created after it are all enriched from plaintext
+	transactions, _ := repo.GetAll()
+	for _, tx := range transactions {
+		if tx.Merchant != tx.Description {
+			t.Errorf("Expected merchant %q, got %q", tx.Description, tx.Merchant)
+		}
+	}
+
+	stored, _ := inner.GetAll()
+	for _, tx := range stored[len(stored)-2:] {
+		if !isSealed(tx.Description) {
+			t.Errorf("Expected enriched records to stay sealed, got %+v", tx)
+		}
+	}
+}
+
+func TestEncryptedRepository_KeyRotation(t *testing.T) {
+	// Write with the old key
+	oldRepo, inner := newTestEncryptedRepository(t, testEncryptionKeyOld, nil)
+	tx := testSensitiveTransaction()
+	if _, err := oldRepo.Create(tx); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	before, _ := inner.GetAll()
+
+	// A new key alone cannot read the record
+	newOnly, err := NewEncryptedRepository(inner, testEncryptionKey, nil)
+	if err != nil {
+		t.Fatalf("Failed to create encrypted repository: %v", err)
+	}
+	if _, err := newOnly.GetAll(); err == nil {
+		t.Error("Expected decryption with the wrong key to fail")
+	}
+
+	// With the old key as fallback the read succeeds and the record is re-encrypted
+	rotating, err := NewEncryptedRepository(inner, testEncryptionKey, testEncryptionKeyOld)
+	if err != nil {
+		t.Fatalf("Failed to create encrypted repository: %v", err)
+	}
+	transactions, err := rotating.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll() during rotation error = %v", err)
+	}
+	if transactions[0].Amount != tx.Amount || transactions[0].Description != tx.Description {
+		t.Errorf("Expected decrypted transaction, got %+v", transactions[0])
+	}
+
+	after, _ := inner.GetAll()
+	if after[0].Description == before[0].Description {
+		t.Error("Expected stored record to be re-encrypted")
+	}
+
+	// Once rotated, the new key alone is enough
+	transactions, err = newOnly.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll() after rotation error = %v", err)
+	}
+	if transactions[0].Amount != tx.Amount {
+		t.Errorf("Amount after rotation = %v, want %v", transactions[0].Amount, tx.Amount)
+	}
+}
+
+func TestNewEncryptedRepository_MissingKey(t *testing.T) {
+	inner, _ := NewJSONRepository([]byte(`[]`))
+
+	if _, err := NewEncryptedRepository(inner, nil, nil); !errors.Is(err, ErrMissingEncryptionKey) {
+		t.Errorf("Expected ErrMissingEncryptionKey, got %v", err)
+	}
+	if _, err := NewEncryptedRepository(inner, []byte("too short"), nil); err == nil {
+		t.Error("Expected error for a key that is not 32 bytes")
+	}
+}
+
+func TestParseEncryptionKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"valid 32-byte key", base64.StdEncoding.EncodeToString(testEncryptionKey), false},
+		{"missing", "", true},
+		{"not base64", "not-base64!", true},
+		{"wrong length", base64.StdEncoding.EncodeToString([]byte("16-byte-key-1234")), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParseEncryptionKey(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEncryptionKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(key) != 32 {
+				t.Errorf("Expected 32-byte key, got %d bytes", len(key))
+			}
+		})
+	}
+}
+
+func TestEncryptedRepository_FindDuplicates(t *testing.T) {
+	repo, _ := newTestEncryptedRepository(t, testEncryptionKey, nil)
+
+	groups, err := repo.FindDuplicates()
+	if err != nil || len(groups) != 0 {
+		t.Fatalf("FindDuplicates() on an empty repository = %v, %v; want no groups", groups, err)
+	}
+
+	// The sealed descriptions differ, so matching must happen on the plaintext
+	tx := testSensitiveTransaction()
+	for _, category := range []string{"healthcare", "other"} {
+		tx.Category = category
+		if _, err := repo.Create(tx); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	groups, err = repo.FindDuplicates()
+	if err != nil {
+		t.Fatalf("FindDuplicates() error = %v", err)
+	}
+	if len(groups) != 1 || len(groups[0].Transactions) != 2 || groups[0].Transactions[0].Description != tx.Description {
+		t.Errorf("Expected one decrypted group matched by description, got %+v", groups)
+	}
+}
+
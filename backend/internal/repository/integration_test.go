@@ -1,23 +1,16 @@
 package repository
 
 import (
-	"os"
-	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/danntastico/stori-backend/internal/testutil/sampledata"
 )
 
 // TestWithActualData tests the repository with the real transactions.json file
 func TestWithActualData(t *testing.T) {
-	// Try to load the actual data file
-	// Path relative to the repository package
-	dataPath := filepath.Join("..", "..", "data", "transactions.json")
-
-	data, err := os.ReadFile(dataPath)
-	if err != nil {
-		t.Skipf("Skipping integration test: could not read data file: %v", err)
-		return
-	}
+	// Load the actual data file
+	data := sampledata.Transactions(t)
 
 	repo, err := NewJSONRepository(data)
 	if err != nil {
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// JSONBudgetRepository implements BudgetRepository backed by a JSON file
+// Every write rewrites the whole file via a temp file and rename, so a crash
+// mid-write leaves the previous contents intact.
+type JSONBudgetRepository struct {
+	mu      sync.RWMutex
+	budgets map[string]domain.Budget
+	path    string
+}
+
+// NewJSONBudgetRepository loads budgets from the JSON file at path
+// The file is created containing [] if it does not exist.
+func NewJSONBudgetRepository(path string) (*JSONBudgetRepository, error) {
+	repo := &JSONBudgetRepository{
+		budgets: make(map[string]domain.Budget),
+		path:    path,
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		if err := os.WriteFile(path, []byte("[]"), 0o644); err != nil {
+			return nil, err
+		}
+		return repo, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var budgets []domain.Budget
+	if err := json.Unmarshal(data, &budgets); err != nil {
+		return nil, err
+	}
+	for _, budget := range budgets {
+		if err := budget.Validate(); err != nil {
+			return nil, err
+		}
+		repo.budgets[budget.Category] = budget
+	}
+
+	return repo, nil
+}
+
+// GetAll returns every budget, sorted by category
+func (r *JSONBudgetRepository) GetAll() ([]domain.Budget, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sorted(), nil
+}
+
+// GetByCategory returns the budget for a category
+func (r *JSONBudgetRepository) GetByCategory(category string) (domain.Budget, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	budget, ok := r.budgets[category]
+	if !ok {
+		return domain.Budget{}, domain.ErrBudgetNotFound
+	}
+	return budget, nil
+}
+
+// Create stores a budget after validating it and rewrites the file
+func (r *JSONBudgetRepository) Create(budget domain.Budget) error {
+	if err := budget.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.put(budget)
+}
+
+// Update changes the limit of an existing budget and rewrites the file
+func (r *JSONBudgetRepository) Update(budget domain.Budget) error {
+	if err := budget.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.budgets[budget.Category]; !ok {
+		return domain.ErrBudgetNotFound
+	}
+	return r.put(budget)
+}
+
+// Delete removes the budget for a category and rewrites the file
+func (r *JSONBudgetRepository) Delete(category string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.budgets[category]
+	if !ok {
+		return domain.ErrBudgetNotFound
+	}
+
+	delete(r.budgets, category)
+	if err := r.persist(); err != nil {
+		r.budgets[category] = previous
+		return err
+	}
+	return nil
+}
+
+// put stores budget and persists, restoring the previous state if the write fails
+// Must be called with the lock held
+func (r *JSONBudgetRepository) put(budget domain.Budget) error {
+	previous, existed := r.budgets[budget.Category]
+
+	r.budgets[budget.Category] = budget
+	if err := r.persist(); err != nil {
+		if existed {
+			r.budgets[budget.Category] = previous
+		} else {
+			delete(r.budgets, budget.Category)
+		}
+		return err
+	}
+	return nil
+}
+
+// sorted returns the budgets ordered by category
+// Must be called with the lock held
+func (r *JSONBudgetRepository) sorted() []domain.Budget {
+	budgets := make([]domain.Budget, 0, len(r.budgets))
+	for _, budget := range r.budgets {
+		budgets = append(budgets, budget)
+	}
+	sort.Slice(budgets, func(i, j int) bool {
+		return budgets[i].Category < budgets[j].Category
+	})
+	return budgets
+}
+
+// persist writes all budgets to the backing file via a temp file and rename
+// Must be called with the lock held
+func (r *JSONBudgetRepository) persist() error {
+	return writeJSONFile(r.path, r.sorted())
+}
+
+// writeJSONFile writes v as indented JSON to path via a temp file and rename,
+// so readers never see a partially written file
+func writeJSONFile(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func TestJSONBudgetRepository_CreatesMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "budgets.json")
+
+	repo, err := NewJSONBudgetRepository(path)
+	if err != nil {
+		t.Fatalf("NewJSONBudgetRepository() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected budget file to be created: %v", err)
+	}
+	if string(data) != "[]" {
+		t.Errorf("Expected new file to contain [], got %q", data)
+	}
+
+	budgets, _ := repo.GetAll()
+	if len(budgets) != 0 {
+		t.Errorf("Expected no budgets, got %+v", budgets)
+	}
+}
+
+func TestJSONBudgetRepository_PersistsAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "budgets.json")
+
+	repo, err := NewJSONBudgetRepository(path)
+	if err != nil {
+		t.Fatalf("NewJSONBudgetRepository() error = %v", err)
+	}
+	if err := repo.Create(domain.Budget{Category: "dining", MonthlyLimit: 200}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repo.Create(domain.Budget{Category: "groceries", MonthlyLimit: 400}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repo.Update(domain.Budget{Category: "dining", MonthlyLimit: 250}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := repo.Delete("groceries"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	// Restart: a new repository reads what the first one wrote
+	restarted, err := NewJSONBudgetRepository(path)
+	if err != nil {
+		t.Fatalf("NewJSONBudgetRepository() after restart error = %v", err)
+	}
+
+	budget, err := restarted.GetByCategory("dining")
+	if err != nil || budget.MonthlyLimit != 250 {
+		t.Errorf("GetByCategory(dining) after restart = %+v, %v; want limit 250", budget, err)
+	}
+	if _, err := restarted.GetByCategory("groceries"); !errors.Is(err, domain.ErrBudgetNotFound) {
+		t.Errorf("Expected deleted budget to stay deleted, got %v", err)
+	}
+
+	// No temp files are left behind
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expected only budgets.json in the directory, got %d entries", len(entries))
+	}
+}
+
+func TestJSONBudgetRepository_Errors(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := NewJSONBudgetRepository(filepath.Join(dir, "budgets.json"))
+	if err != nil {
+		t.Fatalf("NewJSONBudgetRepository() error = %v", err)
+	}
+
+	if err := repo.Create(domain.Budget{Category: "dining", MonthlyLimit: -5}); !errors.Is(err, domain.ErrInvalidBudget) {
+		t.Errorf("Expected ErrInvalidBudget, got %v", err)
+	}
+	if err := repo.Update(domain.Budget{Category: "rent", MonthlyLimit: 1000}); !errors.Is(err, domain.ErrBudgetNotFound) {
+		t.Errorf("Expected ErrBudgetNotFound updating a missing budget, got %v", err)
+	}
+	if err := repo.Delete("rent"); !errors.Is(err, domain.ErrBudgetNotFound) {
+		t.Errorf("Expected ErrBudgetNotFound deleting a missing budget, got %v", err)
+	}
+
+	// Corrupt and invalid files are rejected on load
+	corrupt := filepath.Join(dir, "corrupt.json")
+	os.WriteFile(corrupt, []byte("{"), 0o644)
+	if _, err := NewJSONBudgetRepository(corrupt); err == nil {
+		t.Error("Expected error loading corrupt budget file")
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	os.WriteFile(invalid, []byte(`[{"category": "dining", "monthly_limit": 0}]`), 0o644)
+	if _, err := NewJSONBudgetRepository(invalid); !errors.Is(err, domain.ErrInvalidBudget) {
+		t.Errorf("Expected ErrInvalidBudget loading invalid budget, got %v", err)
+	}
+}
+
+func TestJSONBudgetRepository_ConcurrentWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "budgets.json")
+	repo, err := NewJSONBudgetRepository(path)
+	if err != nil {
+		t.Fatalf("NewJSONBudgetRepository() error = %v", err)
+	}
+
+	categories := []string{"dining", "groceries", "transport", "entertainment", "utilities"}
+	var wg sync.WaitGroup
+	for _, category := range categories {
+		wg.Add(1)
+		go func(category string) {
+			defer wg.Done()
+			if err := repo.Create(domain.Budget{Category: category, MonthlyLimit: 100}); err != nil {
+				t.Errorf("Create(%s) error = %v", category, err)
+			}
+			repo.GetAll()
+		}(category)
+	}
+	wg.Wait()
+
+	restarted, err := NewJSONBudgetRepository(path)
+	if err != nil {
+		t.Fatalf("NewJSONBudgetRepository() after restart error = %v", err)
+	}
+	budgets, _ := restarted.GetAll()
+	if len(budgets) != len(categories) {
+		t.Errorf("Expected %d budgets on disk, got %d", len(categories), len(budgets))
+	}
+}
+
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// JSONCategoryMetadataRepository implements CategoryMetadataRepository backed by a JSON file
+// Only overrides are stored in the file; the built-in defaults come from
+// domain.DefaultCategories. Writes go through a temp file and rename, like budgets.
+type JSONCategoryMetadataRepository struct {
+	mu        sync.RWMutex
+	defaults  map[string]domain.CategoryMetadata
+	overrides map[string]domain.CategoryMetadata
+	path      string
+}
+
+// NewJSONCategoryMetadataRepository loads metadata overrides from the JSON file at path
+// The file is created containing [] if it does not exist.
+func NewJSONCategoryMetadataRepository(path string) (*JSONCategoryMetadataRepository, error) {
+	repo := &JSONCategoryMetadataRepository{
+		defaults:  make(map[string]domain.CategoryMetadata),
+		overrides: make(map[string]domain.CategoryMetadata),
+		path:      path,
+	}
+	for _, metadata := range domain.DefaultCategories() {
+		repo.defaults[metadata.Name] = metadata
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		if err := os.WriteFile(path, []byte("[]"), 0o644); err != nil {
+			return nil, err
+		}
+		return repo, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides []domain.CategoryMetadata
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, err
+	}
+	for _, metadata := range overrides {
+		if err := metadata.Validate(); err != nil {
+			return nil, err
+		}
+		metadata.IsDefault = false
+		repo.overrides[metadata.Name] = metadata
+	}
+
+	return repo, nil
+}
+
+// GetAll returns the defaults merged with the overrides, sorted by name
+func (r *JSONCategoryMetadataRepository) GetAll() ([]domain.CategoryMetadata, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	merged := make(map[string]domain.CategoryMetadata, len(r.defaults)+len(r.overrides))
+	for name, metadata := range r.defaults {
+		merged[name] = metadata
+	}
+	for name, metadata := range r.overrides {
+		merged[name] = metadata
+	}
+
+	return sortedCategoryMetadata(merged), nil
+}
+
+// GetByName returns the override for a category, or its default
+func (r *JSONCategoryMetadataRepository) GetByName(name string) (domain.CategoryMetadata, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if metadata, ok := r.overrides[name]; ok {
+		return metadata, nil
+	}
+	if metadata, ok := r.defaults[name]; ok {
+		return metadata, nil
+	}
+	return domain.CategoryMetadata{}, domain.ErrCategoryNotFound
+}
+
+// Upsert stores an override after validating it and rewrites the file
+func (r *JSONCategoryMetadataRepository) Upsert(metadata domain.CategoryMetadata) error {
+	if err := metadata.Validate(); err != nil {
+		return err
+	}
+	metadata.IsDefault = false
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, existed := r.overrides[metadata.Name]
+	r.overrides[metadata.Name] = metadata
+	if err := writeJSONFile(r.path, sortedCategoryMetadata(r.overrides)); err != nil {
+		if existed {
+			r.overrides[metadata.Name] = previous
+		} else {
+			delete(r.overrides, metadata.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+// sortedCategoryMetadata returns the entries of byName ordered by name
+func sortedCategoryMetadata(byName map[string]domain.CategoryMetadata) []domain.CategoryMetadata {
+	entries := make([]domain.CategoryMetadata, 0, len(byName))
+	for _, metadata := range byName {
+		entries = append(entries, metadata)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func TestJSONCategoryMetadataRepository_DefaultsAndOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "category_metadata.json")
+	repo, err := NewJSONCategoryMetadataRepository(path)
+	if err != nil {
+		t.Fatalf("NewJSONCategoryMetadataRepository() error = %v", err)
+	}
+
+	all, err := repo.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(all) != len(domain.DefaultCategories()) {
+		t.Fatalf("Expected %d default categories, got %d", len(domain.DefaultCategories()), len(all))
+	}
+
+	override := domain.CategoryMetadata{Name: "rent", Description: "Housing", IconName: "building", Color: "#000000", IsDefault: true}
+	if err := repo.Upsert(override); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	if err := repo.Upsert(domain.CategoryMetadata{Name: "pets", Description: "Vet and pet food", Color: "#FFFFFF"}); err != nil {
+		t.Fatalf("Upsert() of a new category error = %v", err)
+	}
+
+	// Overrides survive a reload and are never reported as defaults
+	reloaded, err := NewJSONCategoryMetadataRepository(path)
+	if err != nil {
+		t.Fatalf("Reload error = %v", err)
+	}
+	rent, err := reloaded.GetByName("rent")
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	override.IsDefault = false
+	if rent != override {
+		t.Errorf("Expected %+v, got %+v", override, rent)
+	}
+
+	all, _ = reloaded.GetAll()
+	if len(all) != len(domain.DefaultCategories())+1 {
+		t.Errorf("Expected the new category alongside the defaults, got %d entries", len(all))
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i-1].Name >= all[i].Name {
+			t.Errorf("Expected entries sorted by name, got %s before %s", all[i-1].Name, all[i].Name)
+		}
+	}
+
+	if _, err := reloaded.GetByName("travel"); !errors.Is(err, domain.ErrCategoryNotFound) {
+		t.Errorf("Expected ErrCategoryNotFound, got %v", err)
+	}
+}
+
+func TestJSONCategoryMetadataRepository_RejectsInvalidMetadata(t *testing.T) {
+	repo, err := NewJSONCategoryMetadataRepository(filepath.Join(t.TempDir(), "category_metadata.json"))
+	if err != nil {
+		t.Fatalf("NewJSONCategoryMetadataRepository() error = %v", err)
+	}
+
+	if err := repo.Upsert(domain.CategoryMetadata{Name: "  "}); !errors.Is(err, domain.ErrInvalidCategory) {
+		t.Errorf("Expected ErrInvalidCategory for a blank name, got %v", err)
+	}
+	if err := repo.Upsert(domain.CategoryMetadata{Name: "rent", Color: "#12345"}); !errors.Is(err, domain.ErrInvalidCategoryMetadata) {
+		t.Errorf("Expected ErrInvalidCategoryMetadata for a short color, got %v", err)
+	}
+}
+
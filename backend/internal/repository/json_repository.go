@@ -2,41 +2,152 @@ package repository
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/danntastico/stori-backend/internal/domain"
 )
 
 // JSONRepository implements TransactionRepository using in-memory JSON data
+// Reads and writes are guarded by mu so transactions can be added while serving requests
 type JSONRepository struct {
+	mu           sync.RWMutex
 	transactions []domain.Transaction
+	enrichers    []Enricher      // Applied to transactions added later via Create
+	ids          map[string]bool // IDs in use, which must stay unique
+	lastID       int             // Sequence for generated transaction IDs
+}
+
+// Enricher populates computed fields on a transaction after it is loaded
+type Enricher interface {
+	Enrich(tx *domain.Transaction)
 }
 
 // NewJSONRepository creates a new JSON-based repository from raw JSON data
 // This is designed to work with embedded JSON files using go:embed
-func NewJSONRepository(data []byte) (*JSONRepository, error) {
+// Several sources, e.g., one file per year, are merged in order like NewMergedRepository,
+// without tagging an account.
+func NewJSONRepository(data ...[]byte) (*JSONRepository, error) {
+	sources := make([][]domain.Transaction, len(data))
+	for i, source := range data {
+		transactions, err := parseTransactions(source)
+		if err != nil {
+			if len(data) > 1 {
+				return nil, fmt.Errorf("data source %d: %w", i+1, err)
+			}
+			return nil, err
+		}
+		sources[i] = transactions
+	}
+
+	return newJSONRepository(mergeSources(sources)), nil
+}
+
+// mergeSources concatenates sources in order, skipping transactions already loaded
+// A transaction with an ID is skipped when that ID was seen before, in any source. One
+// without an ID is skipped when an earlier source had the same date, amount and
+// description; such entries within a single source are separate purchases and all kept.
+func mergeSources(sources [][]domain.Transaction) []domain.Transaction {
+	var merged []domain.Transaction
+	seen := make(map[string]bool)
+	for _, transactions := range sources {
+		var keys []string
+		for _, tx := range transactions {
+			if tx.ID != "" {
+				if seen["id:"+tx.ID] {
+					continue
+				}
+				seen["id:"+tx.ID] = true
+			} else {
+				key := "hash:" + tx.Fingerprint()
+				if seen[key] {
+					continue
+				}
+				keys = append(keys, key)
+			}
+			merged = append(merged, tx)
+		}
+		for _, key := range keys {
+			seen[key] = true
+		}
+	}
+
+	return merged
+}
+
+// parseTransactions decodes a JSON array of transactions, normalizing each one
+func parseTransactions(data []byte) ([]domain.Transaction, error) {
 	var transactions []domain.Transaction
 
 	if err := json.Unmarshal(data, &transactions); err != nil {
 		return nil, err
 	}
 
-	// Validate all transactions on load
-	for i, tx := range transactions {
-		if err := tx.Validate(); err != nil {
+	// Validate (and normalize) all transactions on load
+	for i := range transactions {
+		if err := transactions[i].Validate(); err != nil {
 			// Note: In production, you might want to log invalid transactions
 			// For now, we trust the provided JSON data is valid
 			_ = i // Placeholder for future logging
 		}
 	}
 
-	return &JSONRepository{
+	return transactions, nil
+}
+
+// newJSONRepository stores transactions, assigning IDs to those without one
+// IDs must already be unique; generated IDs never reuse a loaded one.
+func newJSONRepository(transactions []domain.Transaction) *JSONRepository {
+	repo := &JSONRepository{
 		transactions: transactions,
-	}, nil
+		ids:          make(map[string]bool, len(transactions)),
+	}
+	for _, tx := range repo.transactions {
+		if tx.ID != "" {
+			repo.reserveID(tx.ID)
+		}
+	}
+	for i := range repo.transactions {
+		if repo.transactions[i].ID == "" {
+			repo.transactions[i].ID = repo.nextID()
+		}
+	}
+
+	return repo
+}
+
+// nextID generates and reserves the next free transaction ID, e.g., "tx-1"
+// Callers must hold mu for writing (or own r exclusively, as during construction).
+func (r *JSONRepository) nextID() string {
+	for {
+		r.lastID++
+		if id := "tx-" + strconv.Itoa(r.lastID); !r.ids[id] {
+			r.ids[id] = true
+			return id
+		}
+	}
+}
+
+// reserveID marks id as in use, moving the ID sequence past it when it has the
+// generated "tx-N" form
+// Callers must hold mu for writing (or own r exclusively, as during construction).
+func (r *JSONRepository) reserveID(id string) {
+	r.ids[id] = true
+	if digits, ok := strings.CutPrefix(id, "tx-"); ok {
+		if n, err := strconv.Atoi(digits); err == nil && n > r.lastID {
+			r.lastID = n
+		}
+	}
 }
 
 // GetAll returns all transactions
 func (r *JSONRepository) GetAll() ([]domain.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	if len(r.transactions) == 0 {
 		return nil, domain.ErrNoTransactions
 	}
@@ -50,6 +161,9 @@ func (r *JSONRepository) GetAll() ([]domain.Transaction, error) {
 
 // GetByDateRange returns transactions within the specified date range (inclusive)
 func (r *JSONRepository) GetByDateRange(start, end time.Time) ([]domain.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	// Validate date range
 	if start.After(end) {
 		return nil, domain.ErrInvalidDateRange
@@ -78,8 +192,36 @@ func (r *JSONRepository) GetByDateRange(start, end time.Time) ([]domain.Transact
 	return filtered, nil
 }
 
+// GetByAmountRange returns transactions whose absolute amount is within [minAbs, maxAbs]
+func (r *JSONRepository) GetByAmountRange(minAbs, maxAbs float64) ([]domain.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if minAbs < 0 || minAbs > maxAbs {
+		return nil, domain.ErrInvalidAmountRange
+	}
+
+	var filtered []domain.Transaction
+
+	for _, tx := range r.transactions {
+		amount := tx.AbsoluteAmount()
+		if amount >= minAbs && amount <= maxAbs {
+			filtered = append(filtered, tx)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil, domain.ErrNoTransactions
+	}
+
+	return filtered, nil
+}
+
 // GetByType returns all transactions of a specific type
 func (r *JSONRepository) GetByType(txType string) ([]domain.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	var filtered []domain.Transaction
 
 	for _, tx := range r.transactions {
@@ -97,6 +239,9 @@ func (r *JSONRepository) GetByType(txType string) ([]domain.Transaction, error)
 
 // GetByCategory returns all transactions for a specific category
 func (r *JSONRepository) GetByCategory(category string) ([]domain.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	var filtered []domain.Transaction
 
 	for _, tx := range r.transactions {
@@ -112,10 +257,247 @@ func (r *JSONRepository) GetByCategory(category string) ([]domain.Transaction, e
 	return filtered, nil
 }
 
+// GetByTag returns all transactions labeled with a specific tag
+func (r *JSONRepository) GetByTag(tag string) ([]domain.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var filtered []domain.Transaction
+
+	for _, tx := range r.transactions {
+		if tx.HasTag(tag) {
+			filtered = append(filtered, tx)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil, domain.ErrNoTransactions
+	}
+
+	return filtered, nil
+}
+
+// GetByMerchant returns all transactions for a specific merchant
+// Matching is case-sensitive: "Amazon" and "amazon" are different merchants
+func (r *JSONRepository) GetByMerchant(merchant string) ([]domain.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var filtered []domain.Transaction
+
+	for _, tx := range r.transactions {
+		if tx.Merchant == merchant {
+			filtered = append(filtered, tx)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil, domain.ErrNoTransactions
+	}
+
+	return filtered, nil
+}
+
+// Enrich applies the given enrichers to every stored transaction
+// The enrichers are kept and also applied to transactions added later via Create
+func (r *JSONRepository) Enrich(enrichers ...Enricher) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.enrichers = append(r.enrichers, enrichers...)
+	for i := range r.transactions {
+		for _, enricher := range enrichers {
+			enricher.Enrich(&r.transactions[i])
+		}
+	}
+}
+
+// Create validates, enriches and stores a new transaction, returning the stored copy
+// Returns *domain.ValidationErrors if the transaction is invalid
+func (r *JSONRepository) Create(tx domain.Transaction) (domain.Transaction, error) {
+	if err := tx.Validate(); err != nil {
+		return domain.Transaction{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if tx.ID == "" {
+		tx.ID = r.nextID()
+	} else if r.ids[tx.ID] {
+		return domain.Transaction{}, fmt.Errorf("%w: %s", domain.ErrDuplicateID, tx.ID)
+	} else {
+		r.reserveID(tx.ID)
+	}
+	for _, enricher := range r.enrichers {
+		enricher.Enrich(&tx)
+	}
+	r.transactions = append(r.transactions, tx)
+
+	return tx, nil
+}
+
+// BulkInsert validates every transaction, then enriches and stores them all at once
+func (r *JSONRepository) BulkInsert(transactions []domain.Transaction) error {
+	validated := make([]domain.Transaction, len(transactions))
+	for i, tx := range transactions {
+		if err := tx.Validate(); err != nil {
+			return err
+		}
+		validated[i] = tx
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Check every client-supplied ID before storing anything
+	batchIDs := make(map[string]bool)
+	for _, tx := range validated {
+		if tx.ID == "" {
+			continue
+		}
+		if r.ids[tx.ID] || batchIDs[tx.ID] {
+			return fmt.Errorf("%w: %s", domain.ErrDuplicateID, tx.ID)
+		}
+		batchIDs[tx.ID] = true
+	}
+
+	for i := range validated {
+		if validated[i].ID == "" {
+			validated[i].ID = r.nextID()
+		} else {
+			r.reserveID(validated[i].ID)
+		}
+		for _, enricher := range r.enrichers {
+			enricher.Enrich(&validated[i])
+		}
+	}
+	r.transactions = append(r.transactions, validated...)
+
+	return nil
+}
+
+// GetByID returns the transaction with the given ID
+func (r *JSONRepository) GetByID(id string) (domain.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, tx := range r.transactions {
+		if tx.ID == id {
+			return tx, nil
+		}
+	}
+
+	return domain.Transaction{}, domain.ErrTransactionNotFound
+}
+
+// Update validates, enriches and stores tx in place of the transaction with the given ID
+func (r *JSONRepository) Update(id string, tx domain.Transaction) error {
+	if err := tx.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.transactions {
+		if r.transactions[i].ID != id {
+			continue
+		}
+
+		tx.ID = id
+		for _, enricher := range r.enrichers {
+			enricher.Enrich(&tx)
+		}
+		r.transactions[i] = tx
+		return nil
+	}
+
+	return domain.ErrTransactionNotFound
+}
+
+// GetByPaymentMethod returns all transactions paid with a specific method
+func (r *JSONRepository) GetByPaymentMethod(method string) ([]domain.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var filtered []domain.Transaction
+
+	for _, tx := range r.transactions {
+		if tx.PaymentMethod == method {
+			filtered = append(filtered, tx)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil, domain.ErrNoTransactions
+	}
+
+	return filtered, nil
+}
+
+// Filter returns the transactions matching every criterion of filter
+func (r *JSONRepository) Filter(filter domain.TransactionFilter) ([]domain.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.transactions) == 0 {
+		return nil, domain.ErrNoTransactions
+	}
+
+	match := filter.Predicate()
+	filtered := []domain.Transaction{}
+	for _, tx := range r.transactions {
+		if match(tx) {
+			filtered = append(filtered, tx)
+		}
+	}
+
+	return filtered, nil
+}
+
+// RenameCategory moves every transaction in category source to category target
+func (r *JSONRepository) RenameCategory(source, target string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	renamed := 0
+	for i := range r.transactions {
+		if r.transactions[i].Category == source {
+			r.transactions[i].Category = target
+			renamed++
+		}
+	}
+
+	return renamed, nil
+}
+
+// Rewrite applies fn to every stored transaction in place
+// Used for bulk maintenance such as re-encryption; fn must keep transactions valid.
+func (r *JSONRepository) Rewrite(fn func(tx *domain.Transaction)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.transactions {
+		fn(&r.transactions[i])
+	}
+}
+
+// FindDuplicates groups transactions that look like the same transaction recorded twice
+func (r *JSONRepository) FindDuplicates() ([]domain.DuplicateGroup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return findDuplicates(r.transactions), nil
+}
+
 // Helper methods for analytics (not part of the interface but useful)
 
 // GetDateRange returns the earliest and latest transaction dates
 func (r *JSONRepository) GetDateRange() (start, end time.Time, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	if len(r.transactions) == 0 {
 		return time.Time{}, time.Time{}, domain.ErrNoTransactions
 	}
@@ -153,6 +535,9 @@ func (r *JSONRepository) GetDateRange() (start, end time.Time, err error) {
 
 // Count returns the total number of transactions
 func (r *JSONRepository) Count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	return len(r.transactions)
 }
 
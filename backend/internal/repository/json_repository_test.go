@@ -1,10 +1,16 @@
 package repository
 
 import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/testutil/sampledata"
 )
 
 // Sample test data
@@ -52,6 +58,48 @@ func TestNewJSONRepository(t *testing.T) {
 	}
 }
 
+func TestNewJSONRepository_MultipleSources(t *testing.T) {
+	// 2023 and 2024 exports overlap in the first days of January
+	year2023 := []byte(`[
+		{"id": "bank-1", "date": "2023-12-30", "amount": -40, "category": "dining", "description": "Dinner", "type": "expense"},
+		{"id": "bank-2", "date": "2024-01-01", "amount": 2800, "category": "salary", "description": "Bi-weekly salary", "type": "income"},
+		{"date": "2024-01-02", "amount": -5, "category": "dining", "description": "Coffee", "type": "expense"},
+		{"date": "2024-01-02", "amount": -5, "category": "dining", "description": "Coffee", "type": "expense"}
+	]`)
+	year2024 := []byte(`[
+		{"id": "bank-2", "date": "2024-01-01", "amount": 2800, "category": "income", "description": "Salary (recategorized)", "type": "income"},
+		{"date": "2024-01-02", "amount": -5, "category": "coffee", "description": "Coffee", "type": "expense"},
+		{"date": "2024-01-02", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"}
+	]`)
+
+	repo, err := NewJSONRepository(year2023, year2024)
+	if err != nil {
+		t.Fatalf("NewJSONRepository() error = %v", err)
+	}
+
+	// bank-2 matches by ID and the coffee by date, amount and description; the two coffees
+	// within the 2023 file are separate purchases and both kept
+	if count := repo.Count(); count != 4+3-2 {
+		t.Fatalf("Count() = %d, want %d", count, 4+3-2)
+	}
+
+	transactions, _ := repo.GetAll()
+	ids := make(map[string]bool)
+	for _, tx := range transactions {
+		if ids[tx.ID] {
+			t.Errorf("Duplicate ID %q after merging", tx.ID)
+		}
+		ids[tx.ID] = true
+		if tx.Category == "income" || tx.Category == "coffee" {
+			t.Errorf("Expected the first file's copy to be kept, got %+v", tx)
+		}
+	}
+
+	if _, err := NewJSONRepository(year2023, []byte(`{not json`)); err == nil || !strings.Contains(err.Error(), "data source 2") {
+		t.Errorf("Expected an error naming data source 2, got %v", err)
+	}
+}
+
 func TestJSONRepository_GetAll(t *testing.T) {
 	repo, err := NewJSONRepository(testJSON)
 	if err != nil {
@@ -274,6 +322,147 @@ func TestJSONRepository_GetByCategory(t *testing.T) {
 	}
 }
 
+func TestJSONRepository_GetByTag(t *testing.T) {
+	repo, err := NewJSONRepository([]byte(`[
+		{"date": "2024-01-05", "amount": -300, "category": "shopping", "description": "Laptop bag", "type": "expense", "tags": ["business", "travel"]},
+		{"date": "2024-01-06", "amount": -80, "category": "dining", "description": "Client lunch", "type": "expense", "tags": ["business"]},
+		{"date": "2024-01-07", "amount": -45, "category": "groceries", "description": "Safeway", "type": "expense"}
+	]`))
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		tag           string
+		expectedCount int
+		wantErr       bool
+	}{
+		{"shared tag", "business", 2, false},
+		{"single tag", "travel", 1, false},
+		{"unknown tag", "vacation", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactions, err := repo.GetByTag(tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetByTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(transactions) != tt.expectedCount {
+				t.Errorf("GetByTag() returned %d transactions, want %d", len(transactions), tt.expectedCount)
+			}
+		})
+	}
+
+	// The multi-tag transaction appears in the results for each of its tags
+	business, _ := repo.GetByTag("business")
+	travel, _ := repo.GetByTag("travel")
+	if business[0].Description != "Laptop bag" || travel[0].Description != "Laptop bag" {
+		t.Error("Expected multi-tag transaction in results for each of its tags")
+	}
+}
+
+func TestJSONRepository_GetByMerchant(t *testing.T) {
+	repo, err := NewJSONRepository([]byte(`[
+		{"date": "2024-01-05", "amount": -30, "category": "shopping", "description": "Order 1", "type": "expense", "merchant": " Amazon "},
+		{"date": "2024-01-06", "amount": -20, "category": "shopping", "description": "Order 2", "type": "expense", "merchant": "amazon"}
+	]`))
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	// Merchant whitespace is trimmed on load
+	transactions, err := repo.GetByMerchant("Amazon")
+	if err != nil {
+		t.Fatalf("GetByMerchant() error = %v", err)
+	}
+
+	// Matching is case-sensitive
+	if len(transactions) != 1 || transactions[0].Description != "Order 1" {
+		t.Errorf("Expected only 'Order 1' for 'Amazon', got %+v", transactions)
+	}
+
+	if _, err := repo.GetByMerchant("Walmart"); err != domain.ErrNoTransactions {
+		t.Errorf("Expected ErrNoTransactions, got %v", err)
+	}
+}
+
+func TestJSONRepository_GetByPaymentMethod(t *testing.T) {
+	repo, err := NewJSONRepository([]byte(`[
+		{"date": "2024-01-05", "amount": -30, "category": "shopping", "description": "Order 1", "type": "expense", "payment_method": "credit_card"},
+		{"date": "2024-01-06", "amount": -20, "category": "dining", "description": "Lunch", "type": "expense", "payment_method": "cash"},
+		{"date": "2024-01-07", "amount": -15, "category": "dining", "description": "Coffee", "type": "expense"}
+	]`))
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	transactions, err := repo.GetByPaymentMethod("cash")
+	if err != nil {
+		t.Fatalf("GetByPaymentMethod() error = %v", err)
+	}
+	if len(transactions) != 1 || transactions[0].Description != "Lunch" {
+		t.Errorf("Expected only 'Lunch' for cash, got %+v", transactions)
+	}
+
+	if _, err := repo.GetByPaymentMethod("bank_transfer"); err != domain.ErrNoTransactions {
+		t.Errorf("Expected ErrNoTransactions, got %v", err)
+	}
+
+	// Unknown payment methods are rejected on create
+	_, err = repo.Create(domain.Transaction{
+		Date: "2024-01-08", Amount: -10, Category: "dining", Type: "expense", PaymentMethod: "crypto",
+	})
+	if !errors.Is(err, domain.ErrInvalidPaymentMethod) {
+		t.Errorf("Expected ErrInvalidPaymentMethod, got %v", err)
+	}
+}
+
+func TestJSONRepository_Filter(t *testing.T) {
+	repo, _ := NewJSONRepository(testJSON)
+	date := func(value string) *time.Time {
+		d, _ := time.Parse("2006-01-02", value)
+		return &d
+	}
+	amount := func(value float64) *float64 { return &value }
+
+	tests := []struct {
+		name          string
+		filter        domain.TransactionFilter
+		expectedCount int
+	}{
+		{"empty filter returns all", domain.TransactionFilter{}, 5},
+		{"type and category", domain.TransactionFilter{Types: []string{"expense"}, Categories: []string{"rent"}}, 2},
+		{"type and category disagree", domain.TransactionFilter{Types: []string{"income"}, Categories: []string{"rent"}}, 0},
+		{"any of several categories", domain.TransactionFilter{Categories: []string{"rent", "groceries"}}, 3},
+		{"date and amount", domain.TransactionFilter{StartDate: date("2024-01-01"), EndDate: date("2024-01-31"), MinAmount: amount(100)}, 2},
+		{"date and amount with no overlap", domain.TransactionFilter{StartDate: date("2024-02-01"), MaxAmount: amount(100)}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := repo.Filter(tt.filter)
+			if err != nil {
+				t.Fatalf("Filter() error = %v", err)
+			}
+			if len(result) != tt.expectedCount {
+				t.Errorf("Filter() returned %d transactions, want %d", len(result), tt.expectedCount)
+			}
+			for _, tx := range result {
+				if !tt.filter.Matches(tx) {
+					t.Errorf("Filter() returned non-matching transaction %+v", tx)
+				}
+			}
+		})
+	}
+
+	emptyRepo, _ := NewJSONRepository([]byte(`[]`))
+	if _, err := emptyRepo.Filter(domain.TransactionFilter{}); !errors.Is(err, domain.ErrNoTransactions) {
+		t.Errorf("Expected ErrNoTransactions on empty repository, got %v", err)
+	}
+}
+
 func TestJSONRepository_GetDateRange(t *testing.T) {
 	repo, err := NewJSONRepository(testJSON)
 	if err != nil {
@@ -323,3 +512,514 @@ func TestJSONRepository_Count(t *testing.T) {
 	}
 }
 
+type descriptionEnricher struct{}
+
+func (descriptionEnricher) Enrich(tx *domain.Transaction) {
+	tx.Merchant = tx.Description
+}
+
+func TestJSONRepository_Enrich(t *testing.T) {
+	repo, err := NewJSONRepository(testJSON)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	repo.Enrich(descriptionEnricher{})
+
+	transactions, _ := repo.GetAll()
+	for _, tx := range transactions {
+		if tx.Merchant != tx.Description {
+			t.Errorf("Expected merchant %q, got %q", tx.Description, tx.Merchant)
+		}
+	}
+}
+
+func TestJSONRepository_Create(t *testing.T) {
+	repo, err := NewJSONRepository(testJSON)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	before := repo.Count()
+
+	valid := domain.Transaction{
+		Date:        "2024-03-01",
+		Amount:      -25,
+		Category:    "dining",
+		Description: "Lunch",
+		Type:        "expense",
+		Merchant:    "  Cafe  ",
+	}
+	created, err := repo.Create(valid)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if created.ID == "" || created.Merchant != "Cafe" {
+		t.Errorf("Expected the stored copy with an ID and trimmed merchant, got %+v", created)
+	}
+
+	invalid := domain.Transaction{Date: "bad", Amount: 10, Type: "expense"}
+	if _, err := repo.Create(invalid); !errors.Is(err, domain.ErrInvalidDate) {
+		t.Errorf("Expected ErrInvalidDate, got %v", err)
+	}
+
+	if repo.Count() != before+1 {
+		t.Errorf("Expected %d transactions, got %d", before+1, repo.Count())
+	}
+
+	// Stored transactions are normalized by validation
+	byMerchant, err := repo.GetByMerchant("Cafe")
+	if err != nil || len(byMerchant) != 1 {
+		t.Errorf("Expected created transaction to be retrievable, got %v (err %v)", byMerchant, err)
+	}
+}
+
+// setupBenchmarkRepository loads the 112-transaction data file used by the server
+func setupBenchmarkRepository(b *testing.B) *JSONRepository {
+	b.Helper()
+
+	data := sampledata.Transactions(b)
+
+	repo, err := NewJSONRepository(data)
+	if err != nil {
+		b.Fatalf("Failed to create repository: %v", err)
+	}
+
+	return repo
+}
+
+func BenchmarkGetAll(b *testing.B) {
+	repo := setupBenchmarkRepository(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetAll(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetByDateRange(b *testing.B) {
+	repo := setupBenchmarkRepository(b)
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetByDateRange(start, end); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetByCategory(b *testing.B) {
+	repo := setupBenchmarkRepository(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetByCategory("groceries"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// FuzzNewJSONRepository checks that arbitrary input never makes the loader panic
+// Errors are acceptable. Run extended fuzzing with, e.g.:
+//
+//	go test -run='^$' -fuzz=FuzzNewJSONRepository -fuzztime=10m ./internal/repository
+//
+// Failing inputs are saved under testdata/fuzz/ and replayed by plain `go test`.
+func FuzzNewJSONRepository(f *testing.F) {
+	f.Add([]byte(`[]`))
+	f.Add([]byte(``))
+	f.Add([]byte(`null`))
+	f.Add([]byte(`{}`))
+	f.Add([]byte(`[{"date": "2024-01-01", "amount": 2800, "category": "salary", "description": "Bi-weekly salary", "type": "income"}]`))
+	f.Add([]byte(`[{"date": "2024-01-01", "amount": 0, "category": "misc", "description": "Zero amount", "type": "expense"}]`))
+	f.Add([]byte(`[{"date": "2024-01-01", "amount": -1e308, "category": "misc", "description": "` + strings.Repeat("x", 10000) + `", "type": "expense"}]`))
+	f.Add([]byte(`[{"date": "not-a-date", "amount": "12", "tags": [""], "currency": 5}]`))
+	f.Add(testJSON)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		repo, err := NewJSONRepository(data)
+		if err != nil {
+			return
+		}
+
+		// Exercise the read paths on whatever loaded successfully
+		repo.GetAll()
+		repo.GetDateRange()
+		repo.Count()
+	})
+}
+
+func TestConcurrentTransactionRead(t *testing.T) {
+	t.Parallel()
+
+	repo, err := NewJSONRepository(testJSON)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	counts := make(chan int, 100)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			transactions, err := repo.GetAll()
+			if err != nil {
+				t.Errorf("GetAll() error = %v", err)
+				return
+			}
+			counts <- len(transactions)
+		}()
+	}
+	wg.Wait()
+	close(counts)
+
+	for count := range counts {
+		if count != 5 {
+			t.Errorf("Expected 5 transactions, got %d", count)
+		}
+	}
+}
+
+func TestConcurrentMixedOperations(t *testing.T) {
+	t.Parallel()
+
+	repo, err := NewJSONRepository(testJSON)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	const writers, readers = 20, 80
+	var wg sync.WaitGroup
+
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			tx := domain.Transaction{
+				Date:        "2024-03-01",
+				Amount:      -float64(10 + i),
+				Category:    "groceries",
+				Description: "Concurrent write",
+				Type:        "expense",
+			}
+			if _, err := repo.Create(tx); err != nil {
+				t.Errorf("Create() error = %v", err)
+			}
+		}(i)
+	}
+
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			// Mutating a returned slice must never reach the repository
+			transactions, err := repo.GetAll()
+			if err != nil {
+				t.Errorf("GetAll() error = %v", err)
+				return
+			}
+			if len(transactions) > 0 {
+				transactions[0].Amount = 0
+			}
+
+			if _, err := repo.GetByCategory("groceries"); err != nil {
+				t.Errorf("GetByCategory() error = %v", err)
+			}
+			if _, _, err := repo.GetDateRange(); err != nil {
+				t.Errorf("GetDateRange() error = %v", err)
+			}
+			repo.Count()
+		}(i)
+	}
+	wg.Wait()
+
+	if count := repo.Count(); count != 5+writers {
+		t.Errorf("Expected %d transactions after concurrent writes, got %d", 5+writers, count)
+	}
+
+	transactions, _ := repo.GetAll()
+	if transactions[0].Amount != 2800 {
+		t.Errorf("Expected stored transaction to be unaffected by caller mutation, got %v", transactions[0].Amount)
+	}
+}
+
+func TestJSONRepository_RenameCategory(t *testing.T) {
+	repo, _ := NewJSONRepository(testJSON)
+
+	renamed, err := repo.RenameCategory("rent", "housing")
+	if err != nil {
+		t.Fatalf("RenameCategory() error = %v", err)
+	}
+	if renamed != 2 {
+		t.Errorf("RenameCategory() = %d, want 2", renamed)
+	}
+
+	if _, err := repo.GetByCategory("rent"); err != domain.ErrNoTransactions {
+		t.Errorf("Expected no rent transactions after rename, got err = %v", err)
+	}
+	if housing, _ := repo.GetByCategory("housing"); len(housing) != 2 {
+		t.Errorf("Expected 2 housing transactions, got %d", len(housing))
+	}
+
+	// Renaming a category with no transactions is a no-op
+	if renamed, _ := repo.RenameCategory("rent", "housing"); renamed != 0 {
+		t.Errorf("RenameCategory() of a missing category = %d, want 0", renamed)
+	}
+}
+
+func TestJSONRepository_GetByAmountRange(t *testing.T) {
+	repo, _ := NewJSONRepository([]byte(`[
+		{"date": "2024-01-01", "amount": 2800, "category": "salary", "description": "Salary", "type": "income"},
+		{"date": "2024-01-03", "amount": -150, "category": "groceries", "description": "Whole Foods", "type": "expense"},
+		{"date": "2024-01-04", "amount": 120, "category": "refund", "description": "Returned jacket", "type": "income"},
+		{"date": "2024-01-05", "amount": -45, "category": "utilities", "description": "Electric bill", "type": "expense"}
+	]`))
+
+	tests := []struct {
+		name          string
+		min, max      float64
+		expectedCount int
+		wantErr       error
+	}{
+		{"expense and income by magnitude", 100, 200, 2, nil},
+		{"inclusive bounds", 45, 150, 3, nil},
+		{"single amount", 150, 150, 1, nil},
+		{"no matches", 300, 400, 0, domain.ErrNoTransactions},
+		{"inverted range", 200, 100, 0, domain.ErrInvalidAmountRange},
+		{"negative minimum", -200, 100, 0, domain.ErrInvalidAmountRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := repo.GetByAmountRange(tt.min, tt.max)
+			if err != tt.wantErr {
+				t.Fatalf("GetByAmountRange() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(result) != tt.expectedCount {
+				t.Errorf("GetByAmountRange() returned %d transactions, want %d", len(result), tt.expectedCount)
+			}
+		})
+	}
+
+	// A -$150 expense falls in the (100, 200) range
+	result, _ := repo.GetByAmountRange(100, 200)
+	if result[0].Amount != -150 {
+		t.Errorf("Expected the -150 expense first, got %+v", result[0])
+	}
+}
+
+func TestJSONRepository_IDs(t *testing.T) {
+	repo, _ := NewJSONRepository([]byte(`[
+		{"id": "seed-1", "date": "2024-01-01", "amount": 2800, "category": "salary", "description": "Salary", "type": "income"},
+		{"date": "2024-01-02", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"}
+	]`))
+
+	if tx, err := repo.GetByID("seed-1"); err != nil || tx.Category != "salary" {
+		t.Errorf("GetByID(seed-1) = %+v, %v; want the salary transaction", tx, err)
+	}
+	if tx, err := repo.GetByID("tx-1"); err != nil || tx.Category != "rent" {
+		t.Errorf("GetByID(tx-1) = %+v, %v; want the generated ID on the rent transaction", tx, err)
+	}
+
+	if _, err := repo.Create(domain.Transaction{Date: "2024-01-03", Amount: -85, Category: "groceries", Description: "Whole Foods", Type: "expense"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if tx, err := repo.GetByID("tx-2"); err != nil || tx.Category != "groceries" {
+		t.Errorf("GetByID(tx-2) = %+v, %v; want the created transaction", tx, err)
+	}
+
+	if _, err := repo.GetByID("missing"); err != domain.ErrTransactionNotFound {
+		t.Errorf("GetByID(missing) error = %v, want ErrTransactionNotFound", err)
+	}
+
+	// Client IDs must be unique, and generated IDs skip past numeric ones
+	groceries := domain.Transaction{Date: "2024-01-04", Amount: -40, Category: "groceries", Description: "Market", Type: "expense"}
+	for _, id := range []string{"seed-1", "tx-2"} {
+		groceries.ID = id
+		if _, err := repo.Create(groceries); !errors.Is(err, domain.ErrDuplicateID) {
+			t.Errorf("Create() with taken ID %s error = %v, want ErrDuplicateID", id, err)
+		}
+	}
+	groceries.ID = "tx-3"
+	if _, err := repo.Create(groceries); err != nil {
+		t.Fatalf("Create() with a free client ID error = %v", err)
+	}
+	groceries.ID = ""
+	if created, err := repo.Create(groceries); err != nil || created.ID != "tx-4" {
+		t.Errorf("Create() = %q, %v; want generated ID tx-4", created.ID, err)
+	}
+}
+
+func TestJSONRepository_IDsNeverCollideWithLoadedOnes(t *testing.T) {
+	repo, _ := NewJSONRepository([]byte(`[
+		{"date": "2024-01-02", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"},
+		{"id": "tx-5", "date": "2024-01-01", "amount": 2800, "category": "salary", "description": "Salary", "type": "income"}
+	]`))
+
+	// The loaded tx-5 comes later in the file, but the generated ID still continues after it
+	if tx, err := repo.GetByID("tx-6"); err != nil || tx.Category != "rent" {
+		t.Errorf("GetByID(tx-6) = %+v, %v; want the generated ID on the rent transaction", tx, err)
+	}
+	if tx, _ := repo.GetByID("tx-5"); tx.Category != "salary" {
+		t.Errorf("GetByID(tx-5) = %+v, want the loaded salary transaction", tx)
+	}
+}
+
+func TestJSONRepository_Update(t *testing.T) {
+	repo, _ := NewJSONRepository(testJSON)
+
+	tx, _ := repo.GetByID("tx-2")
+	tx.Description = "Rent (January)"
+	tx.ID = "ignored"
+	if err := repo.Update("tx-2", tx); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	updated, err := repo.GetByID("tx-2")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if updated.Description != "Rent (January)" || updated.ID != "tx-2" {
+		t.Errorf("Update() stored %+v, want the new description under the same ID", updated)
+	}
+
+	// Invalid transactions are rejected without changing the stored one
+	tx.Type = "transfer"
+	var validationErrs *domain.ValidationErrors
+	if err := repo.Update("tx-2", tx); !errors.As(err, &validationErrs) {
+		t.Errorf("Update() of an invalid transaction error = %v, want *ValidationErrors", err)
+	}
+	if stored, _ := repo.GetByID("tx-2"); stored.Type != "expense" {
+		t.Errorf("Expected the stored transaction to be unchanged, got type %q", stored.Type)
+	}
+
+	if err := repo.Update("missing", updated); err != domain.ErrTransactionNotFound {
+		t.Errorf("Update(missing) error = %v, want ErrTransactionNotFound", err)
+	}
+}
+
+func TestJSONRepository_BulkInsert(t *testing.T) {
+	repo, _ := NewJSONRepository(testJSON)
+	before := repo.Count()
+
+	err := repo.BulkInsert([]domain.Transaction{
+		{Date: "2024-03-01", Amount: -60, Category: "groceries", Description: "Market", Type: "expense"},
+		{Date: "2024-03-02", Amount: 60, Category: "groceries", Description: "Market", Type: "expense"},
+	})
+	var validationErrs *domain.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("BulkInsert() with an invalid transaction error = %v, want *ValidationErrors", err)
+	}
+	if repo.Count() != before {
+		t.Errorf("Expected nothing stored after a failed BulkInsert, got %d transactions", repo.Count())
+	}
+
+	err = repo.BulkInsert([]domain.Transaction{
+		{Date: "2024-03-01", Amount: -60, Category: "groceries", Description: "Market", Type: "expense"},
+		{Date: "2024-03-02", Amount: 100, Category: "refund", Description: "Refund", Type: "income"},
+	})
+	if err != nil {
+		t.Fatalf("BulkInsert() error = %v", err)
+	}
+	if repo.Count() != before+2 {
+		t.Errorf("Expected %d transactions, got %d", before+2, repo.Count())
+	}
+	if tx, err := repo.GetByID("tx-" + strconv.Itoa(before+2)); err != nil || tx.Category != "refund" {
+		t.Errorf("Expected inserted transactions to get IDs, got %+v, %v", tx, err)
+	}
+
+	// A taken or repeated ID rejects the whole batch
+	before = repo.Count()
+	for name, batch := range map[string][]domain.Transaction{
+		"taken ID": {
+			{Date: "2024-03-03", Amount: -20, Category: "dining", Description: "Cafe", Type: "expense"},
+			{ID: "tx-1", Date: "2024-03-04", Amount: -20, Category: "dining", Description: "Cafe", Type: "expense"},
+		},
+		"repeated ID": {
+			{ID: "import-1", Date: "2024-03-03", Amount: -20, Category: "dining", Description: "Cafe", Type: "expense"},
+			{ID: "import-1", Date: "2024-03-04", Amount: -20, Category: "dining", Description: "Cafe", Type: "expense"},
+		},
+	} {
+		if err := repo.BulkInsert(batch); !errors.Is(err, domain.ErrDuplicateID) {
+			t.Errorf("BulkInsert() with a %s error = %v, want ErrDuplicateID", name, err)
+		}
+	}
+	if repo.Count() != before {
+		t.Errorf("Expected nothing stored after rejected batches, got %d transactions", repo.Count()-before)
+	}
+}
+
+func TestJSONRepository_FindDuplicates(t *testing.T) {
+	data := sampledata.Transactions(t)
+	var transactions []domain.Transaction
+	if err := json.Unmarshal(data, &transactions); err != nil {
+		t.Fatalf("Failed to parse data file: %v", err)
+	}
+
+	// The data file has no duplicates of its own
+	repo, err := NewJSONRepository(data)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	groups, err := repo.FindDuplicates()
+	if err != nil {
+		t.Fatalf("FindDuplicates() error = %v", err)
+	}
+	if groups == nil || len(groups) != 0 {
+		t.Fatalf("Expected no duplicates in the data file, got %+v", groups)
+	}
+
+	// Inject an exact copy, a copy with another description and one with another category
+	exact := transactions[2]
+	sameCategory := transactions[5]
+	sameCategory.Description = "Card charge"
+	sameDescription := transactions[10]
+	sameDescription.Category = "other"
+	sameDescription.Description = "  INTERNET AND CABLE"
+	injected := append(transactions, exact, sameCategory, sameDescription)
+
+	data, _ = json.Marshal(injected)
+	repo, err = NewJSONRepository(data)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	groups, err = repo.FindDuplicates()
+	if err != nil {
+		t.Fatalf("FindDuplicates() error = %v", err)
+	}
+
+	expected := []struct {
+		original  domain.Transaction
+		duplicate domain.Transaction
+		reason    string
+	}{
+		{transactions[2], exact, "same date, amount, category and description"},
+		{transactions[5], sameCategory, "same date, amount and category"},
+		{transactions[10], sameDescription, "same date, amount and description"},
+	}
+	if len(groups) != len(expected) {
+		t.Fatalf("Expected %d duplicate groups, got %d: %+v", len(expected), len(groups), groups)
+	}
+	for i, want := range expected {
+		group := groups[i]
+		if group.Reason != want.reason {
+			t.Errorf("Group %d reason = %q, want %q", i, group.Reason, want.reason)
+		}
+		if len(group.Transactions) != 2 ||
+			group.Transactions[0].Description != want.original.Description || group.Transactions[0].Category != want.original.Category ||
+			group.Transactions[1].Description != want.duplicate.Description || group.Transactions[1].Category != want.duplicate.Category {
+			t.Errorf("Group %d = %+v, want the original followed by the injected copy", i, group.Transactions)
+		}
+	}
+}
+
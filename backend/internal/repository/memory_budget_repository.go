@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// InMemoryBudgetRepository implements BudgetRepository with an in-memory map
+// Budgets are lost on restart.
+type InMemoryBudgetRepository struct {
+	mu      sync.RWMutex
+	budgets map[string]domain.Budget
+}
+
+// NewInMemoryBudgetRepository creates an empty budget repository
+func NewInMemoryBudgetRepository() *InMemoryBudgetRepository {
+	return &InMemoryBudgetRepository{
+		budgets: make(map[string]domain.Budget),
+	}
+}
+
+// GetAll returns every budget, sorted by category
+func (r *InMemoryBudgetRepository) GetAll() ([]domain.Budget, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	budgets := make([]domain.Budget, 0, len(r.budgets))
+	for _, budget := range r.budgets {
+		budgets = append(budgets, budget)
+	}
+	sort.Slice(budgets, func(i, j int) bool {
+		return budgets[i].Category < budgets[j].Category
+	})
+
+	return budgets, nil
+}
+
+// GetByCategory returns the budget for a category
+func (r *InMemoryBudgetRepository) GetByCategory(category string) (domain.Budget, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	budget, ok := r.budgets[category]
+	if !ok {
+		return domain.Budget{}, domain.ErrBudgetNotFound
+	}
+	return budget, nil
+}
+
+// Create stores a budget after validating it
+func (r *InMemoryBudgetRepository) Create(budget domain.Budget) error {
+	if err := budget.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.budgets[budget.Category] = budget
+	return nil
+}
+
+// Update changes the limit of an existing budget
+func (r *InMemoryBudgetRepository) Update(budget domain.Budget) error {
+	if err := budget.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.budgets[budget.Category]; !ok {
+		return domain.ErrBudgetNotFound
+	}
+	r.budgets[budget.Category] = budget
+	return nil
+}
+
+// Delete removes the budget for a category
+func (r *InMemoryBudgetRepository) Delete(category string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.budgets[category]; !ok {
+		return domain.ErrBudgetNotFound
+	}
+	delete(r.budgets, category)
+	return nil
+}
+
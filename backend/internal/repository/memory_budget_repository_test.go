@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func TestInMemoryBudgetRepository(t *testing.T) {
+	repo := NewInMemoryBudgetRepository()
+
+	if err := repo.Create(domain.Budget{Category: " dining ", MonthlyLimit: 200}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repo.Create(domain.Budget{Category: "groceries", MonthlyLimit: 0}); !errors.Is(err, domain.ErrInvalidBudget) {
+		t.Errorf("Expected ErrInvalidBudget, got %v", err)
+	}
+
+	budget, err := repo.GetByCategory("dining")
+	if err != nil || budget.MonthlyLimit != 200 {
+		t.Fatalf("GetByCategory() = %+v, %v", budget, err)
+	}
+
+	if err := repo.Update(domain.Budget{Category: "dining", MonthlyLimit: 250}); err != nil {
+		t.Errorf("Update() error = %v", err)
+	}
+	if err := repo.Update(domain.Budget{Category: "rent", MonthlyLimit: 1000}); !errors.Is(err, domain.ErrBudgetNotFound) {
+		t.Errorf("Expected ErrBudgetNotFound updating a missing budget, got %v", err)
+	}
+
+	budgets, _ := repo.GetAll()
+	if len(budgets) != 1 || budgets[0].MonthlyLimit != 250 {
+		t.Errorf("GetAll() = %+v", budgets)
+	}
+
+	if err := repo.Delete("dining"); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if _, err := repo.GetByCategory("dining"); !errors.Is(err, domain.ErrBudgetNotFound) {
+		t.Errorf("Expected ErrBudgetNotFound after delete, got %v", err)
+	}
+}
+
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// DataSource is one named JSON array of transactions, e.g., an account's export
+type DataSource struct {
+	Name string // Stored as the AccountID of its transactions
+	Data []byte
+}
+
+// NewMergedRepository creates a JSON repository from several sources, e.g., one file per account
+// Each source's transactions are tagged with its name as AccountID, then the sources are
+// merged in the given order with the same rules as NewJSONRepository, so the first copy of
+// a transaction found in several sources is the one kept.
+func NewMergedRepository(sources []DataSource) (*JSONRepository, error) {
+	parsed := make([][]domain.Transaction, len(sources))
+	for i, source := range sources {
+		transactions, err := parseTransactions(source.Data)
+		if err != nil {
+			return nil, fmt.Errorf("data source %q: %w", source.Name, err)
+		}
+		for j := range transactions {
+			transactions[j].AccountID = source.Name
+		}
+		parsed[i] = transactions
+	}
+
+	return newJSONRepository(mergeSources(parsed)), nil
+}
+
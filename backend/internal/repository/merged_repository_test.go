@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewMergedRepository(t *testing.T) {
+	checking := []byte(`[
+		{"date": "2024-01-01", "amount": 2800, "category": "salary", "description": "Bi-weekly salary", "type": "income"},
+		{"date": "2024-01-02", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"},
+		{"date": "2024-01-05", "amount": -500, "category": "transfer", "description": "To savings", "type": "expense"}
+	]`)
+	savings := []byte(`[
+		{"date": "2024-01-05", "amount": -500, "category": "transfer", "description": "To savings", "type": "expense"},
+		{"date": "2024-01-31", "amount": 4.2, "category": "interest", "description": "Monthly interest", "type": "income"}
+	]`)
+
+	repo, err := NewMergedRepository([]DataSource{{"checking", checking}, {"savings", savings}})
+	if err != nil {
+		t.Fatalf("NewMergedRepository() error = %v", err)
+	}
+
+	// The transfer appears in both exports and is kept once
+	if count := repo.Count(); count != 3+2-1 {
+		t.Fatalf("Count() = %d, want %d", count, 3+2-1)
+	}
+
+	accounts := map[string]string{}
+	transactions, _ := repo.GetAll()
+	for _, tx := range transactions {
+		accounts[tx.Category] = tx.AccountID
+		if tx.ID == "" {
+			t.Errorf("Expected every merged transaction to have an ID, got %+v", tx)
+		}
+	}
+
+	want := map[string]string{"salary": "checking", "rent": "checking", "transfer": "checking", "interest": "savings"}
+	for category, account := range want {
+		if accounts[category] != account {
+			t.Errorf("AccountID of %s = %q, want %q", category, accounts[category], account)
+		}
+	}
+}
+
+func TestNewMergedRepository_DuplicateIDs(t *testing.T) {
+	repo, err := NewMergedRepository([]DataSource{
+		{"checking", []byte(`[{"id": "tx-9", "date": "2024-01-02", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"}]`)},
+		{"external", []byte(`[{"id": "tx-9", "date": "2024-01-02", "amount": -1200, "category": "housing", "description": "Rent", "type": "expense"}]`)},
+	})
+	if err != nil {
+		t.Fatalf("NewMergedRepository() error = %v", err)
+	}
+
+	// The contents differ, but IDs must stay unique
+	if count := repo.Count(); count != 1 {
+		t.Fatalf("Count() = %d, want 1", count)
+	}
+	if tx, _ := repo.GetByID("tx-9"); tx.AccountID != "checking" {
+		t.Errorf("Expected the first source's transaction, got %+v", tx)
+	}
+}
+
+func TestNewMergedRepository_SourceOrder(t *testing.T) {
+	coffee := []byte(`[
+		{"date": "2024-01-02", "amount": -5, "category": "dining", "description": "Coffee", "type": "expense"},
+		{"date": "2024-01-02", "amount": -5, "category": "dining", "description": "Coffee", "type": "expense"}
+	]`)
+
+	// Sources are merged in the order given, not by name
+	repo, err := NewMergedRepository([]DataSource{{"zeta", coffee}, {"alpha", coffee}})
+	if err != nil {
+		t.Fatalf("NewMergedRepository() error = %v", err)
+	}
+
+	// Identical rows within one file are separate purchases; the second file repeats both
+	transactions, _ := repo.GetAll()
+	if len(transactions) != 2 {
+		t.Fatalf("Count() = %d, want 2", len(transactions))
+	}
+	for _, tx := range transactions {
+		if tx.AccountID != "zeta" {
+			t.Errorf("AccountID = %q, want the first source zeta", tx.AccountID)
+		}
+	}
+}
+
+func TestNewMergedRepository_InvalidSource(t *testing.T) {
+	_, err := NewMergedRepository([]DataSource{
+		{"checking", []byte(`[]`)},
+		{"broken", []byte(`{not json`)},
+	})
+	if err == nil {
+		t.Fatal("Expected an error for invalid JSON, got nil")
+	}
+	if want := `data source "broken"`; !strings.Contains(err.Error(), want) {
+		t.Errorf("Error = %q, want it to mention %s", err, want)
+	}
+}
+
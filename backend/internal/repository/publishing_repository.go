@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// PublishingRepository wraps a TransactionRepository and publishes an event for every change
+// Created transactions are announced with EventTransactionCreated; every successful write,
+// creation included, is followed by EventAnalyticsUpdated. Reads pass straight through.
+type PublishingRepository struct {
+	TransactionRepository
+	bus domain.EventBus
+}
+
+// NewPublishingRepository wraps inner, publishing changes to bus
+func NewPublishingRepository(inner TransactionRepository, bus domain.EventBus) *PublishingRepository {
+	return &PublishingRepository{TransactionRepository: inner, bus: bus}
+}
+
+// Create stores the transaction and announces it as stored
+func (r *PublishingRepository) Create(tx domain.Transaction) (domain.Transaction, error) {
+	created, err := r.TransactionRepository.Create(tx)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+
+	r.bus.Publish(domain.Event{Type: domain.EventTransactionCreated, Data: created})
+	r.publishUpdate("created", 1)
+	return created, nil
+}
+
+// BulkInsert stores the transactions and announces the analytics change
+func (r *PublishingRepository) BulkInsert(transactions []domain.Transaction) error {
+	if err := r.TransactionRepository.BulkInsert(transactions); err != nil {
+		return err
+	}
+
+	if len(transactions) > 0 {
+		r.publishUpdate("imported", len(transactions))
+	}
+	return nil
+}
+
+// Update replaces the transaction and announces the analytics change
+func (r *PublishingRepository) Update(id string, tx domain.Transaction) error {
+	if err := r.TransactionRepository.Update(id, tx); err != nil {
+		return err
+	}
+
+	r.publishUpdate("updated", 1)
+	return nil
+}
+
+// RenameCategory renames the category and announces the change if any transaction moved
+func (r *PublishingRepository) RenameCategory(source, target string) (int, error) {
+	renamed, err := r.TransactionRepository.RenameCategory(source, target)
+	if err != nil {
+		return renamed, err
+	}
+
+	if renamed > 0 {
+		r.publishUpdate("category_renamed", renamed)
+	}
+	return renamed, nil
+}
+
+// publishUpdate announces that count transactions changed
+func (r *PublishingRepository) publishUpdate(change string, count int) {
+	r.bus.Publish(domain.Event{
+		Type: domain.EventAnalyticsUpdated,
+		Data: domain.AnalyticsUpdate{Change: change, Count: count},
+	})
+}
+
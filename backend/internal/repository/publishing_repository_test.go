@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// recordingBus collects published events
+type recordingBus struct {
+	events []domain.Event
+}
+
+func (b *recordingBus) Subscribe() <-chan domain.Event         { return nil }
+func (b *recordingBus) Unsubscribe(events <-chan domain.Event) {}
+func (b *recordingBus) Publish(event domain.Event)             { b.events = append(b.events, event) }
+
+func newTestPublishingRepository(t *testing.T) (*PublishingRepository, *recordingBus) {
+	t.Helper()
+
+	inner, err := NewJSONRepository([]byte(`[{"date": "2024-01-05", "amount": -20, "category": "dining", "description": "Lunch", "type": "expense"}]`))
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	bus := &recordingBus{}
+	return NewPublishingRepository(inner, bus), bus
+}
+
+func TestPublishingRepository_CreatePublishesTransactionAndUpdate(t *testing.T) {
+	repo, bus := newTestPublishingRepository(t)
+
+	created, err := repo.Create(domain.Transaction{
+		Date: "2024-01-06", Amount: -12, Category: "transportation", Description: "Bus pass", Type: "expense",
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if len(bus.events) != 2 {
+		t.Fatalf("Expected 2 events, got %+v", bus.events)
+	}
+	if bus.events[0].Type != domain.EventTransactionCreated {
+		t.Errorf("Expected %s first, got %s", domain.EventTransactionCreated, bus.events[0].Type)
+	}
+	if tx, ok := bus.events[0].Data.(domain.Transaction); !ok || tx.ID != created.ID {
+		t.Errorf("Expected the stored transaction %s, got %+v", created.ID, bus.events[0].Data)
+	}
+	want := domain.AnalyticsUpdate{Change: "created", Count: 1}
+	if bus.events[1].Type != domain.EventAnalyticsUpdated || bus.events[1].Data != want {
+		t.Errorf("Expected %s with %+v, got %+v", domain.EventAnalyticsUpdated, want, bus.events[1])
+	}
+}
+
+func TestPublishingRepository_PublishesOnlyActualChanges(t *testing.T) {
+	repo, bus := newTestPublishingRepository(t)
+
+	if _, err := repo.Create(domain.Transaction{Date: "not-a-date"}); err == nil {
+		t.Fatal("Expected an invalid transaction to be rejected")
+	}
+	if renamed, err := repo.RenameCategory("utilities", "bills"); err != nil || renamed != 0 {
+		t.Fatalf("RenameCategory() = %d, %v; want 0, nil", renamed, err)
+	}
+	if err := repo.Update("tx-404", domain.Transaction{
+		Date: "2024-01-06", Amount: -12, Category: "dining", Description: "Dinner", Type: "expense",
+	}); err == nil {
+		t.Fatal("Expected updating an unknown ID to fail")
+	}
+	if len(bus.events) != 0 {
+		t.Fatalf("Expected no events, got %+v", bus.events)
+	}
+
+	if _, err := repo.RenameCategory("dining", "restaurants"); err != nil {
+		t.Fatalf("RenameCategory() error = %v", err)
+	}
+	want := domain.AnalyticsUpdate{Change: "category_renamed", Count: 1}
+	if len(bus.events) != 1 || bus.events[0].Data != want {
+		t.Errorf("Expected one update %+v, got %+v", want, bus.events)
+	}
+}
+
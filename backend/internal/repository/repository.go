@@ -18,15 +18,104 @@ type TransactionRepository interface {
 	// Returns ErrNoTransactions if no transactions found in range
 	GetByDateRange(start, end time.Time) ([]domain.Transaction, error)
 
+	// GetByAmountRange returns transactions whose absolute amount is within [minAbs, maxAbs]
+	// Income and expenses are compared the same way, so -150 matches (100, 200).
+	// Returns ErrInvalidAmountRange if minAbs is negative or greater than maxAbs
+	// Returns ErrNoTransactions if no transactions found in range
+	GetByAmountRange(minAbs, maxAbs float64) ([]domain.Transaction, error)
+
 	// GetByType returns all transactions of a specific type ("income" or "expense")
 	GetByType(txType string) ([]domain.Transaction, error)
 
 	// GetByCategory returns all transactions for a specific category
 	GetByCategory(category string) ([]domain.Transaction, error)
 
+	// GetByTag returns all transactions labeled with a specific tag
+	GetByTag(tag string) ([]domain.Transaction, error)
+
+	// GetByMerchant returns all transactions for a specific merchant (case-sensitive)
+	GetByMerchant(merchant string) ([]domain.Transaction, error)
+
+	// GetByPaymentMethod returns all transactions paid with a specific method, e.g., "cash"
+	GetByPaymentMethod(method string) ([]domain.Transaction, error)
+
+	// Filter returns the transactions matching every criterion of filter (AND semantics)
+	// An empty filter returns all transactions; no matches returns an empty slice.
+	// Returns ErrNoTransactions only if the data source has no transactions at all.
+	Filter(filter domain.TransactionFilter) ([]domain.Transaction, error)
+
+	// GetByID returns the transaction with the given ID
+	// Returns ErrTransactionNotFound if no transaction has that ID
+	GetByID(id string) (domain.Transaction, error)
+
+	// Create stores a new transaction after validating it and returns it as stored
+	// A transaction without an ID is assigned one.
+	// Returns *domain.ValidationErrors if the transaction is invalid and
+	// domain.ErrDuplicateID if its ID is already taken
+	Create(tx domain.Transaction) (domain.Transaction, error)
+
+	// BulkInsert stores every transaction, or none if any is invalid
+	// Transactions without an ID are assigned one.
+	// Returns *domain.ValidationErrors for the first invalid transaction and
+	// domain.ErrDuplicateID if an ID is already taken or repeated in the batch
+	BulkInsert(transactions []domain.Transaction) error
+
+	// Update replaces the transaction with the given ID after validating tx
+	// The stored transaction keeps its ID regardless of tx.ID.
+	// Returns ErrTransactionNotFound if no transaction has that ID
+	// Returns *domain.ValidationErrors if tx is invalid
+	Update(id string, tx domain.Transaction) error
+
+	// RenameCategory moves every transaction in category source to category target
+	// Returns the number of transactions changed; zero if source has none.
+	RenameCategory(source, target string) (int, error)
+
+	// FindDuplicates groups transactions sharing a date and absolute amount and either their
+	// category or their description (case-insensitive)
+	// Returns an empty slice when there are no suspected duplicates.
+	FindDuplicates() ([]domain.DuplicateGroup, error)
+
 	// Future methods for write operations (Phase 2):
-	// Create(tx domain.Transaction) error
-	// Update(id string, tx domain.Transaction) error
 	// Delete(id string) error
 }
 
+// CategoryMetadataRepository defines the interface for category display metadata
+// The built-in entries of domain.DefaultCategories are always present; stored entries
+// override them by name and can describe additional categories.
+type CategoryMetadataRepository interface {
+	// GetAll returns the metadata of every category, sorted by name
+	GetAll() ([]domain.CategoryMetadata, error)
+
+	// GetByName returns the metadata for a category
+	// Returns ErrCategoryNotFound if neither a default nor a stored entry has that name
+	GetByName(name string) (domain.CategoryMetadata, error)
+
+	// Upsert stores metadata for a category, replacing any earlier entry with the same name
+	// The stored entry is no longer a default; IsDefault is ignored.
+	// Returns a domain error if the metadata is invalid
+	Upsert(metadata domain.CategoryMetadata) error
+}
+
+// BudgetRepository defines the interface for category budget storage
+// Budgets are keyed by category; there is at most one budget per category.
+type BudgetRepository interface {
+	// GetAll returns every budget, sorted by category
+	GetAll() ([]domain.Budget, error)
+
+	// GetByCategory returns the budget for a category
+	// Returns ErrBudgetNotFound if the category has no budget
+	GetByCategory(category string) (domain.Budget, error)
+
+	// Create stores a new budget, replacing any existing budget for the category
+	// Returns a domain error if the budget is invalid
+	Create(budget domain.Budget) error
+
+	// Update changes the limit of an existing budget
+	// Returns ErrBudgetNotFound if the category has no budget
+	Update(budget domain.Budget) error
+
+	// Delete removes the budget for a category
+	// Returns ErrBudgetNotFound if the category has no budget
+	Delete(category string) error
+}
+
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// AdviceFeedbackService collects user ratings of generated advice
+type AdviceFeedbackService struct {
+	history AdviceRepository
+}
+
+// NewAdviceFeedbackService creates a feedback service storing feedback alongside advice history
+func NewAdviceFeedbackService(history AdviceRepository) *AdviceFeedbackService {
+	return &AdviceFeedbackService{history: history}
+}
+
+// SubmitFeedback validates and stores feedback for the advice with adviceID
+// Returns *domain.ValidationErrors for an invalid rating or comment, and
+// domain.ErrAdviceNotFound if no advice has that ID
+func (s *AdviceFeedbackService) SubmitFeedback(adviceID string, feedback domain.AdviceFeedback) (*domain.AdviceFeedback, error) {
+	if err := feedback.Validate(); err != nil {
+		return nil, err
+	}
+
+	feedback.AdviceID = adviceID
+	feedback.SubmittedAt = time.Now().UTC()
+
+	if err := s.history.SaveFeedback(feedback); err != nil {
+		return nil, err
+	}
+	return &feedback, nil
+}
+
+// GetAdviceFeedbackStats summarizes all feedback received so far
+// With no feedback, every field is zero
+func (s *AdviceFeedbackService) GetAdviceFeedbackStats() (*domain.FeedbackStats, error) {
+	feedback, err := s.history.ListFeedback()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &domain.FeedbackStats{TotalResponses: len(feedback)}
+	if len(feedback) == 0 {
+		return stats, nil
+	}
+
+	totalRating, helpful := 0, 0
+	for _, f := range feedback {
+		totalRating += f.Rating
+		if f.Helpful {
+			helpful++
+		}
+	}
+
+	stats.AverageRating = roundToN(float64(totalRating)/float64(len(feedback)), metricPrecision)
+	stats.PercentHelpful = roundToN(float64(helpful)/float64(len(feedback))*100, metricPrecision)
+
+	return stats, nil
+}
+
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func setupFeedbackService(t *testing.T) (*AdviceFeedbackService, string) {
+	t.Helper()
+
+	history, err := NewJSONAdviceRepository("")
+	if err != nil {
+		t.Fatalf("NewJSONAdviceRepository() error = %v", err)
+	}
+	if err := history.Save(AdviceRecord{ID: "advice-1"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	return NewAdviceFeedbackService(history), "advice-1"
+}
+
+func TestAdviceFeedbackService_Stats(t *testing.T) {
+	service, adviceID := setupFeedbackService(t)
+
+	stats, err := service.GetAdviceFeedbackStats()
+	if err != nil {
+		t.Fatalf("GetAdviceFeedbackStats() error = %v", err)
+	}
+	if *stats != (domain.FeedbackStats{}) {
+		t.Errorf("Expected zero stats without feedback, got %+v", stats)
+	}
+
+	for _, f := range []domain.AdviceFeedback{
+		{Rating: 5, Helpful: true},
+		{Rating: 4, Helpful: true},
+		{Rating: 2, Helpful: false},
+	} {
+		if _, err := service.SubmitFeedback(adviceID, f); err != nil {
+			t.Fatalf("SubmitFeedback() error = %v", err)
+		}
+	}
+
+	stats, err = service.GetAdviceFeedbackStats()
+	if err != nil {
+		t.Fatalf("GetAdviceFeedbackStats() error = %v", err)
+	}
+
+	// (5 + 4 + 2) / 3 = 3.67; 2 of 3 helpful
+	want := domain.FeedbackStats{AverageRating: 3.67, PercentHelpful: 66.67, TotalResponses: 3}
+	if *stats != want {
+		t.Errorf("GetAdviceFeedbackStats() = %+v, want %+v", *stats, want)
+	}
+}
+
+func TestAdviceFeedbackService_SubmitFeedback_Errors(t *testing.T) {
+	service, adviceID := setupFeedbackService(t)
+
+	tests := []struct {
+		name      string
+		adviceID  string
+		feedback  domain.AdviceFeedback
+		wantErr   error
+		wantField string
+	}{
+		{"unknown advice", "missing", domain.AdviceFeedback{Rating: 3}, domain.ErrAdviceNotFound, ""},
+		{"rating too low", adviceID, domain.AdviceFeedback{Rating: 0}, domain.ErrInvalidRating, "rating"},
+		{"rating too high", adviceID, domain.AdviceFeedback{Rating: 6}, domain.ErrInvalidRating, "rating"},
+		{"comment too long", adviceID, domain.AdviceFeedback{Rating: 3, Comment: strings.Repeat("a", 201)}, domain.ErrInvalidComment, "comment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.SubmitFeedback(tt.adviceID, tt.feedback)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SubmitFeedback() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var validationErrs *domain.ValidationErrors
+			if tt.wantField != "" && (!errors.As(err, &validationErrs) || (*validationErrs)[0].Field != tt.wantField) {
+				t.Errorf("Expected validation error on field %q, got %v", tt.wantField, err)
+			}
+		})
+	}
+
+	// A 200-character comment is still accepted
+	if _, err := service.SubmitFeedback(adviceID, domain.AdviceFeedback{Rating: 3, Comment: strings.Repeat("é", 200)}); err != nil {
+		t.Errorf("SubmitFeedback() with 200-character comment error = %v", err)
+	}
+}
+
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// AdviceRecord is a stored advice request together with the data it was based on
+type AdviceRecord struct {
+	ID                string                  `json:"id"`
+	RequestedAt       time.Time               `json:"requested_at"`
+	Request           AdviceRequest           `json:"request"`
+	Response          AdviceResponse          `json:"response"`
+	FinancialSnapshot domain.CategorySummary  `json:"financial_snapshot"` // Summary sent to the AI
+	Feedback          []domain.AdviceFeedback `json:"feedback,omitempty"`
+}
+
+// AdviceHistoryResponse is one page of advice records, newest first
+type AdviceHistoryResponse struct {
+	Records []AdviceRecord `json:"records"`
+	domain.PaginationMeta
+}
+
+// AdviceRepository stores generated advice
+// Lives in the service package because records embed the AI request/response types
+type AdviceRepository interface {
+	// Save stores a record, assigning an ID when it has none
+	Save(record AdviceRecord) error
+
+	// List returns one page of records sorted by RequestedAt descending (pages start at 1)
+	List(page, pageSize int) (*AdviceHistoryResponse, error)
+
+	// SaveFeedback attaches feedback to the record with feedback.AdviceID
+	// Returns domain.ErrAdviceNotFound if no such record exists
+	SaveFeedback(feedback domain.AdviceFeedback) error
+
+	// ListFeedback returns the feedback for every record
+	ListFeedback() ([]domain.AdviceFeedback, error)
+}
+
+// JSONAdviceRepository keeps advice records in memory, optionally mirrored to a JSON file
+type JSONAdviceRepository struct {
+	mu      sync.RWMutex
+	records []AdviceRecord
+	path    string
+}
+
+// NewJSONAdviceRepository creates an advice repository backed by the JSON file at path
+// Existing records are loaded from the file; an empty path keeps history in memory only.
+func NewJSONAdviceRepository(path string) (*JSONAdviceRepository, error) {
+	repo := &JSONAdviceRepository{
+		records: []AdviceRecord{},
+		path:    path,
+	}
+
+	if path == "" {
+		return repo, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return repo, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &repo.records); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// Save stores a record and rewrites the backing file, if any
+func (r *JSONAdviceRepository) Save(record AdviceRecord) error {
+	if record.ID == "" {
+		id, err := newAdviceID()
+		if err != nil {
+			return err
+		}
+		record.ID = id
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records = append(r.records, record)
+	if err := r.persist(); err != nil {
+		r.records = r.records[:len(r.records)-1]
+		return err
+	}
+
+	return nil
+}
+
+// List returns one page of records, newest first
+// Pages past the end return an empty slice; returns ErrInvalidPage if page or pageSize is below 1
+func (r *JSONAdviceRepository) List(page, pageSize int) (*AdviceHistoryResponse, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Newest first; records saved at the same instant keep reverse insertion order
+	sorted := make([]AdviceRecord, len(r.records))
+	for i, record := range r.records {
+		sorted[len(r.records)-1-i] = record
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].RequestedAt.After(sorted[j].RequestedAt)
+	})
+
+	records, meta, err := domain.Paginate(sorted, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AdviceHistoryResponse{Records: records, PaginationMeta: meta}, nil
+}
+
+// SaveFeedback attaches feedback to its advice record and rewrites the backing file, if any
+func (r *JSONAdviceRepository) SaveFeedback(feedback domain.AdviceFeedback) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.records {
+		if r.records[i].ID != feedback.AdviceID {
+			continue
+		}
+
+		r.records[i].Feedback = append(r.records[i].Feedback, feedback)
+		if err := r.persist(); err != nil {
+			r.records[i].Feedback = r.records[i].Feedback[:len(r.records[i].Feedback)-1]
+			return err
+		}
+		return nil
+	}
+
+	return domain.ErrAdviceNotFound
+}
+
+// ListFeedback returns the feedback for every record, in insertion order
+func (r *JSONAdviceRepository) ListFeedback() ([]domain.AdviceFeedback, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	feedback := []domain.AdviceFeedback{}
+	for _, record := range r.records {
+		feedback = append(feedback, record.Feedback...)
+	}
+	return feedback, nil
+}
+
+// persist writes all records to the backing file via a temp file and rename
+// Must be called with the lock held
+func (r *JSONAdviceRepository) persist() error {
+	if r.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(r.records, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), ".advice-history-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), r.path)
+}
+
+// newAdviceID returns a random 16-character hex identifier
+func newAdviceID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestJSONAdviceRepository_PersistsToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "advice.json")
+
+	repo, err := NewJSONAdviceRepository(path)
+	if err != nil {
+		t.Fatalf("NewJSONAdviceRepository() error = %v", err)
+	}
+
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, adviceContext := range []string{"general", "savings"} {
+		record := AdviceRecord{
+			RequestedAt: base.Add(time.Duration(i) * time.Hour),
+			Request:     AdviceRequest{Context: adviceContext},
+		}
+		if err := repo.Save(record); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	// A new repository reads the records back
+	reloaded, err := NewJSONAdviceRepository(path)
+	if err != nil {
+		t.Fatalf("Failed to reload advice history: %v", err)
+	}
+
+	page, err := reloaded.List(1, 10)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if page.Total != 2 {
+		t.Fatalf("Expected 2 records, got %d", page.Total)
+	}
+	if page.Records[0].Request.Context != "savings" || page.Records[0].ID == "" {
+		t.Errorf("Expected newest record with an ID first, got %+v", page.Records[0])
+	}
+
+	// Pages past the end are empty, not nil
+	beyond, _ := reloaded.List(5, 10)
+	if beyond.Records == nil || len(beyond.Records) != 0 {
+		t.Errorf("Expected empty page, got %v", beyond.Records)
+	}
+
+	// A page whose offset would overflow an int is just past the end
+	huge, err := reloaded.List(math.MaxInt, 100)
+	if err != nil || len(huge.Records) != 0 {
+		t.Errorf("List(MaxInt, 100) = %v, %v; want an empty page", huge, err)
+	}
+}
+
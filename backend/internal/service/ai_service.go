@@ -4,30 +4,105 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/danntastico/stori-backend/internal/domain"
 )
 
+// DefaultOpenAIURL is the chat completions endpoint used when no base URL is configured
+const DefaultOpenAIURL = "https://api.openai.com/v1/chat/completions"
+
+// DefaultAzureAPIVersion is the api-version sent to Azure OpenAI when none is configured
+const DefaultAzureAPIVersion = "2024-02-01"
+
 // AIService handles AI-powered financial advice generation
 type AIService struct {
 	apiKey     string
 	apiURL     string
+	apiVersion string // Azure's api-version query parameter; empty leaves the URL as is
 	httpClient *http.Client
+	breaker    *CircuitBreaker // Optional; guards calls to OpenAI
 }
 
 // NewAIService creates a new AI service instance
-func NewAIService(apiKey string) *AIService {
+// baseURL is the full chat completions URL, used verbatim, e.g., an Azure OpenAI deployment
+// ("https://<resource>.openai.azure.com/openai/deployments/<deployment>/chat/completions")
+// or a proxy; empty uses DefaultOpenAIURL.
+func NewAIService(apiKey, baseURL string) *AIService {
+	if baseURL == "" {
+		baseURL = DefaultOpenAIURL
+	}
+
 	return &AIService{
-		apiKey: apiKey,
-		apiURL: "https://api.openai.com/v1/chat/completions",
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		apiKey:     apiKey,
+		apiURL:     baseURL,
+		httpClient: newSSRFSafeClient(30 * time.Second),
+	}
+}
+
+// SetAPIURL overrides the OpenAI endpoint, e.g., for a proxy or a test server
+func (s *AIService) SetAPIURL(apiURL string) {
+	s.apiURL = apiURL
+}
+
+// SetAPIVersion sets the api-version query parameter Azure OpenAI requires, e.g., "2024-02-01"
+// It is added to the endpoint unless the URL already carries one. Azure endpoints without
+// either use DefaultAzureAPIVersion.
+func (s *AIService) SetAPIVersion(apiVersion string) {
+	s.apiVersion = apiVersion
+}
+
+// IsAzureOpenAIURL reports whether rawURL points at an Azure OpenAI resource
+// Azure authenticates with an api-key header instead of a bearer token.
+func IsAzureOpenAIURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
 	}
+	return strings.HasSuffix(strings.ToLower(parsed.Hostname()), ".openai.azure.com")
+}
+
+// requestURL returns the endpoint to call, with the api-version parameter when one applies
+func (s *AIService) requestURL() (string, error) {
+	apiVersion := s.apiVersion
+	if apiVersion == "" && IsAzureOpenAIURL(s.apiURL) {
+		apiVersion = DefaultAzureAPIVersion
+	}
+	if apiVersion == "" {
+		return s.apiURL, nil
+	}
+
+	parsed, err := url.Parse(s.apiURL)
+	if err != nil {
+		return "", err
+	}
+	query := parsed.Query()
+	if query.Has("api-version") {
+		return s.apiURL, nil
+	}
+	query.Set("api-version", apiVersion)
+	parsed.RawQuery = query.Encode()
+
+	return parsed.String(), nil
+}
+
+// SetHTTPClient replaces the HTTP client used to call OpenAI
+// The default client only allows HTTPS to public addresses; a replacement bypasses
+// that protection, so only use it for tests against a local server.
+func (s *AIService) SetHTTPClient(client *http.Client) {
+	s.httpClient = client
+}
+
+// SetCircuitBreaker guards OpenAI calls with cb so an outage fails fast instead of
+// waiting for the HTTP timeout on every request
+func (s *AIService) SetCircuitBreaker(cb *CircuitBreaker) {
+	s.breaker = cb
 }
 
 // AdviceRequest represents the request structure for advice
@@ -80,10 +155,30 @@ func (s *AIService) GetFinancialAdvice(ctx context.Context, summary domain.Categ
 	// Build the prompt
 	prompt := s.buildPrompt(summary, req)
 
-	// Call OpenAI API
-	advice, err := s.callOpenAI(ctx, prompt)
+	// Call OpenAI API, through the circuit breaker when configured
+	var advice string
+	var err error
+	if s.breaker != nil {
+		err = s.breaker.Execute(func() error {
+			advice, err = s.callOpenAI(ctx, prompt)
+			return err
+		})
+	} else {
+		advice, err = s.callOpenAI(ctx, prompt)
+	}
 	if err != nil {
-		// On error, fallback to mock advice
+		// Fail fast while the circuit is open, and never hide a blocked endpoint behind mock advice
+		if errors.Is(err, domain.ErrServiceUnavailable) || errors.Is(err, domain.ErrSSRFAttempt) {
+			return nil, err
+		}
+
+		// Surface rate limiting so the client can retry after the advertised delay
+		var httpErr *domain.HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusTooManyRequests {
+			return nil, err
+		}
+
+		// On other errors, fallback to mock advice
 		return s.getMockAdvice(summary, req), nil
 	}
 
@@ -167,13 +262,22 @@ func (s *AIService) callOpenAI(ctx context.Context, prompt string) (string, erro
 		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", s.apiURL, bytes.NewBuffer(jsonData))
+	endpoint, err := s.requestURL()
+	if err != nil {
+		return "", fmt.Errorf("invalid OpenAI URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	if IsAzureOpenAIURL(s.apiURL) {
+		req.Header.Set("api-key", s.apiKey)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
@@ -187,7 +291,14 @@ func (s *AIService) callOpenAI(ctx context.Context, prompt string) (string, erro
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("OpenAI API error (status %d): %s", resp.StatusCode, string(body))
+		httpErr := &domain.HTTPError{
+			StatusCode: resp.StatusCode,
+			Message:    string(body),
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			httpErr.RetryAfter = resp.Header.Get("Retry-After")
+		}
+		return "", fmt.Errorf("OpenAI API error: %w", httpErr)
 	}
 
 	var openAIResp openAIResponse
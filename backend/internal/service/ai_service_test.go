@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// capturedRequest is what the mock OpenAI server saw, plus the URL the client asked for
+type capturedRequest struct {
+	url    string
+	header http.Header
+}
+
+// redirectTransport sends every request to target, recording the URL it was meant for
+// This lets tests use real OpenAI and Azure URLs against a local mock server.
+type redirectTransport struct {
+	target *url.URL
+	urls   *[]string
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	*t.urls = append(*t.urls, req.URL.String())
+
+	redirected := req.Clone(req.Context())
+	redirected.URL.Scheme = t.target.Scheme
+	redirected.URL.Host = t.target.Host
+	redirected.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(redirected)
+}
+
+// callMockOpenAI calls OpenAI through aiService against a mock server and returns the request it made
+func callMockOpenAI(t *testing.T, aiService *AIService) capturedRequest {
+	t.Helper()
+
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"Save more."}}]}`))
+	}))
+	defer server.Close()
+
+	target, _ := url.Parse(server.URL)
+	var urls []string
+	aiService.SetHTTPClient(&http.Client{Transport: redirectTransport{target: target, urls: &urls}})
+
+	content, err := aiService.callOpenAI(context.Background(), "prompt")
+	if err != nil {
+		t.Fatalf("callOpenAI() error = %v", err)
+	}
+	if content != "Save more." {
+		t.Errorf("Expected the mock completion, got %q", content)
+	}
+	if len(urls) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(urls))
+	}
+
+	return capturedRequest{url: urls[0], header: header}
+}
+
+func TestAIService_RequestURLAndAuth(t *testing.T) {
+	const azureURL = "https://acme.openai.azure.com/openai/deployments/gpt-35/chat/completions"
+
+	tests := []struct {
+		name       string
+		baseURL    string
+		apiVersion string
+		wantURL    string
+		wantAzure  bool
+	}{
+		{
+			name:    "standard openai by default",
+			baseURL: "",
+			wantURL: "https://api.openai.com/v1/chat/completions",
+		},
+		{
+			name:    "custom base url used verbatim",
+			baseURL: "https://proxy.example.com/openai/chat",
+			wantURL: "https://proxy.example.com/openai/chat",
+		},
+		{
+			name:      "azure with default api version",
+			baseURL:   azureURL,
+			wantURL:   azureURL + "?api-version=2024-02-01",
+			wantAzure: true,
+		},
+		{
+			name:       "azure with configured api version",
+			baseURL:    azureURL,
+			apiVersion: "2024-06-01",
+			wantURL:    azureURL + "?api-version=2024-06-01",
+			wantAzure:  true,
+		},
+		{
+			name:       "api version already in url",
+			baseURL:    azureURL + "?api-version=2023-05-15",
+			apiVersion: "2024-06-01",
+			wantURL:    azureURL + "?api-version=2023-05-15",
+			wantAzure:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aiService := NewAIService("secret-key", tt.baseURL)
+			aiService.SetAPIVersion(tt.apiVersion)
+
+			got := callMockOpenAI(t, aiService)
+
+			if got.url != tt.wantURL {
+				t.Errorf("Expected request to %s, got %s", tt.wantURL, got.url)
+			}
+			if tt.wantAzure {
+				if key := got.header.Get("api-key"); key != "secret-key" {
+					t.Errorf("Expected api-key header 'secret-key', got %q", key)
+				}
+				if auth := got.header.Get("Authorization"); auth != "" {
+					t.Errorf("Expected no Authorization header for Azure, got %q", auth)
+				}
+			} else {
+				if auth := got.header.Get("Authorization"); auth != "Bearer secret-key" {
+					t.Errorf("Expected Authorization 'Bearer secret-key', got %q", auth)
+				}
+				if key := got.header.Get("api-key"); key != "" {
+					t.Errorf("Expected no api-key header, got %q", key)
+				}
+			}
+		})
+	}
+}
+
+func TestIsAzureOpenAIURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://acme.openai.azure.com/openai/deployments/gpt/chat/completions", true},
+		{"https://ACME.OpenAI.Azure.com/openai", true},
+		{"https://api.openai.com/v1/chat/completions", false},
+		{"https://openai.azure.com.evil.example/chat", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsAzureOpenAIURL(tt.url); got != tt.want {
+			t.Errorf("IsAzureOpenAIURL(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// AnalyticsCache keeps the category summary, timeline and transaction list computed ahead
+// of time so reads do not recompute them from every transaction
+// Results are computed in a background goroutine, started by WarmUp or by the first read.
+// Invalidate discards them and recomputes asynchronously; until the new results are
+// ready, reads compute directly so they never return stale data.
+// Reads return copies of the cached maps and slices, so callers may change their entries.
+type AnalyticsCache struct {
+	analytics *AnalyticsService
+
+	mu         sync.RWMutex
+	snapshot   *analyticsSnapshot // nil until computed, and again after Invalidate
+	generation uint64             // Bumped by Invalidate so outdated computations are discarded
+	refreshing bool               // A computation for the current generation is running
+	ready      chan struct{}      // Closed once snapshot holds the current generation
+}
+
+// analyticsSnapshot holds one computation of every cached result, errors included
+type analyticsSnapshot struct {
+	summary         *domain.CategorySummary
+	summaryErr      error
+	timeline        *domain.TimelineResponse
+	timelineErr     error
+	transactions    *domain.TransactionsResponse
+	transactionsErr error
+}
+
+// NewAnalyticsCache creates an empty cache over analytics
+// Pass it to analytics.SetCache so the service's own reads and writes use it.
+func NewAnalyticsCache(analytics *AnalyticsService) *AnalyticsCache {
+	return &AnalyticsCache{
+		analytics: analytics,
+		ready:     make(chan struct{}),
+	}
+}
+
+// SetCache makes GetCategorySummary, GetTimeline and GetTransactions read from cache
+// Writes made through the service invalidate it.
+func (s *AnalyticsService) SetCache(cache *AnalyticsCache) {
+	s.cache = cache
+}
+
+// invalidateCache discards cached results after the transactions changed
+func (s *AnalyticsService) invalidateCache() {
+	if s.cache != nil {
+		s.cache.Invalidate()
+	}
+}
+
+// WarmUp computes every cached result and waits until they are stored
+// Returns ctx.Err() if ctx ends first; the computation then finishes in the background.
+// A data set without transactions is not an error, any other computation error is.
+func (c *AnalyticsCache) WarmUp(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case <-c.startRefresh():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	c.mu.RLock()
+	snapshot := c.snapshot
+	c.mu.RUnlock()
+	if snapshot == nil {
+		// Invalidated again while warming up; a newer computation is already running
+		return nil
+	}
+
+	for _, err := range []error{snapshot.summaryErr, snapshot.timelineErr, snapshot.transactionsErr} {
+		if err != nil && !errors.Is(err, domain.ErrNoTransactions) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Invalidate discards the cached results and recomputes them in the background
+func (c *AnalyticsCache) Invalidate() {
+	c.mu.Lock()
+	c.generation++
+	c.snapshot = nil
+	c.refreshing = false
+	select {
+	case <-c.ready:
+		c.ready = make(chan struct{})
+	default:
+		// Still open: waiters are released by the next computation instead
+	}
+	c.mu.Unlock()
+
+	c.startRefresh()
+}
+
+// GetCategorySummary returns the category summary without budget warnings
+func (c *AnalyticsCache) GetCategorySummary() (*domain.CategorySummary, error) {
+	if snapshot := c.current(); snapshot != nil {
+		return cloneCategorySummary(snapshot.summary), snapshot.summaryErr
+	}
+	return c.analytics.computeCategorySummary()
+}
+
+// GetTimeline returns the monthly timeline
+func (c *AnalyticsCache) GetTimeline() (*domain.TimelineResponse, error) {
+	if snapshot := c.current(); snapshot != nil {
+		return cloneTimeline(snapshot.timeline), snapshot.timelineErr
+	}
+	return c.analytics.computeTimeline()
+}
+
+// GetTransactions returns every transaction, sorted by date
+func (c *AnalyticsCache) GetTransactions() (*domain.TransactionsResponse, error) {
+	if snapshot := c.current(); snapshot != nil {
+		return cloneTransactionsResponse(snapshot.transactions), snapshot.transactionsErr
+	}
+	return c.analytics.computeTransactions()
+}
+
+// current returns the stored snapshot, or nil after starting a computation when there is none
+func (c *AnalyticsCache) current() *analyticsSnapshot {
+	c.mu.RLock()
+	snapshot := c.snapshot
+	c.mu.RUnlock()
+
+	if snapshot == nil {
+		c.startRefresh()
+	}
+	return snapshot
+}
+
+// startRefresh starts computing the current generation unless it is stored or running
+// Returns a channel closed once the results are stored.
+func (c *AnalyticsCache) startRefresh() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.snapshot == nil && !c.refreshing {
+		c.refreshing = true
+		go c.refresh(c.generation)
+	}
+	return c.ready
+}
+
+// refresh computes every result and stores them if no invalidation happened meanwhile
+func (c *AnalyticsCache) refresh(generation uint64) {
+	snapshot := &analyticsSnapshot{}
+	snapshot.summary, snapshot.summaryErr = c.analytics.computeCategorySummary()
+	snapshot.timeline, snapshot.timelineErr = c.analytics.computeTimeline()
+	snapshot.transactions, snapshot.transactionsErr = c.analytics.computeTransactions()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	c.snapshot = snapshot
+	c.refreshing = false
+	close(c.ready)
+}
+
+// cloneCategorySummary copies summary, including its maps
+func cloneCategorySummary(summary *domain.CategorySummary) *domain.CategorySummary {
+	if summary == nil {
+		return nil
+	}
+
+	clone := *summary
+	clone.Income = maps.Clone(summary.Income)
+	clone.Expenses = maps.Clone(summary.Expenses)
+	clone.MoMChange = maps.Clone(summary.MoMChange)
+	return &clone
+}
+
+// cloneTimeline copies timeline, including its points
+func cloneTimeline(timeline *domain.TimelineResponse) *domain.TimelineResponse {
+	if timeline == nil {
+		return nil
+	}
+
+	clone := *timeline
+	clone.Timeline = slices.Clone(timeline.Timeline)
+	return &clone
+}
+
+// cloneTransactionsResponse copies response, including its transaction slice
+func cloneTransactionsResponse(response *domain.TransactionsResponse) *domain.TransactionsResponse {
+	if response == nil {
+		return nil
+	}
+
+	clone := *response
+	clone.Transactions = slices.Clone(response.Transactions)
+	return &clone
+}
+
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// setupCachedService creates a service over the test data with a cache set
+func setupCachedService(t *testing.T) (*AnalyticsService, *AnalyticsCache) {
+	t.Helper()
+
+	service := setupTestService(t)
+	cache := NewAnalyticsCache(service)
+	service.SetCache(cache)
+	return service, cache
+}
+
+func TestAnalyticsCache_WarmUpMatchesDirectComputation(t *testing.T) {
+	service, cache := setupCachedService(t)
+
+	if err := cache.WarmUp(context.Background()); err != nil {
+		t.Fatalf("WarmUp() error = %v", err)
+	}
+	if cache.current() == nil {
+		t.Fatal("Expected results to be stored after WarmUp()")
+	}
+
+	summary, _ := service.GetCategorySummary()
+	wantSummary, _ := service.computeCategorySummary()
+	if !reflect.DeepEqual(summary, wantSummary) {
+		t.Errorf("Cached summary = %+v, want %+v", summary, wantSummary)
+	}
+
+	timeline, _ := service.GetTimeline()
+	wantTimeline, _ := service.computeTimeline()
+	if !reflect.DeepEqual(timeline, wantTimeline) {
+		t.Errorf("Cached timeline = %+v, want %+v", timeline, wantTimeline)
+	}
+
+	transactions, _ := service.GetTransactions()
+	wantTransactions, _ := service.computeTransactions()
+	if !reflect.DeepEqual(transactions, wantTransactions) {
+		t.Errorf("Cached transactions = %+v, want %+v", transactions, wantTransactions)
+	}
+}
+
+func TestAnalyticsCache_ConcurrentReads(t *testing.T) {
+	service, cache := setupCachedService(t)
+	if err := cache.WarmUp(context.Background()); err != nil {
+		t.Fatalf("WarmUp() error = %v", err)
+	}
+	want, _ := service.computeCategorySummary()
+
+	var wg sync.WaitGroup
+	errs := make(chan string, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			summary, err := service.GetCategorySummary()
+			if err != nil || !reflect.DeepEqual(summary, want) {
+				errs <- "inconsistent summary"
+				return
+			}
+			// Callers own their copy
+			delete(summary.Expenses, "rent")
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestAnalyticsCache_InvalidatedByWrites(t *testing.T) {
+	service, cache := setupCachedService(t)
+	if err := cache.WarmUp(context.Background()); err != nil {
+		t.Fatalf("WarmUp() error = %v", err)
+	}
+
+	if _, err := service.CreateTransaction(domain.Transaction{
+		Date: "2024-02-10", Amount: -40, Category: "dining", Description: "Dinner", Type: "expense",
+	}); err != nil {
+		t.Fatalf("CreateTransaction() error = %v", err)
+	}
+
+	// Reads right after the write see it, whether or not the recomputation has finished
+	summary, err := service.GetCategorySummary()
+	if err != nil {
+		t.Fatalf("GetCategorySummary() error = %v", err)
+	}
+	if summary.Expenses["dining"].Total != 40 {
+		t.Errorf("Expected the new dining expense, got %+v", summary.Expenses["dining"])
+	}
+
+	if err := cache.WarmUp(context.Background()); err != nil {
+		t.Fatalf("WarmUp() after a write error = %v", err)
+	}
+	cached, _ := service.GetCategorySummary()
+	if !reflect.DeepEqual(cached, summary) {
+		t.Errorf("Recomputed summary = %+v, want %+v", cached, summary)
+	}
+}
+
@@ -1,57 +1,219 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math"
 	"sort"
+	"strings"
 	"time"
 
+	"github.com/danntastico/stori-backend/internal/categorizer"
 	"github.com/danntastico/stori-backend/internal/domain"
 	"github.com/danntastico/stori-backend/internal/repository"
 )
 
 // AnalyticsService provides business logic for financial data analysis
 type AnalyticsService struct {
-	repo repository.TransactionRepository
+	repo                repository.TransactionRepository
+	converter           CurrencyConverter
+	baseCurrency        string
+	recurrenceThreshold int
+	broadcaster         TransactionBroadcaster   // Optional; notified of created transactions
+	workers             int                      // Goroutines for category aggregation; 0 is one per CPU
+	cache               *AnalyticsCache          // Optional; serves summaries computed ahead of time
+	categorizer         *categorizer.Categorizer // Optional; fills in the category of created transactions
+	decimalPrecision    int                      // Decimals money amounts are rounded to
+}
+
+// TransactionBroadcaster is notified of every transaction created through the service
+// e.g., handlers.TransactionHub forwards them to WebSocket clients
+type TransactionBroadcaster interface {
+	Broadcast(tx domain.Transaction)
+}
+
+// DefaultDecimalPrecision is the number of decimals money amounts are rounded to by default
+const DefaultDecimalPrecision = 2
+
+// metricPrecision is the number of decimals for percentages, ratios, scores and other
+// figures that are not money, whatever the currency
+const metricPrecision = 2
+
+// AnalyticsOption configures an AnalyticsService when it is created
+type AnalyticsOption func(*AnalyticsService)
+
+// WithDecimalPrecision rounds money amounts to n decimals, e.g., 0 for JPY or 3 for KWD
+// Percentages and other ratios keep two decimals. Negative values are ignored.
+func WithDecimalPrecision(n int) AnalyticsOption {
+	return func(s *AnalyticsService) {
+		if n >= 0 {
+			s.decimalPrecision = n
+		}
+	}
 }
 
 // NewAnalyticsService creates a new analytics service
-func NewAnalyticsService(repo repository.TransactionRepository) *AnalyticsService {
-	return &AnalyticsService{
-		repo: repo,
+func NewAnalyticsService(repo repository.TransactionRepository, opts ...AnalyticsOption) *AnalyticsService {
+	s := &AnalyticsService{
+		repo:                repo,
+		baseCurrency:        "USD",
+		recurrenceThreshold: defaultRecurrenceThreshold,
+		decimalPrecision:    DefaultDecimalPrecision,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
+}
+
+// SetCurrencyConverter configures currency conversion for multi-currency summaries
+// Transactions without an explicit currency are assumed to be in baseCurrency
+func (s *AnalyticsService) SetCurrencyConverter(converter CurrencyConverter, baseCurrency string) {
+	s.converter = converter
+	s.baseCurrency = baseCurrency
+}
+
+// SetTransactionBroadcaster configures where created transactions are announced
+func (s *AnalyticsService) SetTransactionBroadcaster(broadcaster TransactionBroadcaster) {
+	s.broadcaster = broadcaster
+}
+
+// AutoCategorizeMinConfidence is the confidence a suggestion needs before it is applied
+// to a transaction created without a category
+const AutoCategorizeMinConfidence = 0.8
+
+// SetAutoCategorizer makes CreateTransaction suggest a category for transactions without one
+// The suggestion is applied only when its confidence is at least AutoCategorizeMinConfidence.
+func (s *AnalyticsService) SetAutoCategorizer(c *categorizer.Categorizer) {
+	s.categorizer = c
 }
 
 // GetCategorySummary calculates spending breakdown by category with totals and percentages
+// Amounts are converted to the base currency. The result comes from the analytics cache,
+// when one is set.
 func (s *AnalyticsService) GetCategorySummary() (*domain.CategorySummary, error) {
+	if s.cache != nil {
+		return s.cache.GetCategorySummary()
+	}
+	return s.computeCategorySummary()
+}
+
+// GetCategorySummaryWithBudgets is GetCategorySummary with budget warnings
+// Expense categories whose spending in the most recent month reaches 80% of their
+// budgets limit (category -> monthly limit) carry a Warning. The warnings depend on
+// budgets, so the summary is always computed afresh rather than read from the cache.
+func (s *AnalyticsService) GetCategorySummaryWithBudgets(budgets map[string]float64) (*domain.CategorySummary, error) {
+	if len(budgets) == 0 {
+		return s.GetCategorySummary()
+	}
+
+	transactions, err := s.baseCurrencyTransactions()
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.buildCategorySummary(transactions)
+	if err != nil {
+		return nil, err
+	}
+	s.annotateBudgetWarnings(summary, transactions, budgets)
+
+	return summary, nil
+}
+
+// computeCategorySummary is GetCategorySummary without the cache
+func (s *AnalyticsService) computeCategorySummary() (*domain.CategorySummary, error) {
 	// Fetch all transactions
+	transactions, err := s.baseCurrencyTransactions()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildCategorySummary(transactions)
+}
+
+// baseCurrencyTransactions returns every transaction with its amount in the base currency
+func (s *AnalyticsService) baseCurrencyTransactions() ([]domain.Transaction, error) {
 	transactions, err := s.repo.GetAll()
 	if err != nil {
 		return nil, err
 	}
+	return s.inBaseCurrency(transactions)
+}
 
-	// Initialize maps for income and expense categories
-	incomeCategories := make(map[string]*domain.CategoryDetail)
-	expenseCategories := make(map[string]*domain.CategoryDetail)
+// GetCategorySummaryInCurrency calculates the category summary with every amount
+// converted to targetCurrency before aggregation
+// An empty targetCurrency falls back to the request's preferred currency, then the base currency.
+func (s *AnalyticsService) GetCategorySummaryInCurrency(ctx context.Context, targetCurrency string) (*domain.CategorySummary, error) {
+	if targetCurrency == "" {
+		targetCurrency = domain.GetPreferredCurrency(ctx)
+	}
+	if targetCurrency == "" {
+		targetCurrency = s.baseCurrency
+	}
+	if !domain.IsValidCurrency(targetCurrency) {
+		return nil, domain.ErrInvalidCurrency
+	}
+	if s.converter == nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrUnsupportedCurrency, targetCurrency)
+	}
 
-	var totalIncome float64
-	var totalExpenses float64
+	transactions, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
 
-	// Aggregate transactions by category
-	for _, tx := range transactions {
-		if tx.IsIncome() {
-			totalIncome += tx.Amount
-			s.aggregateCategory(incomeCategories, tx)
-		} else if tx.IsExpense() {
-			totalExpenses += tx.AbsoluteAmount()
-			s.aggregateCategory(expenseCategories, tx)
+	// Convert amounts (GetAll returns a copy, so this is safe)
+	for i := range transactions {
+		from := transactions[i].Currency
+		if from == "" {
+			from = s.baseCurrency
 		}
+
+		converted, err := s.converter.Convert(transactions[i].Amount, from, targetCurrency)
+		if err != nil {
+			return nil, err
+		}
+		transactions[i].Amount = converted
+		transactions[i].Currency = targetCurrency
 	}
 
-	// Calculate percentages for income categories
-	incomeMap := s.calculatePercentages(incomeCategories, totalIncome)
+	summary, err := s.buildCategorySummary(transactions)
+	if err != nil {
+		return nil, err
+	}
+	summary.Currency = targetCurrency
 
-	// Calculate percentages for expense categories
-	expenseMap := s.calculatePercentages(expenseCategories, totalExpenses)
+	return summary, nil
+}
+
+// GetMonthlyCategorySummary calculates the category summary for the calendar month
+// containing month
+// Returns domain.ErrInsufficientData when the month has no transactions.
+func (s *AnalyticsService) GetMonthlyCategorySummary(month time.Time) (*domain.CategorySummary, error) {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, -1)
+
+	transactions, err := s.repo.GetByDateRange(start, end)
+	if errors.Is(err, domain.ErrNoTransactions) {
+		return nil, fmt.Errorf("%w: no transactions in %s", domain.ErrInsufficientData, start.Format("2006-01"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if transactions, err = s.inBaseCurrency(transactions); err != nil {
+		return nil, err
+	}
+
+	return s.buildCategorySummary(transactions)
+}
+
+// buildCategorySummary aggregates transactions into a category summary
+func (s *AnalyticsService) buildCategorySummary(transactions []domain.Transaction) (*domain.CategorySummary, error) {
+	// Aggregate transactions by category
+	totals := s.aggregateByCategory(transactions)
+	totalIncome, totalExpenses := totals.totalIncome, totals.totalExpenses
 
 	// Get date range
 	start, end, err := s.getDateRangeFromTransactions(transactions)
@@ -62,11 +224,17 @@ func (s *AnalyticsService) GetCategorySummary() (*domain.CategorySummary, error)
 	// Calculate number of months
 	months := s.calculateMonthsBetween(start, end)
 
+	// Calculate percentages for income categories
+	incomeMap := s.calculatePercentages(totals.income, totalIncome, totalIncome, months)
+
+	// Calculate percentages for expense categories
+	expenseMap := s.calculatePercentages(totals.expenses, totalExpenses, totalIncome, months)
+
 	// Create financial summary
 	summary := domain.FinancialSummary{
-		TotalIncome:   roundToTwo(totalIncome),
-		TotalExpenses: roundToTwo(totalExpenses),
-		NetSavings:    roundToTwo(totalIncome - totalExpenses),
+		TotalIncome:   s.roundAmount(totalIncome),
+		TotalExpenses: s.roundAmount(totalExpenses),
+		NetSavings:    s.roundAmount(totalIncome - totalExpenses),
 	}
 	summary.CalculateSavingsRate()
 
@@ -79,13 +247,133 @@ func (s *AnalyticsService) GetCategorySummary() (*domain.CategorySummary, error)
 			End:    end.Format("2006-01-02"),
 			Months: months,
 		},
+		MoMChange: s.computeMoMChange(transactions),
 	}, nil
 }
 
+// annotateBudgetWarnings flags expense categories whose spending in the most recent month
+// of data is at least 80% (approaching_limit) or 100% (over_limit) of their monthly budget
+func (s *AnalyticsService) annotateBudgetWarnings(summary *domain.CategorySummary, transactions []domain.Transaction, budgets map[string]float64) {
+	if len(budgets) == 0 {
+		return
+	}
+
+	spent, err := s.latestMonthSpending(transactions)
+	if err != nil {
+		return
+	}
+
+	for category, limit := range budgets {
+		detail, ok := summary.Expenses[category]
+		if !ok || limit <= 0 {
+			continue
+		}
+
+		ratio := s.roundAmount(spent[category]) / limit
+		switch {
+		case ratio >= 1:
+			detail.Warning = domain.BudgetWarningOverLimit
+		case ratio >= domain.BudgetWarningThreshold:
+			detail.Warning = domain.BudgetWarningApproachingLimit
+		default:
+			continue
+		}
+		summary.Expenses[category] = detail
+	}
+}
+
+// latestMonthSpending sums expenses per category in the most recent month of data
+// Returns the month as "YYYY-MM", or ErrNoTransactions when no transaction has a valid date.
+func (s *AnalyticsService) latestMonthSpending(transactions []domain.Transaction) (map[string]float64, error) {
+	_, latest, err := s.getDateRangeFromTransactions(transactions)
+	if err != nil {
+		return nil, err
+	}
+	current := latest.Format("2006-01")
+
+	spent := make(map[string]float64)
+	for _, tx := range transactions {
+		if !tx.IsExpense() {
+			continue
+		}
+		if yearMonth, err := tx.GetYearMonth(); err == nil && yearMonth == current {
+			spent[tx.Category] += tx.AbsoluteAmount()
+		}
+	}
+
+	return spent, nil
+}
+
+// computeMoMChange compares spending per expense category in the most recent month of
+// data against the previous calendar month
+// A category without spending in the previous month is +100%, one without spending in
+// the recent month is -100%. Returns an empty map when there is no previous month to compare.
+func (s *AnalyticsService) computeMoMChange(transactions []domain.Transaction) map[string]float64 {
+	changes := make(map[string]float64)
+
+	_, latest, err := s.getDateRangeFromTransactions(transactions)
+	if err != nil {
+		return changes
+	}
+	current := latest.Format("2006-01")
+	previous := latest.AddDate(0, 0, -latest.Day()).Format("2006-01") // Last day of the prior month
+
+	currentSpend := make(map[string]float64)
+	previousSpend := make(map[string]float64)
+	hasPrevious := false
+
+	for _, tx := range transactions {
+		if !tx.IsExpense() {
+			continue
+		}
+		txDate, err := tx.ParseDate()
+		if err != nil {
+			continue
+		}
+
+		switch txDate.Format("2006-01") {
+		case current:
+			currentSpend[tx.Category] += tx.AbsoluteAmount()
+		case previous:
+			previousSpend[tx.Category] += tx.AbsoluteAmount()
+			hasPrevious = true
+		}
+	}
+
+	if !hasPrevious {
+		return changes
+	}
+
+	for category, amount := range currentSpend {
+		if prior := previousSpend[category]; prior > 0 {
+			changes[category] = roundToN((amount-prior)/prior*100, metricPrecision)
+		} else {
+			changes[category] = 100
+		}
+	}
+	for category := range previousSpend {
+		if _, ok := currentSpend[category]; !ok {
+			changes[category] = -100
+		}
+	}
+
+	return changes
+}
+
 // GetTimeline calculates monthly income vs expenses over time
+// Amounts are converted to the base currency. The result comes from the analytics cache,
+// when one is set.
 func (s *AnalyticsService) GetTimeline() (*domain.TimelineResponse, error) {
+	if s.cache != nil {
+		return s.cache.GetTimeline()
+	}
+	return s.computeTimeline()
+}
+
+// computeTimeline is GetTimeline without the cache
+func (s *AnalyticsService) computeTimeline() (*domain.TimelineResponse, error) {
 	// Fetch all transactions
-	transactions, err := s.repo.GetAll()
+	transactions, err := s.baseCurrencyTransactions()
 	if err != nil {
 		return nil, err
 	}
@@ -120,9 +408,9 @@ func (s *AnalyticsService) GetTimeline() (*domain.TimelineResponse, error) {
 
 	// Calculate net for each month and round values
 	for _, point := range monthlyData {
-		point.Income = roundToTwo(point.Income)
-		point.Expenses = roundToTwo(point.Expenses)
-		point.Net = roundToTwo(point.Income - point.Expenses)
+		point.Income = s.roundAmount(point.Income)
+		point.Expenses = s.roundAmount(point.Expenses)
+		point.Net = s.roundAmount(point.Income - point.Expenses)
 	}
 
 	// Convert map to sorted slice
@@ -137,33 +425,237 @@ func (s *AnalyticsService) GetTimeline() (*domain.TimelineResponse, error) {
 	})
 
 	return &domain.TimelineResponse{
-		Timeline:    timeline,
-		Aggregation: "monthly",
+		Timeline:       timeline,
+		Aggregation:    "monthly",
+		PaginationMeta: domain.SinglePage(len(timeline)),
 	}, nil
 }
 
-// GetTransactions returns all transactions with metadata
+// GetTransactions returns all transactions with metadata, sorted by date ascending
+// Each transaction is annotated with its recurrence flag. The result comes from the
+// analytics cache, when one is set.
 func (s *AnalyticsService) GetTransactions() (*domain.TransactionsResponse, error) {
+	if s.cache != nil {
+		return s.cache.GetTransactions()
+	}
+	return s.computeTransactions()
+}
+
+// computeTransactions is GetTransactions without the cache
+func (s *AnalyticsService) computeTransactions() (*domain.TransactionsResponse, error) {
 	transactions, err := s.repo.GetAll()
 	if err != nil {
 		return nil, err
 	}
 
+	// The data file may have been edited by hand, so load order is not necessarily chronological
+	transactions = sortTransactionsByDate(transactions)
+	transactions = s.DetectAndAnnotateRecurring(transactions)
+
 	start, end, err := s.getDateRangeFromTransactions(transactions)
 	if err != nil {
 		return nil, err
 	}
 
+	period, err := s.transactionsPeriod(start, end, transactions)
+	if err != nil {
+		return nil, err
+	}
+
 	return &domain.TransactionsResponse{
-		Transactions: transactions,
-		Count:        len(transactions),
-		Period: domain.Period{
-			Start: start.Format("2006-01-02"),
-			End:   end.Format("2006-01-02"),
-		},
+		Transactions:   transactions,
+		Count:          len(transactions),
+		Period:         period,
+		PaginationMeta: domain.SinglePage(len(transactions)),
+	}, nil
+}
+
+// transactionsPeriod returns the period from start to end with the totals of transactions
+func (s *AnalyticsService) transactionsPeriod(start, end time.Time, transactions []domain.Transaction) (domain.TransactionsPeriod, error) {
+	return s.periodWithTotals(domain.Period{
+		Start: start.Format("2006-01-02"),
+		End:   end.Format("2006-01-02"),
+	}, transactions)
+}
+
+// periodWithTotals adds the totals of transactions to period, in the base currency
+// Amounts in other currencies are converted first; without a converter they cannot be
+// summed and domain.ErrUnsupportedCurrency is returned.
+func (s *AnalyticsService) periodWithTotals(period domain.Period, transactions []domain.Transaction) (domain.TransactionsPeriod, error) {
+	converted, err := s.inBaseCurrency(transactions)
+	if err != nil {
+		return domain.TransactionsPeriod{}, err
+	}
+
+	totals := domain.TransactionsPeriod{Period: period, Currency: s.baseCurrency}
+	totals.AddTotals(converted)
+	return totals, nil
+}
+
+// inBaseCurrency returns a copy of transactions with every amount converted to the base
+// currency, so they can be summed; transactions without a currency are already in it
+// Returns domain.ErrUnsupportedCurrency for a foreign currency when no converter is set.
+func (s *AnalyticsService) inBaseCurrency(transactions []domain.Transaction) ([]domain.Transaction, error) {
+	converted := make([]domain.Transaction, len(transactions))
+	copy(converted, transactions)
+	for i := range converted {
+		from := converted[i].Currency
+		if from == "" || from == s.baseCurrency {
+			continue
+		}
+		if s.converter == nil {
+			return nil, fmt.Errorf("%w: %s", domain.ErrUnsupportedCurrency, from)
+		}
+
+		amount, err := s.converter.Convert(converted[i].Amount, from, s.baseCurrency)
+		if err != nil {
+			return nil, err
+		}
+		converted[i].Amount = amount
+		converted[i].Currency = s.baseCurrency
+	}
+	return converted, nil
+}
+
+// NewTransactionsResponse wraps transactions with their count and the period they cover, with its totals
+// Totals are in the base currency, as for every other transaction listing.
+func (s *AnalyticsService) NewTransactionsResponse(transactions []domain.Transaction) (*domain.TransactionsResponse, error) {
+	// Dates are ISO 8601, so string order is chronological
+	var period domain.Period
+	for _, tx := range transactions {
+		if period.Start == "" || tx.Date < period.Start {
+			period.Start = tx.Date
+		}
+		if tx.Date > period.End {
+			period.End = tx.Date
+		}
+	}
+
+	totals, err := s.periodWithTotals(period, transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TransactionsResponse{
+		Transactions:   transactions,
+		Count:          len(transactions),
+		Period:         totals,
+		PaginationMeta: domain.SinglePage(len(transactions)),
 	}, nil
 }
 
+// sortTransactionsByDate returns a copy of txs sorted by date ascending
+// Dates are ISO 8601, so string order is chronological; the stable sort keeps
+// transactions on the same date in load order.
+func sortTransactionsByDate(txs []domain.Transaction) []domain.Transaction {
+	sorted := make([]domain.Transaction, len(txs))
+	copy(sorted, txs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date < sorted[j].Date
+	})
+	return sorted
+}
+
+// CreateTransaction validates and stores a new transaction and announces it to the broadcaster
+// With an auto-categorizer set, a transaction without a category gets the suggested one
+// when the suggestion is confident enough; otherwise the category stays empty and fails validation.
+// Returns the transaction as stored, with its ID, or *domain.ValidationErrors if it is invalid
+func (s *AnalyticsService) CreateTransaction(tx domain.Transaction) (*domain.Transaction, error) {
+	if s.categorizer != nil && strings.TrimSpace(tx.Category) == "" {
+		category, confidence, err := s.categorizer.Classify(tx)
+		if err == nil && confidence >= AutoCategorizeMinConfidence {
+			tx.Category = category
+		}
+	}
+
+	created, err := s.repo.Create(tx)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateCache()
+
+	if s.broadcaster != nil {
+		s.broadcaster.Broadcast(created)
+	}
+	return &created, nil
+}
+
+// PatchTransaction applies the set fields of patch to the transaction with the given ID
+// The merged transaction is validated as a whole, so a patch that changes only the type
+// still fails when the existing amount's sign no longer matches.
+// Returns ErrTransactionNotFound for an unknown ID and *domain.ValidationErrors when the
+// merged transaction is invalid; nothing is stored in either case.
+func (s *AnalyticsService) PatchTransaction(id string, patch domain.TransactionPatch) (*domain.Transaction, error) {
+	tx, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	patch.Apply(&tx)
+	if err := s.repo.Update(id, tx); err != nil {
+		return nil, err
+	}
+	s.invalidateCache()
+
+	updated, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// FindDuplicates reports groups of transactions that look like the same transaction
+// recorded more than once: same date and absolute amount plus the same category or description
+func (s *AnalyticsService) FindDuplicates() (*domain.DuplicatesResponse, error) {
+	groups, err := s.repo.FindDuplicates()
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.DuplicatesResponse{
+		Groups: groups,
+		Count:  len(groups),
+	}, nil
+}
+
+// FilterTransactions returns the transactions matching every criterion of filter, sorted by date
+// An empty filter returns all transactions. Recurrence flags are computed over the
+// full history, so a date range does not hide a charge's earlier occurrences.
+func (s *AnalyticsService) FilterTransactions(filter domain.TransactionFilter) ([]domain.Transaction, error) {
+	matching, err := s.repo.Filter(filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(matching) == 0 {
+		return matching, nil
+	}
+	matching = sortTransactionsByDate(matching)
+
+	all, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Every occurrence in a recurring group (category + description) shares its period
+	periods := make(map[string]*int)
+	for _, tx := range s.DetectAndAnnotateRecurring(all) {
+		if tx.IsRecurring {
+			periods[tx.Category+"|"+tx.Description] = tx.RecurrencePeriodDays
+		}
+	}
+	for i := range matching {
+		if !matching[i].IsExpense() {
+			continue
+		}
+		if period, ok := periods[matching[i].Category+"|"+matching[i].Description]; ok {
+			matching[i].IsRecurring = true
+			matching[i].RecurrencePeriodDays = period
+		}
+	}
+
+	return matching, nil
+}
+
 // GetTransactionsByDateRange returns filtered transactions within a date range
 func (s *AnalyticsService) GetTransactionsByDateRange(start, end time.Time) (*domain.TransactionsResponse, error) {
 	transactions, err := s.repo.GetByDateRange(start, end)
@@ -171,13 +663,136 @@ func (s *AnalyticsService) GetTransactionsByDateRange(start, end time.Time) (*do
 		return nil, err
 	}
 
+	period, err := s.transactionsPeriod(start, end, transactions)
+	if err != nil {
+		return nil, err
+	}
+
 	return &domain.TransactionsResponse{
-		Transactions: transactions,
-		Count:        len(transactions),
-		Period: domain.Period{
-			Start: start.Format("2006-01-02"),
-			End:   end.Format("2006-01-02"),
-		},
+		Transactions:   transactions,
+		Count:          len(transactions),
+		Period:         period,
+		PaginationMeta: domain.SinglePage(len(transactions)),
+	}, nil
+}
+
+// GetTagSummary calculates spending breakdown by tag across categories
+// A transaction with several tags counts toward each of them, so percentages
+// (relative to total expenses) can add up to more than 100%
+func (s *AnalyticsService) GetTagSummary() (map[string]domain.CategoryDetail, error) {
+	transactions, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]*domain.CategoryDetail)
+	var totalIncome, totalExpenses float64
+
+	for _, tx := range transactions {
+		if tx.IsIncome() {
+			totalIncome += tx.Amount
+		}
+		if !tx.IsExpense() {
+			continue
+		}
+
+		totalExpenses += tx.AbsoluteAmount()
+		for _, tag := range tx.Tags {
+			if _, exists := tags[tag]; !exists {
+				tags[tag] = &domain.CategoryDetail{}
+			}
+			tags[tag].Total += tx.AbsoluteAmount()
+			tags[tag].Count++
+		}
+	}
+
+	return s.calculatePercentages(tags, totalExpenses, totalIncome, s.monthsCovered(transactions)), nil
+}
+
+// GetMerchantSummary calculates spending breakdown by merchant with totals, percentages
+// and the category with the highest spend at each merchant
+// Merchant names are case-sensitive: "Amazon" and "amazon" are reported separately
+func (s *AnalyticsService) GetMerchantSummary() (map[string]domain.MerchantDetail, error) {
+	transactions, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	merchants := make(map[string]*domain.CategoryDetail)
+	categorySpend := make(map[string]map[string]float64)
+	var totalIncome, totalExpenses float64
+
+	for _, tx := range transactions {
+		if tx.IsIncome() {
+			totalIncome += tx.Amount
+		}
+		if !tx.IsExpense() {
+			continue
+		}
+
+		merchant := tx.Merchant
+		if merchant == "" {
+			merchant = ExtractMerchantName(tx.Description)
+		}
+
+		if _, exists := merchants[merchant]; !exists {
+			merchants[merchant] = &domain.CategoryDetail{}
+			categorySpend[merchant] = make(map[string]float64)
+		}
+		merchants[merchant].Total += tx.AbsoluteAmount()
+		merchants[merchant].Count++
+		categorySpend[merchant][tx.Category] += tx.AbsoluteAmount()
+		totalExpenses += tx.AbsoluteAmount()
+	}
+
+	details := s.calculatePercentages(merchants, totalExpenses, totalIncome, s.monthsCovered(transactions))
+
+	result := make(map[string]domain.MerchantDetail, len(details))
+	for merchant, detail := range details {
+		result[merchant] = domain.MerchantDetail{
+			CategoryDetail: detail,
+			TopCategory:    topCategory(categorySpend[merchant]),
+		}
+	}
+
+	return result, nil
+}
+
+// GetPaymentMethodSummary calculates spending breakdown by payment method
+// Expenses without a payment method are reported under "unspecified"
+func (s *AnalyticsService) GetPaymentMethodSummary() (*domain.PaymentMethodSummary, error) {
+	transactions, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	methods := make(map[string]*domain.CategoryDetail)
+	var totalIncome, totalExpenses float64
+
+	for _, tx := range transactions {
+		if tx.IsIncome() {
+			totalIncome += tx.Amount
+		}
+		if !tx.IsExpense() {
+			continue
+		}
+
+		method := tx.PaymentMethod
+		if method == "" {
+			method = "unspecified"
+		}
+
+		if _, exists := methods[method]; !exists {
+			methods[method] = &domain.CategoryDetail{}
+		}
+		methods[method].Total += tx.AbsoluteAmount()
+		methods[method].Count++
+		totalExpenses += tx.AbsoluteAmount()
+	}
+
+	return &domain.PaymentMethodSummary{
+		Methods:       s.calculatePercentages(methods, totalExpenses, totalIncome, s.monthsCovered(transactions)),
+		TotalExpenses: s.roundAmount(totalExpenses),
 	}, nil
 }
 
@@ -197,8 +812,10 @@ func (s *AnalyticsService) aggregateCategory(categories map[string]*domain.Categ
 	categories[tx.Category].Count++
 }
 
-// calculatePercentages converts category map to final format with percentages
-func (s *AnalyticsService) calculatePercentages(categories map[string]*domain.CategoryDetail, total float64) map[string]domain.CategoryDetail {
+// calculatePercentages converts category map to final format with percentages and averages
+// months is the length of the period; MonthlyAverage is left at 0 when it is not positive
+// PercentOfIncome is relative to totalIncome, and 0 when there is no income
+func (s *AnalyticsService) calculatePercentages(categories map[string]*domain.CategoryDetail, total, totalIncome float64, months int) map[string]domain.CategoryDetail {
 	result := make(map[string]domain.CategoryDetail)
 
 	for category, detail := range categories {
@@ -207,10 +824,28 @@ func (s *AnalyticsService) calculatePercentages(categories map[string]*domain.Ca
 			percentage = (detail.Total / total) * 100
 		}
 
+		percentOfIncome := 0.0
+		if totalIncome > 0 {
+			percentOfIncome = (detail.Total / totalIncome) * 100
+		}
+
+		average := 0.0
+		if detail.Count > 0 {
+			average = detail.Total / float64(detail.Count)
+		}
+
+		monthlyAverage := 0.0
+		if months > 0 {
+			monthlyAverage = detail.Total / float64(months)
+		}
+
 		result[category] = domain.CategoryDetail{
-			Total:      roundToTwo(detail.Total),
-			Count:      detail.Count,
-			Percentage: roundToTwo(percentage),
+			Total:           s.roundAmount(detail.Total),
+			Count:           detail.Count,
+			Percentage:      roundToN(percentage, metricPrecision),
+			PercentOfIncome: roundToN(percentOfIncome, metricPrecision),
+			Average:         s.roundAmount(average),
+			MonthlyAverage:  s.roundAmount(monthlyAverage),
 		}
 	}
 
@@ -254,6 +889,15 @@ func (s *AnalyticsService) getDateRangeFromTransactions(transactions []domain.Tr
 	return minDate, maxDate, nil
 }
 
+// monthsCovered returns the number of calendar months spanned by transactions, or 0 if none
+func (s *AnalyticsService) monthsCovered(transactions []domain.Transaction) int {
+	start, end, err := s.getDateRangeFromTransactions(transactions)
+	if err != nil {
+		return 0
+	}
+	return s.calculateMonthsBetween(start, end)
+}
+
 // calculateMonthsBetween calculates the number of months between two dates
 func (s *AnalyticsService) calculateMonthsBetween(start, end time.Time) int {
 	years := end.Year() - start.Year()
@@ -263,8 +907,27 @@ func (s *AnalyticsService) calculateMonthsBetween(start, end time.Time) int {
 	return years*12 + months + 1
 }
 
-// roundToTwo rounds a float64 to 2 decimal places
-func roundToTwo(val float64) float64 {
-	return math.Round(val*100) / 100
+// topCategory returns the category with the highest spend (alphabetical on ties)
+func topCategory(spend map[string]float64) string {
+	var top string
+	var topAmount float64
+	for category, amount := range spend {
+		if top == "" || amount > topAmount || (amount == topAmount && category < top) {
+			top = category
+			topAmount = amount
+		}
+	}
+	return top
+}
+
+// roundAmount rounds a money amount to the service's decimal precision
+func (s *AnalyticsService) roundAmount(val float64) float64 {
+	return roundToN(val, s.decimalPrecision)
+}
+
+// roundToN rounds a float64 to n decimal places
+func roundToN(val float64, n int) float64 {
+	scale := math.Pow(10, float64(n))
+	return math.Round(val*scale) / scale
 }
 
@@ -1,11 +1,14 @@
 package service
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/danntastico/stori-backend/internal/categorizer"
 	"github.com/danntastico/stori-backend/internal/domain"
 	"github.com/danntastico/stori-backend/internal/repository"
+	"github.com/danntastico/stori-backend/internal/testutil/sampledata"
 )
 
 // Test data
@@ -90,6 +93,25 @@ func TestAnalyticsService_GetCategorySummary(t *testing.T) {
 		t.Errorf("Rent count = %d, want 2", rent.Count)
 	}
 
+	// 1200 x 2 = 2400 over 2 months
+	if rent.Average != 1200 {
+		t.Errorf("Rent average = %v, want 1200", rent.Average)
+	}
+	if rent.MonthlyAverage != 1200 {
+		t.Errorf("Rent monthly average = %v, want 1200", rent.MonthlyAverage)
+	}
+	if salary.MonthlyAverage != 4200 {
+		t.Errorf("Salary monthly average = %v, want 4200", salary.MonthlyAverage)
+	}
+
+	// Rent is 2400 of 8400 income; income categories are a share of income by definition
+	if want := roundToN(rent.Total/8400*100, 2); rent.PercentOfIncome != want {
+		t.Errorf("Rent percent of income = %v, want %v", rent.PercentOfIncome, want)
+	}
+	if salary.PercentOfIncome != salary.Percentage {
+		t.Errorf("Salary percent of income = %v, want its percentage %v", salary.PercentOfIncome, salary.Percentage)
+	}
+
 	// Check groceries category
 	groceries, exists := summary.Expenses["groceries"]
 	if !exists {
@@ -135,6 +157,79 @@ func TestAnalyticsService_GetCategorySummary(t *testing.T) {
 	}
 }
 
+func TestAnalyticsService_GetMonthlyCategorySummary(t *testing.T) {
+	service := setupTestService(t)
+
+	// Any day of February selects the whole month
+	summary, err := service.GetMonthlyCategorySummary(time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetMonthlyCategorySummary() error = %v", err)
+	}
+	if summary.Summary.TotalIncome != 2800 || summary.Summary.TotalExpenses != 1310 {
+		t.Errorf("Summary = %+v, want income 2800 and expenses 1310", summary.Summary)
+	}
+	if summary.Period.Start != "2024-02-01" || summary.Period.End != "2024-02-04" {
+		t.Errorf("Period = %s to %s, want 2024-02-01 to 2024-02-04", summary.Period.Start, summary.Period.End)
+	}
+
+	if _, err := service.GetMonthlyCategorySummary(time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)); !errors.Is(err, domain.ErrInsufficientData) {
+		t.Errorf("GetMonthlyCategorySummary() for an empty month error = %v, want ErrInsufficientData", err)
+	}
+}
+
+func TestAnalyticsService_ComputeMoMChange(t *testing.T) {
+	service := setupTestService(t)
+
+	summary, err := service.GetCategorySummary()
+	if err != nil {
+		t.Fatalf("GetCategorySummary() error = %v", err)
+	}
+
+	// February vs January
+	tests := []struct {
+		category string
+		expected float64
+	}{
+		{"rent", 0},           // 1200 both months
+		{"groceries", 29.41},  // 85 -> 110 (Costco)
+		{"utilities", -100.0}, // No February electric bill
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.category, func(t *testing.T) {
+			change, ok := summary.MoMChange[tt.category]
+			if !ok {
+				t.Fatalf("Expected MoM change for %s", tt.category)
+			}
+			if change != tt.expected {
+				t.Errorf("MoM change = %v, want %v", change, tt.expected)
+			}
+		})
+	}
+
+	// Income categories are not included
+	if _, ok := summary.MoMChange["salary"]; ok {
+		t.Error("Expected no MoM change for income categories")
+	}
+
+	// New category in the recent month
+	newCategory := service.computeMoMChange([]domain.Transaction{
+		{Date: "2024-01-10", Amount: -50, Category: "dining", Type: "expense"},
+		{Date: "2024-02-10", Amount: -30, Category: "shopping", Type: "expense"},
+	})
+	if newCategory["shopping"] != 100 || newCategory["dining"] != -100 {
+		t.Errorf("Expected shopping +100 and dining -100, got %v", newCategory)
+	}
+
+	// A single month has nothing to compare against
+	single := service.computeMoMChange([]domain.Transaction{
+		{Date: "2024-01-10", Amount: -50, Category: "dining", Type: "expense"},
+	})
+	if len(single) != 0 {
+		t.Errorf("Expected empty MoM change for a single month, got %v", single)
+	}
+}
+
 func TestAnalyticsService_GetTimeline(t *testing.T) {
 	service := setupTestService(t)
 
@@ -226,6 +321,58 @@ func TestAnalyticsService_GetTransactions(t *testing.T) {
 	if response.Period.End != "2024-02-04" {
 		t.Errorf("Period end = %v, want 2024-02-04", response.Period.End)
 	}
+
+	if response.Period.Income <= 0 {
+		t.Errorf("Period income = %v, want > 0", response.Period.Income)
+	}
+	if response.Period.Income != 8400 || response.Period.Expenses != 2640 || response.Period.NetSavings != 5760 {
+		t.Errorf("Period totals = %v/%v/%v, want 8400/2640/5760",
+			response.Period.Income, response.Period.Expenses, response.Period.NetSavings)
+	}
+	if response.Period.TransactionCount != expectedCount {
+		t.Errorf("Period transaction count = %d, want %d", response.Period.TransactionCount, expectedCount)
+	}
+	assertSortedByDate(t, response.Transactions)
+
+	// A hand-edited file is returned chronologically, while the repository keeps load order
+	repo, err := repository.NewJSONRepository([]byte(`[
+		{"date": "2024-02-02", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"},
+		{"date": "2024-01-01", "amount": 2800, "category": "salary", "description": "Bi-weekly salary", "type": "income"},
+		{"date": "2024-01-16", "amount": 2800, "category": "salary", "description": "Bi-weekly salary", "type": "income"},
+		{"date": "2024-01-03", "amount": -85, "category": "groceries", "description": "Whole Foods", "type": "expense"}
+	]`))
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	response, err = NewAnalyticsService(repo).GetTransactions()
+	if err != nil {
+		t.Fatalf("GetTransactions() error = %v", err)
+	}
+	assertSortedByDate(t, response.Transactions)
+
+	// Filtered listings are sorted the same way
+	filtered, err := NewAnalyticsService(repo).FilterTransactions(domain.TransactionFilter{})
+	if err != nil {
+		t.Fatalf("FilterTransactions() error = %v", err)
+	}
+	assertSortedByDate(t, filtered)
+
+	loaded, _ := repo.GetAll()
+	if loaded[0].Date != "2024-02-02" {
+		t.Errorf("Expected the repository to keep load order, got %s first", loaded[0].Date)
+	}
+}
+
+// assertSortedByDate fails unless each transaction's date is on or after the previous one
+func assertSortedByDate(t *testing.T, transactions []domain.Transaction) {
+	t.Helper()
+
+	for i := 1; i < len(transactions); i++ {
+		if transactions[i].Date < transactions[i-1].Date {
+			t.Fatalf("Transactions not sorted: %s at %d follows %s", transactions[i].Date, i, transactions[i-1].Date)
+		}
+	}
 }
 
 func TestAnalyticsService_GetTransactionsByDateRange(t *testing.T) {
@@ -296,6 +443,14 @@ func TestAnalyticsService_GetTransactionsByDateRange(t *testing.T) {
 					t.Errorf("Transactions length = %d, want %d", len(response.Transactions), tt.expectedCount)
 				}
 
+				// Every range in the fixture includes a salary payment
+				if response.Period.Income <= 0 {
+					t.Errorf("Period income = %v, want > 0", response.Period.Income)
+				}
+				if response.Period.TransactionCount != tt.expectedCount {
+					t.Errorf("Period transaction count = %d, want %d", response.Period.TransactionCount, tt.expectedCount)
+				}
+
 				// Verify all transactions are within range
 				for _, tx := range response.Transactions {
 					txDate, _ := tx.ParseDate()
@@ -373,7 +528,7 @@ func TestAnalyticsService_RoundingAccuracy(t *testing.T) {
 
 	// Verify all monetary values are rounded to 2 decimal places
 	checkRounding := func(val float64, name string) {
-		rounded := roundToTwo(val)
+		rounded := roundToN(val, 2)
 		if rounded != val {
 			t.Errorf("%s value %v is not rounded to 2 decimal places", name, val)
 		}
@@ -395,6 +550,51 @@ func TestAnalyticsService_RoundingAccuracy(t *testing.T) {
 	}
 }
 
+func TestAnalyticsService_DecimalPrecision(t *testing.T) {
+	data := []byte(`[
+		{"date": "2024-01-01", "amount": 300000.4, "category": "salary", "description": "Salary", "type": "income"},
+		{"date": "2024-01-03", "amount": -1234.5678, "category": "groceries", "description": "Market", "type": "expense"},
+		{"date": "2024-01-20", "amount": -0.1234, "category": "groceries", "description": "Bag", "type": "expense"}
+	]`)
+
+	tests := []struct {
+		name          string
+		opts          []AnalyticsOption
+		wantExpenses  float64
+		wantGroceries float64
+	}{
+		{"default two decimals", nil, 1234.69, 1234.69},
+		{"JPY has no decimals", []AnalyticsOption{WithDecimalPrecision(0)}, 1235, 1235},
+		{"KWD has three decimals", []AnalyticsOption{WithDecimalPrecision(3)}, 1234.691, 1234.691},
+		{"negative precision is ignored", []AnalyticsOption{WithDecimalPrecision(-1)}, 1234.69, 1234.69},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := repository.NewJSONRepository(data)
+			if err != nil {
+				t.Fatalf("Failed to create repository: %v", err)
+			}
+
+			summary, err := NewAnalyticsService(repo, tt.opts...).GetCategorySummary()
+			if err != nil {
+				t.Fatalf("GetCategorySummary() error = %v", err)
+			}
+
+			if summary.Summary.TotalExpenses != tt.wantExpenses {
+				t.Errorf("TotalExpenses = %v, want %v", summary.Summary.TotalExpenses, tt.wantExpenses)
+			}
+			if got := summary.Expenses["groceries"].Total; got != tt.wantGroceries {
+				t.Errorf("Groceries total = %v, want %v", got, tt.wantGroceries)
+			}
+			// Percentages keep two decimals whatever the currency
+			if got := summary.Expenses["groceries"].PercentOfIncome; got != 0.41 {
+				t.Errorf("Groceries percent of income = %v, want 0.41", got)
+			}
+		})
+	}
+}
+
 func TestAnalyticsService_EmptyData(t *testing.T) {
 	emptyJSON := []byte(`[]`)
 	repo, err := repository.NewJSONRepository(emptyJSON)
@@ -426,3 +626,366 @@ func TestAnalyticsService_EmptyData(t *testing.T) {
 	})
 }
 
+func TestAnalyticsService_GetMerchantSummary(t *testing.T) {
+	service := setupTestService(t)
+
+	merchants, err := service.GetMerchantSummary()
+	if err != nil {
+		t.Fatalf("GetMerchantSummary() error = %v", err)
+	}
+
+	// Only expense transactions are grouped by merchant
+	if _, exists := merchants["Bi-weekly salary"]; exists {
+		t.Error("Income transactions should not appear in merchant summary")
+	}
+
+	rent, exists := merchants["Monthly rent"]
+	if !exists {
+		t.Fatal("Expected 'Monthly rent' merchant to exist")
+	}
+
+	if rent.Total != 2400 {
+		t.Errorf("Expected rent total 2400, got %v", rent.Total)
+	}
+
+	if rent.Count != 2 {
+		t.Errorf("Expected rent count 2, got %d", rent.Count)
+	}
+
+	// Total expenses: 1200 + 85 + 45 + 1200 + 110 = 2640
+	expectedPercentage := 90.91 // (2400 / 2640) * 100
+	if rent.Percentage != expectedPercentage {
+		t.Errorf("Expected rent percentage %v, got %v", expectedPercentage, rent.Percentage)
+	}
+}
+
+func TestAnalyticsService_GetTagSummary(t *testing.T) {
+	repo, err := repository.NewJSONRepository([]byte(`[
+		{"date": "2024-01-01", "amount": 2800, "category": "salary", "description": "Salary", "type": "income", "tags": ["work"]},
+		{"date": "2024-01-05", "amount": -300, "category": "shopping", "description": "Laptop bag", "type": "expense", "tags": ["business", "travel"]},
+		{"date": "2024-01-06", "amount": -100, "category": "dining", "description": "Client lunch", "type": "expense", "tags": ["business"]},
+		{"date": "2024-01-07", "amount": -100, "category": "groceries", "description": "Safeway", "type": "expense"}
+	]`))
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	service := NewAnalyticsService(repo)
+
+	tags, err := service.GetTagSummary()
+	if err != nil {
+		t.Fatalf("GetTagSummary() error = %v", err)
+	}
+
+	// Income tags are not part of the spending breakdown
+	if _, exists := tags["work"]; exists {
+		t.Error("Income tags should not appear in tag summary")
+	}
+
+	business := tags["business"]
+	if business.Total != 400 || business.Count != 2 || business.Percentage != 80 {
+		t.Errorf("Unexpected business tag detail: %+v", business)
+	}
+
+	travel := tags["travel"]
+	if travel.Total != 300 || travel.Count != 1 || travel.Percentage != 60 {
+		t.Errorf("Unexpected travel tag detail: %+v", travel)
+	}
+}
+
+// Merchant names are case-sensitive by design: data sources that disagree on
+// casing produce separate merchants rather than being silently merged
+func TestAnalyticsService_GetPaymentMethodSummary(t *testing.T) {
+	repo, err := repository.NewJSONRepository([]byte(`[
+		{"date": "2024-01-01", "amount": 2800, "category": "salary", "description": "Salary", "type": "income", "payment_method": "bank_transfer"},
+		{"date": "2024-01-02", "amount": -1200, "category": "rent", "description": "Rent", "type": "expense", "payment_method": "bank_transfer"},
+		{"date": "2024-01-05", "amount": -60, "category": "shopping", "description": "Order", "type": "expense", "payment_method": "credit_card"},
+		{"date": "2024-01-06", "amount": -40, "category": "shopping", "description": "Order", "type": "expense", "payment_method": "credit_card"},
+		{"date": "2024-01-07", "amount": -20, "category": "dining", "description": "Lunch", "type": "expense", "payment_method": "cash"},
+		{"date": "2024-01-08", "amount": -80, "category": "groceries", "description": "Groceries", "type": "expense"}
+	]`))
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	summary, err := NewAnalyticsService(repo).GetPaymentMethodSummary()
+	if err != nil {
+		t.Fatalf("GetPaymentMethodSummary() error = %v", err)
+	}
+
+	if summary.TotalExpenses != 1400 {
+		t.Errorf("Expected total expenses 1400, got %v", summary.TotalExpenses)
+	}
+
+	// Income is excluded, so bank_transfer only counts the rent
+	expected := map[string]struct {
+		total float64
+		count int
+	}{
+		"bank_transfer": {1200, 1},
+		"credit_card":   {100, 2},
+		"cash":          {20, 1},
+		"unspecified":   {80, 1},
+	}
+	if len(summary.Methods) != len(expected) {
+		t.Fatalf("Expected %d payment methods, got %+v", len(expected), summary.Methods)
+	}
+	for method, want := range expected {
+		got := summary.Methods[method]
+		if got.Total != want.total || got.Count != want.count {
+			t.Errorf("%s: got total %v count %d, want total %v count %d", method, got.Total, got.Count, want.total, want.count)
+		}
+	}
+
+	// Income still counts toward the share of income: rent is 1200 of 2800
+	if got := summary.Methods["bank_transfer"].PercentOfIncome; got != 42.86 {
+		t.Errorf("bank_transfer percent of income = %v, want 42.86", got)
+	}
+}
+
+func TestAnalyticsService_PercentOfIncome_NoIncome(t *testing.T) {
+	service := setupRecurringService(t, `[
+		{"date": "2024-01-02", "amount": -1200, "category": "rent", "description": "Rent", "type": "expense"}
+	]`)
+
+	summary, err := service.GetCategorySummary()
+	if err != nil {
+		t.Fatalf("GetCategorySummary() error = %v", err)
+	}
+	if got := summary.Expenses["rent"].PercentOfIncome; got != 0 {
+		t.Errorf("Percent of income without income = %v, want 0", got)
+	}
+}
+
+func TestAnalyticsService_GetMerchantSummary_CaseSensitive(t *testing.T) {
+	repo, err := repository.NewJSONRepository([]byte(`[
+		{"date": "2024-01-05", "amount": -30, "category": "shopping", "description": "Order 1", "type": "expense", "merchant": "Amazon"},
+		{"date": "2024-01-06", "amount": -50, "category": "entertainment", "description": "Prime Video", "type": "expense", "merchant": "Amazon"},
+		{"date": "2024-01-07", "amount": -20, "category": "shopping", "description": "Order 2", "type": "expense", "merchant": "amazon"}
+	]`))
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	service := NewAnalyticsService(repo)
+
+	merchants, err := service.GetMerchantSummary()
+	if err != nil {
+		t.Fatalf("GetMerchantSummary() error = %v", err)
+	}
+
+	if len(merchants) != 2 {
+		t.Fatalf("Expected 'Amazon' and 'amazon' as distinct merchants, got %d", len(merchants))
+	}
+
+	upper := merchants["Amazon"]
+	if upper.Total != 80 || upper.Count != 2 || upper.TopCategory != "entertainment" {
+		t.Errorf("Unexpected 'Amazon' detail: %+v", upper)
+	}
+
+	lower := merchants["amazon"]
+	if lower.Total != 20 || lower.Count != 1 || lower.TopCategory != "shopping" {
+		t.Errorf("Unexpected 'amazon' detail: %+v", lower)
+	}
+}
+
+// setupBenchmarkService loads the 112-transaction data file used by the server
+func setupBenchmarkService(b *testing.B) *AnalyticsService {
+	b.Helper()
+
+	data := sampledata.Transactions(b)
+
+	repo, err := repository.NewJSONRepository(data)
+	if err != nil {
+		b.Fatalf("Failed to create repository: %v", err)
+	}
+
+	return NewAnalyticsService(repo)
+}
+
+func BenchmarkGetCategorySummary(b *testing.B) {
+	service := setupBenchmarkService(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := service.GetCategorySummary(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetTimeline(b *testing.B) {
+	service := setupBenchmarkService(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := service.GetTimeline(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetTransactions(b *testing.B) {
+	service := setupBenchmarkService(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := service.GetTransactions(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// recordingBroadcaster collects broadcast transactions
+type recordingBroadcaster struct {
+	transactions []domain.Transaction
+}
+
+func (b *recordingBroadcaster) Broadcast(tx domain.Transaction) {
+	b.transactions = append(b.transactions, tx)
+}
+
+func TestAnalyticsService_CreateTransaction(t *testing.T) {
+	service := setupTestService(t)
+	broadcaster := &recordingBroadcaster{}
+	service.SetTransactionBroadcaster(broadcaster)
+
+	created, err := service.CreateTransaction(domain.Transaction{
+		Date: "2024-02-10", Amount: -30, Category: "dining", Description: "Lunch", Type: "expense",
+	})
+	if err != nil {
+		t.Fatalf("CreateTransaction() error = %v", err)
+	}
+	if created.ID == "" {
+		t.Error("Expected the created transaction to have an ID")
+	}
+	if len(broadcaster.transactions) != 1 || broadcaster.transactions[0].ID != created.ID {
+		t.Errorf("Expected the created transaction to be broadcast, got %+v", broadcaster.transactions)
+	}
+
+	// Invalid transactions are neither stored nor broadcast
+	_, err = service.CreateTransaction(domain.Transaction{Date: "2024-02-10", Amount: 30, Category: "dining", Type: "expense"})
+	var validationErrs *domain.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Errorf("Expected validation errors, got %v", err)
+	}
+	if len(broadcaster.transactions) != 1 {
+		t.Errorf("Expected no broadcast for an invalid transaction, got %+v", broadcaster.transactions)
+	}
+}
+
+func TestAnalyticsService_PatchTransaction(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name  string
+		patch domain.TransactionPatch
+		check func(t *testing.T, tx *domain.Transaction)
+	}{
+		{
+			name:  "description only",
+			patch: domain.TransactionPatch{Description: strPtr("Rent (January)")},
+			check: func(t *testing.T, tx *domain.Transaction) {
+				if tx.Description != "Rent (January)" || tx.Category != "rent" || tx.Amount != -1200 || tx.Date != "2024-01-02" {
+					t.Errorf("Expected only the description to change, got %+v", tx)
+				}
+			},
+		},
+		{
+			name:  "category",
+			patch: domain.TransactionPatch{Category: strPtr("housing")},
+			check: func(t *testing.T, tx *domain.Transaction) {
+				if tx.Category != "housing" || tx.Description != "Monthly rent" {
+					t.Errorf("Expected only the category to change, got %+v", tx)
+				}
+			},
+		},
+		{
+			name:  "notes",
+			patch: domain.TransactionPatch{Notes: strPtr("Paid late")},
+			check: func(t *testing.T, tx *domain.Transaction) {
+				if tx.Notes != "Paid late" || tx.Description != "Monthly rent" {
+					t.Errorf("Expected only the notes to change, got %+v", tx)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := setupTestService(t)
+
+			tx, err := service.PatchTransaction("tx-2", tt.patch)
+			if err != nil {
+				t.Fatalf("PatchTransaction() error = %v", err)
+			}
+			tt.check(t, tx)
+
+			stored, _ := service.repo.GetByID("tx-2")
+			tt.check(t, &stored)
+		})
+	}
+}
+
+func TestAnalyticsService_PatchTransaction_Invalid(t *testing.T) {
+	service := setupTestService(t)
+
+	_, err := service.PatchTransaction("tx-2", domain.TransactionPatch{Type: func() *string { s := "transfer"; return &s }()})
+	var validationErrs *domain.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("PatchTransaction() error = %v, want *ValidationErrors", err)
+	}
+	if !errors.Is(err, domain.ErrInvalidType) {
+		t.Errorf("Expected an invalid type error, got %v", err)
+	}
+
+	// The stored transaction is unchanged
+	if stored, _ := service.repo.GetByID("tx-2"); stored.Type != "expense" {
+		t.Errorf("Expected the type to stay expense, got %q", stored.Type)
+	}
+
+	if _, err := service.PatchTransaction("missing", domain.TransactionPatch{}); !errors.Is(err, domain.ErrTransactionNotFound) {
+		t.Errorf("PatchTransaction(missing) error = %v, want ErrTransactionNotFound", err)
+	}
+}
+
+func TestAnalyticsService_CreateTransaction_AutoCategorize(t *testing.T) {
+	autoCategorizer, err := categorizer.New()
+	if err != nil {
+		t.Fatalf("categorizer.New() error = %v", err)
+	}
+	service := setupTestService(t)
+	service.SetAutoCategorizer(autoCategorizer)
+
+	created, err := service.CreateTransaction(domain.Transaction{
+		Date: "2024-02-10", Amount: -15.99, Description: "NETFLIX.COM", Type: "expense",
+	})
+	if err != nil {
+		t.Fatalf("CreateTransaction() error = %v", err)
+	}
+	if created.Category != "subscriptions" {
+		t.Errorf("Category = %q, want subscriptions", created.Category)
+	}
+
+	// A category given by the client is kept
+	created, err = service.CreateTransaction(domain.Transaction{
+		Date: "2024-02-10", Amount: -15.99, Category: "entertainment", Description: "NETFLIX.COM", Type: "expense",
+	})
+	if err != nil {
+		t.Fatalf("CreateTransaction() error = %v", err)
+	}
+	if created.Category != "entertainment" {
+		t.Errorf("Category = %q, want the given entertainment", created.Category)
+	}
+
+	// An ambiguous description stays uncategorized, so validation rejects it
+	_, err = service.CreateTransaction(domain.Transaction{
+		Date: "2024-02-10", Amount: -24, Description: "UBER EATS", Type: "expense",
+	})
+	var validationErrs *domain.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Errorf("Expected validation errors for an ambiguous description, got %v", err)
+	}
+}
+
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// BudgetAlertSignatureHeader carries hex(HMAC-SHA256(secret, body)) on budget alert webhooks
+const BudgetAlertSignatureHeader = "X-Signature"
+
+// DefaultBudgetAlertThreshold is the share of a monthly limit that triggers an alert
+const DefaultBudgetAlertThreshold = 0.9
+
+// BudgetAlertService posts a webhook notification when spending in a category reaches a
+// share of its monthly budget
+// Spending is measured in the current calendar month. Each category is reported once per
+// month; a failed delivery is retried on the next check.
+type BudgetAlertService struct {
+	analytics  *AnalyticsService
+	budgets    *BudgetService
+	webhookURL string
+	secret     string
+	threshold  float64
+	httpClient *http.Client
+	now        func() time.Time // Replaced in tests
+
+	mu           sync.Mutex      // Held for a whole check so concurrent checks cannot send duplicates
+	alertedMonth string          // Month of the alerts in alerted, "YYYY-MM"
+	alerted      map[string]bool // Categories already delivered in alertedMonth
+}
+
+// NewBudgetAlertService creates a budget alert service posting to webhookURL
+// Payloads are signed with secret; threshold is the share of a limit that triggers an
+// alert, e.g., 0.9 for 90%.
+func NewBudgetAlertService(analytics *AnalyticsService, budgets *BudgetService, webhookURL, secret string, threshold float64) *BudgetAlertService {
+	return &BudgetAlertService{
+		analytics:  analytics,
+		budgets:    budgets,
+		webhookURL: webhookURL,
+		secret:     secret,
+		threshold:  threshold,
+		httpClient: newSSRFSafeClient(10 * time.Second),
+		now:        time.Now,
+		alerted:    make(map[string]bool),
+	}
+}
+
+// SetHTTPClient replaces the HTTP client used to call the webhook
+// The default client only allows HTTPS to public addresses; a replacement bypasses
+// that protection, so only use it for tests against a local server.
+func (s *BudgetAlertService) SetHTTPClient(client *http.Client) {
+	s.httpClient = client
+}
+
+// CheckAndAlert compares the current month's spending against every budget and posts an
+// alert for each category at or above the threshold that was not reported yet
+// Returns the delivery failures joined together; the other alerts are still sent.
+func (s *BudgetAlertService) CheckAndAlert(ctx context.Context) error {
+	limits, err := s.budgets.MonthlyLimits()
+	if err != nil {
+		return err
+	}
+	if len(limits) == 0 {
+		return nil
+	}
+
+	now := s.now()
+	month := now.Format("2006-01")
+	summary, err := s.analytics.GetMonthlyCategorySummary(now)
+	if errors.Is(err, domain.ErrInsufficientData) {
+		// Nothing was spent this month yet
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Alert in a stable order
+	categories := make([]string, 0, len(limits))
+	for category := range limits {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Alerts from earlier months can no longer repeat, so only this month's are kept
+	if s.alertedMonth != month {
+		s.alertedMonth = month
+		s.alerted = make(map[string]bool)
+	}
+
+	var errs []error
+	for _, category := range categories {
+		limit := limits[category]
+		actual := summary.Expenses[category].Total
+		if limit <= 0 || actual < limit*s.threshold || s.alerted[category] {
+			continue
+		}
+
+		overage := s.analytics.roundAmount(actual - limit)
+		payload := domain.BudgetAlertPayload{
+			Category:       category,
+			Month:          month,
+			BudgetLimit:    limit,
+			ActualSpend:    actual,
+			OverageAmount:  overage,
+			OveragePercent: roundToN(overage/limit*100, metricPrecision),
+		}
+		if err := s.send(ctx, payload); err != nil {
+			errs = append(errs, fmt.Errorf("budget alert for %s: %w", category, err))
+			continue
+		}
+		s.alerted[category] = true
+	}
+
+	return errors.Join(errs...)
+}
+
+// send posts payload to the webhook, signed with the shared secret
+func (s *BudgetAlertService) send(ctx context.Context, payload domain.BudgetAlertPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(BudgetAlertSignatureHeader, SignBudgetAlert(s.secret, body))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook error: %w", &domain.HTTPError{
+			StatusCode: resp.StatusCode,
+			Message:    string(message),
+		})
+	}
+
+	return nil
+}
+
+// SignBudgetAlert returns the hex-encoded HMAC-SHA256 of body, as sent in X-Signature
+func SignBudgetAlert(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
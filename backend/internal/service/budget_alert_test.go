@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/repository"
+)
+
+// budgetAlertTransactionsJSON spends 100 on groceries, 1200 on rent and 45 on utilities in
+// February 2024, the month the alert service's clock is set to
+const budgetAlertTransactionsJSON = `[
+	{"date": "2024-01-03", "amount": -500, "category": "groceries", "description": "Stock up", "type": "expense"},
+	{"date": "2024-02-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"},
+	{"date": "2024-02-02", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"},
+	{"date": "2024-02-03", "amount": -60, "category": "groceries", "description": "Whole Foods", "type": "expense"},
+	{"date": "2024-02-05", "amount": -45, "category": "utilities", "description": "Electric bill", "type": "expense"},
+	{"date": "2024-02-17", "amount": -40, "category": "groceries", "description": "Costco", "type": "expense"}
+]`
+
+// webhookRecorder is a mock webhook that records each delivery and answers with status
+type webhookRecorder struct {
+	mu         sync.Mutex
+	status     int
+	bodies     [][]byte
+	signatures []string
+}
+
+func (rec *webhookRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	rec.bodies = append(rec.bodies, body)
+	rec.signatures = append(rec.signatures, r.Header.Get(BudgetAlertSignatureHeader))
+	w.WriteHeader(rec.status)
+}
+
+// setupBudgetAlertService creates an alert service over the test data and budgets, posting to
+// a mock webhook
+func setupBudgetAlertService(t *testing.T, limits map[string]float64) (*BudgetAlertService, *webhookRecorder) {
+	t.Helper()
+
+	budgets := repository.NewInMemoryBudgetRepository()
+	for category, limit := range limits {
+		if err := budgets.Create(domain.Budget{Category: category, MonthlyLimit: limit}); err != nil {
+			t.Fatalf("Failed to create budget: %v", err)
+		}
+	}
+	analytics := setupRecurringService(t, budgetAlertTransactionsJSON)
+	budgetService := NewBudgetService(analytics, budgets)
+
+	recorder := &webhookRecorder{status: http.StatusOK}
+	server := httptest.NewServer(recorder)
+	t.Cleanup(server.Close)
+
+	alerts := NewBudgetAlertService(analytics, budgetService, server.URL, "alert-secret", DefaultBudgetAlertThreshold)
+	alerts.SetHTTPClient(server.Client())
+	alerts.now = func() time.Time { return time.Date(2024, 2, 20, 9, 0, 0, 0, time.UTC) }
+	return alerts, recorder
+}
+
+func TestBudgetAlertService_CheckAndAlert(t *testing.T) {
+	// Groceries is at 95% and utilities at 150% of their limits; rent is at 60%
+	alerts, recorder := setupBudgetAlertService(t, map[string]float64{
+		"groceries": 105.26,
+		"rent":      2000,
+		"utilities": 30,
+	})
+
+	if err := alerts.CheckAndAlert(context.Background()); err != nil {
+		t.Fatalf("CheckAndAlert() error = %v", err)
+	}
+
+	expected := []domain.BudgetAlertPayload{
+		{Category: "groceries", Month: "2024-02", BudgetLimit: 105.26, ActualSpend: 100, OverageAmount: -5.26, OveragePercent: -5},
+		{Category: "utilities", Month: "2024-02", BudgetLimit: 30, ActualSpend: 45, OverageAmount: 15, OveragePercent: 50},
+	}
+	if len(recorder.bodies) != len(expected) {
+		t.Fatalf("Expected %d alerts, got %d", len(expected), len(recorder.bodies))
+	}
+	for i, body := range recorder.bodies {
+		var payload domain.BudgetAlertPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("Alert %d is not JSON: %v", i, err)
+		}
+		if payload != expected[i] {
+			t.Errorf("Alert %d = %+v, want %+v", i, payload, expected[i])
+		}
+		if want := SignBudgetAlert("alert-secret", body); recorder.signatures[i] != want {
+			t.Errorf("Alert %d signature = %q, want %q", i, recorder.signatures[i], want)
+		}
+	}
+
+	// Categories already reported this month are not sent again
+	if err := alerts.CheckAndAlert(context.Background()); err != nil {
+		t.Fatalf("Second CheckAndAlert() error = %v", err)
+	}
+	if len(recorder.bodies) != len(expected) {
+		t.Errorf("Expected no repeated alerts, got %d in total", len(recorder.bodies))
+	}
+}
+
+func TestBudgetAlertService_RetriesFailedDelivery(t *testing.T) {
+	alerts, recorder := setupBudgetAlertService(t, map[string]float64{"utilities": 30})
+	recorder.status = http.StatusInternalServerError
+
+	err := alerts.CheckAndAlert(context.Background())
+	if err == nil {
+		t.Fatal("Expected an error when the webhook fails")
+	}
+	var httpErr *domain.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected a wrapped 500 HTTPError, got %v", err)
+	}
+
+	recorder.status = http.StatusAccepted
+	if err := alerts.CheckAndAlert(context.Background()); err != nil {
+		t.Fatalf("CheckAndAlert() after recovery error = %v", err)
+	}
+	if len(recorder.bodies) != 2 {
+		t.Errorf("Expected the failed alert to be sent again, got %d deliveries", len(recorder.bodies))
+	}
+}
+
+func TestBudgetAlertService_NothingToReport(t *testing.T) {
+	alerts, recorder := setupBudgetAlertService(t, nil)
+	if err := alerts.CheckAndAlert(context.Background()); err != nil {
+		t.Fatalf("CheckAndAlert() without budgets error = %v", err)
+	}
+	if len(recorder.bodies) != 0 {
+		t.Errorf("Expected no alert without budgets, got %d", len(recorder.bodies))
+	}
+
+	alerts, recorder = setupBudgetAlertService(t, map[string]float64{"rent": 1334})
+	if err := alerts.CheckAndAlert(context.Background()); err != nil {
+		t.Fatalf("CheckAndAlert() under the threshold error = %v", err)
+	}
+	if len(recorder.bodies) != 0 {
+		t.Errorf("Expected no alert below 90%% of the limit, got %d", len(recorder.bodies))
+	}
+}
+
+func TestBudgetAlertService_ChecksCurrentMonth(t *testing.T) {
+	alerts, recorder := setupBudgetAlertService(t, map[string]float64{"groceries": 100})
+
+	// January's groceries are over the limit, but only the current month is checked
+	alerts.now = func() time.Time { return time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC) }
+	if err := alerts.CheckAndAlert(context.Background()); err != nil {
+		t.Fatalf("CheckAndAlert() in a month without data error = %v", err)
+	}
+	if len(recorder.bodies) != 0 {
+		t.Errorf("Expected no alert for a month without spending, got %d", len(recorder.bodies))
+	}
+
+	alerts.now = func() time.Time { return time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC) }
+	if err := alerts.CheckAndAlert(context.Background()); err != nil {
+		t.Fatalf("CheckAndAlert() in January error = %v", err)
+	}
+	var payload domain.BudgetAlertPayload
+	if len(recorder.bodies) != 1 || json.Unmarshal(recorder.bodies[0], &payload) != nil || payload.Month != "2024-01" || payload.ActualSpend != 500 {
+		t.Fatalf("Expected one January alert for 500 spent, got %d deliveries", len(recorder.bodies))
+	}
+
+	// A new month starts a fresh record, so the category is reported again
+	alerts.now = func() time.Time { return time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC) }
+	if err := alerts.CheckAndAlert(context.Background()); err != nil {
+		t.Fatalf("CheckAndAlert() in February error = %v", err)
+	}
+	if len(recorder.bodies) != 2 {
+		t.Errorf("Expected a February alert, got %d deliveries in total", len(recorder.bodies))
+	}
+	if len(alerts.alerted) != 1 || alerts.alertedMonth != "2024-02" {
+		t.Errorf("Expected only February's alert to be remembered, got %s %v", alerts.alertedMonth, alerts.alerted)
+	}
+}
+
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/repository"
+)
+
+// BudgetService evaluates spending against category budgets
+type BudgetService struct {
+	analyticsService *AnalyticsService
+	budgets          repository.BudgetRepository
+}
+
+// NewBudgetService creates a new budget service
+func NewBudgetService(analyticsService *AnalyticsService, budgets repository.BudgetRepository) *BudgetService {
+	return &BudgetService{
+		analyticsService: analyticsService,
+		budgets:          budgets,
+	}
+}
+
+// MonthlyLimits returns every budget as a map of category to monthly limit
+func (s *BudgetService) MonthlyLimits() (map[string]float64, error) {
+	budgets, err := s.budgets.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	limits := make(map[string]float64, len(budgets))
+	for _, budget := range budgets {
+		limits[budget.Category] = budget.MonthlyLimit
+	}
+	return limits, nil
+}
+
+// GetBudgetAdherenceStreak reports consecutive months where spending in category stayed
+// within its monthly budget
+// Every month in the data counts, including months without spending in the category.
+// Returns ErrBudgetNotFound if the category has no budget.
+func (s *BudgetService) GetBudgetAdherenceStreak(category string) (*domain.SavingsStreak, error) {
+	budget, err := s.budgets.GetByCategory(category)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.analyticsService.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	months, err := s.analyticsService.calendarMonths(transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	spent := make(map[string]float64)
+	for _, tx := range transactions {
+		if !tx.IsExpense() || tx.Category != category {
+			continue
+		}
+		yearMonth, err := tx.GetYearMonth()
+		if err != nil {
+			continue
+		}
+		spent[yearMonth] += tx.AbsoluteAmount()
+	}
+
+	return buildStreak(months, func(month string) bool {
+		return s.analyticsService.roundAmount(spent[month]) <= budget.MonthlyLimit
+	}), nil
+}
+
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func TestGetCategorySummaryWithBudgets(t *testing.T) {
+	// Groceries spent 100 in the latest month (February); January spending is ignored
+	service := setupRecurringService(t, `[
+		{"date": "2024-01-03", "amount": -500, "category": "groceries", "description": "Stock up", "type": "expense"},
+		{"date": "2024-02-03", "amount": -60, "category": "groceries", "description": "Whole Foods", "type": "expense"},
+		{"date": "2024-02-17", "amount": -40, "category": "groceries", "description": "Costco", "type": "expense"},
+		{"date": "2024-02-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"}
+	]`)
+
+	tests := []struct {
+		name        string
+		budgets     map[string]float64
+		wantWarning string
+	}{
+		{"79% of the limit", map[string]float64{"groceries": 126.59}, ""},
+		{"80% of the limit", map[string]float64{"groceries": 125}, domain.BudgetWarningApproachingLimit},
+		{"99% of the limit", map[string]float64{"groceries": 101.02}, domain.BudgetWarningApproachingLimit},
+		{"100% of the limit", map[string]float64{"groceries": 100}, domain.BudgetWarningOverLimit},
+		{"over the limit", map[string]float64{"groceries": 50}, domain.BudgetWarningOverLimit},
+		{"no budget set", map[string]float64{"rent": 10}, ""},
+		{"no budgets", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary, err := service.GetCategorySummaryWithBudgets(tt.budgets)
+			if err != nil {
+				t.Fatalf("GetCategorySummaryWithBudgets() error = %v", err)
+			}
+
+			if got := summary.Expenses["groceries"].Warning; got != tt.wantWarning {
+				t.Errorf("Warning = %q, want %q", got, tt.wantWarning)
+			}
+			if _, ok := summary.Expenses["rent"]; ok {
+				t.Error("A budget without spending must not add an expense category")
+			}
+			if got := summary.Income["salary"].Warning; got != "" {
+				t.Errorf("Income categories never carry warnings, got %q", got)
+			}
+		})
+	}
+}
+
+func TestGetCategorySummary_WithoutBudgetArgument(t *testing.T) {
+	summary, err := setupTestService(t).GetCategorySummary()
+	if err != nil {
+		t.Fatalf("GetCategorySummary() error = %v", err)
+	}
+
+	for category, detail := range summary.Expenses {
+		if detail.Warning != "" {
+			t.Errorf("Expected no warning for %s without budgets, got %q", category, detail.Warning)
+		}
+	}
+}
+
+func TestGetCategorySummaryWithBudgets_LeavesCacheUntouched(t *testing.T) {
+	service := setupTestService(t)
+	service.SetCache(NewAnalyticsCache(service))
+
+	warned, err := service.GetCategorySummaryWithBudgets(map[string]float64{"rent": 1})
+	if err != nil {
+		t.Fatalf("GetCategorySummaryWithBudgets() error = %v", err)
+	}
+	if warned.Expenses["rent"].Warning != domain.BudgetWarningOverLimit {
+		t.Fatalf("Expected rent over its limit, got %q", warned.Expenses["rent"].Warning)
+	}
+
+	// The cached summary never carries another caller's warnings
+	cached, err := service.GetCategorySummary()
+	if err != nil {
+		t.Fatalf("GetCategorySummary() error = %v", err)
+	}
+	if cached.Expenses["rent"].Warning != "" {
+		t.Errorf("Expected no warning from the cache, got %q", cached.Expenses["rent"].Warning)
+	}
+}
+
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// GetBurnRate computes average spending per day, week and month, and how many days
+// net savings and a typical month's income would cover at that pace
+// The daily average spreads total expenses over every day from the first to the last transaction.
+func (s *AnalyticsService) GetBurnRate() (*domain.BurnRate, error) {
+	summary, err := s.GetCategorySummary()
+	if err != nil {
+		return nil, err
+	}
+
+	// Period dates are produced by the summary, so they always parse
+	start, _ := time.Parse("2006-01-02", summary.Period.Start)
+	end, _ := time.Parse("2006-01-02", summary.Period.End)
+	days := int(end.Sub(start).Hours()/24) + 1 // Inclusive
+
+	dailySpend := summary.Summary.TotalExpenses / float64(days)
+	burnRate := &domain.BurnRate{
+		DailyAverageSpend:  s.roundAmount(dailySpend),
+		WeeklyAverageSpend: s.roundAmount(dailySpend * 7),
+		MonthlyRunRate:     s.roundAmount(dailySpend * 30),
+	}
+
+	// Without expenses the runway is infinite, which JSON can't represent; leave it null
+	if dailySpend == 0 {
+		return burnRate, nil
+	}
+
+	savingsRunway := roundToN(math.Max(summary.Summary.NetSavings, 0)/dailySpend, metricPrecision)
+	burnRate.DaysOfRunwayFromSavings = &savingsRunway
+
+	monthlyIncome := summary.Summary.TotalIncome / float64(summary.Period.Months)
+	incomeRunway := roundToN(monthlyIncome/dailySpend, metricPrecision)
+	burnRate.DaysOfRunwayFromIncome = &incomeRunway
+
+	return burnRate, nil
+}
+
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestAnalyticsService_GetBurnRate(t *testing.T) {
+	service := setupTestService(t)
+
+	burnRate, err := service.GetBurnRate()
+	if err != nil {
+		t.Fatalf("GetBurnRate() error = %v", err)
+	}
+
+	// Expenses 1200 + 85 + 45 + 1200 + 110 = 2640 over 2024-01-01..2024-02-04 (35 days)
+	if burnRate.DailyAverageSpend != 75.43 {
+		t.Errorf("DailyAverageSpend = %v, want 75.43", burnRate.DailyAverageSpend)
+	}
+	if burnRate.WeeklyAverageSpend != 528 {
+		t.Errorf("WeeklyAverageSpend = %v, want 528", burnRate.WeeklyAverageSpend)
+	}
+	if burnRate.MonthlyRunRate != 2262.86 {
+		t.Errorf("MonthlyRunRate = %v, want 2262.86", burnRate.MonthlyRunRate)
+	}
+
+	// Net savings 8400 - 2640 = 5760; 5760 / (2640/35) = 76.36 days
+	if burnRate.DaysOfRunwayFromSavings == nil || *burnRate.DaysOfRunwayFromSavings != 76.36 {
+		t.Errorf("DaysOfRunwayFromSavings = %v, want 76.36", burnRate.DaysOfRunwayFromSavings)
+	}
+
+	// Monthly income 8400 / 2 = 4200; 4200 / (2640/35) = 55.68 days
+	if burnRate.DaysOfRunwayFromIncome == nil || *burnRate.DaysOfRunwayFromIncome != 55.68 {
+		t.Errorf("DaysOfRunwayFromIncome = %v, want 55.68", burnRate.DaysOfRunwayFromIncome)
+	}
+}
+
+func TestAnalyticsService_GetBurnRate_NoExpenses(t *testing.T) {
+	service := setupRecurringService(t, `[
+		{"date": "2024-01-01", "amount": 2800, "category": "salary", "description": "Salary", "type": "income"},
+		{"date": "2024-01-15", "amount": 2800, "category": "salary", "description": "Salary", "type": "income"}
+	]`)
+
+	burnRate, err := service.GetBurnRate()
+	if err != nil {
+		t.Fatalf("GetBurnRate() error = %v", err)
+	}
+
+	if burnRate.DailyAverageSpend != 0 {
+		t.Errorf("DailyAverageSpend = %v, want 0", burnRate.DailyAverageSpend)
+	}
+
+	// Infinite runway is reported as null
+	if burnRate.DaysOfRunwayFromSavings != nil || burnRate.DaysOfRunwayFromIncome != nil {
+		t.Errorf("Expected nil runways, got %v and %v", burnRate.DaysOfRunwayFromSavings, burnRate.DaysOfRunwayFromIncome)
+	}
+}
+
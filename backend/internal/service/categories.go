@@ -0,0 +1,54 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// GetUniqueCategories returns every category name in use, sorted alphabetically
+func (s *AnalyticsService) GetUniqueCategories() ([]string, error) {
+	transactions, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	categories := []string{}
+	for _, tx := range transactions {
+		if !seen[tx.Category] {
+			seen[tx.Category] = true
+			categories = append(categories, tx.Category)
+		}
+	}
+	sort.Strings(categories)
+
+	return categories, nil
+}
+
+// MergeCategories renames every transaction in category source to category target
+// and returns how many changed, e.g., to fold "grocery" into "groceries" after an import.
+// Cached analytics are invalidated, so summaries reflect the merge immediately.
+// Returns ErrInvalidCategory if either name is blank and ErrInvalidCategoryMerge if they are equal.
+func (s *AnalyticsService) MergeCategories(source, target string) (int, error) {
+	source = strings.TrimSpace(source)
+	target = strings.TrimSpace(target)
+
+	if source == "" || target == "" {
+		return 0, domain.ErrInvalidCategory
+	}
+	if source == target {
+		return 0, domain.ErrInvalidCategoryMerge
+	}
+
+	renamed, err := s.repo.RenameCategory(source, target)
+	if err != nil {
+		return 0, err
+	}
+	if renamed > 0 {
+		s.invalidateCache()
+	}
+	return renamed, nil
+}
+
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/repository"
+)
+
+func TestMergeCategories(t *testing.T) {
+	service := setupRecurringService(t, `[
+		{"date": "2024-01-03", "amount": -85, "category": "groceries", "description": "Whole Foods", "type": "expense"},
+		{"date": "2024-01-10", "amount": -40, "category": "grocery", "description": "Trader Joe's", "type": "expense"},
+		{"date": "2024-01-17", "amount": -25, "category": "grocery", "description": "Corner shop", "type": "expense"},
+		{"date": "2024-01-02", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"}
+	]`)
+
+	merged, err := service.MergeCategories("grocery", "groceries")
+	if err != nil {
+		t.Fatalf("MergeCategories() error = %v", err)
+	}
+	if merged != 2 {
+		t.Errorf("MergeCategories() = %d, want 2", merged)
+	}
+
+	categories, err := service.GetUniqueCategories()
+	if err != nil {
+		t.Fatalf("GetUniqueCategories() error = %v", err)
+	}
+	if len(categories) != 2 || categories[0] != "groceries" || categories[1] != "rent" {
+		t.Errorf("GetUniqueCategories() = %v, want [groceries rent]", categories)
+	}
+
+	// The target carries the merged spending in summaries
+	summary, err := service.GetCategorySummary()
+	if err != nil {
+		t.Fatalf("GetCategorySummary() error = %v", err)
+	}
+	groceries := summary.Expenses["groceries"]
+	if groceries.Count != 3 || groceries.Total != 150 {
+		t.Errorf("Expected groceries to have 3 transactions totaling 150, got %+v", groceries)
+	}
+}
+
+func TestMergeCategories_Invalid(t *testing.T) {
+	tests := []struct {
+		name           string
+		source, target string
+		wantErr        error
+	}{
+		{"same category", "rent", "rent", domain.ErrInvalidCategoryMerge},
+		{"same after trimming", "rent ", "rent", domain.ErrInvalidCategoryMerge},
+		{"blank source", "", "rent", domain.ErrInvalidCategory},
+		{"blank target", "rent", "  ", domain.ErrInvalidCategory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := setupTestService(t)
+
+			if _, err := service.MergeCategories(tt.source, tt.target); !errors.Is(err, tt.wantErr) {
+				t.Errorf("MergeCategories() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetUniqueCategories_Empty(t *testing.T) {
+	repo, _ := repository.NewJSONRepository([]byte(`[]`))
+
+	if _, err := NewAnalyticsService(repo).GetUniqueCategories(); !errors.Is(err, domain.ErrNoTransactions) {
+		t.Errorf("GetUniqueCategories() error = %v, want ErrNoTransactions", err)
+	}
+}
+
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// minParallelAggregation is the smallest dataset aggregated in parallel
+// Below it, starting goroutines costs more than the loop they would split.
+const minParallelAggregation = 10000
+
+// categoryTotals holds per-category and overall income and expense totals
+type categoryTotals struct {
+	income        map[string]*domain.CategoryDetail
+	expenses      map[string]*domain.CategoryDetail
+	totalIncome   float64
+	totalExpenses float64
+}
+
+// SetWorkers sets how many goroutines aggregate large datasets by category
+// 0 uses one per CPU; 1 always aggregates on the calling goroutine.
+func (s *AnalyticsService) SetWorkers(workers int) {
+	s.workers = workers
+}
+
+// aggregateByCategory totals transactions per category, in parallel for large datasets
+func (s *AnalyticsService) aggregateByCategory(transactions []domain.Transaction) categoryTotals {
+	workers := s.workers
+	if workers == 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers < 2 || len(transactions) < minParallelAggregation {
+		return s.aggregateChunk(transactions)
+	}
+
+	// Each worker fills its own slot, so no locking is needed until the merge
+	chunkSize := (len(transactions) + workers - 1) / workers
+	partials := make([]categoryTotals, 0, workers)
+	for start := 0; start < len(transactions); start += chunkSize {
+		partials = append(partials, categoryTotals{})
+	}
+
+	var wg sync.WaitGroup
+	for i := range partials {
+		start := i * chunkSize
+		end := min(start+chunkSize, len(transactions))
+
+		wg.Add(1)
+		go func(i int, chunk []domain.Transaction) {
+			defer wg.Done()
+			partials[i] = s.aggregateChunk(chunk)
+		}(i, transactions[start:end])
+	}
+	wg.Wait()
+
+	// Merge in chunk order so the result does not depend on scheduling
+	totals := partials[0]
+	for _, partial := range partials[1:] {
+		totals.merge(partial)
+	}
+	return totals
+}
+
+// aggregateChunk totals transactions per category on the calling goroutine
+func (s *AnalyticsService) aggregateChunk(transactions []domain.Transaction) categoryTotals {
+	totals := categoryTotals{
+		income:   make(map[string]*domain.CategoryDetail),
+		expenses: make(map[string]*domain.CategoryDetail),
+	}
+
+	for _, tx := range transactions {
+		if tx.IsIncome() {
+			totals.totalIncome += tx.Amount
+			s.aggregateCategory(totals.income, tx)
+		} else if tx.IsExpense() {
+			totals.totalExpenses += tx.AbsoluteAmount()
+			s.aggregateCategory(totals.expenses, tx)
+		}
+	}
+
+	return totals
+}
+
+// merge adds other's totals into t
+func (t *categoryTotals) merge(other categoryTotals) {
+	t.totalIncome += other.totalIncome
+	t.totalExpenses += other.totalExpenses
+	mergeCategoryDetails(t.income, other.income)
+	mergeCategoryDetails(t.expenses, other.expenses)
+}
+
+// mergeCategoryDetails adds the totals and counts of from into into
+func mergeCategoryDetails(into, from map[string]*domain.CategoryDetail) {
+	for category, detail := range from {
+		existing, ok := into[category]
+		if !ok {
+			into[category] = detail
+			continue
+		}
+		existing.Total += detail.Total
+		existing.Count += detail.Count
+	}
+}
+
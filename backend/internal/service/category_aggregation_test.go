@@ -0,0 +1,91 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/repository"
+)
+
+// newLargeTestService creates a service over n generated transactions spread across
+// a year, eight expense categories and two income categories
+func newLargeTestService(tb testing.TB, n int) *AnalyticsService {
+	tb.Helper()
+
+	expenseCategories := []string{"rent", "groceries", "dining", "utilities", "transportation", "entertainment", "healthcare", "shopping"}
+	transactions := make([]domain.Transaction, n)
+	for i := range transactions {
+		tx := domain.Transaction{
+			Date:        fmt.Sprintf("2024-%02d-%02d", i%12+1, i%28+1),
+			Description: fmt.Sprintf("Generated %d", i),
+		}
+		if i%10 == 0 {
+			tx.Type, tx.Category, tx.Amount = "income", []string{"salary", "freelance"}[i%20/10], float64(1000+i%500)+0.25
+		} else {
+			tx.Type, tx.Category, tx.Amount = "expense", expenseCategories[i%len(expenseCategories)], -(float64(i%300) + 0.99)
+		}
+		transactions[i] = tx
+	}
+
+	data, err := json.Marshal(transactions)
+	if err != nil {
+		tb.Fatalf("Failed to encode transactions: %v", err)
+	}
+	repo, err := repository.NewJSONRepository(data)
+	if err != nil {
+		tb.Fatalf("Failed to create repository: %v", err)
+	}
+
+	return NewAnalyticsService(repo)
+}
+
+func TestAnalyticsService_ParallelCategorySummaryMatchesSequential(t *testing.T) {
+	service := newLargeTestService(t, 25000)
+
+	service.SetWorkers(1)
+	sequential, err := service.GetCategorySummary()
+	if err != nil {
+		t.Fatalf("GetCategorySummary() error = %v", err)
+	}
+
+	// 7 workers leave a short final chunk
+	for _, workers := range []int{2, 4, 7, 0} {
+		service.SetWorkers(workers)
+		parallel, err := service.GetCategorySummary()
+		if err != nil {
+			t.Fatalf("GetCategorySummary() with %d workers error = %v", workers, err)
+		}
+		if !reflect.DeepEqual(parallel, sequential) {
+			t.Errorf("Summary with %d workers differs from sequential:\n got %+v\nwant %+v", workers, parallel, sequential)
+		}
+	}
+}
+
+func BenchmarkGetCategorySummary_Large(b *testing.B) {
+	for _, size := range []int{10000, 50000} {
+		service := newLargeTestService(b, size)
+
+		for _, workers := range []int{1, 0} {
+			name := fmt.Sprintf("%d_transactions/sequential", size)
+			if workers == 0 {
+				name = fmt.Sprintf("%d_transactions/parallel", size)
+			}
+
+			b.Run(name, func(b *testing.B) {
+				service.SetWorkers(workers)
+				b.ReportAllocs()
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					if _, err := service.GetCategorySummary(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/repository"
+)
+
+// CategoryMetadataService manages how categories are described and displayed
+type CategoryMetadataService struct {
+	metadata repository.CategoryMetadataRepository
+}
+
+// NewCategoryMetadataService creates a new category metadata service
+func NewCategoryMetadataService(metadata repository.CategoryMetadataRepository) *CategoryMetadataService {
+	return &CategoryMetadataService{metadata: metadata}
+}
+
+// ListMetadata returns the metadata of every category, sorted by name
+func (s *CategoryMetadataService) ListMetadata() ([]domain.CategoryMetadata, error) {
+	return s.metadata.GetAll()
+}
+
+// OverrideMetadata replaces the description, icon or color of a category
+// Blank fields in override keep the current value, so a single field can be changed
+// on its own. Categories without metadata yet are created. Returns the stored entry.
+func (s *CategoryMetadataService) OverrideMetadata(name string, override domain.CategoryMetadata) (*domain.CategoryMetadata, error) {
+	current, err := s.metadata.GetByName(name)
+	if err != nil && !errors.Is(err, domain.ErrCategoryNotFound) {
+		return nil, err
+	}
+
+	current.Name = name
+	if override.Description != "" {
+		current.Description = override.Description
+	}
+	if override.IconName != "" {
+		current.IconName = override.IconName
+	}
+	if override.Color != "" {
+		current.Color = override.Color
+	}
+
+	if err := s.metadata.Upsert(current); err != nil {
+		return nil, err
+	}
+
+	stored, err := s.metadata.GetByName(current.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
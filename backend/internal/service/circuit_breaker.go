@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// CircuitState is the state of a CircuitBreaker
+type CircuitState int
+
+// Circuit breaker states
+const (
+	CircuitClosed   CircuitState = iota // Calls pass through; failures are counted
+	CircuitOpen                         // Calls fail fast until the recovery timeout elapses
+	CircuitHalfOpen                     // A limited number of trial calls probe for recovery
+)
+
+// String returns the state name used in logs
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Circuit breaker defaults, used when a setting is not positive
+const (
+	DefaultCircuitFailureThreshold    = 5
+	DefaultCircuitRecoveryTimeout     = 30 * time.Second
+	DefaultCircuitHalfOpenMaxRequests = 1
+)
+
+// callOutcome is how a call run through a CircuitBreaker affects its state
+type callOutcome int
+
+const (
+	callSucceeded callOutcome = iota
+	callFailed                // The dependency itself is unhealthy
+	callIgnored               // The error says nothing about the dependency, e.g., a cancelled caller
+)
+
+// CircuitBreaker stops calling a failing dependency so callers fail fast instead of waiting
+// for timeouts. After FailureThreshold consecutive failures the circuit opens and every call
+// returns domain.ErrServiceUnavailable. Once RecoveryTimeout has passed, up to
+// HalfOpenMaxRequests concurrent trial calls are let through: a success closes the circuit,
+// a failure opens it again. Only server errors, timeouts and network errors count as
+// failures; see isCircuitFailure.
+type CircuitBreaker struct {
+	failureThreshold    int
+	recoveryTimeout     time.Duration
+	halfOpenMaxRequests int
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int       // Consecutive failures while closed
+	openedAt time.Time // When the circuit last opened
+	inFlight int       // Trial calls running while half-open
+	now      func() time.Time
+}
+
+// NewCircuitBreaker creates a closed circuit breaker
+// Settings that are not positive fall back to the Default* values.
+func NewCircuitBreaker(failureThreshold int, recoveryTimeout time.Duration, halfOpenMaxRequests int) *CircuitBreaker {
+	if failureThreshold <= 0 {
+		failureThreshold = DefaultCircuitFailureThreshold
+	}
+	if recoveryTimeout <= 0 {
+		recoveryTimeout = DefaultCircuitRecoveryTimeout
+	}
+	if halfOpenMaxRequests <= 0 {
+		halfOpenMaxRequests = DefaultCircuitHalfOpenMaxRequests
+	}
+
+	return &CircuitBreaker{
+		failureThreshold:    failureThreshold,
+		recoveryTimeout:     recoveryTimeout,
+		halfOpenMaxRequests: halfOpenMaxRequests,
+		state:               CircuitClosed,
+		now:                 time.Now,
+	}
+}
+
+// Execute runs fn unless the circuit is open, recording its outcome
+// Returns domain.ErrServiceUnavailable without calling fn when the circuit rejects the call.
+func (cb *CircuitBreaker) Execute(fn func() error) error {
+	if !cb.allow() {
+		return domain.ErrServiceUnavailable
+	}
+
+	err := fn()
+	switch {
+	case err == nil:
+		cb.record(callSucceeded)
+	case isCircuitFailure(err):
+		cb.record(callFailed)
+	default:
+		cb.record(callIgnored)
+	}
+	return err
+}
+
+// isCircuitFailure reports whether err shows the dependency itself is unhealthy
+// Server errors (5xx), timeouts and network errors count. A cancelled caller, a rejected
+// request (4xx) or a blocked SSRF target says nothing about the dependency's health.
+func isCircuitFailure(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, domain.ErrSSRFAttempt) {
+		return false
+	}
+
+	var httpErr *domain.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500
+	}
+
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr)
+}
+
+// State returns the current state, moving an open circuit to half-open once the
+// recovery timeout has passed
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.checkRecovery()
+	return cb.state
+}
+
+// allow reports whether a call may proceed, reserving a trial slot when half-open
+func (cb *CircuitBreaker) allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.checkRecovery()
+
+	switch cb.state {
+	case CircuitOpen:
+		return false
+	case CircuitHalfOpen:
+		if cb.inFlight >= cb.halfOpenMaxRequests {
+			return false
+		}
+		cb.inFlight++
+	}
+	return true
+}
+
+// record updates the state with the outcome of an allowed call
+// Ignored outcomes only release a half-open trial slot.
+func (cb *CircuitBreaker) record(outcome callOutcome) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitHalfOpen:
+		if cb.inFlight > 0 {
+			cb.inFlight--
+		}
+		switch outcome {
+		case callSucceeded:
+			cb.state = CircuitClosed
+			cb.failures = 0
+		case callFailed:
+			cb.open()
+		}
+
+	case CircuitClosed:
+		switch outcome {
+		case callSucceeded:
+			cb.failures = 0
+			return
+		case callIgnored:
+			return
+		}
+		cb.failures++
+		if cb.failures >= cb.failureThreshold {
+			cb.open()
+		}
+
+	case CircuitOpen:
+		// A call admitted while half-open finished after another trial reopened the
+		// circuit; the circuit is already open, so there is nothing to record
+	}
+}
+
+// open trips the circuit
+// Must be called with the lock held
+func (cb *CircuitBreaker) open() {
+	cb.state = CircuitOpen
+	cb.openedAt = cb.now()
+	cb.failures = 0
+	cb.inFlight = 0
+}
+
+// checkRecovery moves an open circuit to half-open once the recovery timeout has passed
+// Must be called with the lock held
+func (cb *CircuitBreaker) checkRecovery() {
+	if cb.state == CircuitOpen && cb.now().Sub(cb.openedAt) >= cb.recoveryTimeout {
+		cb.state = CircuitHalfOpen
+		cb.inFlight = 0
+	}
+}
+
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+var errUpstream = &domain.HTTPError{StatusCode: http.StatusServiceUnavailable, Message: "upstream failure"}
+
+// newTestCircuitBreaker returns a breaker driven by a fake clock the test can advance
+func newTestCircuitBreaker(threshold int, timeout time.Duration, halfOpenMax int) (*CircuitBreaker, *time.Time) {
+	cb := NewCircuitBreaker(threshold, timeout, halfOpenMax)
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cb.now = func() time.Time { return clock }
+	return cb, &clock
+}
+
+func fail() error    { return errUpstream }
+func succeed() error { return nil }
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	cb, _ := newTestCircuitBreaker(3, time.Minute, 1)
+
+	for i := 0; i < 2; i++ {
+		if err := cb.Execute(fail); !errors.Is(err, errUpstream) {
+			t.Fatalf("Execute() = %v, want the upstream error", err)
+		}
+	}
+	if state := cb.State(); state != CircuitClosed {
+		t.Fatalf("Expected closed below the threshold, got %s", state)
+	}
+
+	cb.Execute(fail)
+	if state := cb.State(); state != CircuitOpen {
+		t.Fatalf("Expected open after 3 failures, got %s", state)
+	}
+}
+
+func TestCircuitBreaker_SuccessResetsFailureCount(t *testing.T) {
+	cb, _ := newTestCircuitBreaker(2, time.Minute, 1)
+
+	cb.Execute(fail)
+	cb.Execute(succeed)
+	cb.Execute(fail)
+
+	// Failures must be consecutive to trip the circuit
+	if state := cb.State(); state != CircuitClosed {
+		t.Errorf("Expected closed, got %s", state)
+	}
+}
+
+func TestCircuitBreaker_OpenRejectsWithoutCalling(t *testing.T) {
+	cb, _ := newTestCircuitBreaker(1, time.Minute, 1)
+	cb.Execute(fail)
+
+	called := false
+	err := cb.Execute(func() error {
+		called = true
+		return nil
+	})
+
+	if !errors.Is(err, domain.ErrServiceUnavailable) {
+		t.Errorf("Execute() = %v, want ErrServiceUnavailable", err)
+	}
+	if called {
+		t.Error("Open circuit called the function")
+	}
+}
+
+func TestCircuitBreaker_HalfOpenAfterTimeout(t *testing.T) {
+	cb, clock := newTestCircuitBreaker(1, time.Minute, 1)
+	cb.Execute(fail)
+
+	*clock = clock.Add(59 * time.Second)
+	if state := cb.State(); state != CircuitOpen {
+		t.Fatalf("Expected open before the timeout, got %s", state)
+	}
+
+	*clock = clock.Add(time.Second)
+	if state := cb.State(); state != CircuitHalfOpen {
+		t.Fatalf("Expected half-open after the timeout, got %s", state)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenSuccessCloses(t *testing.T) {
+	cb, clock := newTestCircuitBreaker(1, time.Minute, 1)
+	cb.Execute(fail)
+	*clock = clock.Add(time.Minute)
+
+	if err := cb.Execute(succeed); err != nil {
+		t.Fatalf("Trial call = %v, want nil", err)
+	}
+	if state := cb.State(); state != CircuitClosed {
+		t.Errorf("Expected closed after a successful trial, got %s", state)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenFailureReopens(t *testing.T) {
+	cb, clock := newTestCircuitBreaker(1, time.Minute, 1)
+	cb.Execute(fail)
+	*clock = clock.Add(time.Minute)
+
+	if err := cb.Execute(fail); !errors.Is(err, errUpstream) {
+		t.Fatalf("Trial call = %v, want the upstream error", err)
+	}
+	if state := cb.State(); state != CircuitOpen {
+		t.Fatalf("Expected open after a failed trial, got %s", state)
+	}
+
+	// The recovery timeout restarts from the failed trial
+	*clock = clock.Add(59 * time.Second)
+	if state := cb.State(); state != CircuitOpen {
+		t.Errorf("Expected still open, got %s", state)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenMaxRequests(t *testing.T) {
+	cb, clock := newTestCircuitBreaker(1, time.Minute, 2)
+	cb.Execute(fail)
+	*clock = clock.Add(time.Minute)
+
+	// Hold two trial calls open and check a third is rejected meanwhile
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			done <- cb.Execute(func() error {
+				started <- struct{}{}
+				<-release
+				return nil
+			})
+		}()
+	}
+	<-started
+	<-started
+
+	if err := cb.Execute(succeed); !errors.Is(err, domain.ErrServiceUnavailable) {
+		t.Errorf("Third trial call = %v, want ErrServiceUnavailable", err)
+	}
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		if err := <-done; err != nil {
+			t.Errorf("Trial call = %v, want nil", err)
+		}
+	}
+	if state := cb.State(); state != CircuitClosed {
+		t.Errorf("Expected closed after successful trials, got %s", state)
+	}
+}
+
+func TestCircuitBreaker_CountsOnlyDependencyFailures(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		trips bool
+	}{
+		{"server error", fmt.Errorf("OpenAI API error: %w", &domain.HTTPError{StatusCode: http.StatusBadGateway}), true},
+		{"timeout", fmt.Errorf("failed to call OpenAI API: %w", context.DeadlineExceeded), true},
+		{"network error", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true},
+		{"caller cancelled", fmt.Errorf("failed to call OpenAI API: %w", context.Canceled), false},
+		{"client error", &domain.HTTPError{StatusCode: http.StatusBadRequest}, false},
+		{"rate limited", &domain.HTTPError{StatusCode: http.StatusTooManyRequests}, false},
+		{"SSRF block", fmt.Errorf("%w: scheme \"http\" is not allowed", domain.ErrSSRFAttempt), false},
+		{"malformed response", errors.New("no response from OpenAI"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb, _ := newTestCircuitBreaker(1, time.Minute, 1)
+			if err := cb.Execute(func() error { return tt.err }); err != tt.err {
+				t.Fatalf("Execute() = %v, want the call's error", err)
+			}
+
+			want := CircuitClosed
+			if tt.trips {
+				want = CircuitOpen
+			}
+			if state := cb.State(); state != want {
+				t.Errorf("Expected %s, got %s", want, state)
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker_HalfOpenIgnoredErrorReleasesTrial(t *testing.T) {
+	cb, clock := newTestCircuitBreaker(1, time.Minute, 1)
+	cb.Execute(fail)
+	*clock = clock.Add(time.Minute)
+
+	cb.Execute(func() error { return context.Canceled })
+	if state := cb.State(); state != CircuitHalfOpen {
+		t.Fatalf("Expected still half-open after a cancelled trial, got %s", state)
+	}
+
+	// The trial slot was released, so the next call is let through
+	if err := cb.Execute(succeed); err != nil {
+		t.Fatalf("Trial call = %v, want nil", err)
+	}
+	if state := cb.State(); state != CircuitClosed {
+		t.Errorf("Expected closed after a successful trial, got %s", state)
+	}
+}
+
+func TestNewCircuitBreaker_Defaults(t *testing.T) {
+	cb := NewCircuitBreaker(0, 0, 0)
+
+	if cb.failureThreshold != DefaultCircuitFailureThreshold ||
+		cb.recoveryTimeout != DefaultCircuitRecoveryTimeout ||
+		cb.halfOpenMaxRequests != DefaultCircuitHalfOpenMaxRequests {
+		t.Errorf("Expected defaults, got threshold=%d timeout=%v halfOpen=%d",
+			cb.failureThreshold, cb.recoveryTimeout, cb.halfOpenMaxRequests)
+	}
+}
+
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"math"
+	"sort"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// minCorrelationMonths is the number of months with spending a category needs to be correlated
+const minCorrelationMonths = 3
+
+// GetCategoryCorrelations correlates monthly spending between every pair of expense categories
+// Each category's series covers every month from the first to the latest transaction, with
+// months without spending counted as zero. Categories with spending in fewer than 3 months
+// are left out. A category whose spending never changes has no measurable relationship with
+// the others, so its coefficients are 0 (except 1 with itself).
+func (s *AnalyticsService) GetCategoryCorrelations() (*domain.CorrelationMatrix, error) {
+	transactions, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	first, latest, err := s.getDateRangeFromTransactions(transactions)
+	if err != nil {
+		return nil, err
+	}
+	months := monthsSpanned(first, latest)
+
+	series := make(map[string][]float64)
+	for _, tx := range transactions {
+		if !tx.IsExpense() {
+			continue
+		}
+		date, err := tx.ParseDate()
+		if err != nil {
+			continue
+		}
+
+		totals, ok := series[tx.Category]
+		if !ok {
+			totals = make([]float64, months)
+			series[tx.Category] = totals
+		}
+		totals[monthsSpanned(first, date)-1] += tx.AbsoluteAmount()
+	}
+
+	var categories []string
+	for category, totals := range series {
+		if activeMonths(totals) >= minCorrelationMonths {
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+
+	matrix := make(domain.CorrelationMatrix, len(categories))
+	for _, category := range categories {
+		matrix[category] = make(map[string]float64, len(categories))
+	}
+	for i, a := range categories {
+		matrix[a][a] = 1
+		for _, b := range categories[i+1:] {
+			coefficient := roundToN(pearsonCorrelation(series[a], series[b]), metricPrecision)
+			matrix[a][b] = coefficient
+			matrix[b][a] = coefficient
+		}
+	}
+
+	return &matrix, nil
+}
+
+// activeMonths counts the months with any spending
+func activeMonths(totals []float64) int {
+	count := 0
+	for _, total := range totals {
+		if total != 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// pearsonCorrelation returns the Pearson correlation coefficient of two equal-length series
+// Returns 0 when either series is constant, as the coefficient is undefined.
+func pearsonCorrelation(x, y []float64) float64 {
+	n := float64(len(x))
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= n
+	meanY /= n
+
+	var covariance, varianceX, varianceY float64
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		covariance += dx * dy
+		varianceX += dx * dx
+		varianceY += dy * dy
+	}
+	if varianceX == 0 || varianceY == 0 {
+		return 0
+	}
+
+	return covariance / math.Sqrt(varianceX*varianceY)
+}
+
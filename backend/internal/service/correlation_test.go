@@ -0,0 +1,102 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// correlationFixture returns six months where dining rises as groceries fall (substitution),
+// rent is steady and travel only appears twice
+func correlationFixture() string {
+	groceries := []int{400, 350, 300, 250, 200, 150}
+	dining := []int{100, 160, 190, 260, 300, 340}
+
+	var rows []string
+	for i := range groceries {
+		month := i + 1
+		rows = append(rows,
+			fmt.Sprintf(`{"date": "2024-%02d-05", "amount": -%d, "category": "groceries", "description": "Market", "type": "expense"}`, month, groceries[i]),
+			fmt.Sprintf(`{"date": "2024-%02d-12", "amount": -%d, "category": "dining", "description": "Bistro", "type": "expense"}`, month, dining[i]),
+			fmt.Sprintf(`{"date": "2024-%02d-01", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"}`, month),
+			fmt.Sprintf(`{"date": "2024-%02d-01", "amount": %d, "category": "salary", "description": "Salary", "type": "income"}`, month, 2800+100*i),
+		)
+	}
+	rows = append(rows,
+		`{"date": "2024-02-20", "amount": -900, "category": "travel", "description": "Flight", "type": "expense"}`,
+		`{"date": "2024-05-20", "amount": -400, "category": "travel", "description": "Hotel", "type": "expense"}`,
+	)
+	return "[" + strings.Join(rows, ",\n") + "]"
+}
+
+func TestGetCategoryCorrelations(t *testing.T) {
+	service := setupRecurringService(t, correlationFixture())
+
+	result, err := service.GetCategoryCorrelations()
+	if err != nil {
+		t.Fatalf("GetCategoryCorrelations() error = %v", err)
+	}
+	matrix := *result
+
+	// Travel has only 2 months and salary is income
+	if len(matrix) != 3 {
+		t.Fatalf("Expected dining, groceries and rent, got %v", matrix)
+	}
+	if _, ok := matrix["travel"]; ok {
+		t.Error("Expected travel (2 months of data) to be excluded")
+	}
+
+	for category, row := range matrix {
+		if row[category] != 1.0 {
+			t.Errorf("Correlation of %s with itself = %v, want 1", category, row[category])
+		}
+		if len(row) != 3 {
+			t.Errorf("Expected %s to be correlated with every category, got %v", category, row)
+		}
+	}
+
+	if got := matrix["groceries"]["dining"]; got > -0.9 {
+		t.Errorf("groceries/dining correlation = %v, want <= -0.9", got)
+	}
+	if matrix["groceries"]["dining"] != matrix["dining"]["groceries"] {
+		t.Errorf("Expected a symmetric matrix, got %v and %v", matrix["groceries"]["dining"], matrix["dining"]["groceries"])
+	}
+
+	// Steady rent has no measurable relationship
+	if got := matrix["rent"]["groceries"]; got != 0 {
+		t.Errorf("rent/groceries correlation = %v, want 0 for a constant series", got)
+	}
+}
+
+func TestGetCategoryCorrelations_Empty(t *testing.T) {
+	service := setupRecurringService(t, "[]")
+
+	if _, err := service.GetCategoryCorrelations(); !errors.Is(err, domain.ErrNoTransactions) {
+		t.Errorf("Expected ErrNoTransactions, got %v", err)
+	}
+}
+
+func TestPearsonCorrelation(t *testing.T) {
+	tests := []struct {
+		name string
+		x, y []float64
+		want float64
+	}{
+		{"identical", []float64{1, 2, 3, 4}, []float64{1, 2, 3, 4}, 1},
+		{"scaled", []float64{1, 2, 3, 4}, []float64{10, 20, 30, 40}, 1},
+		{"inverse", []float64{1, 2, 3, 4}, []float64{8, 6, 4, 2}, -1},
+		{"constant", []float64{5, 5, 5}, []float64{1, 2, 3}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := roundToN(pearsonCorrelation(tt.x, tt.y), 2); got != tt.want {
+				t.Errorf("pearsonCorrelation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
@@ -0,0 +1,74 @@
+package service
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+//go:embed exchange_rates.json
+var exchangeRatesData []byte
+
+// CurrencyConverter converts amounts between ISO 4217 currencies
+type CurrencyConverter interface {
+	Convert(amount float64, from, to string) (float64, error)
+}
+
+// StaticRateConverter converts currencies using a fixed table of rates
+// Rates are expressed as units of each currency per one unit of the base currency
+type StaticRateConverter struct {
+	base  string
+	rates map[string]float64
+}
+
+// exchangeRates mirrors the structure of exchange_rates.json
+type exchangeRates struct {
+	Base  string             `json:"base"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// NewStaticRateConverter creates a converter from the embedded exchange_rates.json
+func NewStaticRateConverter() (*StaticRateConverter, error) {
+	return NewStaticRateConverterFromJSON(exchangeRatesData)
+}
+
+// NewStaticRateConverterFromJSON creates a converter from raw exchange rate JSON
+func NewStaticRateConverterFromJSON(data []byte) (*StaticRateConverter, error) {
+	var parsed exchangeRates
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse exchange rates: %w", err)
+	}
+
+	for code, rate := range parsed.Rates {
+		if rate <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate for %s: %v", code, rate)
+		}
+	}
+
+	return &StaticRateConverter{
+		base:  parsed.Base,
+		rates: parsed.Rates,
+	}, nil
+}
+
+// Convert converts amount from one currency to another via the base currency
+func (c *StaticRateConverter) Convert(amount float64, from, to string) (float64, error) {
+	if from == to {
+		return amount, nil
+	}
+
+	fromRate, ok := c.rates[from]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", domain.ErrUnsupportedCurrency, from)
+	}
+
+	toRate, ok := c.rates[to]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", domain.ErrUnsupportedCurrency, to)
+	}
+
+	return amount / fromRate * toRate, nil
+}
+
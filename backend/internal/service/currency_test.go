@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/repository"
+)
+
+// testRates uses round numbers so expected conversions are easy to verify
+var testRates = []byte(`{"base": "USD", "rates": {"USD": 1, "MXN": 20, "EUR": 0.5}}`)
+
+func TestStaticRateConverter_Convert(t *testing.T) {
+	converter, err := NewStaticRateConverterFromJSON(testRates)
+	if err != nil {
+		t.Fatalf("Failed to create converter: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		amount   float64
+		from     string
+		to       string
+		expected float64
+		wantErr  bool
+	}{
+		{"same currency", 100, "USD", "USD", 100, false},
+		{"base to other", 100, "USD", "MXN", 2000, false},
+		{"other to base", 2000, "MXN", "USD", 100, false},
+		{"cross rate", 100, "EUR", "MXN", 4000, false},
+		{"negative amount keeps sign", -50, "USD", "EUR", -25, false},
+		{"unknown source", 100, "XYZ", "USD", 0, true},
+		{"unknown target", 100, "USD", "JPY", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := converter.Convert(tt.amount, tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Convert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, domain.ErrUnsupportedCurrency) {
+				t.Errorf("Expected ErrUnsupportedCurrency, got %v", err)
+			}
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("Convert() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewStaticRateConverter_Embedded(t *testing.T) {
+	converter, err := NewStaticRateConverter()
+	if err != nil {
+		t.Fatalf("Failed to load embedded exchange rates: %v", err)
+	}
+
+	if _, err := converter.Convert(1, "USD", "MXN"); err != nil {
+		t.Errorf("Expected embedded rates to include MXN: %v", err)
+	}
+}
+
+func TestNewStaticRateConverterFromJSON_InvalidRate(t *testing.T) {
+	_, err := NewStaticRateConverterFromJSON([]byte(`{"base": "USD", "rates": {"USD": 1, "MXN": 0}}`))
+	if err == nil {
+		t.Error("Expected error for non-positive rate")
+	}
+}
+
+func TestAnalyticsService_GetCategorySummaryInCurrency(t *testing.T) {
+	service := setupTestService(t)
+	converter, _ := NewStaticRateConverterFromJSON(testRates)
+	service.SetCurrencyConverter(converter, "USD")
+
+	summary, err := service.GetCategorySummaryInCurrency(context.Background(), "MXN")
+	if err != nil {
+		t.Fatalf("GetCategorySummaryInCurrency() error = %v", err)
+	}
+
+	if summary.Currency != "MXN" {
+		t.Errorf("Expected currency MXN, got %s", summary.Currency)
+	}
+
+	// 8400 USD * 20 = 168000 MXN
+	if summary.Summary.TotalIncome != 168000 {
+		t.Errorf("Expected total income 168000, got %v", summary.Summary.TotalIncome)
+	}
+
+	// 2400 USD rent * 20 = 48000 MXN
+	if rent := summary.Expenses["rent"]; rent.Total != 48000 {
+		t.Errorf("Expected rent total 48000, got %v", rent.Total)
+	}
+
+	// Percentages are currency independent
+	if summary.Summary.SavingsRate != 68.57 {
+		t.Errorf("Expected savings rate 68.57, got %v", summary.Summary.SavingsRate)
+	}
+}
+
+func TestAnalyticsService_GetCategorySummaryInCurrency_MixedCurrencies(t *testing.T) {
+	repo, err := repository.NewJSONRepository([]byte(`[
+		{"date": "2024-01-01", "amount": 1000, "category": "salary", "description": "Salary", "type": "income"},
+		{"date": "2024-01-02", "amount": -2000, "category": "rent", "description": "Renta", "type": "expense", "currency": "MXN"}
+	]`))
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	service := NewAnalyticsService(repo)
+	converter, _ := NewStaticRateConverterFromJSON(testRates)
+	service.SetCurrencyConverter(converter, "USD")
+
+	summary, err := service.GetCategorySummaryInCurrency(context.Background(), "USD")
+	if err != nil {
+		t.Fatalf("GetCategorySummaryInCurrency() error = %v", err)
+	}
+
+	// 2000 MXN / 20 = 100 USD
+	if summary.Summary.TotalExpenses != 100 {
+		t.Errorf("Expected total expenses 100, got %v", summary.Summary.TotalExpenses)
+	}
+}
+
+func TestAnalyticsService_GetTransactions_TotalsInBaseCurrency(t *testing.T) {
+	repo, err := repository.NewJSONRepository([]byte(`[
+		{"date": "2024-01-01", "amount": 1000, "category": "salary", "description": "Salary", "type": "income"},
+		{"date": "2024-01-02", "amount": -2000, "category": "rent", "description": "Renta", "type": "expense", "currency": "MXN"}
+	]`))
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	service := NewAnalyticsService(repo)
+
+	// Without a converter the MXN rent cannot be added to USD totals
+	if _, err := service.GetTransactions(); !errors.Is(err, domain.ErrUnsupportedCurrency) {
+		t.Errorf("Expected ErrUnsupportedCurrency without converter, got %v", err)
+	}
+
+	converter, _ := NewStaticRateConverterFromJSON(testRates)
+	service.SetCurrencyConverter(converter, "USD")
+
+	response, err := service.GetTransactions()
+	if err != nil {
+		t.Fatalf("GetTransactions() error = %v", err)
+	}
+
+	// 2000 MXN / 20 = 100 USD; the listed transactions keep their own currency
+	period := response.Period
+	if period.Currency != "USD" || period.Income != 1000 || period.Expenses != 100 || period.NetSavings != 900 {
+		t.Errorf("Expected USD totals 1000/100/900, got %s %v/%v/%v", period.Currency, period.Income, period.Expenses, period.NetSavings)
+	}
+	if response.Transactions[1].Amount != -2000 || response.Transactions[1].Currency != "MXN" {
+		t.Errorf("Expected the MXN transaction unchanged, got %+v", response.Transactions[1])
+	}
+}
+
+func TestAnalyticsService_AggregatesInBaseCurrency(t *testing.T) {
+	repo, err := repository.NewJSONRepository([]byte(`[
+		{"date": "2024-01-01", "amount": 1000, "category": "salary", "description": "Salary", "type": "income"},
+		{"date": "2024-01-02", "amount": -2000, "category": "rent", "description": "Renta", "type": "expense", "currency": "MXN"},
+		{"date": "2024-01-03", "amount": -50, "category": "rent", "description": "Deposit", "type": "expense", "currency": "USD"}
+	]`))
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	service := NewAnalyticsService(repo)
+	converter, _ := NewStaticRateConverterFromJSON(testRates)
+	service.SetCurrencyConverter(converter, "USD")
+
+	// 2000 MXN / 20 = 100 USD, plus the 50 USD deposit
+	summary, err := service.GetCategorySummary()
+	if err != nil {
+		t.Fatalf("GetCategorySummary() error = %v", err)
+	}
+	if summary.Summary.TotalExpenses != 150 || summary.Expenses["rent"].Total != 150 {
+		t.Errorf("Expected 150 USD of rent, got total %v and rent %v", summary.Summary.TotalExpenses, summary.Expenses["rent"].Total)
+	}
+
+	monthly, err := service.GetMonthlyCategorySummary(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetMonthlyCategorySummary() error = %v", err)
+	}
+	if monthly.Summary.TotalExpenses != 150 {
+		t.Errorf("Expected 150 USD of monthly expenses, got %v", monthly.Summary.TotalExpenses)
+	}
+
+	withBudgets, err := service.GetCategorySummaryWithBudgets(map[string]float64{"rent": 160})
+	if err != nil {
+		t.Fatalf("GetCategorySummaryWithBudgets() error = %v", err)
+	}
+	if warning := withBudgets.Expenses["rent"].Warning; warning != domain.BudgetWarningApproachingLimit {
+		t.Errorf("Expected 150 of a 160 budget to approach the limit, got %q", warning)
+	}
+
+	timeline, err := service.GetTimeline()
+	if err != nil {
+		t.Fatalf("GetTimeline() error = %v", err)
+	}
+	if point := timeline.Timeline[0]; point.Expenses != 150 || point.Net != 850 {
+		t.Errorf("Expected January expenses 150 and net 850, got %+v", point)
+	}
+
+	// Without a converter foreign amounts cannot be added to base-currency totals
+	unconverted := NewAnalyticsService(repo)
+	if _, err := unconverted.GetCategorySummary(); !errors.Is(err, domain.ErrUnsupportedCurrency) {
+		t.Errorf("Expected ErrUnsupportedCurrency without converter, got %v", err)
+	}
+}
+
+func TestAnalyticsService_GetCategorySummaryInCurrency_Errors(t *testing.T) {
+	service := setupTestService(t)
+
+	// No converter configured
+	if _, err := service.GetCategorySummaryInCurrency(context.Background(), "MXN"); !errors.Is(err, domain.ErrUnsupportedCurrency) {
+		t.Errorf("Expected ErrUnsupportedCurrency without converter, got %v", err)
+	}
+
+	converter, _ := NewStaticRateConverterFromJSON(testRates)
+	service.SetCurrencyConverter(converter, "USD")
+
+	if _, err := service.GetCategorySummaryInCurrency(context.Background(), "ABC"); !errors.Is(err, domain.ErrInvalidCurrency) {
+		t.Errorf("Expected ErrInvalidCurrency, got %v", err)
+	}
+
+	if _, err := service.GetCategorySummaryInCurrency(context.Background(), "JPY"); !errors.Is(err, domain.ErrUnsupportedCurrency) {
+		t.Errorf("Expected ErrUnsupportedCurrency for missing rate, got %v", err)
+	}
+}
+
+func TestAnalyticsService_GetCategorySummaryInCurrency_PreferredCurrency(t *testing.T) {
+	service := setupTestService(t)
+	converter, _ := NewStaticRateConverterFromJSON(testRates)
+	service.SetCurrencyConverter(converter, "USD")
+
+	// No explicit currency: use the request's preferred currency
+	ctx := domain.SetPreferredCurrency(context.Background(), "EUR")
+	summary, err := service.GetCategorySummaryInCurrency(ctx, "")
+	if err != nil {
+		t.Fatalf("GetCategorySummaryInCurrency() error = %v", err)
+	}
+	if summary.Currency != "EUR" {
+		t.Errorf("Expected currency EUR from context, got %s", summary.Currency)
+	}
+
+	// An explicit currency wins over the context
+	summary, err = service.GetCategorySummaryInCurrency(ctx, "MXN")
+	if err != nil {
+		t.Fatalf("GetCategorySummaryInCurrency() error = %v", err)
+	}
+	if summary.Currency != "MXN" {
+		t.Errorf("Expected explicit currency MXN, got %s", summary.Currency)
+	}
+
+	// Neither set: fall back to the base currency
+	summary, err = service.GetCategorySummaryInCurrency(context.Background(), "")
+	if err != nil {
+		t.Fatalf("GetCategorySummaryInCurrency() error = %v", err)
+	}
+	if summary.Currency != "USD" {
+		t.Errorf("Expected base currency USD, got %s", summary.Currency)
+	}
+}
+
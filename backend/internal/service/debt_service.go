@@ -0,0 +1,78 @@
+package service
+
+import (
+	"math"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// DebtTermMonths is the loan term the minimum payment is amortized over
+const DebtTermMonths = 60
+
+// maxDebtRate caps the annual rate; anything higher is almost certainly a unit mistake (15 vs 0.15)
+const maxDebtRate = 1.0
+
+// DebtService provides loan repayment calculations
+type DebtService struct{}
+
+// NewDebtService creates a new debt service
+func NewDebtService() *DebtService {
+	return &DebtService{}
+}
+
+// CalculateDebtPayoff simulates repaying principal month by month
+// The minimum payment follows the standard amortization formula over DebtTermMonths:
+// payment = P*r / (1 - (1+r)^-n), with r = annualRate/12 (P/n for an interest-free loan).
+// Each month accrues interest on the balance, then the minimum plus extraMonthlyPayment is paid;
+// the final payment only covers what is left.
+// Returns ErrInvalidDebt if principal is not positive, annualRate is outside [0, 1] or
+// extraMonthlyPayment is negative.
+func (s *DebtService) CalculateDebtPayoff(principal, annualRate, extraMonthlyPayment float64) (*domain.DebtPayoffPlan, error) {
+	if principal <= 0 || annualRate < 0 || annualRate > maxDebtRate || extraMonthlyPayment < 0 {
+		return nil, domain.ErrInvalidDebt
+	}
+
+	monthlyRate := annualRate / 12
+	minimum := principal / DebtTermMonths
+	if monthlyRate > 0 {
+		minimum = principal * monthlyRate / (1 - math.Pow(1+monthlyRate, -DebtTermMonths))
+	}
+	payment := minimum + extraMonthlyPayment
+
+	balance := principal
+	var principalPaid, interestPaid float64
+	var snapshots []domain.AmortizationSnapshot
+	month := 0
+
+	// Stop at half a cent so floating-point residue does not add a month
+	for balance > 0.005 {
+		month++
+		interest := balance * monthlyRate
+		toPrincipal := math.Min(payment-interest, balance)
+
+		balance -= toPrincipal
+		principalPaid += toPrincipal
+		interestPaid += interest
+
+		// Record a snapshot at the end of each year and at payoff
+		if month%12 == 0 || balance <= 0.005 {
+			snapshots = append(snapshots, domain.AmortizationSnapshot{
+				Year:          (month + 11) / 12,
+				Balance:       roundToN(math.Max(balance, 0), DefaultDecimalPrecision),
+				PrincipalPaid: roundToN(principalPaid, DefaultDecimalPrecision),
+				InterestPaid:  roundToN(interestPaid, DefaultDecimalPrecision),
+			})
+		}
+	}
+
+	return &domain.DebtPayoffPlan{
+		Principal:           principal,
+		AnnualRate:          annualRate,
+		MinimumPayment:      roundToN(minimum, DefaultDecimalPrecision),
+		ExtraMonthlyPayment: extraMonthlyPayment,
+		TotalPayoffMonths:   month,
+		TotalInterestPaid:   roundToN(interestPaid, DefaultDecimalPrecision),
+		YearlySnapshots:     snapshots,
+	}, nil
+}
+
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func TestDebtService_CalculateDebtPayoff_NoExtra(t *testing.T) {
+	plan, err := NewDebtService().CalculateDebtPayoff(10000, 0.15, 0)
+	if err != nil {
+		t.Fatalf("CalculateDebtPayoff() error = %v", err)
+	}
+
+	// 10000 at 15% over 60 months amortizes to 237.90 per month
+	if plan.MinimumPayment != 237.9 {
+		t.Errorf("MinimumPayment = %v, want 237.9", plan.MinimumPayment)
+	}
+	if plan.TotalPayoffMonths != DebtTermMonths {
+		t.Errorf("TotalPayoffMonths = %d, want %d", plan.TotalPayoffMonths, DebtTermMonths)
+	}
+	if math.Abs(plan.TotalInterestPaid-4273.96) > 0.05 {
+		t.Errorf("TotalInterestPaid = %v, want about 4273.96", plan.TotalInterestPaid)
+	}
+
+	if len(plan.YearlySnapshots) != 5 {
+		t.Fatalf("Expected 5 yearly snapshots, got %d", len(plan.YearlySnapshots))
+	}
+	last := plan.YearlySnapshots[4]
+	if last.Year != 5 || last.Balance != 0 || last.PrincipalPaid != 10000 || last.InterestPaid != plan.TotalInterestPaid {
+		t.Errorf("Unexpected final snapshot %+v", last)
+	}
+	for i := 1; i < len(plan.YearlySnapshots); i++ {
+		if plan.YearlySnapshots[i].Balance >= plan.YearlySnapshots[i-1].Balance {
+			t.Errorf("Expected the balance to fall every year, got %+v", plan.YearlySnapshots)
+		}
+	}
+}
+
+func TestDebtService_CalculateDebtPayoff_ExtraEqualsMinimum(t *testing.T) {
+	service := NewDebtService()
+
+	// Interest-free, doubling the payment halves the term exactly
+	plan, err := service.CalculateDebtPayoff(12000, 0, 200)
+	if err != nil {
+		t.Fatalf("CalculateDebtPayoff() error = %v", err)
+	}
+	if plan.TotalPayoffMonths != DebtTermMonths/2 {
+		t.Errorf("TotalPayoffMonths = %d, want %d", plan.TotalPayoffMonths, DebtTermMonths/2)
+	}
+
+	// With interest, less interest accrues as well, so payoff takes at most half the term
+	minimum, _ := service.CalculateDebtPayoff(10000, 0.15, 0)
+	doubled, err := service.CalculateDebtPayoff(10000, 0.15, minimum.MinimumPayment)
+	if err != nil {
+		t.Fatalf("CalculateDebtPayoff() error = %v", err)
+	}
+	if doubled.TotalPayoffMonths > DebtTermMonths/2 {
+		t.Errorf("TotalPayoffMonths = %d, want at most %d", doubled.TotalPayoffMonths, DebtTermMonths/2)
+	}
+	if doubled.TotalInterestPaid >= minimum.TotalInterestPaid {
+		t.Errorf("Expected extra payments to reduce interest, got %v vs %v", doubled.TotalInterestPaid, minimum.TotalInterestPaid)
+	}
+
+	// The final snapshot covers the partial year at payoff
+	last := doubled.YearlySnapshots[len(doubled.YearlySnapshots)-1]
+	if last.Balance != 0 || last.Year != (doubled.TotalPayoffMonths+11)/12 {
+		t.Errorf("Unexpected final snapshot %+v for %d months", last, doubled.TotalPayoffMonths)
+	}
+}
+
+func TestDebtService_CalculateDebtPayoff_InterestFree(t *testing.T) {
+	plan, err := NewDebtService().CalculateDebtPayoff(9000, 0, 0)
+	if err != nil {
+		t.Fatalf("CalculateDebtPayoff() error = %v", err)
+	}
+
+	if plan.MinimumPayment != 150 {
+		t.Errorf("MinimumPayment = %v, want 150", plan.MinimumPayment)
+	}
+	if want := int(9000 / plan.MinimumPayment); plan.TotalPayoffMonths != want {
+		t.Errorf("TotalPayoffMonths = %d, want %d", plan.TotalPayoffMonths, want)
+	}
+	if plan.TotalInterestPaid != 0 {
+		t.Errorf("TotalInterestPaid = %v, want 0", plan.TotalInterestPaid)
+	}
+}
+
+func TestDebtService_CalculateDebtPayoff_Invalid(t *testing.T) {
+	tests := []struct {
+		name                   string
+		principal, rate, extra float64
+	}{
+		{"zero principal", 0, 0.1, 0},
+		{"negative rate", 1000, -0.1, 0},
+		{"rate as percentage", 1000, 15, 0},
+		{"negative extra", 1000, 0.1, -10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewDebtService().CalculateDebtPayoff(tt.principal, tt.rate, tt.extra); err != domain.ErrInvalidDebt {
+				t.Errorf("CalculateDebtPayoff() error = %v, want ErrInvalidDebt", err)
+			}
+		})
+	}
+}
+
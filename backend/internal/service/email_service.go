@@ -0,0 +1,187 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+//go:embed data/email_template.html
+var emailTemplateData string
+
+// emailTemplate renders the HTML body of the summary email; styles are inline since most
+// mail clients drop <style> blocks
+var emailTemplate = template.Must(template.New("email").Funcs(template.FuncMap{
+	"percent": func(v float64) string { return fmt.Sprintf("%.1f%%", v) },
+}).Parse(emailTemplateData))
+
+// DefaultSMTPPort is the mail submission port used when none is configured
+const DefaultSMTPPort = 587
+
+// smtpTimeout bounds a whole delivery when the context has no deadline
+const smtpTimeout = 30 * time.Second
+
+// EmailService sends reports by email over SMTP
+// The connection is upgraded with STARTTLS whenever the server offers it; credentials are
+// only sent over TLS or to localhost.
+type EmailService struct {
+	host     string
+	port     int
+	username string // Also the sender address
+	password string
+}
+
+// NewEmailService creates an email service for the SMTP server at host:port
+// username and password are optional; without them mail is sent unauthenticated.
+func NewEmailService(host string, port int, username, password string) *EmailService {
+	return &EmailService{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+	}
+}
+
+// summaryEmail is the data the email template renders
+type summaryEmail struct {
+	Subject  string
+	Period   domain.Period
+	Summary  domain.FinancialSummary
+	Expenses []summaryEmailCategory // Largest first
+	Currency string
+}
+
+// summaryEmailCategory is one row of the spending table
+type summaryEmailCategory struct {
+	Name       string
+	Total      float64
+	Percentage float64
+}
+
+// Money formats an amount with two decimals and the summary's currency, if any
+func (e summaryEmail) Money(v float64) string {
+	if e.Currency == "" {
+		return fmt.Sprintf("%.2f", v)
+	}
+	return fmt.Sprintf("%.2f %s", v, e.Currency)
+}
+
+// SendMonthlySummary emails summary to toAddr as an HTML report
+// Returns an error if toAddr is not a valid address or the SMTP server rejects the message.
+func (s *EmailService) SendMonthlySummary(ctx context.Context, toAddr string, summary *domain.CategorySummary) error {
+	to, err := mail.ParseAddress(toAddr)
+	if err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", toAddr, err)
+	}
+
+	subject := fmt.Sprintf("Your spending summary for %s to %s", summary.Period.Start, summary.Period.End)
+	body, err := renderSummaryEmail(subject, summary)
+	if err != nil {
+		return err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.username)
+	fmt.Fprintf(&msg, "To: %s\r\n", to.Address)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.Write(body)
+
+	return s.send(ctx, to.Address, msg.Bytes())
+}
+
+// renderSummaryEmail renders the HTML body for summary, listing expense categories by total
+func renderSummaryEmail(subject string, summary *domain.CategorySummary) ([]byte, error) {
+	data := summaryEmail{
+		Subject:  subject,
+		Period:   summary.Period,
+		Summary:  summary.Summary,
+		Currency: summary.Currency,
+	}
+	for name, detail := range summary.Expenses {
+		data.Expenses = append(data.Expenses, summaryEmailCategory{Name: name, Total: detail.Total, Percentage: detail.Percentage})
+	}
+	sort.Slice(data.Expenses, func(i, j int) bool {
+		if data.Expenses[i].Total != data.Expenses[j].Total {
+			return data.Expenses[i].Total > data.Expenses[j].Total
+		}
+		return data.Expenses[i].Name < data.Expenses[j].Name
+	})
+
+	var body bytes.Buffer
+	if err := emailTemplate.Execute(&body, data); err != nil {
+		return nil, fmt.Errorf("failed to render email: %w", err)
+	}
+	return body.Bytes(), nil
+}
+
+// send delivers msg to the SMTP server, like smtp.SendMail but bounded by ctx
+func (s *EmailService) send(ctx context.Context, to string, msg []byte) error {
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if s.username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+				return fmt.Errorf("SMTP authentication failed: %w", err)
+			}
+		}
+	}
+
+	if err := client.Mail(s.username); err != nil {
+		return fmt.Errorf("SMTP server rejected sender: %w", err)
+	}
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("SMTP server rejected recipient: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("SMTP server rejected email: %w", err)
+	}
+
+	return client.Quit()
+}
+
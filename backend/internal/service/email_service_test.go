@@ -0,0 +1,175 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// smtpRecorder is a mock SMTP server that accepts every message and records the session
+type smtpRecorder struct {
+	listener net.Listener
+
+	mu   sync.Mutex
+	auth string // Decoded AUTH PLAIN credentials, "\x00user\x00pass"
+	from string
+	to   []string
+	data []byte
+}
+
+// newSMTPRecorder starts a mock SMTP server on a local port, closed when the test ends
+func newSMTPRecorder(t *testing.T) *smtpRecorder {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	rec := &smtpRecorder{listener: listener}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go rec.serve(conn)
+		}
+	}()
+	return rec
+}
+
+// port returns the port the server listens on
+func (rec *smtpRecorder) port() int {
+	return rec.listener.Addr().(*net.TCPAddr).Port
+}
+
+// serve answers one SMTP session, offering AUTH but not STARTTLS
+func (rec *smtpRecorder) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 localhost ESMTP")
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+
+		rec.mu.Lock()
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250-localhost")
+			tp.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			credentials, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(arg, "PLAIN "))
+			rec.auth = string(credentials)
+			tp.PrintfLine("235 Authenticated")
+		case "MAIL":
+			rec.from = arg
+			tp.PrintfLine("250 OK")
+		case "RCPT":
+			rec.to = append(rec.to, arg)
+			tp.PrintfLine("250 OK")
+		case "DATA":
+			tp.PrintfLine("354 Send data")
+			rec.data, _ = tp.ReadDotBytes()
+			tp.PrintfLine("250 Queued")
+		case "QUIT":
+			tp.PrintfLine("221 Bye")
+			rec.mu.Unlock()
+			return
+		default:
+			tp.PrintfLine("502 Not implemented")
+		}
+		rec.mu.Unlock()
+	}
+}
+
+func TestEmailService_SendMonthlySummary(t *testing.T) {
+	server := newSMTPRecorder(t)
+	emails := NewEmailService("127.0.0.1", server.port(), "reports@stori.test", "s3cret")
+
+	summary, err := setupTestService(t).GetCategorySummary()
+	if err != nil {
+		t.Fatalf("GetCategorySummary() error = %v", err)
+	}
+
+	if err := emails.SendMonthlySummary(context.Background(), "Ana <ana@example.com>", summary); err != nil {
+		t.Fatalf("SendMonthlySummary() error = %v", err)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	if server.auth != "\x00reports@stori.test\x00s3cret" {
+		t.Errorf("Unexpected credentials %q", server.auth)
+	}
+	if server.from != "FROM:<reports@stori.test>" || len(server.to) != 1 || server.to[0] != "TO:<ana@example.com>" {
+		t.Errorf("Unexpected envelope from %q to %q", server.from, server.to)
+	}
+
+	msg, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(string(server.data))))
+	if err != nil {
+		t.Fatalf("Failed to parse sent message: %v", err)
+	}
+	headers := map[string]string{
+		"From":         "reports@stori.test",
+		"To":           "ana@example.com",
+		"Subject":      "Your spending summary for 2024-01-01 to 2024-02-04",
+		"Mime-Version": "1.0",
+		"Content-Type": "text/html; charset=UTF-8",
+	}
+	for name, want := range headers {
+		if got := msg.Header.Get(name); got != want {
+			t.Errorf("Header %s = %q, want %q", name, got, want)
+		}
+	}
+	if _, err := msg.Header.Date(); err != nil {
+		t.Errorf("Expected a valid Date header: %v", err)
+	}
+
+	body, _ := io.ReadAll(msg.Body)
+	html := string(body)
+	for _, want := range []string{"<!DOCTYPE html>", `style="`, "8400.00", "2640.00", "5760.00", ">rent<", "90.9%"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected the HTML body to contain %q", want)
+		}
+	}
+	// Categories are listed by total, largest first
+	if strings.Index(html, ">rent<") > strings.Index(html, ">groceries<") {
+		t.Error("Expected rent to be listed before groceries")
+	}
+}
+
+func TestEmailService_SendMonthlySummary_Errors(t *testing.T) {
+	summary, err := setupTestService(t).GetCategorySummary()
+	if err != nil {
+		t.Fatalf("GetCategorySummary() error = %v", err)
+	}
+
+	server := newSMTPRecorder(t)
+	emails := NewEmailService("127.0.0.1", server.port(), "reports@stori.test", "")
+	if err := emails.SendMonthlySummary(context.Background(), "not an address", summary); err == nil {
+		t.Error("Expected an error for an invalid recipient")
+	}
+
+	// Nothing listens on a closed port
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+	emails = NewEmailService("127.0.0.1", port, "reports@stori.test", "")
+	if err := emails.SendMonthlySummary(context.Background(), "ana@example.com", summary); err == nil {
+		t.Error("Expected an error when the SMTP server is unreachable")
+	}
+}
+
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// eventBufferSize is how many undelivered events a subscriber may fall behind by
+const eventBufferSize = 16
+
+// InMemoryEventBus implements domain.EventBus within a single process
+// Subscribers that fall eventBufferSize events behind miss events rather than
+// slowing down publishers.
+type InMemoryEventBus struct {
+	mu          sync.Mutex
+	subscribers map[<-chan domain.Event]chan domain.Event
+}
+
+// NewInMemoryEventBus creates an event bus with no subscribers
+func NewInMemoryEventBus() *InMemoryEventBus {
+	return &InMemoryEventBus{subscribers: make(map[<-chan domain.Event]chan domain.Event)}
+}
+
+// Subscribe returns a channel receiving every event published from now on
+func (b *InMemoryEventBus) Subscribe() <-chan domain.Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	events := make(chan domain.Event, eventBufferSize)
+	b.subscribers[events] = events
+	return events
+}
+
+// Unsubscribe stops delivery to events and closes it
+// Unknown or already unsubscribed channels are ignored.
+func (b *InMemoryEventBus) Unsubscribe(events <-chan domain.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ch, ok := b.subscribers[events]; ok {
+		delete(b.subscribers, events)
+		close(ch)
+	}
+}
+
+// Publish delivers event to every subscriber with room in its buffer
+func (b *InMemoryEventBus) Publish(event domain.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// Subscriber is behind; drop the event for it
+		}
+	}
+}
+
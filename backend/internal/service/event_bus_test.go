@@ -0,0 +1,54 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func TestInMemoryEventBus_DeliversToEverySubscriber(t *testing.T) {
+	bus := NewInMemoryEventBus()
+	first := bus.Subscribe()
+	second := bus.Subscribe()
+
+	bus.Publish(domain.Event{Type: domain.EventAnalyticsUpdated})
+
+	for i, events := range []<-chan domain.Event{first, second} {
+		select {
+		case event := <-events:
+			if event.Type != domain.EventAnalyticsUpdated {
+				t.Errorf("Subscriber %d: expected %s, got %s", i, domain.EventAnalyticsUpdated, event.Type)
+			}
+		default:
+			t.Errorf("Subscriber %d received nothing", i)
+		}
+	}
+}
+
+func TestInMemoryEventBus_UnsubscribeClosesChannel(t *testing.T) {
+	bus := NewInMemoryEventBus()
+	events := bus.Subscribe()
+
+	bus.Unsubscribe(events)
+	bus.Unsubscribe(events) // Second call is a no-op
+	bus.Publish(domain.Event{Type: domain.EventAnalyticsUpdated})
+
+	if _, ok := <-events; ok {
+		t.Error("Expected the channel to be closed without receiving the event")
+	}
+}
+
+func TestInMemoryEventBus_SlowSubscriberDoesNotBlock(t *testing.T) {
+	bus := NewInMemoryEventBus()
+	events := bus.Subscribe()
+
+	// Publishing past the buffer must drop events instead of blocking
+	for i := 0; i < eventBufferSize+5; i++ {
+		bus.Publish(domain.Event{Type: domain.EventAnalyticsUpdated})
+	}
+
+	if got := len(events); got != eventBufferSize {
+		t.Errorf("Expected %d buffered events, got %d", eventBufferSize, got)
+	}
+}
+
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// csvHeader lists the exported columns in order
+var csvHeader = []string{"date", "amount", "category", "description", "type", "merchant", "currency", "tags"}
+
+// csvFields maps each exportable column (a Transaction JSON name) to its struct field name
+var csvFields = exportableFields(reflect.TypeOf(domain.Transaction{}))
+
+// exportableFields reads the JSON names of a struct's fields
+func exportableFields(t reflect.Type) map[string]string {
+	fields := make(map[string]string)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = field.Name
+	}
+	return fields
+}
+
+// ExportService serializes transactions into downloadable formats
+type ExportService struct{}
+
+// NewExportService creates a new export service
+func NewExportService() *ExportService {
+	return &ExportService{}
+}
+
+// ValidateColumns checks that every column is an exportable Transaction field
+// Column names are the JSON names, e.g., "date" or "payment_method".
+// Returns domain.ErrInvalidExportField naming the first unknown column.
+func (s *ExportService) ValidateColumns(columns []string) error {
+	for _, column := range columns {
+		if _, ok := csvFields[column]; !ok {
+			return fmt.Errorf("%w: %q", domain.ErrInvalidExportField, column)
+		}
+	}
+	return nil
+}
+
+// ExportCSV writes transactions as CSV with a header row
+// columns selects and orders the output columns; nil or empty exports the default set.
+// Amounts have two decimals and tags are joined with ";" so each transaction stays on one row.
+func (s *ExportService) ExportCSV(w io.Writer, transactions []domain.Transaction, columns []string) error {
+	if len(columns) == 0 {
+		columns = csvHeader
+	}
+	if err := s.ValidateColumns(columns); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+
+	record := make([]string, len(columns))
+	for _, tx := range transactions {
+		value := reflect.ValueOf(&tx).Elem()
+		for i, column := range columns {
+			record[i] = formatCSVValue(value.FieldByName(csvFields[column]))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatCSVValue renders a Transaction field as a CSV cell
+func formatCSVValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return ""
+		}
+		return formatCSVValue(v.Elem())
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', 2, 64)
+	case reflect.Int:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Slice:
+		values := make([]string, v.Len())
+		for i := range values {
+			values[i] = formatCSVValue(v.Index(i))
+		}
+		return strings.Join(values, ";")
+	default:
+		return v.String()
+	}
+}
+
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func TestExportService_ExportCSV(t *testing.T) {
+	transactions := []domain.Transaction{
+		{Date: "2024-01-01", Amount: 2800, Category: "salary", Description: "Bi-weekly salary", Type: "income"},
+		{Date: "2024-01-05", Amount: -300.5, Category: "shopping", Description: "Bag, leather", Type: "expense", Merchant: "Acme", Currency: "MXN", Tags: []string{"business", "travel"}},
+	}
+
+	var buf bytes.Buffer
+	if err := NewExportService().ExportCSV(&buf, transactions, nil); err != nil {
+		t.Fatalf("ExportCSV() error = %v", err)
+	}
+
+	expected := "date,amount,category,description,type,merchant,currency,tags\n" +
+		"2024-01-01,2800.00,salary,Bi-weekly salary,income,,,\n" +
+		"2024-01-05,-300.50,shopping,\"Bag, leather\",expense,Acme,MXN,business;travel\n"
+
+	if buf.String() != expected {
+		t.Errorf("ExportCSV() =\n%s\nwant\n%s", buf.String(), expected)
+	}
+}
+
+func TestExportService_ExportCSV_Columns(t *testing.T) {
+	period := 30
+	transactions := []domain.Transaction{
+		{Date: "2024-01-02", Amount: -1200, Category: "rent", Description: "Monthly rent", Type: "expense", IsRecurring: true, RecurrencePeriodDays: &period},
+		{Date: "2024-01-05", Amount: -45.5, Category: "shopping", Description: "Gift", Type: "expense", PaymentMethod: "cash"},
+	}
+
+	tests := []struct {
+		name     string
+		columns  []string
+		expected string
+	}{
+		{
+			name:     "subset",
+			columns:  []string{"date", "amount", "category"},
+			expected: "date,amount,category\n2024-01-02,-1200.00,rent\n2024-01-05,-45.50,shopping\n",
+		},
+		{
+			name:     "order preserved",
+			columns:  []string{"category", "date"},
+			expected: "category,date\nrent,2024-01-02\nshopping,2024-01-05\n",
+		},
+		{
+			name:     "fields outside the default set",
+			columns:  []string{"payment_method", "is_recurring", "recurrence_period_days"},
+			expected: "payment_method,is_recurring,recurrence_period_days\n,true,30\ncash,false,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewExportService().ExportCSV(&buf, transactions, tt.columns); err != nil {
+				t.Fatalf("ExportCSV() error = %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("ExportCSV() =\n%s\nwant\n%s", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestExportService_ValidateColumns(t *testing.T) {
+	service := NewExportService()
+
+	if err := service.ValidateColumns([]string{"date", "tags", "payment_method"}); err != nil {
+		t.Errorf("ValidateColumns() error = %v, want nil", err)
+	}
+
+	for _, column := range []string{"password", "Date", ""} {
+		err := service.ValidateColumns([]string{"date", column})
+		if !errors.Is(err, domain.ErrInvalidExportField) {
+			t.Errorf("ValidateColumns(%q) error = %v, want ErrInvalidExportField", column, err)
+		}
+	}
+
+	// Nothing is written for an invalid column list
+	var buf bytes.Buffer
+	if err := service.ExportCSV(&buf, nil, []string{"nope"}); err == nil || buf.Len() != 0 {
+		t.Errorf("ExportCSV() with an unknown column = %v, wrote %q", err, buf.String())
+	}
+}
+
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"pgregory.net/rapid"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/testutil/sampledata"
+)
+
+// filterFixtureStart and filterFixtureDays bound generated dates slightly beyond the fixture
+var filterFixtureStart = time.Date(2023, 12, 15, 0, 0, 0, 0, time.UTC)
+
+const filterFixtureDays = 330
+
+// drawDate draws a date around the fixture's date range
+func drawDate(t *rapid.T, label string) time.Time {
+	return filterFixtureStart.AddDate(0, 0, rapid.IntRange(0, filterFixtureDays).Draw(t, label))
+}
+
+// drawFilter draws a TransactionFilter with a random subset of criteria set
+func drawFilter(t *rapid.T, categories []string) domain.TransactionFilter {
+	var filter domain.TransactionFilter
+
+	if rapid.Bool().Draw(t, "hasStart") {
+		start := drawDate(t, "start")
+		filter.StartDate = &start
+	}
+	if rapid.Bool().Draw(t, "hasEnd") {
+		end := drawDate(t, "end")
+		filter.EndDate = &end
+	}
+	if txType := rapid.SampledFrom([]string{"", "income", "expense"}).Draw(t, "type"); txType != "" {
+		filter.Types = []string{txType}
+	}
+	if rapid.Bool().Draw(t, "hasCategory") {
+		filter.Categories = []string{rapid.SampledFrom(categories).Draw(t, "category")}
+	}
+	if rapid.Bool().Draw(t, "hasMinAmount") {
+		minAmount := rapid.Float64Range(0, 3000).Draw(t, "minAmount")
+		filter.MinAmount = &minAmount
+	}
+	if rapid.Bool().Draw(t, "hasMaxAmount") {
+		maxAmount := rapid.Float64Range(0, 3000).Draw(t, "maxAmount")
+		filter.MaxAmount = &maxAmount
+	}
+
+	return filter
+}
+
+// filterAll returns every transaction matching filter in a single page
+func filterAll(t *rapid.T, service *AnalyticsService, filter domain.TransactionFilter) []domain.Transaction {
+	response, err := service.GetTransactionsByFilter(filter, "", MaxPageLimit)
+	if err != nil {
+		t.Fatalf("GetTransactionsByFilter(%+v) error = %v", filter, err)
+	}
+	return response.Transactions
+}
+
+// transactionKeys counts transactions by identity so subsets can be checked with duplicates
+func transactionKeys(transactions []domain.Transaction) map[string]int {
+	keys := make(map[string]int, len(transactions))
+	for _, tx := range transactions {
+		keys[tx.Date+"|"+tx.Category+"|"+tx.Description+"|"+strconv.FormatFloat(tx.Amount, 'f', -1, 64)]++
+	}
+	return keys
+}
+
+// isSubset reports whether every transaction in sub also appears in super
+func isSubset(sub, super []domain.Transaction) bool {
+	superKeys := transactionKeys(super)
+	for key, count := range transactionKeys(sub) {
+		if superKeys[key] < count {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTransactionFilterProperties(t *testing.T) {
+	data := sampledata.Transactions(t)
+	service := setupRecurringService(t, string(data))
+
+	response, err := service.GetTransactions()
+	if err != nil {
+		t.Fatalf("GetTransactions() error = %v", err)
+	}
+	all := response.Transactions
+	if len(all) != 112 {
+		t.Fatalf("Expected the 112-transaction fixture, got %d", len(all))
+	}
+
+	categorySet := map[string]bool{"no-such-category": true}
+	for _, tx := range all {
+		categorySet[tx.Category] = true
+	}
+	categories := make([]string, 0, len(categorySet))
+	for category := range categorySet {
+		categories = append(categories, category)
+	}
+
+	t.Run("category filter never increases the count", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			filter := drawFilter(t, categories)
+			filter.Categories = nil
+			unfiltered := len(filterAll(t, service, filter))
+
+			filter.Categories = []string{rapid.SampledFrom(categories).Draw(t, "extraCategory")}
+			if filtered := len(filterAll(t, service, filter)); filtered > unfiltered {
+				t.Fatalf("Categories %v returned %d transactions, more than the %d without them", filter.Categories, filtered, unfiltered)
+			}
+		})
+	})
+
+	t.Run("intersecting date ranges yields a subset of each", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			startA, endA := drawDate(t, "startA"), drawDate(t, "endA")
+			startB, endB := drawDate(t, "startB"), drawDate(t, "endB")
+
+			rangeA := filterAll(t, service, domain.TransactionFilter{StartDate: &startA, EndDate: &endA})
+			rangeB := filterAll(t, service, domain.TransactionFilter{StartDate: &startB, EndDate: &endB})
+
+			start, end := startA, endA
+			if startB.After(start) {
+				start = startB
+			}
+			if endB.Before(end) {
+				end = endB
+			}
+			intersection := filterAll(t, service, domain.TransactionFilter{StartDate: &start, EndDate: &end})
+
+			if !isSubset(intersection, rangeA) || !isSubset(intersection, rangeB) {
+				t.Fatalf("Intersection [%s, %s] is not a subset of both ranges", start.Format("2006-01-02"), end.Format("2006-01-02"))
+			}
+		})
+	})
+
+	t.Run("type filter returns only correctly signed amounts", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			filter := drawFilter(t, categories)
+			txType := rapid.SampledFrom([]string{"income", "expense"}).Draw(t, "type")
+			filter.Types = []string{txType}
+
+			for _, tx := range filterAll(t, service, filter) {
+				if txType == "income" && tx.Amount <= 0 {
+					t.Fatalf("Income filter returned non-positive amount %v", tx.Amount)
+				}
+				if txType == "expense" && tx.Amount >= 0 {
+					t.Fatalf("Expense filter returned non-negative amount %v", tx.Amount)
+				}
+			}
+		})
+	})
+
+	t.Run("income and expense results partition the filtered set", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			filter := drawFilter(t, categories)
+			filter.Types = nil
+			total := len(filterAll(t, service, filter))
+
+			filter.Types = []string{"income"}
+			income := len(filterAll(t, service, filter))
+			filter.Types = []string{"expense"}
+			expenses := len(filterAll(t, service, filter))
+
+			if income+expenses != total {
+				t.Fatalf("income (%d) + expenses (%d) != total (%d)", income, expenses, total)
+			}
+
+			// Listing both types is the same as listing neither
+			filter.Types = []string{"income", "expense"}
+			if both := len(filterAll(t, service, filter)); both != total {
+				t.Fatalf("both types returned %d transactions, want %d", both, total)
+			}
+		})
+	})
+
+	t.Run("adding a criterion yields a subset", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			loose := drawFilter(t, categories)
+			strict := loose
+
+			switch rapid.IntRange(0, 3).Draw(t, "criterion") {
+			case 0:
+				start := drawDate(t, "extraStart")
+				if loose.StartDate == nil || start.After(*loose.StartDate) {
+					strict.StartDate = &start
+				}
+			case 1:
+				if len(loose.Categories) == 0 {
+					strict.Categories = []string{rapid.SampledFrom(categories).Draw(t, "extraCategory")}
+				}
+			case 2:
+				if len(loose.Types) == 0 {
+					strict.Types = []string{rapid.SampledFrom([]string{"income", "expense"}).Draw(t, "extraType")}
+				}
+			case 3:
+				maxAmount := rapid.Float64Range(0, 3000).Draw(t, "extraMaxAmount")
+				if loose.MaxAmount == nil || maxAmount < *loose.MaxAmount {
+					strict.MaxAmount = &maxAmount
+				}
+			}
+
+			if !isSubset(filterAll(t, service, strict), filterAll(t, service, loose)) {
+				t.Fatalf("Stricter filter %+v returned transactions not in %+v", strict, loose)
+			}
+		})
+	})
+
+	t.Run("every result matches the filter and results are date ordered", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			filter := drawFilter(t, categories)
+			results := filterAll(t, service, filter)
+
+			for i, tx := range results {
+				if !filter.Matches(tx) {
+					t.Fatalf("Result %+v does not match filter %+v", tx, filter)
+				}
+				amount := tx.AbsoluteAmount()
+				if filter.MinAmount != nil && amount < *filter.MinAmount {
+					t.Fatalf("Amount %v below minAmount %v", amount, *filter.MinAmount)
+				}
+				if filter.MaxAmount != nil && amount > *filter.MaxAmount {
+					t.Fatalf("Amount %v above maxAmount %v", amount, *filter.MaxAmount)
+				}
+				if i > 0 && results[i-1].Date > tx.Date {
+					t.Fatalf("Results out of order: %s before %s", results[i-1].Date, tx.Date)
+				}
+			}
+		})
+	})
+
+	t.Run("results agree with Matches over the raw transactions", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			filter := drawFilter(t, categories)
+
+			matched := 0
+			for _, tx := range all {
+				if filter.Matches(tx) {
+					matched++
+				}
+			}
+
+			if got := len(filterAll(t, service, filter)); got != matched {
+				t.Fatalf("GetTransactionsByFilter returned %d transactions, %d match directly", got, matched)
+			}
+		})
+	})
+}
+
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func TestAnalyticsService_FilterTransactions(t *testing.T) {
+	service := setupTestService(t)
+	date := func(value string) *time.Time {
+		d, _ := time.Parse("2006-01-02", value)
+		return &d
+	}
+	amount := func(value float64) *float64 { return &value }
+
+	t.Run("type and category", func(t *testing.T) {
+		result, err := service.FilterTransactions(domain.TransactionFilter{
+			Types:      []string{"expense"},
+			Categories: []string{"groceries", "utilities"},
+		})
+		if err != nil {
+			t.Fatalf("FilterTransactions() error = %v", err)
+		}
+		// Whole Foods, Electric bill and Costco
+		if len(result) != 3 {
+			t.Fatalf("Expected 3 transactions, got %d", len(result))
+		}
+		for _, tx := range result {
+			if !tx.IsExpense() || (tx.Category != "groceries" && tx.Category != "utilities") {
+				t.Errorf("Unexpected transaction %+v", tx)
+			}
+		}
+	})
+
+	t.Run("date range and amount", func(t *testing.T) {
+		result, err := service.FilterTransactions(domain.TransactionFilter{
+			StartDate: date("2024-01-01"),
+			EndDate:   date("2024-01-31"),
+			MinAmount: amount(50),
+			MaxAmount: amount(1500),
+		})
+		if err != nil {
+			t.Fatalf("FilterTransactions() error = %v", err)
+		}
+		// January rent (1200) and Whole Foods (85); salaries are too large, the electric bill too small
+		if len(result) != 2 || result[0].Category != "rent" || result[1].Category != "groceries" {
+			t.Errorf("Unexpected result %+v", result)
+		}
+	})
+
+	t.Run("empty filter returns all transactions", func(t *testing.T) {
+		result, err := service.FilterTransactions(domain.TransactionFilter{})
+		if err != nil {
+			t.Fatalf("FilterTransactions() error = %v", err)
+		}
+		if len(result) != 8 {
+			t.Errorf("Expected 8 transactions, got %d", len(result))
+		}
+	})
+
+	t.Run("no matches returns an empty slice", func(t *testing.T) {
+		result, err := service.FilterTransactions(domain.TransactionFilter{Categories: []string{"travel"}})
+		if err != nil {
+			t.Fatalf("FilterTransactions() error = %v", err)
+		}
+		if result == nil || len(result) != 0 {
+			t.Errorf("Expected empty non-nil slice, got %#v", result)
+		}
+	})
+}
+
+func TestAnalyticsService_FilterTransactions_RecurrenceUsesFullHistory(t *testing.T) {
+	service := setupRecurringService(t, `[
+		{"date": "2024-01-10", "amount": -15.99, "category": "entertainment", "description": "Netflix", "type": "expense"},
+		{"date": "2024-02-10", "amount": -15.99, "category": "entertainment", "description": "Netflix", "type": "expense"},
+		{"date": "2024-03-10", "amount": -15.99, "category": "entertainment", "description": "Netflix", "type": "expense"}
+	]`)
+
+	// A single month holds one occurrence, which alone is not a pattern
+	start, _ := time.Parse("2006-01-02", "2024-03-01")
+	result, err := service.FilterTransactions(domain.TransactionFilter{StartDate: &start})
+	if err != nil {
+		t.Fatalf("FilterTransactions() error = %v", err)
+	}
+	if len(result) != 1 || !result[0].IsRecurring || result[0].RecurrencePeriodDays == nil {
+		t.Errorf("Expected the March charge to be flagged recurring, got %+v", result)
+	}
+}
+
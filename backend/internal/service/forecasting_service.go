@@ -0,0 +1,71 @@
+package service
+
+import (
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// maxProjectionYears caps the projection horizon to keep responses bounded
+const maxProjectionYears = 50
+
+// ForecastingService provides forward-looking projections based on historical data
+type ForecastingService struct {
+	analyticsService *AnalyticsService
+}
+
+// NewForecastingService creates a new forecasting service
+func NewForecastingService(analyticsService *AnalyticsService) *ForecastingService {
+	return &ForecastingService{
+		analyticsService: analyticsService,
+	}
+}
+
+// ProjectSavingsGrowth projects how average monthly net savings compound over time
+// Each month: balance = balance*(1+annualRate/12) + contribution
+func (s *ForecastingService) ProjectSavingsGrowth(annualRate float64, years int) (*domain.SavingsProjection, error) {
+	if years < 1 || years > maxProjectionYears || annualRate < 0 {
+		return nil, domain.ErrInvalidProjection
+	}
+
+	summary, err := s.analyticsService.GetCategorySummary()
+	if err != nil {
+		return nil, err
+	}
+
+	// Use average monthly net savings as the recurring contribution
+	contribution := 0.0
+	if summary.Period.Months > 0 {
+		contribution = summary.Summary.NetSavings / float64(summary.Period.Months)
+	}
+
+	monthlyRate := annualRate / 12
+	var balance, contributions, interest float64
+	snapshots := make([]domain.YearlyBalance, 0, years)
+
+	for month := 1; month <= years*12; month++ {
+		monthInterest := balance * monthlyRate
+		balance += monthInterest + contribution
+		interest += monthInterest
+		contributions += contribution
+
+		// Record a snapshot at the end of each year
+		if month%12 == 0 {
+			snapshots = append(snapshots, domain.YearlyBalance{
+				Year:                 month / 12,
+				Balance:              s.analyticsService.roundAmount(balance),
+				ContributionsToDate:  s.analyticsService.roundAmount(contributions),
+				InterestEarnedToDate: s.analyticsService.roundAmount(interest),
+			})
+		}
+	}
+
+	return &domain.SavingsProjection{
+		AnnualRate:          annualRate,
+		Years:               years,
+		MonthlyContribution: s.analyticsService.roundAmount(contribution),
+		YearlySnapshots:     snapshots,
+		FinalBalance:        s.analyticsService.roundAmount(balance),
+		TotalContributions:  s.analyticsService.roundAmount(contributions),
+		TotalInterestEarned: s.analyticsService.roundAmount(interest),
+	}, nil
+}
+
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func setupTestForecastingService(t *testing.T) *ForecastingService {
+	t.Helper()
+
+	return NewForecastingService(setupTestService(t))
+}
+
+func TestForecastingService_ProjectSavingsGrowth_ZeroRate(t *testing.T) {
+	service := setupTestForecastingService(t)
+
+	projection, err := service.ProjectSavingsGrowth(0, 10)
+	if err != nil {
+		t.Fatalf("ProjectSavingsGrowth() error = %v", err)
+	}
+
+	// Net savings: 8400 - 2640 = 5760 over 2 months = 2880/month
+	expectedContribution := 2880.0
+	if projection.MonthlyContribution != expectedContribution {
+		t.Errorf("Expected monthly contribution %v, got %v", expectedContribution, projection.MonthlyContribution)
+	}
+
+	// With no interest, balance is contributions only
+	expectedBalance := expectedContribution * 12 * 10
+	if projection.FinalBalance != expectedBalance {
+		t.Errorf("Expected final balance %v, got %v", expectedBalance, projection.FinalBalance)
+	}
+
+	if projection.TotalContributions != expectedBalance {
+		t.Errorf("Expected total contributions %v, got %v", expectedBalance, projection.TotalContributions)
+	}
+
+	if projection.TotalInterestEarned != 0 {
+		t.Errorf("Expected zero interest, got %v", projection.TotalInterestEarned)
+	}
+
+	if len(projection.YearlySnapshots) != 10 {
+		t.Fatalf("Expected 10 yearly snapshots, got %d", len(projection.YearlySnapshots))
+	}
+
+	first := projection.YearlySnapshots[0]
+	if first.Year != 1 || first.Balance != expectedContribution*12 {
+		t.Errorf("Unexpected first snapshot: %+v", first)
+	}
+}
+
+func TestForecastingService_ProjectSavingsGrowth_WithInterest(t *testing.T) {
+	service := setupTestForecastingService(t)
+
+	projection, err := service.ProjectSavingsGrowth(0.07, 10)
+	if err != nil {
+		t.Fatalf("ProjectSavingsGrowth() error = %v", err)
+	}
+
+	if projection.TotalInterestEarned <= 0 {
+		t.Error("Expected positive interest with a positive rate")
+	}
+
+	if projection.FinalBalance <= projection.TotalContributions {
+		t.Error("Expected final balance to exceed contributions")
+	}
+
+	// Balance must equal contributions plus interest
+	diff := projection.FinalBalance - (projection.TotalContributions + projection.TotalInterestEarned)
+	if diff > 0.01 || diff < -0.01 {
+		t.Errorf("Balance %v != contributions %v + interest %v",
+			projection.FinalBalance, projection.TotalContributions, projection.TotalInterestEarned)
+	}
+}
+
+func TestForecastingService_ProjectSavingsGrowth_InvalidParams(t *testing.T) {
+	service := setupTestForecastingService(t)
+
+	tests := []struct {
+		name       string
+		annualRate float64
+		years      int
+	}{
+		{"zero years", 0.07, 0},
+		{"too many years", 0.07, 51},
+		{"negative rate", -0.01, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ProjectSavingsGrowth(tt.annualRate, tt.years)
+			if !errors.Is(err, domain.ErrInvalidProjection) {
+				t.Errorf("Expected ErrInvalidProjection, got %v", err)
+			}
+		})
+	}
+}
+
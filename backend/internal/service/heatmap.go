@@ -0,0 +1,60 @@
+package service
+
+import (
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+const (
+	heatmapDays  = 7
+	heatmapHours = 24
+)
+
+// GetSpendingHeatmap totals expenses by day of week and hour of day
+// Transactions have no time component yet, so every expense is placed in hour 0
+func (s *AnalyticsService) GetSpendingHeatmap() (*domain.SpendingHeatmap, error) {
+	transactions, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := make([][]float64, heatmapDays)
+	for day := range matrix {
+		matrix[day] = make([]float64, heatmapHours)
+	}
+
+	for _, tx := range transactions {
+		if !tx.IsExpense() {
+			continue
+		}
+
+		date, err := tx.ParseDate()
+		if err != nil {
+			// Skip transactions with invalid dates
+			continue
+		}
+
+		// time.Weekday numbers Sunday as 0; the hour is always 0 until times are recorded
+		matrix[date.Weekday()][date.Hour()] += tx.AbsoluteAmount()
+	}
+
+	for day := range matrix {
+		for hour := range matrix[day] {
+			matrix[day][hour] = s.roundAmount(matrix[day][hour])
+		}
+	}
+
+	start, end, err := s.getDateRangeFromTransactions(transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SpendingHeatmap{
+		Matrix: matrix,
+		Period: domain.Period{
+			Start:  start.Format("2006-01-02"),
+			End:    end.Format("2006-01-02"),
+			Months: s.calculateMonthsBetween(start, end),
+		},
+	}, nil
+}
+
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestAnalyticsService_GetSpendingHeatmap(t *testing.T) {
+	data := `[
+		{"date": "2024-01-06", "amount": -60, "category": "dining", "description": "Brunch", "type": "expense"},
+		{"date": "2024-01-13", "amount": -40.5, "category": "shopping", "description": "Market", "type": "expense"},
+		{"date": "2024-01-08", "amount": -20, "category": "transport", "description": "Bus pass", "type": "expense"},
+		{"date": "2024-01-06", "amount": 2800, "category": "salary", "description": "Salary", "type": "income"}
+	]`
+	service := setupRecurringService(t, data)
+
+	heatmap, err := service.GetSpendingHeatmap()
+	if err != nil {
+		t.Fatalf("GetSpendingHeatmap() error = %v", err)
+	}
+
+	if len(heatmap.Matrix) != 7 {
+		t.Fatalf("Expected 7 rows, got %d", len(heatmap.Matrix))
+	}
+	for day, row := range heatmap.Matrix {
+		if len(row) != 24 {
+			t.Errorf("Row %d: expected 24 columns, got %d", day, len(row))
+		}
+	}
+
+	// 2024-01-06 and 2024-01-13 are Saturdays; income is excluded
+	if heatmap.Matrix[6][0] != 100.5 {
+		t.Errorf("Expected Saturday total 100.5, got %v", heatmap.Matrix[6][0])
+	}
+
+	// 2024-01-08 is a Monday
+	if heatmap.Matrix[1][0] != 20 {
+		t.Errorf("Expected Monday total 20, got %v", heatmap.Matrix[1][0])
+	}
+
+	// Without time data, only hour 0 is populated
+	for day, row := range heatmap.Matrix {
+		for hour := 1; hour < len(row); hour++ {
+			if row[hour] != 0 {
+				t.Errorf("Expected no spending at [%d][%d], got %v", day, hour, row[hour])
+			}
+		}
+	}
+}
+
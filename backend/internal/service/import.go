@@ -0,0 +1,158 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// ImportTransactions validates every transaction and stores the valid ones
+// Invalid transactions are reported individually and do not block the rest.
+// A valid transaction whose date, amount and description match a stored one, or one
+// earlier in the list, is a conflict handled by opts.ConflictResolution; without one,
+// conflicts are reported but stored as they are. With opts.DryRun set nothing is stored and transactions that would be stored are reported
+// as "valid" instead of "created" or "overwritten", so clients can preview an import.
+func (s *AnalyticsService) ImportTransactions(transactions []domain.Transaction, opts domain.ImportOptions) (*domain.ImportReport, error) {
+	resolution := opts.ConflictResolution
+	if resolution != "" && !resolution.IsValid() {
+		return nil, fmt.Errorf("unknown conflict resolution %q", resolution)
+	}
+
+	report := &domain.ImportReport{
+		DryRun:  opts.DryRun,
+		Results: make([]domain.ImportItemResult, len(transactions)),
+	}
+
+	// Fingerprints of the stored transactions, mapped to the first one's ID
+	existing, err := s.repo.GetAll()
+	if err != nil && !errors.Is(err, domain.ErrNoTransactions) {
+		return nil, err
+	}
+	storedIDs := make(map[string]string, len(existing))
+	for _, tx := range existing {
+		fingerprint := tx.Fingerprint()
+		if _, ok := storedIDs[fingerprint]; !ok {
+			storedIDs[fingerprint] = tx.ID
+		}
+	}
+
+	var inserts []domain.Transaction
+	var insertIndexes []int
+	pending := make(map[string]int)                // Fingerprint -> position in inserts
+	updates := make(map[string]domain.Transaction) // Stored ID -> replacement
+	updateIndexes := make(map[string]int)          // Stored ID -> position in transactions
+
+	for i, tx := range transactions {
+		report.Results[i].Index = i
+
+		if err := tx.Validate(); err != nil {
+			var validationErrs *domain.ValidationErrors
+			if !errors.As(err, &validationErrs) {
+				return nil, err
+			}
+			report.Results[i].Status = domain.ImportStatusInvalid
+			report.Results[i].Errors = *validationErrs
+			report.InvalidCount++
+			continue
+		}
+
+		report.ValidCount++
+		if tx.Type == "income" {
+			report.EstimatedTotalIncome += tx.Amount
+		} else {
+			report.EstimatedTotalExpenses += math.Abs(tx.Amount)
+		}
+
+		fingerprint := tx.Fingerprint()
+		storedID, stored := storedIDs[fingerprint]
+		position, queued := pending[fingerprint]
+		if !stored && !queued {
+			pending[fingerprint] = len(inserts)
+			inserts = append(inserts, tx)
+			insertIndexes = append(insertIndexes, i)
+			continue
+		}
+
+		report.Results[i].Conflict = true
+		report.ConflictCount++
+		switch resolution {
+		case domain.ConflictSkip:
+			report.Results[i].Status = domain.ImportStatusSkipped
+		case domain.ConflictOverwrite:
+			// The last duplicate wins; earlier ones are reported as skipped
+			if stored {
+				if previous, ok := updateIndexes[storedID]; ok {
+					report.Results[previous].Status = domain.ImportStatusSkipped
+				}
+				updates[storedID] = tx
+				updateIndexes[storedID] = i
+			} else {
+				inserts[position] = tx
+				report.Results[insertIndexes[position]].Status = domain.ImportStatusSkipped
+				insertIndexes[position] = i
+			}
+		case domain.ConflictAppend:
+			tx.Description = uniqueDescription(tx, storedIDs, pending)
+			pending[tx.Fingerprint()] = len(inserts)
+			inserts = append(inserts, tx)
+			insertIndexes = append(insertIndexes, i)
+		default:
+			inserts = append(inserts, tx)
+			insertIndexes = append(insertIndexes, i)
+		}
+	}
+
+	report.EstimatedTotalIncome = s.roundAmount(report.EstimatedTotalIncome)
+	report.EstimatedTotalExpenses = s.roundAmount(report.EstimatedTotalExpenses)
+
+	createdStatus, overwrittenStatus := domain.ImportStatusValid, domain.ImportStatusValid
+	if !opts.DryRun {
+		// Check every overwrite target before writing, so a vanished one cannot leave the
+		// inserts stored and the overwrites missing
+		for id := range updates {
+			if _, err := s.repo.GetByID(id); err != nil {
+				return nil, fmt.Errorf("failed to overwrite transaction %s: %w", id, err)
+			}
+		}
+		if len(inserts) > 0 {
+			if err := s.repo.BulkInsert(inserts); err != nil {
+				return nil, err
+			}
+		}
+		for id, tx := range updates {
+			if err := s.repo.Update(id, tx); err != nil {
+				return nil, fmt.Errorf("failed to overwrite transaction %s: %w", id, err)
+			}
+		}
+		if len(inserts) > 0 || len(updates) > 0 {
+			s.invalidateCache()
+		}
+		createdStatus, overwrittenStatus = domain.ImportStatusCreated, domain.ImportStatusOverwritten
+	}
+	for _, i := range insertIndexes {
+		report.Results[i].Status = createdStatus
+	}
+	for _, i := range updateIndexes {
+		report.Results[i].Status = overwrittenStatus
+	}
+
+	return report, nil
+}
+
+// uniqueDescription suffixes tx's description with the first number, from 2, that gives
+// a fingerprint neither stored nor pending, e.g., "Market" -> "Market (2)"
+func uniqueDescription(tx domain.Transaction, storedIDs map[string]string, pending map[string]int) string {
+	description := tx.Description
+	for n := 2; ; n++ {
+		tx.Description = fmt.Sprintf("%s (%d)", description, n)
+		fingerprint := tx.Fingerprint()
+		_, stored := storedIDs[fingerprint]
+		_, queued := pending[fingerprint]
+		if !stored && !queued {
+			return tx.Description
+		}
+	}
+}
+
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/repository"
+)
+
+var importTransactions = []domain.Transaction{
+	{Date: "2024-03-01", Amount: 2800, Category: "salary", Description: "Salary", Type: "income"},
+	{Date: "2024-03-02", Amount: -60, Category: "groceries", Description: "Market", Type: "expense"},
+	{Date: "not-a-date", Amount: -10, Category: "", Description: "Broken", Type: "expense"},
+	{Date: "2024-03-04", Amount: -25.5, Category: "dining", Description: "Lunch", Type: "expense"},
+}
+
+func TestAnalyticsService_ImportTransactions(t *testing.T) {
+	repo, _ := repository.NewJSONRepository(testTransactionsJSON)
+	service := NewAnalyticsService(repo)
+
+	report, err := service.ImportTransactions(importTransactions, domain.ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportTransactions() error = %v", err)
+	}
+
+	if report.DryRun || report.ValidCount != 3 || report.InvalidCount != 1 {
+		t.Errorf("Unexpected report counts %+v", report)
+	}
+	if report.EstimatedTotalIncome != 2800 || report.EstimatedTotalExpenses != 85.5 {
+		t.Errorf("Estimated totals = %v / %v, want 2800 / 85.5", report.EstimatedTotalIncome, report.EstimatedTotalExpenses)
+	}
+
+	wantStatuses := []string{domain.ImportStatusCreated, domain.ImportStatusCreated, domain.ImportStatusInvalid, domain.ImportStatusCreated}
+	for i, result := range report.Results {
+		if result.Index != i || result.Status != wantStatuses[i] {
+			t.Errorf("Results[%d] = %+v, want status %q", i, result, wantStatuses[i])
+		}
+	}
+	if errs := report.Results[2].Errors; len(errs) != 2 || errs[0].Field != "date" || errs[1].Field != "category" {
+		t.Errorf("Expected date and category errors for the invalid item, got %+v", errs)
+	}
+
+	if count := repo.Count(); count != 8+3 {
+		t.Errorf("Expected %d stored transactions, got %d", 8+3, count)
+	}
+}
+
+func TestAnalyticsService_ImportTransactions_DryRun(t *testing.T) {
+	repo, _ := repository.NewJSONRepository(testTransactionsJSON)
+	service := NewAnalyticsService(repo)
+
+	report, err := service.ImportTransactions(importTransactions, domain.ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("ImportTransactions() error = %v", err)
+	}
+
+	if !report.DryRun || report.ValidCount != 3 || report.InvalidCount != 1 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+	if report.Results[0].Status != domain.ImportStatusValid || report.Results[2].Status != domain.ImportStatusInvalid {
+		t.Errorf("Unexpected dry-run statuses %+v", report.Results)
+	}
+
+	if count := repo.Count(); count != 8 {
+		t.Errorf("Expected a dry run to store nothing, got %d transactions", count)
+	}
+}
+
+// conflictTransactions overlaps testTransactionsJSON by half: the rent, Whole Foods and
+// Costco rows match stored transactions, with a different category
+var conflictTransactions = []domain.Transaction{
+	{Date: "2024-01-02", Amount: -1200, Category: "housing", Description: "Monthly rent", Type: "expense"},
+	{Date: "2024-03-01", Amount: 2800, Category: "salary", Description: "Salary", Type: "income"},
+	{Date: "2024-01-03", Amount: -85, Category: "food", Description: "Whole Foods", Type: "expense"},
+	{Date: "2024-03-02", Amount: -60, Category: "groceries", Description: "Market", Type: "expense"},
+	{Date: "2024-02-04", Amount: -110, Category: "food", Description: "Costco", Type: "expense"},
+	{Date: "2024-03-04", Amount: -25.5, Category: "dining", Description: "Lunch", Type: "expense"},
+}
+
+func TestAnalyticsService_ImportTransactions_ConflictResolution(t *testing.T) {
+	tests := []struct {
+		resolution    domain.ConflictResolution
+		conflictState string // Status of the overlapping rows
+		expectedCount int    // Repository size after the import
+		rentCategory  string // Category of the stored "Monthly rent" on 2024-01-02
+		rentCopy      string // Description of the appended copy, if any
+	}{
+		{domain.ConflictSkip, domain.ImportStatusSkipped, 8 + 3, "rent", ""},
+		{domain.ConflictOverwrite, domain.ImportStatusOverwritten, 8 + 3, "housing", ""},
+		{domain.ConflictAppend, domain.ImportStatusCreated, 8 + 6, "rent", "Monthly rent (2)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.resolution), func(t *testing.T) {
+			repo, _ := repository.NewJSONRepository(testTransactionsJSON)
+			service := NewAnalyticsService(repo)
+
+			report, err := service.ImportTransactions(conflictTransactions, domain.ImportOptions{ConflictResolution: tt.resolution})
+			if err != nil {
+				t.Fatalf("ImportTransactions() error = %v", err)
+			}
+
+			if report.ValidCount != 6 || report.ConflictCount != 3 {
+				t.Errorf("Unexpected report counts %+v", report)
+			}
+			for i, result := range report.Results {
+				conflict := i%2 == 0
+				wantStatus := domain.ImportStatusCreated
+				if conflict {
+					wantStatus = tt.conflictState
+				}
+				if result.Status != wantStatus || result.Conflict != conflict {
+					t.Errorf("Results[%d] = %+v, want status %q and conflict %v", i, result, wantStatus, conflict)
+				}
+			}
+
+			if count := repo.Count(); count != tt.expectedCount {
+				t.Errorf("Expected %d stored transactions, got %d", tt.expectedCount, count)
+			}
+
+			stored, _ := repo.GetAll()
+			var rentCategory, rentCopy string
+			for _, tx := range stored {
+				if tx.Date != "2024-01-02" {
+					continue
+				}
+				if tx.Description == "Monthly rent" {
+					rentCategory = tx.Category
+				} else {
+					rentCopy = tx.Description
+				}
+			}
+			if rentCategory != tt.rentCategory || rentCopy != tt.rentCopy {
+				t.Errorf("Stored rent = (%q, copy %q), want (%q, copy %q)", rentCategory, rentCopy, tt.rentCategory, tt.rentCopy)
+			}
+		})
+	}
+}
+
+func TestAnalyticsService_ImportTransactions_DefaultStoresConflicts(t *testing.T) {
+	repo, _ := repository.NewJSONRepository(testTransactionsJSON)
+	service := NewAnalyticsService(repo)
+
+	// Without a strategy, conflicts are flagged but stored unchanged
+	report, err := service.ImportTransactions(conflictTransactions, domain.ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportTransactions() error = %v", err)
+	}
+	if report.ConflictCount != 3 {
+		t.Errorf("ConflictCount = %d, want 3", report.ConflictCount)
+	}
+	for i, result := range report.Results {
+		if result.Status != domain.ImportStatusCreated || result.Conflict != (i%2 == 0) {
+			t.Errorf("Results[%d] = %+v, want created with conflict %v", i, result, i%2 == 0)
+		}
+	}
+
+	if count := repo.Count(); count != 8+6 {
+		t.Errorf("Expected %d stored transactions, got %d", 8+6, count)
+	}
+	rent, _ := repo.GetByCategory("housing")
+	if len(rent) != 1 || rent[0].Description != "Monthly rent" {
+		t.Errorf("Expected the imported rent stored with its description unchanged, got %+v", rent)
+	}
+}
+
+// vanishingRepository reports one stored transaction as missing when it is looked up by ID
+type vanishingRepository struct {
+	*repository.JSONRepository
+	missingID string
+}
+
+func (r vanishingRepository) GetByID(id string) (domain.Transaction, error) {
+	if id == r.missingID {
+		return domain.Transaction{}, domain.ErrTransactionNotFound
+	}
+	return r.JSONRepository.GetByID(id)
+}
+
+func TestAnalyticsService_ImportTransactions_OverwriteTargetsCheckedFirst(t *testing.T) {
+	repo, _ := repository.NewJSONRepository(testTransactionsJSON)
+	service := NewAnalyticsService(vanishingRepository{JSONRepository: repo, missingID: "tx-2"})
+
+	// tx-2 is the stored rent the first row would overwrite
+	_, err := service.ImportTransactions(conflictTransactions, domain.ImportOptions{ConflictResolution: domain.ConflictOverwrite})
+	if !errors.Is(err, domain.ErrTransactionNotFound) {
+		t.Fatalf("ImportTransactions() error = %v, want ErrTransactionNotFound", err)
+	}
+	if count := repo.Count(); count != 8 {
+		t.Errorf("Expected nothing stored when an overwrite target is missing, got %d transactions", count)
+	}
+}
+
+func TestAnalyticsService_ImportTransactions_ConflictWithinImport(t *testing.T) {
+	repo, _ := repository.NewJSONRepository(testTransactionsJSON)
+	service := NewAnalyticsService(repo)
+
+	repeated := []domain.Transaction{
+		{Date: "2024-03-02", Amount: -60, Category: "groceries", Description: "Market", Type: "expense"},
+		{Date: "2024-03-02", Amount: -60, Category: "food", Description: "Market", Type: "expense"},
+	}
+
+	// Dry runs report the conflict without storing either row
+	report, err := service.ImportTransactions(repeated, domain.ImportOptions{ConflictResolution: domain.ConflictSkip, DryRun: true})
+	if err != nil {
+		t.Fatalf("ImportTransactions() error = %v", err)
+	}
+	if report.Results[0].Status != domain.ImportStatusValid || report.Results[1].Status != domain.ImportStatusSkipped {
+		t.Errorf("Unexpected dry-run statuses %+v", report.Results)
+	}
+	if count := repo.Count(); count != 8 {
+		t.Errorf("Expected a dry run to store nothing, got %d transactions", count)
+	}
+
+	// The last duplicate wins an overwrite
+	report, err = service.ImportTransactions(repeated, domain.ImportOptions{ConflictResolution: domain.ConflictOverwrite})
+	if err != nil {
+		t.Fatalf("ImportTransactions() error = %v", err)
+	}
+	if report.Results[0].Status != domain.ImportStatusSkipped || report.Results[1].Status != domain.ImportStatusCreated {
+		t.Errorf("Unexpected overwrite statuses %+v", report.Results)
+	}
+	market, _ := repo.GetByCategory("food")
+	if count := repo.Count(); count != 9 || len(market) != 1 {
+		t.Errorf("Expected only the later row stored, got %d transactions and %d in food", count, len(market))
+	}
+
+	if _, err := service.ImportTransactions(repeated, domain.ImportOptions{ConflictResolution: "merge"}); err == nil {
+		t.Error("Expected an error for an unknown conflict resolution")
+	}
+}
+
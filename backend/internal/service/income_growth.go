@@ -0,0 +1,61 @@
+package service
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// minIncomeGrowthMonths is the history needed for two halves of at least 2 months each
+const minIncomeGrowthMonths = 4
+
+// GetIncomeGrowthRate compares average monthly income in the first and second half of the history
+// Months between the first and last transaction without income count as zero. With an odd
+// number of months the middle month belongs to neither half.
+// Returns domain.ErrInsufficientData with fewer than 4 months.
+func (s *AnalyticsService) GetIncomeGrowthRate() (*domain.IncomeGrowth, error) {
+	timeline, err := s.GetTimeline()
+	if err != nil {
+		return nil, err
+	}
+
+	incomes := monthlySeries(timeline.Timeline, func(point domain.TimelinePoint) float64 { return point.Income })
+	if len(incomes) < minIncomeGrowthMonths {
+		return nil, fmt.Errorf("%w: income growth needs at least %d months, got %d",
+			domain.ErrInsufficientData, minIncomeGrowthMonths, len(incomes))
+	}
+
+	half := len(incomes) / 2
+	early := average(incomes[:half])
+	late := average(incomes[len(incomes)-half:])
+
+	growth := &domain.IncomeGrowth{
+		EarlyPeriodAvg: s.roundAmount(early),
+		LatePeriodAvg:  s.roundAmount(late),
+		AbsoluteChange: s.roundAmount(late - early),
+	}
+
+	if early > 0 {
+		// The halves' midpoints are this many months apart
+		gapMonths := float64(len(incomes) - half)
+		growth.PercentageChange = roundToN((late-early)/early*100, metricPrecision)
+		growth.AnnualizedRate = roundToN((math.Pow(late/early, 12/gapMonths)-1)*100, metricPrecision)
+	}
+
+	return growth, nil
+}
+
+// average returns the arithmetic mean of values, or 0 for an empty slice
+func average(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// monthlyIncomeJSON builds one salary payment per month of 2024, starting in January
+func monthlyIncomeJSON(amounts ...float64) string {
+	entries := make([]string, len(amounts))
+	for i, amount := range amounts {
+		entries[i] = fmt.Sprintf(
+			`{"date": "2024-%02d-01", "amount": %.2f, "category": "salary", "description": "Salary", "type": "income"}`,
+			i+1, amount)
+	}
+	return "[" + strings.Join(entries, ",") + "]"
+}
+
+func TestGetIncomeGrowthRate(t *testing.T) {
+	tests := []struct {
+		name           string
+		incomes        []float64
+		wantEarly      float64
+		wantLate       float64
+		wantChange     float64
+		wantPercentage float64
+		wantAnnualized float64
+	}{
+		{
+			name:      "flat income",
+			incomes:   []float64{3000, 3000, 3000, 3000, 3000, 3000},
+			wantEarly: 3000,
+			wantLate:  3000,
+		},
+		{
+			// Halves are 3 months apart, so 10% compounds four times a year
+			name:           "10% growth over 6 months",
+			incomes:        []float64{1000, 1000, 1000, 1100, 1100, 1100},
+			wantEarly:      1000,
+			wantLate:       1100,
+			wantChange:     100,
+			wantPercentage: 10,
+			wantAnnualized: 46.41,
+		},
+		{
+			name:           "declining income",
+			incomes:        []float64{4000, 4000, 4000, 3000, 3000, 3000},
+			wantEarly:      4000,
+			wantLate:       3000,
+			wantChange:     -1000,
+			wantPercentage: -25,
+			wantAnnualized: -68.36,
+		},
+		{
+			name:           "exactly 4 months",
+			incomes:        []float64{2000, 2000, 2200, 2200},
+			wantEarly:      2000,
+			wantLate:       2200,
+			wantChange:     200,
+			wantPercentage: 10,
+			wantAnnualized: 77.16,
+		},
+		{
+			name:           "odd month count skips the middle month",
+			incomes:        []float64{1000, 1000, 9999, 1200, 1200},
+			wantEarly:      1000,
+			wantLate:       1200,
+			wantChange:     200,
+			wantPercentage: 20,
+			wantAnnualized: 107.36,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := setupRecurringService(t, monthlyIncomeJSON(tt.incomes...))
+
+			growth, err := service.GetIncomeGrowthRate()
+			if err != nil {
+				t.Fatalf("GetIncomeGrowthRate() error = %v", err)
+			}
+
+			want := domain.IncomeGrowth{
+				EarlyPeriodAvg:   tt.wantEarly,
+				LatePeriodAvg:    tt.wantLate,
+				AbsoluteChange:   tt.wantChange,
+				PercentageChange: tt.wantPercentage,
+				AnnualizedRate:   tt.wantAnnualized,
+			}
+			if *growth != want {
+				t.Errorf("GetIncomeGrowthRate() = %+v, want %+v", *growth, want)
+			}
+		})
+	}
+}
+
+func TestGetIncomeGrowthRate_InsufficientData(t *testing.T) {
+	service := setupRecurringService(t, monthlyIncomeJSON(3000, 3000, 3000))
+
+	if _, err := service.GetIncomeGrowthRate(); !errors.Is(err, domain.ErrInsufficientData) {
+		t.Errorf("GetIncomeGrowthRate() error = %v, want ErrInsufficientData", err)
+	}
+}
+
+func TestGetIncomeGrowthRate_NoEarlyIncome(t *testing.T) {
+	// Income starts in month 3; growth from zero has no meaningful percentage
+	service := setupRecurringService(t, `[
+		{"date": "2024-01-05", "amount": -50, "category": "groceries", "description": "Groceries", "type": "expense"},
+		{"date": "2024-03-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"},
+		{"date": "2024-04-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"}
+	]`)
+
+	growth, err := service.GetIncomeGrowthRate()
+	if err != nil {
+		t.Fatalf("GetIncomeGrowthRate() error = %v", err)
+	}
+	if growth.EarlyPeriodAvg != 0 || growth.LatePeriodAvg != 3000 {
+		t.Errorf("Expected averages 0 and 3000, got %+v", growth)
+	}
+	if growth.PercentageChange != 0 || growth.AnnualizedRate != 0 {
+		t.Errorf("Expected zero percentages without early income, got %+v", growth)
+	}
+}
+
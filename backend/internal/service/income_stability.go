@@ -0,0 +1,51 @@
+package service
+
+import (
+	"math"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// GetIncomeStability computes the spread of monthly income from the timeline
+// Every month in the timeline counts, including months with expenses but no income.
+func (s *AnalyticsService) GetIncomeStability() (*domain.IncomeStability, error) {
+	timeline, err := s.GetTimeline()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(timeline.Timeline) == 0 {
+		return nil, domain.ErrNoTransactions
+	}
+
+	incomes := make([]float64, len(timeline.Timeline))
+	var sum float64
+	for i, point := range timeline.Timeline {
+		incomes[i] = point.Income
+		sum += point.Income
+	}
+	mean := sum / float64(len(incomes))
+
+	stability := &domain.IncomeStability{
+		MinMonthlyIncome: incomes[0],
+		MaxMonthlyIncome: incomes[0],
+		MonthlyIncomes:   incomes,
+	}
+
+	var squaredDiffs float64
+	for _, income := range incomes {
+		squaredDiffs += (income - mean) * (income - mean)
+		stability.MinMonthlyIncome = math.Min(stability.MinMonthlyIncome, income)
+		stability.MaxMonthlyIncome = math.Max(stability.MaxMonthlyIncome, income)
+	}
+	stdDev := math.Sqrt(squaredDiffs / float64(len(incomes)))
+
+	stability.Mean = s.roundAmount(mean)
+	stability.StdDev = s.roundAmount(stdDev)
+	if mean > 0 {
+		stability.CoefficientOfVariation = roundToN(stdDev/mean*100, metricPrecision)
+	}
+
+	return stability, nil
+}
+
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestAnalyticsService_GetIncomeStability(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           string
+		expectedMean   float64
+		expectedStdDev float64
+		expectedCV     float64
+		expectedMin    float64
+		expectedMax    float64
+	}{
+		{
+			name: "stable salary",
+			data: `[
+				{"date": "2024-01-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"},
+				{"date": "2024-02-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"},
+				{"date": "2024-03-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"}
+			]`,
+			expectedMean: 3000, expectedStdDev: 0, expectedCV: 0, expectedMin: 3000, expectedMax: 3000,
+		},
+		{
+			// Mean 2000, population std dev sqrt((1000² + 1500² + 500² + 0²) / 4) = 935.41
+			name: "variable freelance income",
+			data: `[
+				{"date": "2024-01-10", "amount": 1000, "category": "freelance", "description": "Client A", "type": "income"},
+				{"date": "2024-02-10", "amount": 3500, "category": "freelance", "description": "Client B", "type": "income"},
+				{"date": "2024-03-10", "amount": 1500, "category": "freelance", "description": "Client A", "type": "income"},
+				{"date": "2024-04-05", "amount": 1200, "category": "freelance", "description": "Client C", "type": "income"},
+				{"date": "2024-04-20", "amount": 800, "category": "freelance", "description": "Client A", "type": "income"}
+			]`,
+			expectedMean: 2000, expectedStdDev: 935.41, expectedCV: 46.77, expectedMin: 1000, expectedMax: 3500,
+		},
+		{
+			name: "month without income",
+			data: `[
+				{"date": "2024-01-01", "amount": 2000, "category": "salary", "description": "Salary", "type": "income"},
+				{"date": "2024-02-03", "amount": -50, "category": "dining", "description": "Dinner", "type": "expense"}
+			]`,
+			expectedMean: 1000, expectedStdDev: 1000, expectedCV: 100, expectedMin: 0, expectedMax: 2000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := setupRecurringService(t, tt.data)
+
+			stability, err := service.GetIncomeStability()
+			if err != nil {
+				t.Fatalf("GetIncomeStability() error = %v", err)
+			}
+
+			if stability.Mean != tt.expectedMean {
+				t.Errorf("Mean = %v, want %v", stability.Mean, tt.expectedMean)
+			}
+			if stability.StdDev != tt.expectedStdDev {
+				t.Errorf("StdDev = %v, want %v", stability.StdDev, tt.expectedStdDev)
+			}
+			if stability.CoefficientOfVariation != tt.expectedCV {
+				t.Errorf("CoefficientOfVariation = %v, want %v", stability.CoefficientOfVariation, tt.expectedCV)
+			}
+			if stability.MinMonthlyIncome != tt.expectedMin || stability.MaxMonthlyIncome != tt.expectedMax {
+				t.Errorf("Min/Max = %v/%v, want %v/%v", stability.MinMonthlyIncome, stability.MaxMonthlyIncome, tt.expectedMin, tt.expectedMax)
+			}
+		})
+	}
+}
+
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// Patterns used to strip bank-specific noise from transaction descriptions
+var (
+	// referenceNumberPattern matches reference/store numbers like "#5342"
+	referenceNumberPattern = regexp.MustCompile(`#\d+`)
+
+	// dateSuffixPattern matches posting dates like "12/13"
+	dateSuffixPattern = regexp.MustCompile(`\b\d{2}/\d{2}\b`)
+
+	// asteriskPrefixPattern matches processor prefixes like "AMZN*" or "SQ *"
+	asteriskPrefixPattern = regexp.MustCompile(`^\w+\s?\*\s*`)
+
+	// whitespacePattern collapses runs of whitespace left behind after stripping
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// ExtractMerchantName strips reference numbers, date suffixes, asterisk prefixes
+// and extra whitespace from a bank description, leaving the merchant name
+// e.g., "SQ *STARBUCKS #0423 05/14" -> "STARBUCKS"
+func ExtractMerchantName(description string) string {
+	name := referenceNumberPattern.ReplaceAllString(description, "")
+	name = dateSuffixPattern.ReplaceAllString(name, "")
+	name = asteriskPrefixPattern.ReplaceAllString(strings.TrimSpace(name), "")
+	name = whitespacePattern.ReplaceAllString(name, " ")
+
+	return strings.TrimSpace(name)
+}
+
+// MerchantEnricher populates the Merchant field of transactions that don't provide one
+type MerchantEnricher struct{}
+
+// NewMerchantEnricher creates a new merchant enricher
+func NewMerchantEnricher() *MerchantEnricher {
+	return &MerchantEnricher{}
+}
+
+// Enrich derives the transaction merchant from its description when not set explicitly
+func (e *MerchantEnricher) Enrich(tx *domain.Transaction) {
+	if tx.Merchant != "" {
+		return
+	}
+	tx.Merchant = ExtractMerchantName(tx.Description)
+}
+
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func TestExtractMerchantName(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		expected    string
+	}{
+		{"amazon asterisk prefix with date and reference", "AMZN*DIGITAL DWNLD 12/13 #5342", "DIGITAL DWNLD"},
+		{"starbucks location suffix", "STARBUCKS #0423 05/14", "STARBUCKS"},
+		{"square prefix with space", "SQ *STARBUCKS #0423", "STARBUCKS"},
+		{"trailing whitespace", "Whole Foods   ", "Whole Foods"},
+		{"plain description", "Costco", "Costco"},
+		{"empty description", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ExtractMerchantName(tt.description); result != tt.expected {
+				t.Errorf("ExtractMerchantName(%q) = %q, want %q", tt.description, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMerchantEnricher_Enrich(t *testing.T) {
+	enricher := NewMerchantEnricher()
+
+	tx := domain.Transaction{Description: "STARBUCKS #0423 05/14"}
+	enricher.Enrich(&tx)
+
+	if tx.Merchant != "STARBUCKS" {
+		t.Errorf("Expected merchant 'STARBUCKS', got '%s'", tx.Merchant)
+	}
+
+	// Explicit merchants are kept as provided
+	explicit := domain.Transaction{Description: "AMZN*DIGITAL DWNLD", Merchant: "Amazon"}
+	enricher.Enrich(&explicit)
+
+	if explicit.Merchant != "Amazon" {
+		t.Errorf("Expected explicit merchant 'Amazon' to be kept, got '%s'", explicit.Merchant)
+	}
+}
+
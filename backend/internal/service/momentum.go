@@ -0,0 +1,89 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+const (
+	// minMomentumMonths is the history needed for a second derivative
+	minMomentumMonths = 3
+
+	// momentumStableThreshold is the |Acceleration| below which spending counts as stable
+	momentumStableThreshold = 5.0
+)
+
+// GetSpendingMomentum measures whether monthly expenses are speeding up or slowing down
+// Months between the first and last transaction without any expenses count as zero.
+// Returns domain.ErrInsufficientData with fewer than 3 months.
+func (s *AnalyticsService) GetSpendingMomentum() (*domain.SpendingMomentum, error) {
+	timeline, err := s.GetTimeline()
+	if err != nil {
+		return nil, err
+	}
+
+	expenses := monthlySeries(timeline.Timeline, func(point domain.TimelinePoint) float64 { return point.Expenses })
+	if len(expenses) < minMomentumMonths {
+		return nil, fmt.Errorf("%w: spending momentum needs at least %d months, got %d",
+			domain.ErrInsufficientData, minMomentumMonths, len(expenses))
+	}
+
+	deltas := make([]float64, len(expenses)-1)
+	for i := range deltas {
+		deltas[i] = s.roundAmount(expenses[i+1] - expenses[i])
+	}
+	accelerations := make([]float64, len(deltas)-1)
+	for i := range accelerations {
+		accelerations[i] = s.roundAmount(deltas[i+1] - deltas[i])
+	}
+
+	momentum := &domain.SpendingMomentum{
+		MoMChange:     deltas[len(deltas)-1],
+		Acceleration:  accelerations[len(accelerations)-1],
+		MonthlyDeltas: deltas,
+		Accelerations: accelerations,
+	}
+
+	switch {
+	case math.Abs(momentum.Acceleration) < momentumStableThreshold:
+		momentum.Trend = domain.MomentumStable
+	case momentum.Acceleration > 0:
+		momentum.Trend = domain.MomentumAccelerating
+	default:
+		momentum.Trend = domain.MomentumDecelerating
+	}
+
+	return momentum, nil
+}
+
+// monthlySeries returns value for every calendar month the timeline spans
+// The timeline only has months with transactions, so gaps are filled with zero.
+func monthlySeries(timeline []domain.TimelinePoint, value func(domain.TimelinePoint) float64) []float64 {
+	if len(timeline) == 0 {
+		return nil
+	}
+
+	first, err := time.Parse("2006-01", timeline[0].Period)
+	if err != nil {
+		return nil
+	}
+	last, err := time.Parse("2006-01", timeline[len(timeline)-1].Period)
+	if err != nil {
+		return nil
+	}
+
+	series := make([]float64, monthsSpanned(first, last))
+	for _, point := range timeline {
+		month, err := time.Parse("2006-01", point.Period)
+		if err != nil {
+			continue
+		}
+		series[monthsSpanned(first, month)-1] = value(point)
+	}
+
+	return series
+}
+
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// monthlyExpensesJSON builds one expense per month of 2024, starting in January
+func monthlyExpensesJSON(amounts ...float64) string {
+	entries := make([]string, len(amounts))
+	for i, amount := range amounts {
+		entries[i] = fmt.Sprintf(
+			`{"date": "2024-%02d-10", "amount": %.2f, "category": "groceries", "description": "Groceries", "type": "expense"}`,
+			i+1, -amount)
+	}
+	return "[" + strings.Join(entries, ",") + "]"
+}
+
+func TestGetSpendingMomentum(t *testing.T) {
+	tests := []struct {
+		name              string
+		expenses          []float64
+		wantMoM           float64
+		wantAcceleration  float64
+		wantTrend         string
+		wantAccelerations []float64
+	}{
+		{
+			name:              "increasingly steep growth accelerates",
+			expenses:          []float64{100, 120, 160, 240},
+			wantMoM:           80,
+			wantAcceleration:  40,
+			wantTrend:         domain.MomentumAccelerating,
+			wantAccelerations: []float64{20, 40},
+		},
+		{
+			name:              "oscillating spending alternates",
+			expenses:          []float64{100, 200, 100, 200, 100},
+			wantMoM:           -100,
+			wantAcceleration:  -200,
+			wantTrend:         domain.MomentumDecelerating,
+			wantAccelerations: []float64{-200, 200, -200},
+		},
+		{
+			name:              "constant spending is stable",
+			expenses:          []float64{500, 500, 500},
+			wantMoM:           0,
+			wantAcceleration:  0,
+			wantTrend:         domain.MomentumStable,
+			wantAccelerations: []float64{0},
+		},
+		{
+			name:              "small wobble is stable",
+			expenses:          []float64{500, 502, 501},
+			wantMoM:           -1,
+			wantAcceleration:  -3,
+			wantTrend:         domain.MomentumStable,
+			wantAccelerations: []float64{-3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := setupRecurringService(t, monthlyExpensesJSON(tt.expenses...))
+
+			momentum, err := service.GetSpendingMomentum()
+			if err != nil {
+				t.Fatalf("GetSpendingMomentum() error = %v", err)
+			}
+
+			if momentum.MoMChange != tt.wantMoM {
+				t.Errorf("MoMChange = %.2f, want %.2f", momentum.MoMChange, tt.wantMoM)
+			}
+			if momentum.Acceleration != tt.wantAcceleration {
+				t.Errorf("Acceleration = %.2f, want %.2f", momentum.Acceleration, tt.wantAcceleration)
+			}
+			if momentum.Trend != tt.wantTrend {
+				t.Errorf("Trend = %q, want %q", momentum.Trend, tt.wantTrend)
+			}
+			if fmt.Sprint(momentum.Accelerations) != fmt.Sprint(tt.wantAccelerations) {
+				t.Errorf("Accelerations = %v, want %v", momentum.Accelerations, tt.wantAccelerations)
+			}
+		})
+	}
+}
+
+func TestGetSpendingMomentum_FillsEmptyMonths(t *testing.T) {
+	// January and March only; February counts as zero spending
+	service := setupRecurringService(t, `[
+		{"date": "2024-01-10", "amount": -100, "category": "groceries", "description": "Groceries", "type": "expense"},
+		{"date": "2024-03-10", "amount": -100, "category": "groceries", "description": "Groceries", "type": "expense"}
+	]`)
+
+	momentum, err := service.GetSpendingMomentum()
+	if err != nil {
+		t.Fatalf("GetSpendingMomentum() error = %v", err)
+	}
+
+	if fmt.Sprint(momentum.MonthlyDeltas) != "[-100 100]" {
+		t.Errorf("MonthlyDeltas = %v, want [-100 100]", momentum.MonthlyDeltas)
+	}
+	if momentum.Acceleration != 200 {
+		t.Errorf("Acceleration = %.2f, want 200", momentum.Acceleration)
+	}
+}
+
+func TestGetSpendingMomentum_InsufficientData(t *testing.T) {
+	service := setupRecurringService(t, monthlyExpensesJSON(100, 200))
+
+	_, err := service.GetSpendingMomentum()
+	if !errors.Is(err, domain.ErrInsufficientData) {
+		t.Errorf("GetSpendingMomentum() error = %v, want ErrInsufficientData", err)
+	}
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+const (
+	// DefaultPageLimit is the page size used when the caller does not specify one
+	DefaultPageLimit = 50
+
+	// MaxPageLimit caps the page size to keep responses small
+	MaxPageLimit = 500
+)
+
+// GetTransactionsByFilter returns one page of transactions matching filter, sorted by date
+// An empty cursor starts from the first transaction. The response carries NextCursor and
+// PrevCursor for the neighbouring pages; Period and the pagination totals cover every
+// matching transaction.
+// Returns ErrInvalidCursor if the cursor is malformed or no longer points at the same data.
+func (s *AnalyticsService) GetTransactionsByFilter(filter domain.TransactionFilter, cursor string, limit int) (*domain.TransactionsResponse, error) {
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+
+	transactions, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	match := filter.Predicate()
+	matching := []domain.Transaction{}
+	for _, tx := range s.DetectAndAnnotateRecurring(transactions) {
+		if match(tx) {
+			matching = append(matching, tx)
+		}
+	}
+
+	// Keeping same-day transactions in load order makes positions deterministic
+	matching = sortTransactionsByDate(matching)
+
+	start := 0
+	if cursor != "" {
+		start, err = decodeCursor(cursor, matching)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	end := start + limit
+	if end > len(matching) {
+		end = len(matching)
+	}
+
+	response := &domain.TransactionsResponse{
+		Transactions:   matching[start:end],
+		Count:          end - start,
+		PaginationMeta: domain.NewPaginationMeta(start/limit+1, limit, len(matching)),
+	}
+
+	if minDate, maxDate, err := s.getDateRangeFromTransactions(matching); err == nil {
+		if response.Period, err = s.transactionsPeriod(minDate, maxDate, matching); err != nil {
+			return nil, err
+		}
+	}
+
+	if end < len(matching) {
+		response.NextCursor = encodeCursor(end, matching)
+	}
+	if start > 0 {
+		prev := start - limit
+		if prev < 0 {
+			prev = 0
+		}
+		response.PrevCursor = encodeCursor(prev, matching)
+	}
+
+	return response, nil
+}
+
+// encodeCursor encodes the page start position together with that transaction's date
+// The date lets decodeCursor detect cursors that no longer match the data
+func encodeCursor(position int, transactions []domain.Transaction) string {
+	raw := transactions[position].Date + "|" + strconv.Itoa(position)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor returns the page start position encoded in cursor
+func decodeCursor(cursor string, transactions []domain.Transaction) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, domain.ErrInvalidCursor
+	}
+
+	date, positionStr, found := strings.Cut(string(raw), "|")
+	if !found {
+		return 0, domain.ErrInvalidCursor
+	}
+
+	position, err := strconv.Atoi(positionStr)
+	if err != nil || position < 0 || position >= len(transactions) {
+		return 0, domain.ErrInvalidCursor
+	}
+
+	if transactions[position].Date != date {
+		return 0, domain.ErrInvalidCursor
+	}
+
+	return position, nil
+}
+
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/testutil/sampledata"
+)
+
+func TestAnalyticsService_GetTransactionsByFilter_CursorWalk(t *testing.T) {
+	data := sampledata.Transactions(t)
+	service := setupRecurringService(t, string(data))
+
+	all, err := service.GetTransactions()
+	if err != nil {
+		t.Fatalf("GetTransactions() error = %v", err)
+	}
+
+	// Walk forward through every page
+	seen := make(map[string]int)
+	pages := 0
+	cursor := ""
+	lastDate := ""
+	for {
+		page, err := service.GetTransactionsByFilter(domain.TransactionFilter{}, cursor, 10)
+		if err != nil {
+			t.Fatalf("GetTransactionsByFilter() error = %v", err)
+		}
+		pages++
+
+		if page.Count > 10 {
+			t.Fatalf("Page %d has %d transactions, limit is 10", pages, page.Count)
+		}
+		if pages > 1 && page.PrevCursor == "" {
+			t.Errorf("Page %d is missing a prev cursor", pages)
+		}
+		if page.Page != pages || page.PageSize != 10 || page.Total != all.Count || page.TotalPages != 12 {
+			t.Errorf("Page %d has pagination metadata %+v", pages, page.PaginationMeta)
+		}
+
+		for _, tx := range page.Transactions {
+			if tx.Date < lastDate {
+				t.Errorf("Transactions out of order: %s after %s", tx.Date, lastDate)
+			}
+			lastDate = tx.Date
+			seen[tx.Date+"|"+tx.Description+"|"+tx.Category]++
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	if pages != 12 {
+		t.Errorf("Expected 12 pages for %d transactions, got %d", all.Count, pages)
+	}
+
+	total := 0
+	for key, count := range seen {
+		total += count
+		expected := 0
+		for _, tx := range all.Transactions {
+			if tx.Date+"|"+tx.Description+"|"+tx.Category == key {
+				expected++
+			}
+		}
+		if count != expected {
+			t.Errorf("%s returned %d times, want %d", key, count, expected)
+		}
+	}
+
+	if total != 112 || total != all.Count {
+		t.Errorf("Expected all 112 transactions exactly once, got %d", total)
+	}
+}
+
+func TestAnalyticsService_GetTransactionsByFilter(t *testing.T) {
+	service := setupTestService(t)
+
+	t.Run("filter applies before paging", func(t *testing.T) {
+		page, err := service.GetTransactionsByFilter(domain.TransactionFilter{Types: []string{"expense"}}, "", 2)
+		if err != nil {
+			t.Fatalf("GetTransactionsByFilter() error = %v", err)
+		}
+
+		if page.Count != 2 || page.NextCursor == "" || page.PrevCursor != "" {
+			t.Errorf("Unexpected first page: count=%d next=%q prev=%q", page.Count, page.NextCursor, page.PrevCursor)
+		}
+
+		next, err := service.GetTransactionsByFilter(domain.TransactionFilter{Types: []string{"expense"}}, page.NextCursor, 2)
+		if err != nil {
+			t.Fatalf("GetTransactionsByFilter() error = %v", err)
+		}
+
+		prev, err := service.GetTransactionsByFilter(domain.TransactionFilter{Types: []string{"expense"}}, next.PrevCursor, 2)
+		if err != nil {
+			t.Fatalf("GetTransactionsByFilter() error = %v", err)
+		}
+		if prev.Transactions[0].Date != page.Transactions[0].Date || prev.Transactions[0].Description != page.Transactions[0].Description {
+			t.Errorf("Expected prev cursor to return the first page")
+		}
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		for _, cursor := range []string{"not base64!", "MjAyNC0wMS0wMQ", "MjAyMC0wMS0wMXww"} {
+			_, err := service.GetTransactionsByFilter(domain.TransactionFilter{}, cursor, 2)
+			if !errors.Is(err, domain.ErrInvalidCursor) {
+				t.Errorf("cursor %q: expected ErrInvalidCursor, got %v", cursor, err)
+			}
+		}
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		page, err := service.GetTransactionsByFilter(domain.TransactionFilter{Categories: []string{"travel"}}, "", 10)
+		if err != nil {
+			t.Fatalf("GetTransactionsByFilter() error = %v", err)
+		}
+		if page.Count != 0 || page.NextCursor != "" {
+			t.Errorf("Expected an empty page, got %+v", page)
+		}
+	})
+}
+
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// GetPeriodStats aggregates transactions per period ("monthly" or "weekly", ISO weeks)
+// and finds the largest and smallest single expense of each period
+// Periods are returned chronologically; ties between expenses go to the earliest one.
+// Returns ErrInvalidAggregation for any other aggregation.
+func (s *AnalyticsService) GetPeriodStats(aggregation string) ([]domain.PeriodStats, error) {
+	if aggregation != "monthly" && aggregation != "weekly" {
+		return nil, domain.ErrInvalidAggregation
+	}
+
+	transactions, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk transactions in date order so ties resolve to the earliest expense
+	sorted := sortTransactionsByDate(transactions)
+
+	periods := make(map[string]*domain.PeriodStats)
+	for i := range sorted {
+		tx := &sorted[i]
+
+		date, err := tx.ParseDate()
+		if err != nil {
+			// Skip transactions with invalid dates
+			continue
+		}
+
+		key := date.Format("2006-01")
+		if aggregation == "weekly" {
+			year, week := date.ISOWeek()
+			key = fmt.Sprintf("%d-W%02d", year, week)
+		}
+
+		stats, exists := periods[key]
+		if !exists {
+			stats = &domain.PeriodStats{Period: key}
+			periods[key] = stats
+		}
+		stats.TransactionCount++
+
+		if tx.IsIncome() {
+			stats.TotalIncome += tx.Amount
+			continue
+		}
+		if !tx.IsExpense() {
+			continue
+		}
+
+		stats.TotalExpenses += tx.AbsoluteAmount()
+		if stats.MaxExpenseTransaction == nil || tx.AbsoluteAmount() > stats.MaxExpenseTransaction.AbsoluteAmount() {
+			stats.MaxExpenseTransaction = tx
+		}
+		if stats.MinExpenseTransaction == nil || tx.AbsoluteAmount() < stats.MinExpenseTransaction.AbsoluteAmount() {
+			stats.MinExpenseTransaction = tx
+		}
+	}
+
+	result := make([]domain.PeriodStats, 0, len(periods))
+	for _, stats := range periods {
+		stats.TotalIncome = s.roundAmount(stats.TotalIncome)
+		stats.TotalExpenses = s.roundAmount(stats.TotalExpenses)
+		stats.NetSavings = s.roundAmount(stats.TotalIncome - stats.TotalExpenses)
+		result = append(result, *stats)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Period < result[j].Period
+	})
+
+	return result, nil
+}
+
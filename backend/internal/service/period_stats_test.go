@@ -0,0 +1,72 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func TestAnalyticsService_GetPeriodStats_Monthly(t *testing.T) {
+	service := setupTestService(t)
+
+	stats, err := service.GetPeriodStats("monthly")
+	if err != nil {
+		t.Fatalf("GetPeriodStats() error = %v", err)
+	}
+
+	if len(stats) != 2 {
+		t.Fatalf("Expected 2 periods, got %d", len(stats))
+	}
+
+	january := stats[0]
+	if january.Period != "2024-01" || january.TransactionCount != 5 {
+		t.Errorf("Unexpected January period/count: %s/%d", january.Period, january.TransactionCount)
+	}
+	if january.TotalIncome != 5600 || january.TotalExpenses != 1330 || january.NetSavings != 4270 {
+		t.Errorf("Unexpected January totals: %+v", january)
+	}
+
+	// The single largest expense is rent, not the category sum
+	if january.MaxExpenseTransaction == nil || january.MaxExpenseTransaction.Description != "Monthly rent" || january.MaxExpenseTransaction.Amount != -1200 {
+		t.Errorf("Expected rent as max expense, got %+v", january.MaxExpenseTransaction)
+	}
+	if january.MinExpenseTransaction == nil || january.MinExpenseTransaction.Description != "Electric bill" {
+		t.Errorf("Expected electric bill as min expense, got %+v", january.MinExpenseTransaction)
+	}
+
+	february := stats[1]
+	if february.MinExpenseTransaction == nil || february.MinExpenseTransaction.Description != "Costco" {
+		t.Errorf("Expected Costco as February min expense, got %+v", february.MinExpenseTransaction)
+	}
+}
+
+func TestAnalyticsService_GetPeriodStats_Weekly(t *testing.T) {
+	service := setupTestService(t)
+
+	stats, err := service.GetPeriodStats("weekly")
+	if err != nil {
+		t.Fatalf("GetPeriodStats() error = %v", err)
+	}
+
+	// 2024-01-16 is the only transaction in ISO week 3 and has no expenses
+	for _, period := range stats {
+		if period.Period != "2024-W03" {
+			continue
+		}
+		if period.TransactionCount != 1 || period.MaxExpenseTransaction != nil || period.MinExpenseTransaction != nil {
+			t.Errorf("Unexpected week 3 stats: %+v", period)
+		}
+		return
+	}
+	t.Errorf("Expected a 2024-W03 period, got %+v", stats)
+}
+
+func TestAnalyticsService_GetPeriodStats_InvalidAggregation(t *testing.T) {
+	service := setupTestService(t)
+
+	if _, err := service.GetPeriodStats("yearly"); !errors.Is(err, domain.ErrInvalidAggregation) {
+		t.Errorf("Expected ErrInvalidAggregation, got %v", err)
+	}
+}
+
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// variableIncomeCV is the coefficient of variation (%) above which income is treated as
+// highly variable and targets are based on the leanest month instead of the average
+const variableIncomeCV = 30.0
+
+// FinancialBenchmark caps spending for a group of categories as a share of monthly income
+type FinancialBenchmark struct {
+	Name           string   // Group name reported in suggestions, e.g., "housing"
+	Categories     []string // Transaction categories in the group
+	MaxIncomeShare float64  // Recommended maximum, e.g., 0.30 for 30% of income
+}
+
+// FinancialBenchmarks are common budgeting guidelines for spending relative to income
+var FinancialBenchmarks = []FinancialBenchmark{
+	{Name: "housing", Categories: []string{"rent", "mortgage"}, MaxIncomeShare: 0.30},
+	{Name: "food", Categories: []string{"groceries", "dining"}, MaxIncomeShare: 0.15},
+	{Name: "entertainment", Categories: []string{"entertainment"}, MaxIncomeShare: 0.05},
+}
+
+// ParseFinancialBenchmarks parses benchmarks written as "name:category|category:percent"
+// entries separated by commas, e.g., "housing:rent|mortgage:30,food:groceries|dining:15"
+func ParseFinancialBenchmarks(value string) ([]FinancialBenchmark, error) {
+	var benchmarks []FinancialBenchmark
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("benchmark %q must be name:category|category:percent", entry)
+		}
+		name := strings.TrimSpace(parts[0])
+		if name == "" {
+			return nil, fmt.Errorf("benchmark %q has no name", entry)
+		}
+
+		var categories []string
+		for _, category := range strings.Split(parts[1], "|") {
+			if category = strings.TrimSpace(category); category != "" {
+				categories = append(categories, category)
+			}
+		}
+		if len(categories) == 0 {
+			return nil, fmt.Errorf("benchmark %q has no categories", name)
+		}
+
+		percent, err := strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
+		if err != nil || percent <= 0 || percent > 100 {
+			return nil, fmt.Errorf("benchmark %q must have a percentage between 0 and 100, got %q", name, parts[2])
+		}
+
+		benchmarks = append(benchmarks, FinancialBenchmark{Name: name, Categories: categories, MaxIncomeShare: percent / 100})
+	}
+	return benchmarks, nil
+}
+
+// RationalizationService suggests where spending could be cut
+type RationalizationService struct {
+	analyticsService *AnalyticsService
+	benchmarks       []FinancialBenchmark
+}
+
+// NewRationalizationService creates a new rationalization service using FinancialBenchmarks
+func NewRationalizationService(analyticsService *AnalyticsService) *RationalizationService {
+	return &RationalizationService{
+		analyticsService: analyticsService,
+		benchmarks:       FinancialBenchmarks,
+	}
+}
+
+// SetBenchmarks replaces the spending guidelines; nil leaves no benchmarks at all
+func (s *RationalizationService) SetBenchmarks(benchmarks []FinancialBenchmark) {
+	s.benchmarks = benchmarks
+}
+
+// GetRationalizationSuggestions compares average monthly spending per benchmark group
+// against the benchmarks and returns a suggestion for every group over its target,
+// largest potential saving first
+// Targets use average monthly income, or the lowest monthly income when income is highly
+// variable. Returns an empty slice when there is no income to compare against.
+func (s *RationalizationService) GetRationalizationSuggestions() ([]domain.RationalizationSuggestion, error) {
+	summary, err := s.analyticsService.GetCategorySummary()
+	if err != nil {
+		return nil, err
+	}
+
+	incomeBase, incomeLabel, err := s.monthlyIncomeBase()
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := []domain.RationalizationSuggestion{}
+	if incomeBase <= 0 {
+		return suggestions, nil
+	}
+
+	for _, benchmark := range s.benchmarks {
+		current := benchmarkMonthlySpend(summary, benchmark)
+		target := incomeBase * benchmark.MaxIncomeShare
+		if current <= target {
+			continue
+		}
+
+		suggestions = append(suggestions, domain.RationalizationSuggestion{
+			Category:                 benchmark.Name,
+			CurrentMonthlyAverage:    s.analyticsService.roundAmount(current),
+			RecommendedMonthlyTarget: s.analyticsService.roundAmount(target),
+			PotentialMonthlySaving:   s.analyticsService.roundAmount(current - target),
+			Rationale: fmt.Sprintf("%s spending is %.0f%% of your %s; the guideline is at most %.0f%%",
+				benchmark.Name, current/incomeBase*100, incomeLabel, benchmark.MaxIncomeShare*100),
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].PotentialMonthlySaving > suggestions[j].PotentialMonthlySaving
+	})
+
+	return suggestions, nil
+}
+
+// monthlyIncomeBase returns the monthly income benchmarks are measured against and how to
+// describe it: the average, or the lowest month when income is highly variable
+func (s *RationalizationService) monthlyIncomeBase() (float64, string, error) {
+	stability, err := s.analyticsService.GetIncomeStability()
+	if err != nil {
+		return 0, "", err
+	}
+
+	if stability.CoefficientOfVariation > variableIncomeCV {
+		return stability.MinMonthlyIncome, "lowest monthly income (your income varies a lot)", nil
+	}
+	return stability.Mean, "average monthly income", nil
+}
+
+// benchmarkMonthlySpend sums the average monthly spending of the benchmark's categories
+func benchmarkMonthlySpend(summary *domain.CategorySummary, benchmark FinancialBenchmark) float64 {
+	var current float64
+	for _, category := range benchmark.Categories {
+		current += summary.Expenses[category].MonthlyAverage
+	}
+	return current
+}
+
+// GetBenchmarkReport rates average monthly spending in every benchmark group against its
+// target share of income, classified with a 5 percentage point tolerance
+// Shares use the same monthly income as the suggestions. Returns domain.ErrInsufficientData
+// when there is no income to compare against.
+func (s *RationalizationService) GetBenchmarkReport() (*domain.BenchmarkReport, error) {
+	summary, err := s.analyticsService.GetCategorySummary()
+	if err != nil {
+		return nil, err
+	}
+
+	incomeBase, _, err := s.monthlyIncomeBase()
+	if err != nil {
+		return nil, err
+	}
+	if incomeBase <= 0 {
+		return nil, fmt.Errorf("%w: benchmarks need monthly income to compare against", domain.ErrInsufficientData)
+	}
+
+	classifier := domain.BenchmarkClassifier{Tolerance: domain.DefaultBenchmarkTolerance}
+	report := &domain.BenchmarkReport{Entries: []domain.BenchmarkEntry{}}
+	compliant := 0
+	for _, benchmark := range s.benchmarks {
+		// Rounded first so a share exactly at the tolerance boundary classifies as on track
+		userPercent := roundToN(benchmarkMonthlySpend(summary, benchmark)/incomeBase*100, metricPrecision)
+		targetPercent := roundToN(benchmark.MaxIncomeShare*100, metricPrecision)
+
+		entry := domain.BenchmarkEntry{
+			Category:      benchmark.Name,
+			UserPercent:   userPercent,
+			TargetPercent: targetPercent,
+			Status:        classifier.Classify(userPercent, targetPercent),
+			Delta:         roundToN(userPercent-targetPercent, metricPrecision),
+		}
+		if entry.Status != domain.BenchmarkOver {
+			compliant++
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+
+	if len(report.Entries) > 0 {
+		report.OverallComplianceRate = roundToN(float64(compliant)/float64(len(report.Entries))*100, metricPrecision)
+	}
+
+	return report, nil
+}
+
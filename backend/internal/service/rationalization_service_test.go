@@ -0,0 +1,172 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func TestRationalizationService_GetRationalizationSuggestions(t *testing.T) {
+	tests := []struct {
+		name             string
+		data             string
+		expectedCategory string // Empty when no suggestion is expected
+		expectedCurrent  float64
+		expectedTarget   float64
+		expectedSaving   float64
+	}{
+		{
+			name: "housing at 35% of income",
+			data: `[
+				{"date": "2024-01-01", "amount": 4000, "category": "salary", "description": "Salary", "type": "income"},
+				{"date": "2024-01-02", "amount": -1400, "category": "rent", "description": "Rent", "type": "expense"},
+				{"date": "2024-02-01", "amount": 4000, "category": "salary", "description": "Salary", "type": "income"},
+				{"date": "2024-02-02", "amount": -1400, "category": "rent", "description": "Rent", "type": "expense"}
+			]`,
+			expectedCategory: "housing",
+			expectedCurrent:  1400,
+			expectedTarget:   1200, // 30% of 4000
+			expectedSaving:   200,
+		},
+		{
+			name: "housing at 25% of income",
+			data: `[
+				{"date": "2024-01-01", "amount": 4000, "category": "salary", "description": "Salary", "type": "income"},
+				{"date": "2024-01-02", "amount": -1000, "category": "rent", "description": "Rent", "type": "expense"},
+				{"date": "2024-02-01", "amount": 4000, "category": "salary", "description": "Salary", "type": "income"},
+				{"date": "2024-02-02", "amount": -1000, "category": "rent", "description": "Rent", "type": "expense"}
+			]`,
+		},
+		{
+			name: "food groups groceries and dining",
+			data: `[
+				{"date": "2024-01-01", "amount": 2000, "category": "salary", "description": "Salary", "type": "income"},
+				{"date": "2024-01-05", "amount": -250, "category": "groceries", "description": "Market", "type": "expense"},
+				{"date": "2024-01-06", "amount": -150, "category": "dining", "description": "Dinner", "type": "expense"}
+			]`,
+			expectedCategory: "food",
+			expectedCurrent:  400,
+			expectedTarget:   300, // 15% of 2000
+			expectedSaving:   100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewRationalizationService(setupRecurringService(t, tt.data))
+
+			suggestions, err := service.GetRationalizationSuggestions()
+			if err != nil {
+				t.Fatalf("GetRationalizationSuggestions() error = %v", err)
+			}
+
+			if tt.expectedCategory == "" {
+				if len(suggestions) != 0 {
+					t.Errorf("Expected no suggestions, got %+v", suggestions)
+				}
+				return
+			}
+
+			if len(suggestions) != 1 {
+				t.Fatalf("Expected 1 suggestion, got %+v", suggestions)
+			}
+
+			got := suggestions[0]
+			if got.Category != tt.expectedCategory {
+				t.Errorf("Category = %q, want %q", got.Category, tt.expectedCategory)
+			}
+			if got.CurrentMonthlyAverage != tt.expectedCurrent || got.RecommendedMonthlyTarget != tt.expectedTarget {
+				t.Errorf("Current/Target = %v/%v, want %v/%v", got.CurrentMonthlyAverage, got.RecommendedMonthlyTarget, tt.expectedCurrent, tt.expectedTarget)
+			}
+			if got.PotentialMonthlySaving != tt.expectedSaving {
+				t.Errorf("PotentialMonthlySaving = %v, want %v", got.PotentialMonthlySaving, tt.expectedSaving)
+			}
+			if got.Rationale == "" {
+				t.Error("Expected a rationale")
+			}
+		})
+	}
+}
+
+func TestRationalizationService_GetBenchmarkReport(t *testing.T) {
+	// Monthly income 4000
+	service := NewRationalizationService(setupRecurringService(t, `[
+		{"date": "2024-01-01", "amount": 4000, "category": "salary", "description": "Salary", "type": "income"},
+		{"date": "2024-01-02", "amount": -1600, "category": "rent", "description": "Rent", "type": "expense"},
+		{"date": "2024-01-05", "amount": -400, "category": "groceries", "description": "Market", "type": "expense"},
+		{"date": "2024-01-06", "amount": -200, "category": "dining", "description": "Dinner", "type": "expense"},
+		{"date": "2024-01-10", "amount": -600, "category": "utilities", "description": "Power", "type": "expense"},
+		{"date": "2024-01-12", "amount": -200, "category": "transportation", "description": "Bus pass", "type": "expense"}
+	]`))
+	service.SetBenchmarks([]FinancialBenchmark{
+		{Name: "housing", Categories: []string{"rent", "mortgage"}, MaxIncomeShare: 0.30},
+		{Name: "food", Categories: []string{"groceries", "dining"}, MaxIncomeShare: 0.15},
+		{Name: "utilities", Categories: []string{"utilities"}, MaxIncomeShare: 0.10},
+		{Name: "transport", Categories: []string{"transportation"}, MaxIncomeShare: 0.20},
+	})
+
+	report, err := service.GetBenchmarkReport()
+	if err != nil {
+		t.Fatalf("GetBenchmarkReport() error = %v", err)
+	}
+
+	expected := []domain.BenchmarkEntry{
+		{Category: "housing", UserPercent: 40, TargetPercent: 30, Status: domain.BenchmarkOver, Delta: 10},
+		{Category: "food", UserPercent: 15, TargetPercent: 15, Status: domain.BenchmarkOnTrack, Delta: 0},
+		{Category: "utilities", UserPercent: 15, TargetPercent: 10, Status: domain.BenchmarkOnTrack, Delta: 5}, // Exactly at the tolerance
+		{Category: "transport", UserPercent: 5, TargetPercent: 20, Status: domain.BenchmarkUnder, Delta: -15},
+	}
+	if !reflect.DeepEqual(report.Entries, expected) {
+		t.Errorf("Entries = %+v, want %+v", report.Entries, expected)
+	}
+	// Only housing is over its target
+	if report.OverallComplianceRate != 75 {
+		t.Errorf("OverallComplianceRate = %v, want 75", report.OverallComplianceRate)
+	}
+}
+
+func TestRationalizationService_GetBenchmarkReport_EmptyConfig(t *testing.T) {
+	service := NewRationalizationService(setupTestService(t))
+	service.SetBenchmarks(nil)
+
+	report, err := service.GetBenchmarkReport()
+	if err != nil {
+		t.Fatalf("GetBenchmarkReport() error = %v", err)
+	}
+	if report.Entries == nil || len(report.Entries) != 0 || report.OverallComplianceRate != 0 {
+		t.Errorf("Expected an empty report, got %+v", report)
+	}
+}
+
+func TestRationalizationService_GetBenchmarkReport_NoIncome(t *testing.T) {
+	service := NewRationalizationService(setupRecurringService(t, `[
+		{"date": "2024-01-02", "amount": -1600, "category": "rent", "description": "Rent", "type": "expense"}
+	]`))
+
+	if _, err := service.GetBenchmarkReport(); !errors.Is(err, domain.ErrInsufficientData) {
+		t.Errorf("Expected ErrInsufficientData, got %v", err)
+	}
+}
+
+func TestParseFinancialBenchmarks(t *testing.T) {
+	benchmarks, err := ParseFinancialBenchmarks(" housing:rent|mortgage:30 , food:groceries | dining:12.5,")
+	if err != nil {
+		t.Fatalf("ParseFinancialBenchmarks() error = %v", err)
+	}
+	expected := []FinancialBenchmark{
+		{Name: "housing", Categories: []string{"rent", "mortgage"}, MaxIncomeShare: 0.30},
+		{Name: "food", Categories: []string{"groceries", "dining"}, MaxIncomeShare: 0.125},
+	}
+	if !reflect.DeepEqual(benchmarks, expected) {
+		t.Errorf("ParseFinancialBenchmarks() = %+v, want %+v", benchmarks, expected)
+	}
+
+	for _, value := range []string{"housing:rent", ":rent:30", "housing::30", "housing:rent:abc", "housing:rent:0", "housing:rent:150"} {
+		if _, err := ParseFinancialBenchmarks(value); err == nil {
+			t.Errorf("ParseFinancialBenchmarks(%q) returned no error", value)
+		}
+	}
+}
+
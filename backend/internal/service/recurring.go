@@ -0,0 +1,204 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// defaultRecurrenceThreshold is the number of charges needed to call a pattern recurring
+const defaultRecurrenceThreshold = 3
+
+// recurringGroup collects expenses sharing a category and description
+type recurringGroup struct {
+	category    string
+	description string
+	occurrences []recurringOccurrence
+	total       float64
+}
+
+// recurringOccurrence is a single charge within a group
+type recurringOccurrence struct {
+	index int // Position in the source slice
+	date  time.Time
+}
+
+// SetRecurrenceThreshold sets the minimum occurrences for a pattern to be recurring
+func (s *AnalyticsService) SetRecurrenceThreshold(minOccurrences int) {
+	s.recurrenceThreshold = minOccurrences
+	s.invalidateCache()
+}
+
+// DetectRecurring finds expenses that repeat with a consistent interval
+// Transactions are grouped by category and description; a group is recurring when it has
+// at least the configured number of occurrences (default 3) and every gap between
+// consecutive charges is within 10% (minimum 3 days) of the average gap
+func (s *AnalyticsService) DetectRecurring() ([]domain.RecurringPattern, error) {
+	transactions, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := []domain.RecurringPattern{}
+
+	for _, g := range s.groupRecurringCandidates(transactions) {
+		frequency, ok := s.recurringFrequency(g)
+		if !ok {
+			continue
+		}
+
+		patterns = append(patterns, domain.RecurringPattern{
+			Category:      g.category,
+			Description:   g.description,
+			AverageAmount: s.roundAmount(g.total / float64(len(g.occurrences))),
+			FrequencyDays: frequency,
+			Occurrences:   len(g.occurrences),
+			LastCharged:   g.occurrences[len(g.occurrences)-1].date.Format("2006-01-02"),
+		})
+	}
+
+	return patterns, nil
+}
+
+// DetectAndAnnotateRecurring returns a copy of txs with IsRecurring and RecurrencePeriodDays
+// set using the same algorithm as DetectRecurring
+func (s *AnalyticsService) DetectAndAnnotateRecurring(txs []domain.Transaction) []domain.Transaction {
+	annotated := make([]domain.Transaction, len(txs))
+	copy(annotated, txs)
+
+	for _, g := range s.groupRecurringCandidates(annotated) {
+		frequency, ok := s.recurringFrequency(g)
+		if !ok {
+			continue
+		}
+
+		for _, occurrence := range g.occurrences {
+			period := frequency
+			annotated[occurrence.index].IsRecurring = true
+			annotated[occurrence.index].RecurrencePeriodDays = &period
+		}
+	}
+
+	return annotated
+}
+
+// groupRecurringCandidates groups expenses by category + description in first-seen order
+// Occurrences within each group are sorted by date
+func (s *AnalyticsService) groupRecurringCandidates(transactions []domain.Transaction) []*recurringGroup {
+	groups := make(map[string]*recurringGroup)
+	var ordered []*recurringGroup
+
+	for i, tx := range transactions {
+		if !tx.IsExpense() {
+			continue
+		}
+
+		date, err := tx.ParseDate()
+		if err != nil {
+			continue
+		}
+
+		key := tx.Category + "|" + tx.Description
+		g, exists := groups[key]
+		if !exists {
+			g = &recurringGroup{category: tx.Category, description: tx.Description}
+			groups[key] = g
+			ordered = append(ordered, g)
+		}
+		g.occurrences = append(g.occurrences, recurringOccurrence{index: i, date: date})
+		g.total += tx.AbsoluteAmount()
+	}
+
+	for _, g := range ordered {
+		sort.SliceStable(g.occurrences, func(i, j int) bool {
+			return g.occurrences[i].date.Before(g.occurrences[j].date)
+		})
+	}
+
+	return ordered
+}
+
+// recurringFrequency returns the group's interval in days if it qualifies as recurring
+func (s *AnalyticsService) recurringFrequency(g *recurringGroup) (int, bool) {
+	if len(g.occurrences) < s.recurrenceThreshold || len(g.occurrences) < 2 {
+		return 0, false
+	}
+
+	dates := make([]time.Time, len(g.occurrences))
+	for i, occurrence := range g.occurrences {
+		dates[i] = occurrence.date
+	}
+
+	return recurringInterval(dates)
+}
+
+// GetUpcomingBills returns recurring charges due within the given number of days from today
+func (s *AnalyticsService) GetUpcomingBills(days int) ([]domain.UpcomingBill, error) {
+	patterns, err := s.DetectRecurring()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	bills := []domain.UpcomingBill{}
+
+	for _, pattern := range patterns {
+		lastCharged, err := time.Parse("2006-01-02", pattern.LastCharged)
+		if err != nil {
+			continue
+		}
+
+		dueDate := lastCharged.AddDate(0, 0, pattern.FrequencyDays)
+		daysUntilDue := int(dueDate.Sub(today).Hours() / 24)
+
+		if daysUntilDue < 0 || daysUntilDue > days {
+			continue
+		}
+
+		bills = append(bills, domain.UpcomingBill{
+			Category:        pattern.Category,
+			EstimatedAmount: pattern.AverageAmount,
+			DueDate:         dueDate.Format("2006-01-02"),
+			DaysUntilDue:    daysUntilDue,
+		})
+	}
+
+	// Sort by due date (soonest first)
+	sort.Slice(bills, func(i, j int) bool {
+		return bills[i].DueDate < bills[j].DueDate
+	})
+
+	return bills, nil
+}
+
+// recurringInterval returns the average gap in days between sorted dates
+// and whether every gap is close enough to the average to be considered regular
+func recurringInterval(dates []time.Time) (int, bool) {
+	gaps := make([]float64, 0, len(dates)-1)
+	var sum float64
+
+	for i := 1; i < len(dates); i++ {
+		gap := dates[i].Sub(dates[i-1]).Hours() / 24
+		gaps = append(gaps, gap)
+		sum += gap
+	}
+
+	average := sum / float64(len(gaps))
+	if average < 1 {
+		return 0, false
+	}
+
+	tolerance := math.Max(3, average*0.1)
+	for _, gap := range gaps {
+		if math.Abs(gap-average) > tolerance {
+			return 0, false
+		}
+	}
+
+	return int(math.Round(average)), true
+}
+
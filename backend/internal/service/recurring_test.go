@@ -0,0 +1,184 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/repository"
+)
+
+// daysAgo formats the date n days before today
+func daysAgo(n int) string {
+	return time.Now().AddDate(0, 0, -n).Format("2006-01-02")
+}
+
+func setupRecurringService(t *testing.T, data string) *AnalyticsService {
+	t.Helper()
+
+	repo, err := repository.NewJSONRepository([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	return NewAnalyticsService(repo)
+}
+
+func TestAnalyticsService_DetectRecurring(t *testing.T) {
+	service := setupTestService(t)
+
+	patterns, err := service.DetectRecurring()
+	if err != nil {
+		t.Fatalf("DetectRecurring() error = %v", err)
+	}
+
+	// Rent only occurs twice in the fixture, below the 3 occurrence minimum
+	if len(patterns) != 0 {
+		t.Errorf("Expected no recurring patterns, got %d", len(patterns))
+	}
+}
+
+func TestAnalyticsService_DetectRecurring_Monthly(t *testing.T) {
+	data := `[
+		{"date": "2024-01-01", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"},
+		{"date": "2024-01-31", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"},
+		{"date": "2024-03-01", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"},
+		{"date": "2024-01-05", "amount": -40, "category": "dining", "description": "Lunch out", "type": "expense"},
+		{"date": "2024-01-06", "amount": -40, "category": "dining", "description": "Lunch out", "type": "expense"},
+		{"date": "2024-03-20", "amount": -40, "category": "dining", "description": "Lunch out", "type": "expense"}
+	]`
+	service := setupRecurringService(t, data)
+
+	patterns, err := service.DetectRecurring()
+	if err != nil {
+		t.Fatalf("DetectRecurring() error = %v", err)
+	}
+
+	// Irregular dining is excluded, rent is detected
+	if len(patterns) != 1 {
+		t.Fatalf("Expected 1 recurring pattern, got %d", len(patterns))
+	}
+
+	rent := patterns[0]
+	if rent.Category != "rent" || rent.FrequencyDays != 30 || rent.Occurrences != 3 {
+		t.Errorf("Unexpected pattern: %+v", rent)
+	}
+
+	if rent.LastCharged != "2024-03-01" {
+		t.Errorf("Expected last charged 2024-03-01, got %s", rent.LastCharged)
+	}
+}
+
+func TestAnalyticsService_DetectAndAnnotateRecurring(t *testing.T) {
+	dates := []string{"2024-01-01", "2024-01-31", "2024-03-01", "2024-03-31"}
+
+	tests := []struct {
+		name          string
+		occurrences   int
+		wantRecurring bool
+	}{
+		{"two charges", 2, false},
+		{"three charges", 3, true},
+		{"four charges", 4, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txs := make([]domain.Transaction, tt.occurrences)
+			for i := range txs {
+				txs[i] = domain.Transaction{
+					Date:        dates[i],
+					Amount:      -15,
+					Category:    "entertainment",
+					Description: "Streaming subscription",
+					Type:        "expense",
+				}
+			}
+
+			service := setupTestService(t)
+			annotated := service.DetectAndAnnotateRecurring(txs)
+
+			if len(annotated) != len(txs) {
+				t.Fatalf("Expected %d transactions, got %d", len(txs), len(annotated))
+			}
+
+			for _, tx := range annotated {
+				if tx.IsRecurring != tt.wantRecurring {
+					t.Errorf("%s: IsRecurring = %v, want %v", tx.Date, tx.IsRecurring, tt.wantRecurring)
+				}
+
+				if tt.wantRecurring {
+					if tx.RecurrencePeriodDays == nil || *tx.RecurrencePeriodDays != 30 {
+						t.Errorf("%s: expected a 30 day period, got %v", tx.Date, tx.RecurrencePeriodDays)
+					}
+				} else if tx.RecurrencePeriodDays != nil {
+					t.Errorf("%s: expected no period, got %d", tx.Date, *tx.RecurrencePeriodDays)
+				}
+			}
+
+			// Input slice is left untouched
+			if txs[0].IsRecurring {
+				t.Error("Expected input transactions not to be modified")
+			}
+		})
+	}
+}
+
+func TestAnalyticsService_SetRecurrenceThreshold(t *testing.T) {
+	service := setupTestService(t)
+	service.SetRecurrenceThreshold(2)
+
+	// Rent appears twice in the fixture, which now meets the threshold
+	response, err := service.GetTransactions()
+	if err != nil {
+		t.Fatalf("GetTransactions() error = %v", err)
+	}
+
+	for _, tx := range response.Transactions {
+		if tx.Category == "rent" && !tx.IsRecurring {
+			t.Errorf("Expected rent on %s to be recurring", tx.Date)
+		}
+		if tx.IsIncome() && tx.IsRecurring {
+			t.Errorf("Expected income on %s not to be flagged", tx.Date)
+		}
+	}
+}
+
+func TestAnalyticsService_GetUpcomingBills(t *testing.T) {
+	data := fmt.Sprintf(`[
+		{"date": "%s", "amount": -50, "category": "utilities", "description": "Internet", "type": "expense"},
+		{"date": "%s", "amount": -50, "category": "utilities", "description": "Internet", "type": "expense"},
+		{"date": "%s", "amount": -50, "category": "utilities", "description": "Internet", "type": "expense"}
+	]`, daysAgo(88), daysAgo(58), daysAgo(28))
+	service := setupRecurringService(t, data)
+
+	bills, err := service.GetUpcomingBills(30)
+	if err != nil {
+		t.Fatalf("GetUpcomingBills() error = %v", err)
+	}
+
+	if len(bills) != 1 {
+		t.Fatalf("Expected 1 upcoming bill, got %d", len(bills))
+	}
+
+	bill := bills[0]
+	if bill.DaysUntilDue != 2 {
+		t.Errorf("Expected bill due in 2 days, got %d", bill.DaysUntilDue)
+	}
+
+	if bill.DueDate != daysAgo(-2) {
+		t.Errorf("Expected due date %s, got %s", daysAgo(-2), bill.DueDate)
+	}
+
+	if bill.EstimatedAmount != 50 {
+		t.Errorf("Expected estimated amount 50, got %v", bill.EstimatedAmount)
+	}
+
+	// A narrower window excludes the bill
+	bills, _ = service.GetUpcomingBills(1)
+	if len(bills) != 0 {
+		t.Errorf("Expected no bills within 1 day, got %d", len(bills))
+	}
+}
+
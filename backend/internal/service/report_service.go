@@ -0,0 +1,134 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// reportBarWidth is the number of characters in the longest income vs expense bar
+const reportBarWidth = 40
+
+// ReportService renders printable reports from analytics data
+type ReportService struct {
+	analyticsService *AnalyticsService
+	now              func() time.Time // Stamped in the page footer
+}
+
+// NewReportService creates a new report service
+func NewReportService(analyticsService *AnalyticsService) *ReportService {
+	return &ReportService{
+		analyticsService: analyticsService,
+		now:              time.Now,
+	}
+}
+
+// GenerateMonthlyPDF writes a PDF report for the calendar month containing month to w
+// The report has a title page, a spending table by category with each one's share of
+// expenses, and an income vs expense bar chart drawn in monospaced text; every page is
+// stamped with the generation time.
+// Returns ErrInsufficientData when the month has no transactions.
+func (s *ReportService) GenerateMonthlyPDF(month time.Time, w io.Writer) error {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	summary, err := s.analyticsService.GetMonthlyCategorySummary(start)
+	if err != nil {
+		return err
+	}
+
+	title := start.Format("January 2006")
+	generatedAt := s.now().UTC().Format("2006-01-02 15:04 MST")
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("Monthly Financial Report - "+title, true)
+	tr := pdf.UnicodeTranslatorFromDescriptor("") // Core fonts are cp1252, category names may not be
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 10, fmt.Sprintf("Generated %s - Page %d", generatedAt, pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	// Title page
+	pdf.AddPage()
+	pdf.SetY(100)
+	pdf.SetFont("Helvetica", "B", 28)
+	pdf.SetTextColor(0, 58, 112)
+	pdf.CellFormat(0, 14, "Monthly Financial Report", "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 20)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.CellFormat(0, 12, title, "", 1, "C", false, 0, "")
+	pdf.Ln(10)
+	pdf.SetFont("Helvetica", "", 12)
+	pdf.CellFormat(0, 8, fmt.Sprintf("Net savings: %.2f (%.1f%% of income)", summary.Summary.NetSavings, summary.Summary.SavingsRate), "", 1, "C", false, 0, "")
+
+	// Spending by category, largest first
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 10, "Spending by category", "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.SetFillColor(230, 236, 242)
+	pdf.CellFormat(90, 8, "Category", "1", 0, "L", true, 0, "")
+	pdf.CellFormat(50, 8, "Amount", "1", 0, "R", true, 0, "")
+	pdf.CellFormat(40, 8, "Share", "1", 1, "R", true, 0, "")
+
+	pdf.SetFont("Helvetica", "", 11)
+	categories := make([]string, 0, len(summary.Expenses))
+	for category := range summary.Expenses {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		a, b := summary.Expenses[categories[i]], summary.Expenses[categories[j]]
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return categories[i] < categories[j]
+	})
+	for _, category := range categories {
+		detail := summary.Expenses[category]
+		pdf.CellFormat(90, 8, tr(category), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(50, 8, fmt.Sprintf("%.2f", detail.Total), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(40, 8, fmt.Sprintf("%.1f%%", detail.Percentage), "1", 1, "R", false, 0, "")
+	}
+	if len(categories) == 0 {
+		pdf.CellFormat(180, 8, "No spending this month", "1", 1, "C", false, 0, "")
+	}
+
+	// Income vs expenses as text bars
+	pdf.Ln(10)
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 10, "Income vs expenses", "", 1, "L", false, 0, "")
+	pdf.SetFont("Courier", "", 11)
+	for _, line := range reportBars(summary.Summary.TotalIncome, summary.Summary.TotalExpenses) {
+		pdf.CellFormat(0, 6, line, "", 1, "L", false, 0, "")
+	}
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("failed to render PDF: %w", err)
+	}
+	return nil
+}
+
+// reportBars draws income and expenses as bars of '#' scaled to the larger of the two, e.g.,
+// "Income   |########################################| 8400.00"
+func reportBars(income, expenses float64) []string {
+	largest := max(income, expenses)
+
+	bar := func(label string, value float64) string {
+		filled := 0
+		if largest > 0 {
+			filled = int(value / largest * reportBarWidth)
+		}
+		return fmt.Sprintf("%-8s |%s%s| %.2f", label,
+			strings.Repeat("#", filled), strings.Repeat(" ", reportBarWidth-filled), value)
+	}
+
+	return []string{bar("Income", income), bar("Expenses", expenses)}
+}
+
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func TestReportService_GenerateMonthlyPDF(t *testing.T) {
+	reports := NewReportService(setupTestService(t))
+	reports.now = func() time.Time { return time.Date(2024, 2, 1, 9, 30, 0, 0, time.UTC) }
+
+	var buf bytes.Buffer
+	if err := reports.GenerateMonthlyPDF(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), &buf); err != nil {
+		t.Fatalf("GenerateMonthlyPDF() error = %v", err)
+	}
+
+	if buf.Len() == 0 {
+		t.Fatal("Expected a non-empty PDF")
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-1.")) {
+		t.Errorf("Expected output to start with %%PDF-1., got %q", buf.Bytes()[:min(buf.Len(), 8)])
+	}
+
+	// A month without transactions has nothing to report
+	err := reports.GenerateMonthlyPDF(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), &bytes.Buffer{})
+	if !errors.Is(err, domain.ErrInsufficientData) {
+		t.Errorf("GenerateMonthlyPDF() for an empty month error = %v, want ErrInsufficientData", err)
+	}
+}
+
+func TestReportBars(t *testing.T) {
+	lines := reportBars(8400, 2100)
+	want := []string{
+		"Income   |########################################| 8400.00",
+		"Expenses |##########                              | 2100.00",
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("reportBars()[%d] = %q, want %q", i, lines[i], want[i])
+		}
+	}
+
+	if lines := reportBars(0, 0); lines[0] != "Income   |                                        | 0.00" {
+		t.Errorf("Expected empty bars without data, got %q", lines[0])
+	}
+}
+
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+const (
+	// SeasonalityThreshold is the coefficient of variation above which a category is seasonal
+	SeasonalityThreshold = 0.3
+
+	// minSeasonalMonths is the minimum span of data per category, so every calendar month is seen
+	minSeasonalMonths = 12
+)
+
+// DetectSeasonalPatterns finds expense categories whose spending varies strongly by calendar month
+// For each category, spending is summed per calendar month (across years) and normalized as a share
+// of the category total; categories whose shares have a coefficient of variation above
+// SeasonalityThreshold are returned, most seasonal first. A category needs at least 12 months of
+// data, counted from its first transaction to the latest transaction in the dataset.
+func (s *AnalyticsService) DetectSeasonalPatterns() ([]domain.SeasonalPattern, error) {
+	transactions, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(transactions) == 0 {
+		return nil, domain.ErrNoTransactions
+	}
+
+	_, latest, err := s.getDateRangeFromTransactions(transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	monthly := make(map[string]*[12]float64)
+	firstSeen := make(map[string]time.Time)
+	for _, tx := range transactions {
+		if !tx.IsExpense() {
+			continue
+		}
+		date, err := tx.ParseDate()
+		if err != nil {
+			continue
+		}
+
+		totals, ok := monthly[tx.Category]
+		if !ok {
+			totals = &[12]float64{}
+			monthly[tx.Category] = totals
+		}
+		totals[date.Month()-1] += tx.AbsoluteAmount()
+
+		if first, ok := firstSeen[tx.Category]; !ok || date.Before(first) {
+			firstSeen[tx.Category] = date
+		}
+	}
+
+	patterns := []domain.SeasonalPattern{}
+	for category, totals := range monthly {
+		if monthsSpanned(firstSeen[category], latest) < minSeasonalMonths {
+			continue
+		}
+
+		if pattern, ok := seasonalPattern(category, totals); ok {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	sort.Slice(patterns, func(i, j int) bool {
+		if patterns[i].SeasonalityScore != patterns[j].SeasonalityScore {
+			return patterns[i].SeasonalityScore > patterns[j].SeasonalityScore
+		}
+		return patterns[i].Category < patterns[j].Category
+	})
+
+	return patterns, nil
+}
+
+// seasonalPattern scores one category's calendar-month totals
+// ok is false when the category is not seasonal
+func seasonalPattern(category string, totals *[12]float64) (domain.SeasonalPattern, bool) {
+	var annual float64
+	for _, total := range totals {
+		annual += total
+	}
+	if annual == 0 {
+		return domain.SeasonalPattern{}, false
+	}
+
+	// Shares always sum to 1, so the mean share is 1/12
+	mean := 1.0 / 12
+	var squaredDiffs float64
+	for _, total := range totals {
+		share := total / annual
+		squaredDiffs += (share - mean) * (share - mean)
+	}
+	cv := math.Sqrt(squaredDiffs/12) / mean
+	if cv <= SeasonalityThreshold {
+		return domain.SeasonalPattern{}, false
+	}
+
+	pattern := domain.SeasonalPattern{
+		Category:         category,
+		HighMonths:       []int{},
+		LowMonths:        []int{},
+		SeasonalityScore: roundToN(cv, metricPrecision),
+	}
+	for i, total := range totals {
+		share := total / annual
+		switch {
+		case share >= 1.5*mean:
+			pattern.HighMonths = append(pattern.HighMonths, i+1)
+		case share <= 0.5*mean:
+			pattern.LowMonths = append(pattern.LowMonths, i+1)
+		}
+	}
+
+	return pattern, true
+}
+
+// monthsSpanned counts calendar months from start to end, inclusive of both
+func monthsSpanned(start, end time.Time) int {
+	return (end.Year()-start.Year())*12 + int(end.Month()-start.Month()) + 1
+}
+
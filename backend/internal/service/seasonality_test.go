@@ -0,0 +1,79 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// seasonalFixture returns a year of data with a December gift spike and steady groceries
+// Travel only starts in June, so it has less than 12 months of data.
+func seasonalFixture() string {
+	var rows []string
+	for month := 1; month <= 12; month++ {
+		gifts := 20
+		if month == 12 {
+			gifts = 500
+		}
+		rows = append(rows,
+			fmt.Sprintf(`{"date": "2023-%02d-10", "amount": -%d, "category": "gifts", "description": "Gift shop", "type": "expense"}`, month, gifts),
+			fmt.Sprintf(`{"date": "2023-%02d-12", "amount": -100, "category": "groceries", "description": "Whole Foods", "type": "expense"}`, month),
+			fmt.Sprintf(`{"date": "2023-%02d-01", "amount": 2800, "category": "salary", "description": "Salary", "type": "income"}`, month),
+		)
+		if month == 7 {
+			rows = append(rows, `{"date": "2023-07-15", "amount": -1500, "category": "travel", "description": "Summer trip", "type": "expense"}`)
+		}
+	}
+	return "[" + strings.Join(rows, ",\n") + "]"
+}
+
+func TestAnalyticsService_DetectSeasonalPatterns(t *testing.T) {
+	service := setupRecurringService(t, seasonalFixture())
+
+	patterns, err := service.DetectSeasonalPatterns()
+	if err != nil {
+		t.Fatalf("DetectSeasonalPatterns() error = %v", err)
+	}
+
+	// Groceries are flat, travel has too little history, salary is income
+	if len(patterns) != 1 {
+		t.Fatalf("Expected only gifts to be seasonal, got %+v", patterns)
+	}
+
+	gifts := patterns[0]
+	if gifts.Category != "gifts" {
+		t.Fatalf("Expected gifts, got %s", gifts.Category)
+	}
+	if gifts.SeasonalityScore <= SeasonalityThreshold {
+		t.Errorf("Expected gifts score above %v, got %v", SeasonalityThreshold, gifts.SeasonalityScore)
+	}
+	if !reflect.DeepEqual(gifts.HighMonths, []int{12}) {
+		t.Errorf("HighMonths = %v, want [12]", gifts.HighMonths)
+	}
+	if len(gifts.LowMonths) != 11 {
+		t.Errorf("Expected the other 11 months to be low, got %v", gifts.LowMonths)
+	}
+}
+
+func TestAnalyticsService_DetectSeasonalPatterns_InsufficientHistory(t *testing.T) {
+	// Two months of data: no category qualifies
+	service := setupTestService(t)
+
+	patterns, err := service.DetectSeasonalPatterns()
+	if err != nil {
+		t.Fatalf("DetectSeasonalPatterns() error = %v", err)
+	}
+	if len(patterns) != 0 {
+		t.Errorf("Expected no patterns, got %+v", patterns)
+	}
+}
+
+func TestAnalyticsService_DetectSeasonalPatterns_Empty(t *testing.T) {
+	service := setupRecurringService(t, `[]`)
+
+	if _, err := service.DetectSeasonalPatterns(); err == nil {
+		t.Error("Expected error for empty data")
+	}
+}
+
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// blockedNetworks are the address ranges outbound API calls may never reach:
+// private (RFC 1918, RFC 4193), loopback, link-local and unspecified addresses
+var blockedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// isBlockedIP reports whether ip falls in one of the blocked ranges
+// IPv4-mapped IPv6 addresses are checked as IPv4.
+func isBlockedIP(ip net.IP) bool {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// newSSRFSafeClient returns an HTTP client that only speaks HTTPS to public addresses
+// The host is resolved once and the connection is made to the checked IP, so a DNS
+// answer cannot change between the check and the dial.
+func newSSRFSafeClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // A proxy would dial on our behalf and bypass the checks
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, ipAddr := range addrs {
+			if isBlockedIP(ipAddr.IP) {
+				return nil, fmt.Errorf("%w: %s resolves to %s", domain.ErrSSRFAttempt, host, ipAddr.IP)
+			}
+		}
+		if len(addrs) == 0 {
+			return nil, fmt.Errorf("no addresses found for %s", host)
+		}
+
+		return dialer.DialContext(ctx, network, net.JoinHostPort(addrs[0].IP.String(), port))
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: httpsOnlyTransport{next: transport},
+	}
+}
+
+// httpsOnlyTransport refuses requests, including redirects, that are not HTTPS
+type httpsOnlyTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t httpsOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme != "https" {
+		return nil, fmt.Errorf("%w: scheme %q is not allowed", domain.ErrSSRFAttempt, req.URL.Scheme)
+	}
+	return t.next.RoundTrip(req)
+}
+
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// GetSavingsStreak reports consecutive months with positive net savings (income > expenses)
+// Months without any transactions count as zero net and break the streak.
+func (s *AnalyticsService) GetSavingsStreak() (*domain.SavingsStreak, error) {
+	transactions, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	months, err := s.calendarMonths(transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	net := make(map[string]float64)
+	for _, tx := range transactions {
+		yearMonth, err := tx.GetYearMonth()
+		if err != nil {
+			continue
+		}
+		net[yearMonth] += tx.Amount // Expenses are negative
+	}
+
+	return buildStreak(months, func(month string) bool {
+		return s.roundAmount(net[month]) > 0
+	}), nil
+}
+
+// calendarMonths lists every month ("YYYY-MM") from the first to the last transaction
+func (s *AnalyticsService) calendarMonths(transactions []domain.Transaction) ([]string, error) {
+	start, end, err := s.getDateRangeFromTransactions(transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	months := []string{}
+	last := time.Date(end.Year(), end.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(last); month = month.AddDate(0, 1, 0) {
+		months = append(months, month.Format("2006-01"))
+	}
+
+	return months, nil
+}
+
+// buildStreak computes streak statistics over chronologically ordered months
+// met reports whether a month counts towards the streak
+func buildStreak(months []string, met func(month string) bool) *domain.SavingsStreak {
+	streak := &domain.SavingsStreak{TotalMonths: len(months)}
+
+	run, runStart := 0, ""
+	for _, month := range months {
+		if !met(month) {
+			run, runStart = 0, ""
+			continue
+		}
+
+		streak.TotalPositiveMonths++
+		if run == 0 {
+			runStart = month
+		}
+		run++
+
+		// Strictly greater keeps the earliest streak on ties
+		if run > streak.LongestStreak {
+			streak.LongestStreak = run
+			streak.LongestStreakStart = runStart
+			streak.LongestStreakEnd = month
+		}
+	}
+
+	// The run still open after the last month is the current streak
+	streak.CurrentStreak = run
+	streak.CurrentStreakStart = runStart
+
+	return streak
+}
+
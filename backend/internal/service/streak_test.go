@@ -0,0 +1,142 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/repository"
+)
+
+// Monthly nets: Jan +, Feb +, Mar -, Apr +, May +, Jun +, Jul (no data), Aug +
+var streakTransactionsJSON = []byte(`[
+	{"date": "2024-01-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"},
+	{"date": "2024-01-02", "amount": -1200, "category": "rent", "description": "Rent", "type": "expense"},
+	{"date": "2024-02-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"},
+	{"date": "2024-02-02", "amount": -1200, "category": "rent", "description": "Rent", "type": "expense"},
+	{"date": "2024-03-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"},
+	{"date": "2024-03-02", "amount": -1200, "category": "rent", "description": "Rent", "type": "expense"},
+	{"date": "2024-03-15", "amount": -2500, "category": "shopping", "description": "New laptop", "type": "expense"},
+	{"date": "2024-04-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"},
+	{"date": "2024-04-02", "amount": -1200, "category": "rent", "description": "Rent", "type": "expense"},
+	{"date": "2024-05-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"},
+	{"date": "2024-05-02", "amount": -1200, "category": "rent", "description": "Rent", "type": "expense"},
+	{"date": "2024-06-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"},
+	{"date": "2024-06-02", "amount": -1500, "category": "rent", "description": "Rent", "type": "expense"},
+	{"date": "2024-08-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"}
+]`)
+
+func setupStreakService(t *testing.T) *AnalyticsService {
+	t.Helper()
+
+	repo, err := repository.NewJSONRepository(streakTransactionsJSON)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	return NewAnalyticsService(repo)
+}
+
+func TestAnalyticsService_GetSavingsStreak(t *testing.T) {
+	service := setupStreakService(t)
+
+	streak, err := service.GetSavingsStreak()
+	if err != nil {
+		t.Fatalf("GetSavingsStreak() error = %v", err)
+	}
+
+	expected := domain.SavingsStreak{
+		CurrentStreak:       1, // Restarted in August after the empty July
+		CurrentStreakStart:  "2024-08",
+		LongestStreak:       3, // April-June, after March reset the Jan-Feb streak
+		LongestStreakStart:  "2024-04",
+		LongestStreakEnd:    "2024-06",
+		TotalPositiveMonths: 6,
+		TotalMonths:         8,
+	}
+	if *streak != expected {
+		t.Errorf("GetSavingsStreak() = %+v, want %+v", *streak, expected)
+	}
+}
+
+func TestBuildStreak(t *testing.T) {
+	months := []string{"2024-01", "2024-02", "2024-03", "2024-04"}
+
+	tests := []struct {
+		name     string
+		met      map[string]bool
+		expected domain.SavingsStreak
+	}{
+		{
+			name:     "no months met",
+			met:      map[string]bool{},
+			expected: domain.SavingsStreak{TotalMonths: 4},
+		},
+		{
+			name: "every month met",
+			met:  map[string]bool{"2024-01": true, "2024-02": true, "2024-03": true, "2024-04": true},
+			expected: domain.SavingsStreak{
+				CurrentStreak: 4, CurrentStreakStart: "2024-01",
+				LongestStreak: 4, LongestStreakStart: "2024-01", LongestStreakEnd: "2024-04",
+				TotalPositiveMonths: 4, TotalMonths: 4,
+			},
+		},
+		{
+			name: "broken by the latest month",
+			met:  map[string]bool{"2024-01": true, "2024-02": true, "2024-03": true},
+			expected: domain.SavingsStreak{
+				LongestStreak: 3, LongestStreakStart: "2024-01", LongestStreakEnd: "2024-03",
+				TotalPositiveMonths: 3, TotalMonths: 4,
+			},
+		},
+		{
+			name: "earliest streak wins ties",
+			met:  map[string]bool{"2024-01": true, "2024-03": true},
+			expected: domain.SavingsStreak{
+				LongestStreak: 1, LongestStreakStart: "2024-01", LongestStreakEnd: "2024-01",
+				TotalPositiveMonths: 2, TotalMonths: 4,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streak := buildStreak(months, func(month string) bool { return tt.met[month] })
+			if *streak != tt.expected {
+				t.Errorf("buildStreak() = %+v, want %+v", *streak, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBudgetService_GetBudgetAdherenceStreak(t *testing.T) {
+	budgets := repository.NewInMemoryBudgetRepository()
+	if err := budgets.Create(domain.Budget{Category: "rent", MonthlyLimit: 1200}); err != nil {
+		t.Fatalf("Failed to create budget: %v", err)
+	}
+	service := NewBudgetService(setupStreakService(t), budgets)
+
+	streak, err := service.GetBudgetAdherenceStreak("rent")
+	if err != nil {
+		t.Fatalf("GetBudgetAdherenceStreak() error = %v", err)
+	}
+
+	// June rent (1500) is over budget; July and August have no rent at all
+	expected := domain.SavingsStreak{
+		CurrentStreak:       2,
+		CurrentStreakStart:  "2024-07",
+		LongestStreak:       5,
+		LongestStreakStart:  "2024-01",
+		LongestStreakEnd:    "2024-05",
+		TotalPositiveMonths: 7,
+		TotalMonths:         8,
+	}
+	if *streak != expected {
+		t.Errorf("GetBudgetAdherenceStreak() = %+v, want %+v", *streak, expected)
+	}
+
+	if _, err := service.GetBudgetAdherenceStreak("dining"); !errors.Is(err, domain.ErrBudgetNotFound) {
+		t.Errorf("Expected ErrBudgetNotFound, got %v", err)
+	}
+}
+
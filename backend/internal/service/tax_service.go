@@ -0,0 +1,160 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// TaxBracket taxes income up to UpTo at Rate; UpTo of 0 means no upper limit
+type TaxBracket struct {
+	UpTo float64 `json:"up_to"`
+	Rate float64 `json:"rate"` // e.g., 0.22 for 22%
+}
+
+// TaxSchedule holds the standard deduction and progressive brackets for a filing status
+type TaxSchedule struct {
+	StandardDeduction float64      `json:"standard_deduction"`
+	Brackets          []TaxBracket `json:"brackets"` // Ascending by UpTo, unlimited bracket last
+}
+
+// taxBrackets are the 2024 US federal schedules, keyed by filing status
+// Override them each year with TAX_YEAR_DATA rather than editing this table.
+var taxBrackets = map[string]TaxSchedule{
+	domain.FilingStatusSingle: {
+		StandardDeduction: 14600,
+		Brackets: []TaxBracket{
+			{11600, 0.10}, {47150, 0.12}, {100525, 0.22}, {191950, 0.24},
+			{243725, 0.32}, {609350, 0.35}, {0, 0.37},
+		},
+	},
+	domain.FilingStatusMarriedJoint: {
+		StandardDeduction: 29200,
+		Brackets: []TaxBracket{
+			{23200, 0.10}, {94300, 0.12}, {201050, 0.22}, {383900, 0.24},
+			{487450, 0.32}, {731200, 0.35}, {0, 0.37},
+		},
+	},
+	domain.FilingStatusMarriedSeparate: {
+		StandardDeduction: 14600,
+		Brackets: []TaxBracket{
+			{11600, 0.10}, {47150, 0.12}, {100525, 0.22}, {191950, 0.24},
+			{243725, 0.32}, {365600, 0.35}, {0, 0.37},
+		},
+	},
+	domain.FilingStatusHeadOfHousehold: {
+		StandardDeduction: 21900,
+		Brackets: []TaxBracket{
+			{16550, 0.10}, {63100, 0.12}, {100500, 0.22}, {191950, 0.24},
+			{243700, 0.32}, {609350, 0.35}, {0, 0.37},
+		},
+	},
+}
+
+// TaxService produces rough income tax estimates from transaction data
+type TaxService struct {
+	analyticsService     *AnalyticsService
+	deductibleCategories map[string]bool
+	schedules            map[string]TaxSchedule
+}
+
+// NewTaxService creates a tax service that treats expenses in deductibleCategories as deductible
+func NewTaxService(analyticsService *AnalyticsService, deductibleCategories []string) *TaxService {
+	deductible := make(map[string]bool, len(deductibleCategories))
+	for _, category := range deductibleCategories {
+		deductible[category] = true
+	}
+
+	return &TaxService{
+		analyticsService:     analyticsService,
+		deductibleCategories: deductible,
+		schedules:            taxBrackets,
+	}
+}
+
+// LoadTaxYearData replaces the built-in schedules with JSON keyed by filing status, e.g.:
+//
+//	{"single": {"standard_deduction": 15000, "brackets": [{"up_to": 11925, "rate": 0.10}, ..., {"up_to": 0, "rate": 0.37}]}}
+func (s *TaxService) LoadTaxYearData(data []byte) error {
+	var schedules map[string]TaxSchedule
+	if err := json.Unmarshal(data, &schedules); err != nil {
+		return fmt.Errorf("failed to parse tax year data: %w", err)
+	}
+
+	for status, schedule := range schedules {
+		if len(schedule.Brackets) == 0 || schedule.Brackets[len(schedule.Brackets)-1].UpTo != 0 {
+			return fmt.Errorf("tax year data for %q must end with an unlimited bracket (up_to 0)", status)
+		}
+	}
+
+	s.schedules = schedules
+	return nil
+}
+
+// EstimateTaxLiability estimates income tax for taxYear
+// Deductible expenses are itemized only when they exceed the standard deduction.
+// Returns ErrInvalidFilingStatus for unknown statuses and ErrNoTransactions if the year has no data.
+func (s *TaxService) EstimateTaxLiability(taxYear int, filingStatus string) (*domain.TaxEstimate, error) {
+	schedule, ok := s.schedules[filingStatus]
+	if !ok {
+		return nil, domain.ErrInvalidFilingStatus
+	}
+
+	start := time.Date(taxYear, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(taxYear, 12, 31, 0, 0, 0, 0, time.UTC)
+	transactions, err := s.analyticsService.repo.GetByDateRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var grossIncome, deductible float64
+	for _, tx := range transactions {
+		if tx.IsIncome() {
+			grossIncome += tx.Amount
+		} else if tx.IsExpense() && s.deductibleCategories[tx.Category] {
+			deductible += tx.AbsoluteAmount()
+		}
+	}
+
+	taxableIncome := math.Max(grossIncome-math.Max(deductible, schedule.StandardDeduction), 0)
+	tax := progressiveTax(taxableIncome, schedule.Brackets)
+
+	estimate := &domain.TaxEstimate{
+		TaxYear:            taxYear,
+		FilingStatus:       filingStatus,
+		GrossIncome:        s.analyticsService.roundAmount(grossIncome),
+		DeductibleExpenses: s.analyticsService.roundAmount(deductible),
+		StandardDeduction:  schedule.StandardDeduction,
+		TaxableIncome:      s.analyticsService.roundAmount(taxableIncome),
+		EstimatedTax:       s.analyticsService.roundAmount(tax),
+		Disclaimer:         domain.TaxDisclaimer,
+	}
+	if grossIncome > 0 {
+		estimate.EffectiveRate = roundToN(tax/grossIncome*100, metricPrecision)
+	}
+
+	return estimate, nil
+}
+
+// progressiveTax applies each bracket's rate to the slice of income that falls in it
+func progressiveTax(income float64, brackets []TaxBracket) float64 {
+	var tax, lower float64
+	for _, bracket := range brackets {
+		upper := bracket.UpTo
+		if upper == 0 || upper > income {
+			upper = income
+		}
+		if upper > lower {
+			tax += (upper - lower) * bracket.Rate
+		}
+		if bracket.UpTo == 0 || bracket.UpTo >= income {
+			break
+		}
+		lower = bracket.UpTo
+	}
+	return tax
+}
+
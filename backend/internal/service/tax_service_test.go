@@ -0,0 +1,97 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func TestProgressiveTax(t *testing.T) {
+	brackets := taxBrackets[domain.FilingStatusSingle].Brackets
+
+	tests := []struct {
+		income   float64
+		expected float64
+	}{
+		{0, 0},
+		{10000, 1000},              // 10% only
+		{11600, 1160},              // Top of the first bracket
+		{50000, 1160 + 4266 + 627}, // 10% + 12% + 22% on 2850
+		{700000, 183647.25 + (700000-609350)*0.37}, // Into the unlimited bracket
+	}
+
+	for _, tt := range tests {
+		if got := progressiveTax(tt.income, brackets); math.Abs(got-tt.expected) > 0.01 {
+			t.Errorf("progressiveTax(%v) = %v, want %v", tt.income, got, tt.expected)
+		}
+	}
+}
+
+func TestTaxService_EstimateTaxLiability(t *testing.T) {
+	data := `[
+		{"date": "2024-01-15", "amount": 30000, "category": "salary", "description": "Salary", "type": "income"},
+		{"date": "2024-07-15", "amount": 30000, "category": "salary", "description": "Salary", "type": "income"},
+		{"date": "2024-03-10", "amount": -2000, "category": "healthcare", "description": "Surgery", "type": "expense"},
+		{"date": "2024-04-01", "amount": -1500, "category": "rent", "description": "Rent", "type": "expense"},
+		{"date": "2023-12-31", "amount": 99999, "category": "salary", "description": "Other year", "type": "income"}
+	]`
+	service := NewTaxService(setupRecurringService(t, data), []string{"healthcare"})
+
+	estimate, err := service.EstimateTaxLiability(2024, domain.FilingStatusSingle)
+	if err != nil {
+		t.Fatalf("EstimateTaxLiability() error = %v", err)
+	}
+
+	// Deductible expenses (2000) are below the standard deduction (14600)
+	expectedTaxable := 60000.0 - 14600
+	expectedTax := progressiveTax(expectedTaxable, taxBrackets[domain.FilingStatusSingle].Brackets)
+
+	if estimate.GrossIncome != 60000 || estimate.DeductibleExpenses != 2000 {
+		t.Errorf("Gross/Deductible = %v/%v, want 60000/2000", estimate.GrossIncome, estimate.DeductibleExpenses)
+	}
+	if estimate.TaxableIncome != expectedTaxable {
+		t.Errorf("TaxableIncome = %v, want %v", estimate.TaxableIncome, expectedTaxable)
+	}
+	if estimate.EstimatedTax != roundToN(expectedTax, 2) {
+		t.Errorf("EstimatedTax = %v, want %v", estimate.EstimatedTax, roundToN(expectedTax, 2))
+	}
+	if estimate.EffectiveRate != roundToN(expectedTax/60000*100, 2) {
+		t.Errorf("EffectiveRate = %v", estimate.EffectiveRate)
+	}
+	if estimate.Disclaimer == "" {
+		t.Error("Expected a disclaimer")
+	}
+
+	if _, err := service.EstimateTaxLiability(2024, "alien"); !errors.Is(err, domain.ErrInvalidFilingStatus) {
+		t.Errorf("Expected ErrInvalidFilingStatus, got %v", err)
+	}
+}
+
+func TestTaxService_LoadTaxYearData(t *testing.T) {
+	service := NewTaxService(setupTestService(t), nil)
+
+	if err := service.LoadTaxYearData([]byte(`{"single": {"standard_deduction": 0, "brackets": [{"up_to": 1000, "rate": 0.1}]}}`)); err == nil {
+		t.Error("Expected an error for data without an unlimited bracket")
+	}
+
+	if err := service.LoadTaxYearData([]byte(`{"single": {"standard_deduction": 0, "brackets": [{"up_to": 0, "rate": 0.1}]}}`)); err != nil {
+		t.Fatalf("LoadTaxYearData() error = %v", err)
+	}
+
+	// Flat 10% on the 8400 of salary in the fixture
+	estimate, err := service.EstimateTaxLiability(2024, domain.FilingStatusSingle)
+	if err != nil {
+		t.Fatalf("EstimateTaxLiability() error = %v", err)
+	}
+	if estimate.EstimatedTax != 840 {
+		t.Errorf("EstimatedTax = %v, want 840", estimate.EstimatedTax)
+	}
+
+	// Statuses missing from the loaded data are rejected
+	if _, err := service.EstimateTaxLiability(2024, domain.FilingStatusMarriedJoint); !errors.Is(err, domain.ErrInvalidFilingStatus) {
+		t.Errorf("Expected ErrInvalidFilingStatus, got %v", err)
+	}
+}
+
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+const (
+	// minTrendMonths is the history needed to fit a line
+	minTrendMonths = 2
+
+	// trendStableThreshold is the |slope| / mean income below which income counts as stable
+	trendStableThreshold = 0.01
+)
+
+// GetTrendLine fits an ordinary least squares line to monthly income and expenses
+// Months between the first and last transaction without income or expenses count as zero.
+// Returns domain.ErrInsufficientData with fewer than 2 months.
+func (s *AnalyticsService) GetTrendLine() (*domain.TrendAnalysis, error) {
+	timeline, err := s.GetTimeline()
+	if err != nil {
+		return nil, err
+	}
+
+	incomes := monthlySeries(timeline.Timeline, func(point domain.TimelinePoint) float64 { return point.Income })
+	expenses := monthlySeries(timeline.Timeline, func(point domain.TimelinePoint) float64 { return point.Expenses })
+	if len(incomes) < minTrendMonths {
+		return nil, fmt.Errorf("%w: trend analysis needs at least %d months, got %d",
+			domain.ErrInsufficientData, minTrendMonths, len(incomes))
+	}
+
+	incomeSlope, incomeIntercept, incomeR2 := linearRegression(incomes)
+	expensesSlope, expensesIntercept, expensesR2 := linearRegression(expenses)
+
+	trend := &domain.TrendAnalysis{
+		IncomeSlopePerMonth:   s.roundAmount(incomeSlope),
+		ExpensesSlopePerMonth: s.roundAmount(expensesSlope),
+		IncomeIntercept:       s.roundAmount(incomeIntercept),
+		ExpensesIntercept:     s.roundAmount(expensesIntercept),
+		RSquaredIncome:        roundToN(incomeR2, metricPrecision),
+		RSquaredExpenses:      roundToN(expensesR2, metricPrecision),
+	}
+
+	// Income is never negative, so a zero mean means no income at all and no trend
+	mean := average(incomes)
+	switch {
+	case mean == 0 || math.Abs(incomeSlope)/mean < trendStableThreshold:
+		trend.IncomeTrend = domain.TrendStable
+	case incomeSlope > 0:
+		trend.IncomeTrend = domain.TrendGrowing
+	default:
+		trend.IncomeTrend = domain.TrendDeclining
+	}
+
+	return trend, nil
+}
+
+// linearRegression fits y = intercept + slope*x by least squares, with x the index of each value
+// rSquared is the share of the variance of values explained by the line; a constant series
+// is fitted exactly and has an rSquared of 1. values must have at least 2 elements.
+func linearRegression(values []float64) (slope, intercept, rSquared float64) {
+	n := float64(len(values))
+	meanX := (n - 1) / 2
+	meanY := average(values)
+
+	var sxx, sxy float64
+	for i, y := range values {
+		dx := float64(i) - meanX
+		sxx += dx * dx
+		sxy += dx * (y - meanY)
+	}
+	slope = sxy / sxx
+	intercept = meanY - slope*meanX
+
+	var ssRes, ssTot float64
+	for i, y := range values {
+		residual := y - (intercept + slope*float64(i))
+		ssRes += residual * residual
+		ssTot += (y - meanY) * (y - meanY)
+	}
+	if ssTot == 0 {
+		return slope, intercept, 1
+	}
+	return slope, intercept, 1 - ssRes/ssTot
+}
+
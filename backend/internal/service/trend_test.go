@@ -0,0 +1,110 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+func TestAnalyticsService_GetTrendLine(t *testing.T) {
+	tests := []struct {
+		name                  string
+		data                  string
+		expectedIncomeSlope   float64
+		expectedIncomeInt     float64
+		expectedIncomeR2      float64
+		expectedExpensesSlope float64
+		expectedExpensesInt   float64
+		expectedExpensesR2    float64
+		expectedIncomeTrend   string
+	}{
+		{
+			name: "monotonically increasing",
+			data: `[
+				{"date": "2024-01-01", "amount": 1000, "category": "salary", "description": "Salary", "type": "income"},
+				{"date": "2024-01-05", "amount": -500, "category": "rent", "description": "Rent", "type": "expense"},
+				{"date": "2024-02-01", "amount": 1200, "category": "salary", "description": "Salary", "type": "income"},
+				{"date": "2024-02-05", "amount": -550, "category": "rent", "description": "Rent", "type": "expense"},
+				{"date": "2024-03-01", "amount": 1400, "category": "salary", "description": "Salary", "type": "income"},
+				{"date": "2024-03-05", "amount": -600, "category": "rent", "description": "Rent", "type": "expense"},
+				{"date": "2024-04-01", "amount": 1600, "category": "salary", "description": "Salary", "type": "income"},
+				{"date": "2024-04-05", "amount": -650, "category": "rent", "description": "Rent", "type": "expense"}
+			]`,
+			expectedIncomeSlope: 200, expectedIncomeInt: 1000, expectedIncomeR2: 1,
+			expectedExpensesSlope: 50, expectedExpensesInt: 500, expectedExpensesR2: 1,
+			expectedIncomeTrend: domain.TrendGrowing,
+		},
+		{
+			name: "constant",
+			data: `[
+				{"date": "2024-01-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"},
+				{"date": "2024-01-05", "amount": -800, "category": "rent", "description": "Rent", "type": "expense"},
+				{"date": "2024-02-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"},
+				{"date": "2024-02-05", "amount": -800, "category": "rent", "description": "Rent", "type": "expense"},
+				{"date": "2024-03-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"},
+				{"date": "2024-03-05", "amount": -800, "category": "rent", "description": "Rent", "type": "expense"}
+			]`,
+			expectedIncomeSlope: 0, expectedIncomeInt: 3000, expectedIncomeR2: 1,
+			expectedExpensesSlope: 0, expectedExpensesInt: 800, expectedExpensesR2: 1,
+			expectedIncomeTrend: domain.TrendStable,
+		},
+		{
+			// Fitted income 2666.67 - 1000x over 3000, 1000, 1000: R² = 1 - 666666.67 / 2666666.67
+			name: "declining",
+			data: `[
+				{"date": "2024-01-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"},
+				{"date": "2024-02-01", "amount": 1000, "category": "salary", "description": "Salary", "type": "income"},
+				{"date": "2024-03-01", "amount": 1000, "category": "salary", "description": "Salary", "type": "income"}
+			]`,
+			expectedIncomeSlope: -1000, expectedIncomeInt: 2666.67, expectedIncomeR2: 0.75,
+			expectedExpensesSlope: 0, expectedExpensesInt: 0, expectedExpensesR2: 1,
+			expectedIncomeTrend: domain.TrendDeclining,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := setupRecurringService(t, tt.data)
+
+			trend, err := service.GetTrendLine()
+			if err != nil {
+				t.Fatalf("GetTrendLine() error = %v", err)
+			}
+
+			if math.Abs(trend.IncomeSlopePerMonth-tt.expectedIncomeSlope) > 0.01 {
+				t.Errorf("IncomeSlopePerMonth = %v, want %v", trend.IncomeSlopePerMonth, tt.expectedIncomeSlope)
+			}
+			if trend.IncomeIntercept != tt.expectedIncomeInt {
+				t.Errorf("IncomeIntercept = %v, want %v", trend.IncomeIntercept, tt.expectedIncomeInt)
+			}
+			if trend.RSquaredIncome != tt.expectedIncomeR2 {
+				t.Errorf("RSquaredIncome = %v, want %v", trend.RSquaredIncome, tt.expectedIncomeR2)
+			}
+			if math.Abs(trend.ExpensesSlopePerMonth-tt.expectedExpensesSlope) > 0.01 {
+				t.Errorf("ExpensesSlopePerMonth = %v, want %v", trend.ExpensesSlopePerMonth, tt.expectedExpensesSlope)
+			}
+			if trend.ExpensesIntercept != tt.expectedExpensesInt {
+				t.Errorf("ExpensesIntercept = %v, want %v", trend.ExpensesIntercept, tt.expectedExpensesInt)
+			}
+			if trend.RSquaredExpenses != tt.expectedExpensesR2 {
+				t.Errorf("RSquaredExpenses = %v, want %v", trend.RSquaredExpenses, tt.expectedExpensesR2)
+			}
+			if trend.IncomeTrend != tt.expectedIncomeTrend {
+				t.Errorf("IncomeTrend = %q, want %q", trend.IncomeTrend, tt.expectedIncomeTrend)
+			}
+		})
+	}
+}
+
+func TestAnalyticsService_GetTrendLine_InsufficientData(t *testing.T) {
+	service := setupRecurringService(t, `[
+		{"date": "2024-01-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"}
+	]`)
+
+	if _, err := service.GetTrendLine(); !errors.Is(err, domain.ErrInsufficientData) {
+		t.Errorf("Expected ErrInsufficientData, got %v", err)
+	}
+}
+
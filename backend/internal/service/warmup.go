@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// Warmup runs the most requested analytics once so the first real request is not cold
+// With an analytics cache set, this fills the cache (see AnalyticsCache.WarmUp). Without
+// one, results are discarded and the run only surfaces data problems at startup instead
+// of on the first request.
+// Stops early with ctx.Err() when ctx is cancelled; an empty data set is not an error.
+func (s *AnalyticsService) Warmup(ctx context.Context) error {
+	if s.cache != nil {
+		return s.cache.WarmUp(ctx)
+	}
+
+	steps := []func() error{
+		func() error { _, err := s.GetCategorySummary(); return err },
+		func() error { _, err := s.GetTimeline(); return err },
+	}
+
+	for _, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := step(); err != nil && !errors.Is(err, domain.ErrNoTransactions) {
+			return err
+		}
+	}
+
+	return nil
+}
+
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/danntastico/stori-backend/internal/repository"
+)
+
+func TestAnalyticsService_Warmup(t *testing.T) {
+	service := setupTestService(t)
+
+	if err := service.Warmup(context.Background()); err != nil {
+		t.Fatalf("Warmup() error = %v", err)
+	}
+
+	// Analytics keep working normally afterwards
+	summary, err := service.GetCategorySummary()
+	if err != nil {
+		t.Fatalf("GetCategorySummary() after Warmup() error = %v", err)
+	}
+	if summary.Summary.TotalIncome != 8400 {
+		t.Errorf("TotalIncome = %v, want 8400", summary.Summary.TotalIncome)
+	}
+}
+
+func TestAnalyticsService_Warmup_FillsCache(t *testing.T) {
+	service := setupTestService(t)
+	cache := NewAnalyticsCache(service)
+	service.SetCache(cache)
+
+	if err := service.Warmup(context.Background()); err != nil {
+		t.Fatalf("Warmup() error = %v", err)
+	}
+
+	snapshot := cache.current()
+	if snapshot == nil {
+		t.Fatal("Expected Warmup() to fill the cache")
+	}
+	if snapshot.summary.Summary.TotalIncome != 8400 {
+		t.Errorf("Cached TotalIncome = %v, want 8400", snapshot.summary.Summary.TotalIncome)
+	}
+	if len(snapshot.timeline.Timeline) != 2 || snapshot.transactions.Count != 8 {
+		t.Errorf("Expected 2 cached months and 8 transactions, got %d and %d", len(snapshot.timeline.Timeline), snapshot.transactions.Count)
+	}
+}
+
+func TestAnalyticsService_Warmup_NoTransactions(t *testing.T) {
+	repo, _ := repository.NewJSONRepository([]byte(`[]`))
+
+	if err := NewAnalyticsService(repo).Warmup(context.Background()); err != nil {
+		t.Errorf("Warmup() on empty data error = %v, want nil", err)
+	}
+}
+
+func TestAnalyticsService_Warmup_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := setupTestService(t).Warmup(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Warmup() with a cancelled context error = %v, want context.Canceled", err)
+	}
+}
+
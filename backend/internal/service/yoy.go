@@ -0,0 +1,76 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// GetYoYComparison compares month of year with the same month of the prior year
+// Returns domain.ErrInsufficientData when either month has no transactions.
+func (s *AnalyticsService) GetYoYComparison(month time.Month, year int) (*domain.YoYComparison, error) {
+	current, err := s.monthSummary(month, year)
+	if err != nil {
+		return nil, err
+	}
+	prior, err := s.monthSummary(month, year-1)
+	if err != nil {
+		return nil, err
+	}
+
+	deltas := make(map[string]float64)
+	for category, amount := range current.Categories {
+		deltas[category] = s.roundAmount(amount - prior.Categories[category])
+	}
+	for category, amount := range prior.Categories {
+		if _, ok := current.Categories[category]; !ok {
+			deltas[category] = -amount
+		}
+	}
+
+	return &domain.YoYComparison{
+		CurrentYear: *current,
+		PriorYear:   *prior,
+		Deltas:      deltas,
+	}, nil
+}
+
+// monthSummary totals income, expenses and spending per category for one calendar month
+// Returns domain.ErrInsufficientData when the month has no transactions.
+func (s *AnalyticsService) monthSummary(month time.Month, year int) (*domain.MonthSummary, error) {
+	start := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, -1)
+
+	transactions, err := s.repo.GetByDateRange(start, end)
+	if errors.Is(err, domain.ErrNoTransactions) {
+		return nil, fmt.Errorf("%w: no transactions in %s", domain.ErrInsufficientData, start.Format("2006-01"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &domain.MonthSummary{
+		Period:     start.Format("2006-01"),
+		Categories: make(map[string]float64),
+	}
+	for _, tx := range transactions {
+		if tx.IsIncome() {
+			summary.Income += tx.Amount
+		} else if tx.IsExpense() {
+			summary.Expenses += tx.AbsoluteAmount()
+			summary.Categories[tx.Category] += tx.AbsoluteAmount()
+		}
+	}
+
+	summary.Income = s.roundAmount(summary.Income)
+	summary.Expenses = s.roundAmount(summary.Expenses)
+	summary.Net = s.roundAmount(summary.Income - summary.Expenses)
+	for category, amount := range summary.Categories {
+		summary.Categories[category] = s.roundAmount(amount)
+	}
+
+	return summary, nil
+}
+
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// yoyTestData covers February 2023 and January–February 2024
+const yoyTestData = `[
+	{"date": "2023-02-01", "amount": 3000, "category": "salary", "description": "Salary", "type": "income"},
+	{"date": "2023-02-03", "amount": -1000, "category": "rent", "description": "Rent", "type": "expense"},
+	{"date": "2023-02-10", "amount": -250.50, "category": "groceries", "description": "Market", "type": "expense"},
+	{"date": "2023-02-20", "amount": -80, "category": "entertainment", "description": "Concert", "type": "expense"},
+	{"date": "2024-01-01", "amount": 3200, "category": "salary", "description": "Salary", "type": "income"},
+	{"date": "2024-01-03", "amount": -1100, "category": "rent", "description": "Rent", "type": "expense"},
+	{"date": "2024-02-01", "amount": 3200, "category": "salary", "description": "Salary", "type": "income"},
+	{"date": "2024-02-03", "amount": -1100, "category": "rent", "description": "Rent", "type": "expense"},
+	{"date": "2024-02-12", "amount": -200, "category": "groceries", "description": "Market", "type": "expense"},
+	{"date": "2024-02-29", "amount": -60, "category": "dining", "description": "Dinner", "type": "expense"}
+]`
+
+func TestAnalyticsService_GetYoYComparison(t *testing.T) {
+	service := setupRecurringService(t, yoyTestData)
+
+	comparison, err := service.GetYoYComparison(time.February, 2024)
+	if err != nil {
+		t.Fatalf("GetYoYComparison() error = %v", err)
+	}
+
+	expectedCurrent := domain.MonthSummary{
+		Period:     "2024-02",
+		Income:     3200,
+		Expenses:   1360,
+		Net:        1840,
+		Categories: map[string]float64{"rent": 1100, "groceries": 200, "dining": 60},
+	}
+	if !reflect.DeepEqual(comparison.CurrentYear, expectedCurrent) {
+		t.Errorf("CurrentYear = %+v, want %+v", comparison.CurrentYear, expectedCurrent)
+	}
+
+	expectedPrior := domain.MonthSummary{
+		Period:     "2023-02",
+		Income:     3000,
+		Expenses:   1330.5,
+		Net:        1669.5,
+		Categories: map[string]float64{"rent": 1000, "groceries": 250.5, "entertainment": 80},
+	}
+	if !reflect.DeepEqual(comparison.PriorYear, expectedPrior) {
+		t.Errorf("PriorYear = %+v, want %+v", comparison.PriorYear, expectedPrior)
+	}
+
+	// Dining is new this year and entertainment stopped
+	expectedDeltas := map[string]float64{"rent": 100, "groceries": -50.5, "dining": 60, "entertainment": -80}
+	if !reflect.DeepEqual(comparison.Deltas, expectedDeltas) {
+		t.Errorf("Deltas = %v, want %v", comparison.Deltas, expectedDeltas)
+	}
+}
+
+func TestAnalyticsService_GetYoYComparison_InsufficientData(t *testing.T) {
+	service := setupRecurringService(t, yoyTestData)
+
+	tests := []struct {
+		name  string
+		month time.Month
+		year  int
+	}{
+		{"no prior year data", time.January, 2024},
+		{"no current year data", time.March, 2024},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.GetYoYComparison(tt.month, tt.year); !errors.Is(err, domain.ErrInsufficientData) {
+				t.Errorf("Expected ErrInsufficientData, got %v", err)
+			}
+		})
+	}
+}
+
@@ -0,0 +1,118 @@
+// Package testutil provides shared transaction fixtures and constructors for tests
+//
+// Tests that live inside the repository and service packages cannot import testutil
+// (it imports both, which would be an import cycle); they keep package-local fixtures and load
+// the shipped dataset through the sampledata subpackage.
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/repository"
+	"github.com/danntastico/stori-backend/internal/service"
+)
+
+// MinimalJSON is the smallest useful dataset: one income and two expenses in January 2024
+// Totals: income 2800, expenses 1285, net 1515
+var MinimalJSON = []byte(`[
+	{"date": "2024-01-01", "amount": 2800, "category": "salary", "description": "Bi-weekly salary", "type": "income"},
+	{"date": "2024-01-02", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"},
+	{"date": "2024-01-03", "amount": -85, "category": "groceries", "description": "Whole Foods", "type": "expense"}
+]`)
+
+// StandardJSON covers three months (January–March 2024) and several categories
+// Totals: income 17400, expenses 5750, net 11650
+var StandardJSON = []byte(`[
+	{"date": "2024-01-01", "amount": 2800, "category": "salary", "description": "Bi-weekly salary", "type": "income"},
+	{"date": "2024-01-02", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"},
+	{"date": "2024-01-05", "amount": -95, "category": "groceries", "description": "Whole Foods", "type": "expense"},
+	{"date": "2024-01-10", "amount": -120, "category": "utilities", "description": "Electric bill", "type": "expense"},
+	{"date": "2024-01-15", "amount": 2800, "category": "salary", "description": "Bi-weekly salary", "type": "income"},
+	{"date": "2024-01-20", "amount": -60, "category": "dining", "description": "Chipotle", "type": "expense"},
+	{"date": "2024-01-25", "amount": -45, "category": "transportation", "description": "Uber ride", "type": "expense"},
+	{"date": "2024-02-01", "amount": 2800, "category": "salary", "description": "Bi-weekly salary", "type": "income"},
+	{"date": "2024-02-02", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"},
+	{"date": "2024-02-06", "amount": -110, "category": "groceries", "description": "Trader Joe's", "type": "expense"},
+	{"date": "2024-02-12", "amount": 600, "category": "freelance", "description": "Website project", "type": "income"},
+	{"date": "2024-02-15", "amount": 2800, "category": "salary", "description": "Bi-weekly salary", "type": "income"},
+	{"date": "2024-02-18", "amount": -75, "category": "entertainment", "description": "Concert tickets", "type": "expense"},
+	{"date": "2024-02-22", "amount": -130, "category": "utilities", "description": "Electric bill", "type": "expense"},
+	{"date": "2024-03-01", "amount": 2800, "category": "salary", "description": "Bi-weekly salary", "type": "income"},
+	{"date": "2024-03-02", "amount": -1200, "category": "rent", "description": "Monthly rent", "type": "expense"},
+	{"date": "2024-03-08", "amount": -100, "category": "groceries", "description": "Whole Foods", "type": "expense"},
+	{"date": "2024-03-15", "amount": 2800, "category": "salary", "description": "Bi-weekly salary", "type": "income"},
+	{"date": "2024-03-20", "amount": -80, "category": "dining", "description": "Olive Garden", "type": "expense"},
+	{"date": "2024-03-28", "amount": -1335, "category": "healthcare", "description": "Dental work", "type": "expense"}
+]`)
+
+// LargeJSON holds 200 generated transactions spread over 2024, for performance tests
+var LargeJSON = generateTransactions(200)
+
+// generateTransactions builds a deterministic dataset of n transactions
+// Every fifth transaction is a salary payment; the rest cycle through expense categories.
+func generateTransactions(n int) []byte {
+	expenses := []struct {
+		category    string
+		description string
+		amount      float64
+	}{
+		{"groceries", "Whole Foods", 85},
+		{"dining", "Chipotle", 24},
+		{"transportation", "Uber ride", 18},
+		{"utilities", "Electric bill", 120},
+		{"entertainment", "Movie tickets", 32},
+		{"shopping", "Amazon purchase", 67},
+		{"healthcare", "Pharmacy", 15},
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	transactions := make([]domain.Transaction, 0, n)
+	for i := 0; i < n; i++ {
+		date := start.AddDate(0, 0, i*365/n).Format("2006-01-02")
+
+		if i%5 == 0 {
+			transactions = append(transactions, domain.Transaction{
+				Date: date, Amount: 2800, Category: "salary", Description: "Bi-weekly salary", Type: "income",
+			})
+			continue
+		}
+
+		expense := expenses[i%len(expenses)]
+		transactions = append(transactions, domain.Transaction{
+			Date:        date,
+			Amount:      -(expense.amount + float64(i%10)),
+			Category:    expense.category,
+			Description: expense.description,
+			Type:        "expense",
+		})
+	}
+
+	data, err := json.Marshal(transactions)
+	if err != nil {
+		panic(fmt.Sprintf("testutil: failed to marshal generated transactions: %v", err))
+	}
+	return data
+}
+
+// NewTestRepo loads data into a JSONRepository, failing the test on error
+func NewTestRepo(t *testing.T, data []byte) *repository.JSONRepository {
+	t.Helper()
+
+	repo, err := repository.NewJSONRepository(data)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	return repo
+}
+
+// NewTestService returns an AnalyticsService over data, failing the test on error
+func NewTestService(t *testing.T, data []byte) *service.AnalyticsService {
+	t.Helper()
+
+	return service.NewAnalyticsService(NewTestRepo(t, data))
+}
+
@@ -0,0 +1,52 @@
+package testutil
+
+import (
+	"testing"
+)
+
+func TestFixtures(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           []byte
+		wantCount      int
+		wantIncome     float64
+		wantExpenses   float64
+		wantMultiMonth bool
+	}{
+		{name: "minimal", data: MinimalJSON, wantCount: 3, wantIncome: 2800, wantExpenses: 1285},
+		{name: "standard", data: StandardJSON, wantCount: 20, wantIncome: 17400, wantExpenses: 5750, wantMultiMonth: true},
+		{name: "large", data: LargeJSON, wantCount: 200, wantMultiMonth: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewTestRepo(t, tt.data)
+			if repo.Count() != tt.wantCount {
+				t.Fatalf("Expected %d transactions, got %d", tt.wantCount, repo.Count())
+			}
+
+			summary, err := NewTestService(t, tt.data).GetCategorySummary()
+			if err != nil {
+				t.Fatalf("GetCategorySummary() error = %v", err)
+			}
+			if len(summary.Income) == 0 || len(summary.Expenses) == 0 {
+				t.Error("Expected both income and expense categories")
+			}
+			if tt.wantIncome != 0 && summary.Summary.TotalIncome != tt.wantIncome {
+				t.Errorf("TotalIncome = %v, want %v", summary.Summary.TotalIncome, tt.wantIncome)
+			}
+			if tt.wantExpenses != 0 && summary.Summary.TotalExpenses != tt.wantExpenses {
+				t.Errorf("TotalExpenses = %v, want %v", summary.Summary.TotalExpenses, tt.wantExpenses)
+			}
+
+			start, end, err := repo.GetDateRange()
+			if err != nil {
+				t.Fatalf("GetDateRange() error = %v", err)
+			}
+			if multiMonth := start.Month() != end.Month(); multiMonth != tt.wantMultiMonth {
+				t.Errorf("Date range %s..%s, want multiple months = %v", start.Format("2006-01-02"), end.Format("2006-01-02"), tt.wantMultiMonth)
+			}
+		})
+	}
+}
+
@@ -0,0 +1,30 @@
+// Package sampledata loads the transaction dataset the server ships with, for tests
+//
+// It imports nothing from the application, so the repository and service tests can use it
+// without the import cycle that keeps them away from testutil.
+package sampledata
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// Transactions returns the contents of data/transactions.json, the 112-transaction dataset
+// The path is resolved from this file, so it works from any package's test directory.
+func Transactions(tb testing.TB) []byte {
+	tb.Helper()
+
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		tb.Fatal("Failed to locate the sample data directory")
+	}
+
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(file), "..", "..", "..", "data", "transactions.json"))
+	if err != nil {
+		tb.Fatalf("Failed to read transactions fixture: %v", err)
+	}
+	return data
+}
+
@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+// maxDateShiftDays bounds the random offset applied to every date
+const maxDateShiftDays = 365
+
+// amountJitter is the maximum relative change applied to amounts (±10%)
+const amountJitter = 0.10
+
+// syntheticMerchants lists made-up merchant names per category
+// Categories without an entry use genericMerchants
+var syntheticMerchants = map[string][]string{
+	"salary":         {"Acme Corp Payroll", "Globex Payroll", "Initech Direct Deposit"},
+	"rent":           {"Maple Street Apartments", "Oakwood Property Mgmt", "Riverside Rentals"},
+	"groceries":      {"Green Basket Market", "Daily Harvest Foods", "Corner Pantry"},
+	"utilities":      {"City Power & Light", "Metro Water Co", "Bluewave Internet"},
+	"dining":         {"The Rusty Spoon", "Blue Door Bistro", "Sunrise Cafe"},
+	"transportation": {"QuickRide", "Metro Transit", "FuelUp Station"},
+	"entertainment":  {"Starlight Cinema", "StreamBox", "Arcade Alley"},
+	"shopping":       {"Everyday Outlet", "Urban Threads", "HomeGoods Depot"},
+	"healthcare":     {"Wellness Pharmacy", "Family Health Clinic", "Bright Smile Dental"},
+}
+
+// genericMerchants is used for categories without a specific word list
+var genericMerchants = []string{"General Store", "Main Street Services", "Online Marketplace"}
+
+// AnonymizeOptions configures Anonymize
+type AnonymizeOptions struct {
+	// Seed makes the output deterministic: the same seed and input always produce the same result
+	Seed int64
+
+	// Fields to anonymize
+	Description bool // Replace Description (and Merchant) with a synthetic merchant name and drop Notes
+	Amount      bool // Randomize Amount within ±10%, keeping its sign
+	Date        bool // Shift every date by the same random offset
+}
+
+// DefaultAnonymizeOptions anonymizes every supported field using seed
+func DefaultAnonymizeOptions(seed int64) AnonymizeOptions {
+	return AnonymizeOptions{
+		Seed:        seed,
+		Description: true,
+		Amount:      true,
+		Date:        true,
+	}
+}
+
+// Anonymize returns privacy-safe copies of transactions for use as shareable test fixtures
+// Categories, types, tags and the transaction count are preserved so aggregates stay realistic.
+// Dates that can't be parsed are left unchanged.
+func Anonymize(txs []domain.Transaction, opts AnonymizeOptions) []domain.Transaction {
+	rng := rand.New(rand.NewSource(opts.Seed))
+
+	// One offset per run keeps the spacing between transactions intact
+	shiftDays := rng.Intn(2*maxDateShiftDays+1) - maxDateShiftDays
+
+	result := make([]domain.Transaction, len(txs))
+	for i, tx := range txs {
+		if tx.Tags != nil {
+			tx.Tags = append([]string(nil), tx.Tags...)
+		}
+
+		if opts.Description {
+			names, ok := syntheticMerchants[tx.Category]
+			if !ok {
+				names = genericMerchants
+			}
+			tx.Description = names[rng.Intn(len(names))]
+			if tx.Merchant != "" {
+				tx.Merchant = tx.Description
+			}
+			tx.Notes = ""
+		}
+
+		if opts.Amount {
+			factor := 1 + (rng.Float64()*2-1)*amountJitter
+			tx.Amount = math.Round(tx.Amount*factor*100) / 100
+		}
+
+		if opts.Date {
+			if date, err := time.Parse("2006-01-02", tx.Date); err == nil {
+				tx.Date = date.AddDate(0, 0, shiftDays).Format("2006-01-02")
+			}
+		}
+
+		result[i] = tx
+	}
+
+	return result
+}
+
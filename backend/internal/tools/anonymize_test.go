@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+)
+
+var sampleTransactions = []domain.Transaction{
+	{Date: "2024-01-01", Amount: 2800, Category: "salary", Description: "ACME PAYROLL 0042", Type: "income"},
+	{Date: "2024-01-02", Amount: -1200, Category: "rent", Description: "Monthly rent", Type: "expense"},
+	{Date: "2024-01-15", Amount: -85.5, Category: "groceries", Description: "Whole Foods #123", Type: "expense", Merchant: "Whole Foods", Tags: []string{"weekly"}},
+	{Date: "2024-02-03", Amount: -42, Category: "pets", Description: "Vet visit", Type: "expense"},
+}
+
+func TestAnonymize(t *testing.T) {
+	result := Anonymize(sampleTransactions, DefaultAnonymizeOptions(42))
+
+	if len(result) != len(sampleTransactions) {
+		t.Fatalf("Expected %d transactions, got %d", len(sampleTransactions), len(result))
+	}
+
+	var offset time.Duration
+	for i, tx := range result {
+		original := sampleTransactions[i]
+
+		if tx.Category != original.Category || tx.Type != original.Type {
+			t.Errorf("Transaction %d: category/type changed from %s/%s to %s/%s",
+				i, original.Category, original.Type, tx.Category, tx.Type)
+		}
+
+		if (tx.Amount > 0) != (original.Amount > 0) {
+			t.Errorf("Transaction %d: amount sign changed from %.2f to %.2f", i, original.Amount, tx.Amount)
+		}
+		if diff := tx.Amount/original.Amount - 1; diff < -amountJitter-0.001 || diff > amountJitter+0.001 {
+			t.Errorf("Transaction %d: amount %.2f is not within 10%% of %.2f", i, tx.Amount, original.Amount)
+		}
+
+		if tx.Description == original.Description {
+			t.Errorf("Transaction %d: description was not anonymized", i)
+		}
+
+		// Every date moves by the same offset
+		date, _ := time.Parse("2006-01-02", tx.Date)
+		originalDate, _ := time.Parse("2006-01-02", original.Date)
+		if i == 0 {
+			offset = date.Sub(originalDate)
+		} else if date.Sub(originalDate) != offset {
+			t.Errorf("Transaction %d: date shifted by %v, expected %v", i, date.Sub(originalDate), offset)
+		}
+	}
+
+	// Explicit merchants follow the synthetic description
+	if result[2].Merchant != result[2].Description {
+		t.Errorf("Expected merchant %q to match description %q", result[2].Merchant, result[2].Description)
+	}
+
+	// The input is not modified
+	if sampleTransactions[2].Description != "Whole Foods #123" {
+		t.Error("Anonymize modified its input")
+	}
+}
+
+func TestAnonymize_Deterministic(t *testing.T) {
+	first := Anonymize(sampleTransactions, DefaultAnonymizeOptions(7))
+	second := Anonymize(sampleTransactions, DefaultAnonymizeOptions(7))
+
+	if !reflect.DeepEqual(first, second) {
+		t.Error("Expected the same seed to produce the same output")
+	}
+}
+
+func TestAnonymize_SelectedFields(t *testing.T) {
+	result := Anonymize(sampleTransactions, AnonymizeOptions{Seed: 1, Amount: true})
+
+	for i, tx := range result {
+		original := sampleTransactions[i]
+		if tx.Description != original.Description || tx.Date != original.Date {
+			t.Errorf("Transaction %d: only the amount should change, got %+v", i, tx)
+		}
+	}
+}
+
@@ -2,15 +2,24 @@ package main
 
 import (
 	"context"
-	_ "embed"
+	"embed"
+	"io/fs"
 	"log"
+	"net"
 	"net/http"
+	"net/http/pprof"
 	"os"
 	"os/signal"
+	"path"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
 	"strings"
 	"syscall"
 	"time"
 
+	"github.com/danntastico/stori-backend/internal/categorizer"
+	"github.com/danntastico/stori-backend/internal/domain"
 	"github.com/danntastico/stori-backend/internal/handlers"
 	"github.com/danntastico/stori-backend/internal/middleware"
 	"github.com/danntastico/stori-backend/internal/repository"
@@ -19,61 +28,461 @@ import (
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 )
 
-//go:embed data/transactions.json
-var transactionsData []byte
+// httpRedirectAddr is where plain HTTP is redirected to HTTPS when TLS is enabled
+const httpRedirectAddr = ":80"
+
+// analyticsWarmupTimeout bounds how long startup waits for the analytics cache to fill
+// Requests are served meanwhile, computing results directly until the cache is ready.
+const analyticsWarmupTimeout = 30 * time.Second
+
+// goroutineSampleInterval is how often the goroutine_count gauge is refreshed
+const goroutineSampleInterval = 30 * time.Second
+
+// dataFiles holds every transaction source, one JSON file per account (e.g., data/checking.json)
+// Files the server writes at runtime (budgets, category metadata) must live elsewhere.
+//
+//go:embed data/*.json
+var dataFiles embed.FS
+
+// Build metadata, overridable at build time:
+//
+//	go build -ldflags "-X main.Version=1.2.3 -X main.Commit=$(git rev-parse HEAD)"
+var (
+	Version   = "dev"
+	Commit    = "dev"
+	BuildDate = "dev"
+)
 
 func main() {
-	// Load environment variables
+	// Load and validate environment variables
 	config := loadConfig()
 
+	// Switch application logs to ECS JSON lines when requested
+	if config.Observability.LogFormat == middleware.LogFormatJSON {
+		log.SetFlags(0)
+		log.SetOutput(middleware.NewECSWriter(os.Stdout))
+	}
+
 	log.Println("🚀 Starting Stori Financial Tracker API...")
-	log.Printf("📊 Loaded %d bytes of transaction data", len(transactionsData))
+	sources, err := loadDataSources(dataFiles)
+	if err != nil {
+		log.Fatalf("❌ Failed to read transaction data: %v", err)
+	}
+	if sources, err = addDataFiles(sources, config.Database.DataFilePaths); err != nil {
+		log.Fatalf("❌ Failed to read transaction data: %v", err)
+	}
+	log.Printf("📊 Loaded %d transaction data file(s)", len(sources))
+
+	// Build services, handlers and routes; background workers stop when ctx is cancelled
+	ctx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	r, analyticsService := newRouter(ctx, config, sources)
 
-	// Initialize repository
-	repo, err := repository.NewJSONRepository(transactionsData)
+	// Create HTTP server
+	srv := &http.Server{
+		Addr:         ":" + config.Server.Port,
+		Handler:      r,
+		ReadTimeout:  config.Server.ReadTimeout,
+		WriteTimeout: config.Server.WriteTimeout,
+		IdleTimeout:  60 * time.Second,
+	}
+
+	// Bind the port up front so warmup only starts once the server is listening
+	listener, err := net.Listen("tcp", srv.Addr)
 	if err != nil {
-		log.Fatalf("❌ Failed to initialize repository: %v", err)
+		log.Fatalf("❌ Failed to start server: %v", err)
+	}
+
+	// Redirect plain HTTP to HTTPS when requested
+	var redirectSrv *http.Server
+	if config.Server.TLSEnabled() && config.Server.TLSAutoRedirectHTTP {
+		redirectSrv = &http.Server{
+			Addr:         httpRedirectAddr,
+			Handler:      newHTTPSRedirectHandler(config.Server.Port),
+			ReadTimeout:  5 * time.Second,
+			WriteTimeout: 5 * time.Second,
+		}
+		go func() {
+			log.Printf("↪️  Redirecting HTTP on %s to HTTPS", httpRedirectAddr)
+			if err := redirectSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Printf("⚠️  HTTP redirect server stopped: %v", err)
+			}
+		}()
+	}
+
+	// Start server in a goroutine
+	go func() {
+		scheme := "http"
+		if config.Server.TLSEnabled() {
+			scheme = "https"
+		}
+		log.Printf("🌐 Server listening on %s://localhost:%s", scheme, config.Server.Port)
+		log.Println("📡 API endpoints:")
+		log.Println("   GET  /api/health")
+		log.Println("   GET  /api/version")
+		log.Println("   GET  /api/transactions")
+		log.Println("   POST /api/transactions")
+		log.Println("   GET  /api/transactions/export")
+		log.Println("   GET  /api/transactions/duplicates")
+		log.Println("   POST /api/transactions/bulk")
+		log.Println("   POST /api/transactions/import?format=mint")
+		log.Println("   PATCH /api/transactions/{id}")
+		log.Println("   GET  /api/ws/transactions (WebSocket)")
+		log.Println("   GET  /api/events (Server-Sent Events)")
+		log.Println("   GET  /api/summary/categories")
+		log.Println("   GET  /api/summary/timeline")
+		log.Println("   GET  /api/summary/merchants")
+		log.Println("   GET  /api/summary/tags")
+		log.Println("   GET  /api/summary/payment-methods")
+		log.Println("   GET  /api/summary/heatmap")
+		log.Println("   GET  /api/summary/income-stability")
+		log.Println("   GET  /api/summary/burn-rate")
+		log.Println("   GET  /api/summary/trend")
+		log.Println("   GET  /api/summary/yoy?month=M&year=YYYY")
+		log.Println("   POST /api/categories/merge")
+		log.Println("   GET  /api/categories/metadata")
+		log.Println("   POST /api/categories/metadata/{name}")
+		log.Println("   POST /api/advice")
+		log.Println("   GET  /api/advice/history")
+		log.Println("   POST /api/advice/{id}/feedback")
+		log.Println("   GET  /api/advice/feedback/stats (admin)")
+		log.Println("   GET  /api/forecast/savings-growth")
+		log.Println("   GET  /api/analysis/upcoming-bills")
+		log.Println("   GET  /api/analysis/rationalize")
+		log.Println("   GET  /api/analysis/benchmarks")
+		log.Println("   GET  /api/analysis/stats")
+		log.Println("   GET  /api/analysis/seasonal")
+		log.Println("   GET  /api/analysis/spending-momentum")
+		log.Println("   GET  /api/analysis/income-growth")
+		log.Println("   GET  /api/analysis/correlations")
+		log.Println("   GET  /api/analysis/tax-estimate")
+		log.Println("   GET  /api/analysis/debt-payoff")
+		log.Println("   GET  /api/gamification/savings-streak")
+		log.Println("   POST /api/webhooks/transaction")
+		log.Println("   GET  /api/reports/pdf?month=YYYY-MM")
+		log.Println("   POST /api/reports/email (admin)")
+		log.Println("   GET  /metrics")
+		log.Println("   GET  /debug/goroutines (admin, bearer token)")
+		log.Println("💡 Press Ctrl+C to shutdown")
+
+		if err := serve(srv, listener, config.Server); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("❌ Failed to start server: %v", err)
+		}
+	}()
+
+	// Warm up the analytics cache in the background; shutdown or the timeout cancels it
+	warmupCtx, cancelWarmup := context.WithTimeout(ctx, analyticsWarmupTimeout)
+	defer cancelWarmup()
+	if config.Analytics.WarmupOnStartup {
+		go func() {
+			start := time.Now()
+			if err := analyticsService.Warmup(warmupCtx); err != nil {
+				log.Printf("⚠️  Analytics warmup stopped: %v", err)
+				return
+			}
+			log.Printf("✅ Analytics warmed up in %s", time.Since(start))
+		}()
+	}
+
+	// Wait for interrupt signal for graceful shutdown
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("\n🛑 Shutdown signal received, gracefully shutting down...")
+
+	// Create shutdown context with timeout
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Attempt graceful shutdown
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("❌ Server forced to shutdown: %v", err)
+	}
+	if redirectSrv != nil {
+		redirectSrv.Shutdown(shutdownCtx)
+	}
+
+	// Once requests have drained, stop warmup and background workers and close the audit log;
+	// this also disconnects WebSocket clients, which Shutdown does not wait for
+	stopWorkers()
+
+	log.Println("✅ Server stopped gracefully")
+}
+
+// serve accepts connections on listener, over TLS when the config has a certificate
+func serve(srv *http.Server, listener net.Listener, config ServerConfig) error {
+	if config.TLSEnabled() {
+		return srv.ServeTLS(listener, config.TLSCertFile, config.TLSKeyFile)
+	}
+	return srv.Serve(listener)
+}
+
+// newHTTPSRedirectHandler permanently redirects every request to the same URL over HTTPS on httpsPort
+func newHTTPSRedirectHandler(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+}
+
+// loadDataSources reads every data/*.json file in fsys in name order, each named after
+// its file without extension
+func loadDataSources(fsys fs.FS) ([]repository.DataSource, error) {
+	files, err := fs.Glob(fsys, "data/*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]repository.DataSource, 0, len(files))
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, repository.DataSource{Name: strings.TrimSuffix(path.Base(file), ".json"), Data: data})
+	}
+
+	return sources, nil
+}
+
+// addDataFiles appends each file in paths to sources in order, named after its file without
+// extension like the embedded files, so the name becomes the account of its transactions
+func addDataFiles(sources []repository.DataSource, paths []string) ([]repository.DataSource, error) {
+	for _, file := range paths {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, repository.DataSource{Name: strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)), Data: data})
 	}
-	log.Printf("✅ Repository initialized with %d transactions", repo.Count())
+
+	return sources, nil
+}
+
+// newRouter wires the repository, services and handlers over the data sources and registers all routes
+// The analytics service is returned as well so startup tasks such as warmup can use it.
+// Background workers (WebSocket hub, budget alerts, summary emails, goroutine gauge) run
+// until ctx is done; the hub then disconnects its clients.
+// Exits the process on invalid configuration or data, like the rest of startup.
+func newRouter(ctx context.Context, config Config, sources []repository.DataSource) (*chi.Mux, *service.AnalyticsService) {
+	// Initialize repository, announcing every change on the event bus for SSE clients
+	eventBus := service.NewInMemoryEventBus()
+	txRepo := repository.NewPublishingRepository(newTransactionRepository(config.Security, sources), eventBus)
 
 	// Initialize analytics service
-	analyticsService := service.NewAnalyticsService(repo)
-	log.Println("✅ Analytics service initialized")
+	analyticsService := newAnalyticsService(config.Analytics, txRepo)
+
+	// Push created transactions to WebSocket clients until ctx is done
+	transactionHub := handlers.NewTransactionHub(config.Server.WSPingInterval)
+	go transactionHub.Run(ctx)
+	analyticsService.SetTransactionBroadcaster(transactionHub)
+
+	// Initialize forecasting service
+	forecastingService := service.NewForecastingService(analyticsService)
+
+	// Initialize rationalization service
+	rationalizationService := service.NewRationalizationService(analyticsService)
+	rationalizationService.SetBenchmarks(config.Analytics.FinancialBenchmarks)
+
+	// Initialize tax service
+	taxService := newTaxService(config.Analytics, analyticsService)
+
+	// Initialize report service
+	reportService := service.NewReportService(analyticsService)
+
+	// Initialize debt service
+	debtService := service.NewDebtService()
 
 	// Initialize AI service
-	aiService := service.NewAIService(config.OpenAIAPIKey)
-	if config.OpenAIAPIKey == "" {
-		log.Println("⚠️  OpenAI API key not provided - using mock responses")
-	} else {
-		log.Println("✅ AI service initialized with OpenAI integration")
+	aiService := newAIService(config.AI)
+
+	// Initialize advice history
+	adviceHistory, err := service.NewJSONAdviceRepository(config.Database.AdviceHistoryFile)
+	if err != nil {
+		log.Fatalf("❌ Failed to load advice history: %v", err)
+	}
+	adviceFeedbackService := service.NewAdviceFeedbackService(adviceHistory)
+
+	// Initialize budgets
+	budgetRepo, err := repository.NewJSONBudgetRepository(config.Database.BudgetFile)
+	if err != nil {
+		log.Fatalf("❌ Failed to load budgets: %v", err)
+	}
+	budgetService := service.NewBudgetService(analyticsService, budgetRepo)
+
+	// Check budgets against the alert webhook in the background until ctx is done
+	if config.Alerts.BudgetWebhookURL != "" {
+		budgetAlerts := service.NewBudgetAlertService(analyticsService, budgetService,
+			config.Alerts.BudgetWebhookURL, config.Alerts.BudgetWebhookSecret, config.Alerts.BudgetThreshold)
+		go runBudgetAlerts(ctx, budgetAlerts, config.Alerts.BudgetCheckInterval)
+		log.Printf("🔔 Budget alerts enabled, checking every %s", config.Alerts.BudgetCheckInterval)
+	}
+
+	// Email the previous month's category summary on the 1st of each month until ctx is done
+	var emailService *service.EmailService
+	if config.Alerts.SMTPHost != "" {
+		emailService = service.NewEmailService(config.Alerts.SMTPHost, config.Alerts.SMTPPort, config.Alerts.SMTPUser, config.Alerts.SMTPPass)
+		go runMonthlySummaryEmails(ctx, emailService, analyticsService, config.Alerts.SummaryEmailTo)
+		log.Printf("📧 Monthly summary emails enabled, sending to %s", config.Alerts.SummaryEmailTo)
+	}
+
+	// Initialize category metadata
+	categoryMetadataRepo, err := repository.NewJSONCategoryMetadataRepository(config.Database.CategoryMetadataFile)
+	if err != nil {
+		log.Fatalf("❌ Failed to load category metadata: %v", err)
 	}
+	categoryMetadataService := service.NewCategoryMetadataService(categoryMetadataRepo)
 
 	// Initialize handlers
 	healthHandler := handlers.NewHealthHandler()
+	versionHandler := handlers.NewVersionHandler(newBuildInfo())
 	transactionHandler := handlers.NewTransactionHandler(analyticsService)
-	summaryHandler := handlers.NewSummaryHandler(analyticsService)
-	adviceHandler := handlers.NewAdviceHandler(analyticsService, aiService)
+	transactionFeedHandler := handlers.NewTransactionFeedHandler(transactionHub, config.Server.WSReadLimit, config.Security.AllowedOrigins)
+	eventsHandler := handlers.NewEventsHandler(eventBus)
+	summaryHandler := handlers.NewSummaryHandler(analyticsService, budgetService)
+	adviceHandler := handlers.NewAdviceHandler(analyticsService, aiService, adviceHistory)
+	adviceFeedbackHandler := handlers.NewAdviceFeedbackHandler(adviceFeedbackService)
+	forecastHandler := handlers.NewForecastHandler(forecastingService)
+	analysisHandler := handlers.NewAnalysisHandler(analyticsService)
+	rationalizationHandler := handlers.NewRationalizationHandler(rationalizationService)
+	taxHandler := handlers.NewTaxHandler(taxService)
+	debtHandler := handlers.NewDebtHandler(debtService)
+	gamificationHandler := handlers.NewGamificationHandler(analyticsService)
+	categoryHandler := handlers.NewCategoryHandler(analyticsService, categoryMetadataService)
+	webhookHandler := handlers.NewWebhookHandler(analyticsService)
+	reportHandler := handlers.NewReportHandler(analyticsService, reportService, emailService, config.Alerts.SummaryEmailTo)
 	log.Println("✅ Handlers initialized")
 
 	// Initialize chi router
 	r := chi.NewRouter()
 
+	if config.Server.Debug {
+		log.Println("⚠️  Debug mode enabled - panic details are returned to clients")
+	}
+
+	// Prometheus collectors, served at /metrics
+	metrics := middleware.NewMetrics()
+	go runGoroutineGauge(ctx, metrics, goroutineSampleInterval)
+
 	// Register middleware (order matters!)
-	r.Use(middleware.Recovery)                    // 1. Catch panics
-	r.Use(middleware.Logger)                      // 2. Log requests
-	r.Use(chimiddleware.RequestID)                // 3. Add request ID
-	r.Use(chimiddleware.RealIP)                   // 4. Get real IP
-	r.Use(middleware.CORS(config.AllowedOrigins)) // 5. Handle CORS
-	r.Use(chimiddleware.Timeout(60 * time.Second)) // 6. Request timeout
+	r.Use(chimiddleware.RequestID)                                                        // 1. Add request ID (before recovery and logging, for trace.id)
+	r.Use(middleware.RequestID)                                                           // 2. Echo the request ID in X-Request-Id
+	r.Use(middleware.NewRecovery(middleware.RecoveryOptions{Debug: config.Server.Debug})) // 3. Catch panics
+	r.Use(middleware.RealIP(config.Security.TrustedProxyCIDRs))                           // 4. Get real IP from trusted proxies
+	r.Use(middleware.RequestLogger(config.Observability.LogFormat, os.Stdout))            // 5. Log requests
+	r.Use(metrics.Middleware)                                                             // 6. Count requests and errors
+	r.Use(middleware.CORS(config.Security.AllowedOrigins, config.Security.ExposeHeaders)) // 7. Handle CORS
+	r.Use(chimiddleware.Timeout(60 * time.Second))                                        // 8. Request timeout
+	r.Use(middleware.FinancialContext(config.Analytics.DefaultLocale))                    // 9. Currency, locale and fiscal year preferences
+
+	// Record state-changing requests for compliance (opt-in)
+	if config.Observability.AuditLogEnabled {
+		r.Use(newAuditLog(ctx, config.Observability))
+	}
+
+	// Wrap JSON responses in {"status", "data"|"error", "request_id", "timestamp"} (opt-in)
+	if config.Server.ResponseEnvelopeEnabled {
+		r.Use(middleware.ResponseEnvelope)
+	}
+
+	// Cache POST responses so retries with the same Idempotency-Key are not reprocessed
+	idempotencyStore := middleware.NewInMemoryIdempotencyStore(middleware.DefaultIdempotencyTTL)
+
+	// Imports come from internal services, which sign them when SIGNING_SECRET is set
+	var imports chi.Router = r
+	if config.Security.SigningSecret != "" {
+		imports = r.With(middleware.HMACSignatureVerifier(config.Security.SigningSecret, middleware.SignatureHeader))
+	} else {
+		log.Println("⚠️  SIGNING_SECRET not set - bulk and file imports accept unsigned requests")
+	}
 
 	log.Println("✅ Middleware registered")
 
 	// Register routes
 	r.Get("/api/health", healthHandler.ServeHTTP)
+	r.Handle("/metrics", metrics.Handler())
+	r.Get("/api/version", versionHandler.ServeHTTP)
 	r.Get("/api/transactions", transactionHandler.ServeHTTP)
+	r.With(middleware.IdempotencyKey(idempotencyStore)).Post("/api/transactions", transactionHandler.HandleCreate)
+	r.Get("/api/transactions/export", transactionHandler.HandleExport)
+	r.Get("/api/transactions/duplicates", transactionHandler.HandleDuplicates)
+	imports.Post("/api/transactions/bulk", transactionHandler.HandleBulkImport)
+	imports.Post("/api/transactions/import", transactionHandler.HandleImport)
+	r.Patch("/api/transactions/{id}", transactionHandler.HandlePatch)
+	r.Get("/api/ws/transactions", transactionFeedHandler.ServeHTTP)
+	r.Get("/api/events", eventsHandler.ServeHTTP)
 	r.Get("/api/summary/categories", summaryHandler.HandleCategorySummary)
 	r.Get("/api/summary/timeline", summaryHandler.HandleTimeline)
-	r.Post("/api/advice", adviceHandler.GetAdvice)
+	r.Get("/api/summary/merchants", summaryHandler.HandleMerchantSummary)
+	r.Get("/api/summary/tags", summaryHandler.HandleTagSummary)
+	r.Get("/api/summary/payment-methods", summaryHandler.HandlePaymentMethodSummary)
+	r.Get("/api/summary/heatmap", summaryHandler.HandleSpendingHeatmap)
+	r.Get("/api/summary/income-stability", summaryHandler.HandleIncomeStability)
+	r.Get("/api/summary/burn-rate", summaryHandler.HandleBurnRate)
+	r.Get("/api/summary/trend", summaryHandler.HandleTrend)
+	r.Get("/api/summary/yoy", summaryHandler.HandleYoY)
+	r.Post("/api/categories/merge", categoryHandler.HandleMerge)
+	r.Get("/api/categories/metadata", categoryHandler.HandleListMetadata)
+	r.Post("/api/categories/metadata/{name}", categoryHandler.HandleOverrideMetadata)
+	r.With(middleware.IdempotencyKey(idempotencyStore)).Post("/api/advice", adviceHandler.GetAdvice)
+	r.Get("/api/advice/history", adviceHandler.GetAdviceHistory)
+	r.Post("/api/advice/{id}/feedback", adviceFeedbackHandler.HandleSubmitFeedback)
+	r.Get("/api/forecast/savings-growth", forecastHandler.HandleSavingsGrowth)
+	r.Get("/api/analysis/upcoming-bills", analysisHandler.HandleUpcomingBills)
+	r.Get("/api/analysis/rationalize", rationalizationHandler.HandleRationalize)
+	r.Get("/api/analysis/benchmarks", rationalizationHandler.HandleBenchmarks)
+	r.Get("/api/analysis/stats", analysisHandler.HandlePeriodStats)
+	r.Get("/api/analysis/seasonal", analysisHandler.HandleSeasonalPatterns)
+	r.Get("/api/analysis/spending-momentum", analysisHandler.HandleSpendingMomentum)
+	r.Get("/api/analysis/income-growth", analysisHandler.HandleIncomeGrowth)
+	r.Get("/api/analysis/correlations", analysisHandler.HandleCategoryCorrelations)
+	r.Get("/api/analysis/tax-estimate", taxHandler.HandleTaxEstimate)
+	r.Get("/api/analysis/debt-payoff", debtHandler.HandleDebtPayoff)
+	r.Get("/api/gamification/savings-streak", gamificationHandler.HandleSavingsStreak)
+	r.Get("/api/reports/pdf", reportHandler.HandleMonthlyPDF)
+
+	// Transaction ingestion webhooks (require a shared secret)
+	if config.Security.WebhookSecret != "" {
+		r.With(middleware.WebhookVerifier(config.Security.WebhookSecret, handlers.PlaidVerificationHeader)).
+			Post("/api/webhooks/transaction", webhookHandler.HandleTransaction)
+	} else {
+		log.Println("⚠️  WEBHOOK_SECRET not set - transaction webhooks disabled")
+	}
+
+	// Admin routes are only reachable from ADMIN_ALLOWED_CIDRS
+	admin := r.With(middleware.IPAllowlist(config.Security.AdminAllowedCIDRs))
+	admin.Get("/api/advice/feedback/stats", adviceFeedbackHandler.HandleFeedbackStats)
+
+	// On-demand summary email (requires SMTP settings)
+	if emailService != nil {
+		admin.Post("/api/reports/email", reportHandler.HandleEmailSummary)
+	} else {
+		log.Println("⚠️  SMTP_HOST not set - summary emails disabled")
+	}
+
+	// Goroutine dumps additionally require DEBUG_TOKEN as a bearer token
+	if config.Security.DebugToken != "" {
+		admin.With(middleware.BearerToken(config.Security.DebugToken)).
+			Get("/debug/goroutines", handlers.NewGoroutineHandler().ServeHTTP)
+	} else {
+		log.Println("⚠️  DEBUG_TOKEN not set - goroutine dumps disabled")
+	}
+
+	// Profiling routes (opt-in)
+	if config.Observability.ProfilingEnabled {
+		registerDebugRoutes(r, config.Security.DebugAllowedIPs)
+		if config.Server.Env != "development" {
+			log.Printf("⚠️  Profiling enabled outside development (ENV=%s) - restrict access carefully", config.Server.Env)
+		}
+	}
 
 	// Root endpoint for API info
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
@@ -85,108 +494,229 @@ func main() {
 			"status": "running",
 			"endpoints": {
 				"health": "/api/health",
+				"version": "/api/version",
 				"transactions": "/api/transactions",
 				"categories": "/api/summary/categories",
 				"timeline": "/api/summary/timeline",
-				"advice": "/api/advice"
+				"merchants": "/api/summary/merchants",
+				"advice": "/api/advice",
+				"adviceHistory": "/api/advice/history"
 			}
 		}`))
 	})
 
 	log.Println("✅ Routes registered")
 
-	// Create HTTP server
-	srv := &http.Server{
-		Addr:         ":" + config.Port,
-		Handler:      r,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+	return r, analyticsService
+}
+
+// newTransactionRepository merges, deduplicates and enriches the transactions of every source
+// Sensitive fields are encrypted at rest when the security config has a key.
+func newTransactionRepository(config SecurityConfig, sources []repository.DataSource) repository.TransactionRepository {
+	repo, err := repository.NewMergedRepository(sources)
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize repository: %v", err)
 	}
+	log.Printf("✅ Repository initialized with %d transactions", repo.Count())
 
-	// Start server in a goroutine
-	go func() {
-		log.Printf("🌐 Server listening on http://localhost:%s", config.Port)
-		log.Println("📡 API endpoints:")
-		log.Println("   GET  /api/health")
-		log.Println("   GET  /api/transactions")
-		log.Println("   GET  /api/summary/categories")
-		log.Println("   GET  /api/summary/timeline")
-		log.Println("   POST /api/advice")
-		log.Println("💡 Press Ctrl+C to shutdown")
+	// Enrich transactions with computed fields
+	merchants := service.NewMerchantEnricher()
 
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("❌ Failed to start server: %v", err)
+	if len(config.EncryptionKey) == 0 {
+		log.Println("⚠️  ENCRYPTION_KEY not set, new transactions are stored unencrypted")
+		repo.Enrich(merchants)
+		return repo
+	}
+
+	encrypted, err := repository.NewEncryptedRepository(repo, config.EncryptionKey, config.EncryptionKeyOld)
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize encrypted repository: %v", err)
+	}
+	// Registered on the encrypted repository so enrichers see plaintext descriptions
+	encrypted.Enrich(merchants)
+	log.Println("🔒 Transaction amounts and descriptions are encrypted at rest")
+	return encrypted
+}
+
+// newAnalyticsService creates the analytics service with currency conversion and recurrence settings
+func newAnalyticsService(config AnalyticsConfig, repo repository.TransactionRepository) *service.AnalyticsService {
+	analyticsService := service.NewAnalyticsService(repo, service.WithDecimalPrecision(config.DecimalPrecision))
+	converter, err := service.NewStaticRateConverter()
+	if err != nil {
+		log.Fatalf("❌ Failed to load exchange rates: %v", err)
+	}
+	analyticsService.SetCurrencyConverter(converter, config.BaseCurrency)
+	analyticsService.SetRecurrenceThreshold(config.RecurrenceMinOccurrences)
+	analyticsService.SetWorkers(config.Workers)
+	analyticsService.SetCache(service.NewAnalyticsCache(analyticsService))
+	if config.AutoCategorize {
+		autoCategorizer, err := categorizer.New()
+		if err != nil {
+			log.Fatalf("❌ Failed to load category rules: %v", err)
 		}
-	}()
+		analyticsService.SetAutoCategorizer(autoCategorizer)
+		log.Println("🏷️  Transactions created without a category are auto-categorized")
+	}
+	log.Println("✅ Analytics service initialized")
 
-	// Wait for interrupt signal for graceful shutdown
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	return analyticsService
+}
 
-	log.Println("\n🛑 Shutdown signal received, gracefully shutting down...")
+// newTaxService creates the tax service, loading updated brackets when configured
+func newTaxService(config AnalyticsConfig, analyticsService *service.AnalyticsService) *service.TaxService {
+	taxService := service.NewTaxService(analyticsService, config.DeductibleCategories)
+	if config.TaxYearDataFile == "" {
+		return taxService
+	}
 
-	// Create shutdown context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	taxYearData, err := os.ReadFile(config.TaxYearDataFile)
+	if err != nil {
+		log.Fatalf("❌ Failed to read tax year data: %v", err)
+	}
+	if err := taxService.LoadTaxYearData(taxYearData); err != nil {
+		log.Fatalf("❌ Failed to load tax year data: %v", err)
+	}
+	log.Printf("✅ Tax brackets loaded from %s", config.TaxYearDataFile)
 
-	// Attempt graceful shutdown
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Printf("❌ Server forced to shutdown: %v", err)
+	return taxService
+}
+
+// newAIService creates the advice generator, falling back to mock responses without an API key
+func newAIService(config AIConfig) *service.AIService {
+	if config.OpenAIAPIKey == "" {
+		log.Println("⚠️  OpenAI API key not provided - using mock responses")
+	} else {
+		log.Println("✅ AI service initialized with OpenAI integration")
 	}
 
-	log.Println("✅ Server stopped gracefully")
+	aiService := service.NewAIService(config.OpenAIAPIKey, config.OpenAIBaseURL)
+	aiService.SetAPIVersion(config.OpenAIAPIVersion)
+	aiService.SetCircuitBreaker(service.NewCircuitBreaker(
+		config.CircuitBreakerThreshold, config.CircuitBreakerTimeout, service.DefaultCircuitHalfOpenMaxRequests,
+	))
+	return aiService
 }
 
-// Config holds application configuration
-type Config struct {
-	Port           string
-	AllowedOrigins []string
-	LogLevel       string
-	OpenAIAPIKey   string
-}
-
-// loadConfig loads configuration from environment variables with defaults
-func loadConfig() Config {
-	port := getEnv("PORT", "8080")
-	originsStr := getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:5173,http://localhost:3000")
-	logLevel := getEnv("LOG_LEVEL", "info")
-	openAIAPIKey := getEnv("OPENAI_API_KEY", "")
-
-	// Parse allowed origins
-	var allowedOrigins []string
-	if originsStr != "" {
-		origins := strings.Split(originsStr, ",")
-		for _, origin := range origins {
-			trimmed := strings.TrimSpace(origin)
-			if trimmed != "" {
-				allowedOrigins = append(allowedOrigins, trimmed)
+// runBudgetAlerts checks budgets right away and then every interval until ctx ends
+// Failed checks are logged; undelivered alerts are retried on the next check.
+func runBudgetAlerts(ctx context.Context, alerts *service.BudgetAlertService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := alerts.CheckAndAlert(ctx); err != nil {
+			log.Printf("⚠️  Budget alert check failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runGoroutineGauge samples the goroutine count right away and then every interval until
+// ctx ends
+func runGoroutineGauge(ctx context.Context, metrics *middleware.Metrics, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		metrics.SampleGoroutines()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runMonthlySummaryEmails emails the previous month's category summary to recipient once on
+// the 1st of each month, checking hourly until ctx is done
+// A failed send is retried at the next check while it is still the 1st.
+func runMonthlySummaryEmails(ctx context.Context, emails *service.EmailService, analytics *service.AnalyticsService, recipient string) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	lastSent := ""
+	for {
+		if now := time.Now(); monthlySummaryDue(now, lastSent) {
+			if err := sendMonthlySummary(ctx, emails, analytics, recipient, now); err != nil {
+				log.Printf("⚠️  Monthly summary email failed: %v", err)
+			} else {
+				lastSent = now.Format("2006-01")
+				log.Printf("📧 Monthly summary emailed to %s", recipient)
 			}
 		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 	}
+}
+
+// monthlySummaryDue reports whether now is the 1st of a month whose summary, identified
+// by lastSent as "YYYY-MM", has not been sent yet
+func monthlySummaryDue(now time.Time, lastSent string) bool {
+	return now.Day() == 1 && now.Format("2006-01") != lastSent
+}
 
-	config := Config{
-		Port:           port,
-		AllowedOrigins: allowedOrigins,
-		LogLevel:       logLevel,
-		OpenAIAPIKey:   openAIAPIKey,
+// sendMonthlySummary emails the summary of the calendar month before now to recipient
+func sendMonthlySummary(ctx context.Context, emails *service.EmailService, analytics *service.AnalyticsService, recipient string, now time.Time) error {
+	summary, err := analytics.GetMonthlyCategorySummary(now.AddDate(0, 0, -now.Day()))
+	if err != nil {
+		return err
 	}
+	return emails.SendMonthlySummary(ctx, recipient, summary)
+}
 
-	log.Println("⚙️  Configuration loaded:")
-	log.Printf("   Port: %s", config.Port)
-	log.Printf("   Allowed Origins: %v", config.AllowedOrigins)
-	log.Printf("   Log Level: %s", config.LogLevel)
+// newAuditLog creates the audit middleware writing to the configured file
+// The file is closed when ctx is cancelled.
+func newAuditLog(ctx context.Context, config ObservabilityConfig) func(http.Handler) http.Handler {
+	auditLogger, err := middleware.NewFileAuditLogger(config.AuditLogFile)
+	if err != nil {
+		log.Fatalf("❌ Failed to open audit log: %v", err)
+	}
+	log.Printf("📝 Audit log enabled, writing to %s", config.AuditLogFile)
+	context.AfterFunc(ctx, func() {
+		if err := auditLogger.Close(); err != nil {
+			log.Printf("⚠️  Failed to close audit log: %v", err)
+		}
+	})
 
-	return config
+	return middleware.AuditLog(auditLogger)
 }
 
-// getEnv gets an environment variable or returns a default value
-func getEnv(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
+// newBuildInfo collects build metadata from ldflags variables and the Go runtime
+func newBuildInfo() domain.BuildInfo {
+	info := domain.BuildInfo{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
 	}
-	return value
+
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		info.ModulePath = buildInfo.Main.Path
+	}
+
+	return info
+}
+
+// registerDebugRoutes mounts the net/http/pprof handlers under /debug/pprof/
+// Access is restricted to DEBUG_ALLOWED_IPS (bare IPs or CIDR ranges)
+func registerDebugRoutes(r chi.Router, allowedIPs []string) {
+	r.Route("/debug/pprof", func(r chi.Router) {
+		r.Use(middleware.IPAllowlist(allowedIPs))
+		r.HandleFunc("/cmdline", pprof.Cmdline)
+		r.HandleFunc("/profile", pprof.Profile)
+		r.HandleFunc("/symbol", pprof.Symbol)
+		r.HandleFunc("/trace", pprof.Trace)
+		r.HandleFunc("/*", pprof.Index) // Index also serves named profiles (heap, goroutine, ...)
+	})
 }
 
@@ -0,0 +1,522 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/danntastico/stori-backend/internal/domain"
+	"github.com/danntastico/stori-backend/internal/middleware"
+	"github.com/danntastico/stori-backend/internal/repository"
+	"github.com/danntastico/stori-backend/internal/testutil"
+)
+
+func TestDebugRoutes_ProfilingEnabled(t *testing.T) {
+	r := chi.NewRouter()
+	registerDebugRoutes(r, []string{"127.0.0.1", "::1"})
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/debug/pprof/")
+	if err != nil {
+		t.Fatalf("Failed to call pprof index: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestDebugRoutes_ProfilingDisabled(t *testing.T) {
+	// Profiling disabled: debug routes are never registered, even for allowed IPs
+	router := newTestRouter(t, testutil.MinimalJSON, func(c *Config) {
+		c.Observability.ProfilingEnabled = false
+	})
+
+	req := httptest.NewRequest("GET", "/debug/pprof/", nil)
+	req.RemoteAddr = "127.0.0.1:12345"
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestDebugRoutes_DisallowedIP(t *testing.T) {
+	r := chi.NewRouter()
+	registerDebugRoutes(r, []string{"10.0.0.1"})
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/debug/pprof/")
+	if err != nil {
+		t.Fatalf("Failed to call pprof index: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected status 403, got %d", resp.StatusCode)
+	}
+}
+
+func TestParseList(t *testing.T) {
+	result := parseList(" 127.0.0.1, ::1 ,,")
+	if len(result) != 2 || result[0] != "127.0.0.1" || result[1] != "::1" {
+		t.Errorf("parseList() = %v, want [127.0.0.1 ::1]", result)
+	}
+}
+
+func TestNewBuildInfo_Defaults(t *testing.T) {
+	info := newBuildInfo()
+
+	if info.Version != "dev" || info.Commit != "dev" || info.BuildDate != "dev" {
+		t.Errorf("Expected dev defaults, got %+v", info)
+	}
+
+	if info.GoVersion == "" {
+		t.Error("Expected non-empty Go version")
+	}
+}
+
+// writeTestCertificate writes a self-signed certificate for 127.0.0.1 and its key as PEM files
+func writeTestCertificate(t *testing.T) (certFile, keyFile string, certPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := os.WriteFile(certFile, certPEM, 0o600); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+
+	return certFile, keyFile, certPEM
+}
+
+func TestServe_TLS(t *testing.T) {
+	certFile, keyFile, certPEM := writeTestCertificate(t)
+	config := ServerConfig{
+		Port: "8443", Env: "production", ReadTimeout: time.Second, WriteTimeout: time.Second,
+		WSReadLimit: 512, WSPingInterval: time.Second,
+		TLSCertFile: certFile, TLSKeyFile: keyFile,
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("secure"))
+	})}
+	go serve(srv, listener, config)
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(certPEM)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+
+	resp, err := client.Get("https://" + listener.Addr().String() + "/")
+	if err != nil {
+		t.Fatalf("HTTPS request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || resp.TLS == nil {
+		t.Errorf("Expected a 200 over TLS, got %d (TLS: %v)", resp.StatusCode, resp.TLS != nil)
+	}
+}
+
+func TestHTTPSRedirectHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		httpsPort string
+		target    string
+		expected  string
+	}{
+		{"default port", "443", "http://example.com/api/health?verbose=1", "https://example.com/api/health?verbose=1"},
+		{"custom port", "8443", "http://example.com:80/api/transactions", "https://example.com:8443/api/transactions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			w := httptest.NewRecorder()
+
+			newHTTPSRedirectHandler(tt.httpsPort).ServeHTTP(w, req)
+
+			if w.Code != http.StatusMovedPermanently {
+				t.Errorf("Expected status 301, got %d", w.Code)
+			}
+			if location := w.Header().Get("Location"); location != tt.expected {
+				t.Errorf("Location = %q, want %q", location, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadDataSources(t *testing.T) {
+	fsys := fstest.MapFS{
+		"data/savings.json":  {Data: []byte(`[{}]`)},
+		"data/checking.json": {Data: []byte(`[]`)},
+		"data/README.md":     {Data: []byte(`not a source`)},
+	}
+
+	sources, err := loadDataSources(fsys)
+	if err != nil {
+		t.Fatalf("loadDataSources() error = %v", err)
+	}
+	want := []repository.DataSource{{Name: "checking", Data: []byte(`[]`)}, {Name: "savings", Data: []byte(`[{}]`)}}
+	if !reflect.DeepEqual(sources, want) {
+		t.Errorf("loadDataSources() = %v, want checking and savings in name order", sources)
+	}
+
+	// The embedded data directory loads as well
+	if sources, err := loadDataSources(dataFiles); err != nil || len(sources) != 1 || sources[0].Name != "transactions" {
+		t.Errorf("loadDataSources(dataFiles) = %d sources, %v; want transactions", len(sources), err)
+	}
+}
+
+func TestAddDataFiles(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "archive-2023.json")
+	if err := os.WriteFile(archive, []byte(`[]`), 0o600); err != nil {
+		t.Fatalf("Failed to write data file: %v", err)
+	}
+	older := filepath.Join(dir, "archive-2022.json")
+	if err := os.WriteFile(older, []byte(`[{}]`), 0o600); err != nil {
+		t.Fatalf("Failed to write data file: %v", err)
+	}
+
+	// Files follow the embedded sources in the order given
+	sources, err := addDataFiles([]repository.DataSource{{Name: "transactions"}}, []string{archive, older})
+	if err != nil {
+		t.Fatalf("addDataFiles() error = %v", err)
+	}
+	var names []string
+	for _, source := range sources {
+		names = append(names, source.Name)
+	}
+	if want := []string{"transactions", "archive-2023", "archive-2022"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("addDataFiles() names = %v, want %v", names, want)
+	}
+
+	if _, err := addDataFiles(nil, []string{filepath.Join(dir, "missing.json")}); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestMonthlySummaryDue(t *testing.T) {
+	tests := []struct {
+		now      string
+		lastSent string
+		want     bool
+	}{
+		{"2024-03-01T09:00:00Z", "", true},
+		{"2024-03-01T09:00:00Z", "2024-02", true},
+		{"2024-03-01T10:00:00Z", "2024-03", false}, // Already sent this month
+		{"2024-03-02T09:00:00Z", "2024-02", false}, // Only sent on the 1st
+	}
+
+	for _, tt := range tests {
+		now, _ := time.Parse(time.RFC3339, tt.now)
+		if got := monthlySummaryDue(now, tt.lastSent); got != tt.want {
+			t.Errorf("monthlySummaryDue(%s, %q) = %v, want %v", tt.now, tt.lastSent, got, tt.want)
+		}
+	}
+}
+
+// newTestRouter wires the full router over data, keeping file-backed state in a temp directory
+func newTestRouter(t *testing.T, data []byte, configure func(*Config)) http.Handler {
+	t.Helper()
+
+	config, err := buildConfig(func(_, defaultValue string) string { return defaultValue })
+	if err != nil {
+		t.Fatalf("buildConfig() error = %v", err)
+	}
+	dir := t.TempDir()
+	config.Database.BudgetFile = filepath.Join(dir, "budgets.json")
+	config.Database.CategoryMetadataFile = filepath.Join(dir, "category_metadata.json")
+	if configure != nil {
+		configure(&config)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	router, _ := newRouter(ctx, config, []repository.DataSource{{Name: "transactions", Data: data}})
+	return router
+}
+
+func TestRouter_ForwardedHeadersOnlyFromTrustedProxies(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		header         string
+		value          string
+		expectStatus   int
+	}{
+		{"direct admin client", nil, "127.0.0.1:12345", "", "", http.StatusOK},
+		{"forged X-Forwarded-For", nil, "203.0.113.7:12345", "X-Forwarded-For", "127.0.0.1", http.StatusForbidden},
+		{"forged X-Real-IP", nil, "203.0.113.7:12345", "X-Real-IP", "127.0.0.1", http.StatusForbidden},
+		{"forged header from untrusted peer", []string{"10.0.0.0/8"}, "203.0.113.7:12345", "X-Forwarded-For", "127.0.0.1", http.StatusForbidden},
+		{"admin client behind trusted proxy", []string{"10.0.0.0/8"}, "10.0.0.2:12345", "X-Forwarded-For", "127.0.0.1", http.StatusOK},
+		{"spoofed hop behind trusted proxy", []string{"10.0.0.0/8"}, "10.0.0.2:12345", "X-Forwarded-For", "127.0.0.1, 203.0.113.7", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(t, testutil.MinimalJSON, func(c *Config) {
+				c.Security.TrustedProxyCIDRs = tt.trustedProxies
+			})
+
+			req := httptest.NewRequest("GET", "/api/advice/feedback/stats", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestRouter_SignedImports(t *testing.T) {
+	const secret = "internal-signing-secret"
+	body := `[{"date": "2024-02-01", "amount": 2800, "category": "salary", "description": "Salary", "type": "income"}]`
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	tests := []struct {
+		name         string
+		secret       string
+		signature    string
+		expectStatus int
+	}{
+		{"signing disabled", "", "", http.StatusMultiStatus},
+		{"unsigned", secret, "", http.StatusUnauthorized},
+		{"wrong secret", secret, middleware.ComputeSignature("other-signing-secret", timestamp, []byte(body)), http.StatusForbidden},
+		{"signed", secret, middleware.ComputeSignature(secret, timestamp, []byte(body)), http.StatusMultiStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(t, testutil.MinimalJSON, func(c *Config) {
+				c.Security.SigningSecret = tt.secret
+			})
+
+			req := httptest.NewRequest("POST", "/api/transactions/bulk", strings.NewReader(body))
+			if tt.signature != "" {
+				req.Header.Set(middleware.SignatureHeader, tt.signature)
+				req.Header.Set(middleware.SignatureTimestampHeader, timestamp)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRouter_CORSAllowsClientHeaders(t *testing.T) {
+	router := newTestRouter(t, testutil.MinimalJSON, nil)
+
+	req := httptest.NewRequest("OPTIONS", "/api/transactions", nil)
+	req.Header.Set("Origin", "http://localhost:5173")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "idempotency-key,x-preferred-currency")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	allowed := w.Header().Get("Access-Control-Allow-Headers")
+	for _, header := range []string{"X-Preferred-Currency", "X-Locale", "X-Fiscal-Year-Start", "Idempotency-Key"} {
+		if !strings.Contains(allowed, header) {
+			t.Errorf("Expected %s in Access-Control-Allow-Headers, got %q", header, allowed)
+		}
+	}
+	if exposed := w.Header().Get("Access-Control-Expose-Headers"); !strings.Contains(exposed, "X-Idempotency-Replayed") {
+		t.Errorf("Expected X-Idempotency-Replayed to be exposed by default, got %q", exposed)
+	}
+}
+
+func TestRouter_CreateTransactionIdempotent(t *testing.T) {
+	router := newTestRouter(t, testutil.MinimalJSON, nil)
+	body := `{"date": "2024-02-10", "amount": -30, "category": "dining", "description": "Lunch", "type": "expense"}`
+
+	for i, expectStatus := range []int{http.StatusCreated, http.StatusOK} {
+		req := httptest.NewRequest("POST", "/api/transactions", strings.NewReader(body))
+		req.Header.Set("Idempotency-Key", "create-lunch")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != expectStatus {
+			t.Fatalf("Request %d: expected status %d, got %d: %s", i+1, expectStatus, w.Code, w.Body.String())
+		}
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/transactions", nil))
+	var response domain.TransactionsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode transactions: %v", err)
+	}
+	if count := len(response.Transactions); count != 4 {
+		t.Errorf("Expected the retried create to be stored once (4 transactions), got %d", count)
+	}
+}
+
+func TestRouter_CategorySummaryPreferredCurrency(t *testing.T) {
+	budgetFile := filepath.Join(t.TempDir(), "budgets.json")
+	if err := os.WriteFile(budgetFile, []byte(`[{"category": "rent", "monthly_limit": 1000}]`), 0o600); err != nil {
+		t.Fatalf("Failed to write budget file: %v", err)
+	}
+	router := newTestRouter(t, testutil.MinimalJSON, func(c *Config) {
+		c.Database.BudgetFile = budgetFile
+	})
+
+	tests := []struct {
+		name           string
+		header         string
+		expectCurrency string
+		expectWarning  string
+	}{
+		// Without a preference the base-currency summary carries budget warnings
+		{"no header", "", "", domain.BudgetWarningOverLimit},
+		{"invalid header", "ABC", "", domain.BudgetWarningOverLimit},
+		// Budgets are in the base currency, so converted summaries have no warnings
+		{"preferred currency", "MXN", "MXN", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/summary/categories", nil)
+			if tt.header != "" {
+				req.Header.Set("X-Preferred-Currency", tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d", w.Code)
+			}
+			var summary domain.CategorySummary
+			if err := json.NewDecoder(w.Body).Decode(&summary); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if summary.Currency != tt.expectCurrency {
+				t.Errorf("Currency = %q, want %q", summary.Currency, tt.expectCurrency)
+			}
+			if warning := summary.Expenses["rent"].Warning; warning != tt.expectWarning {
+				t.Errorf("rent warning = %q, want %q", warning, tt.expectWarning)
+			}
+		})
+	}
+}
+
+func TestRouter_SummaryEmailRequiresAdminNetwork(t *testing.T) {
+	router := newTestRouter(t, testutil.MinimalJSON, func(c *Config) {
+		c.Alerts.SMTPHost = "127.0.0.1"
+		c.Alerts.SMTPPort = 1 // Nothing listens there, so allowed requests fail to send
+		c.Alerts.SummaryEmailTo = "ana@example.com"
+	})
+
+	req := httptest.NewRequest("POST", "/api/reports/email", nil)
+	req.RemoteAddr = "203.0.113.7:12345"
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 outside the admin networks, got %d", w.Code)
+	}
+}
+
+func TestNewRouter_WorkersStopWithContext(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	config, err := buildConfig(func(_, defaultValue string) string { return defaultValue })
+	if err != nil {
+		t.Fatalf("buildConfig() error = %v", err)
+	}
+	config.Database.BudgetFile = filepath.Join(t.TempDir(), "budgets.json")
+	config.Database.CategoryMetadataFile = filepath.Join(t.TempDir(), "category_metadata.json")
+	config.Alerts.BudgetWebhookURL = "https://alerts.example.com/hook"
+	config.Alerts.BudgetCheckInterval = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	newRouter(ctx, config, []repository.DataSource{{Name: "transactions", Data: testutil.MinimalJSON}})
+
+	// Goroutines left by earlier tests can still be exiting, so only the count after cancel is checked
+	cancel()
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("Expected background workers to stop, %d goroutines left over", after-before)
+	}
+}
+